@@ -0,0 +1,87 @@
+package benchmarks_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allocsBudget defines how many allocations scany is allowed to make
+// on top of what raw database/sql makes for the same result set.
+type allocsBudget struct {
+	// fixed is the per-query overhead: reflection work, row scanner instantiation, etc.
+	fixed float64
+	// perRow is the overhead for every scanned row.
+	perRow float64
+}
+
+func measureAllocs(t *testing.T, rowsNum int, scanFn func(db *sql.DB) error) float64 {
+	t.Helper()
+	db := newBenchDB(rowsNum)
+	// Warm up caches so the one time reflection work isn't counted.
+	require.NoError(t, scanFn(db))
+	return testing.AllocsPerRun(10, func() {
+		if err := scanFn(db); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func assertAllocsWithinBudget(t *testing.T, budget allocsBudget, rawFn, scanyFn func(db *sql.DB) error) {
+	t.Helper()
+	const smallRowsNum, bigRowsNum = 1, 1001
+
+	rawSmall := measureAllocs(t, smallRowsNum, rawFn)
+	rawBig := measureAllocs(t, bigRowsNum, rawFn)
+	scanySmall := measureAllocs(t, smallRowsNum, scanyFn)
+	scanyBig := measureAllocs(t, bigRowsNum, scanyFn)
+
+	rawPerRow := (rawBig - rawSmall) / (bigRowsNum - smallRowsNum)
+	scanyPerRow := (scanyBig - scanySmall) / (bigRowsNum - smallRowsNum)
+
+	assert.LessOrEqualf(t, scanySmall-rawSmall, budget.fixed,
+		"fixed allocations overhead exceeds the budget: scany %v, raw database/sql %v", scanySmall, rawSmall)
+	assert.LessOrEqualf(t, scanyPerRow-rawPerRow, budget.perRow,
+		"per row allocations overhead exceeds the budget: scany %v, raw database/sql %v", scanyPerRow, rawPerRow)
+}
+
+func TestAllocsBudget_struct(t *testing.T) {
+	assertAllocsWithinBudget(t, allocsBudget{fixed: 10, perRow: 0.5},
+		func(db *sql.DB) error {
+			_, err := scanRawSQL(db)
+			return err
+		},
+		func(db *sql.DB) error {
+			_, err := scanySQLStruct(db)
+			return err
+		},
+	)
+}
+
+func TestAllocsBudget_map(t *testing.T) {
+	assertAllocsWithinBudget(t, allocsBudget{fixed: 20, perRow: 10},
+		func(db *sql.DB) error {
+			_, err := scanRawSQLMap(db)
+			return err
+		},
+		func(db *sql.DB) error {
+			_, err := scanySQLMap(db)
+			return err
+		},
+	)
+}
+
+func TestAllocsBudget_primitive(t *testing.T) {
+	assertAllocsWithinBudget(t, allocsBudget{fixed: 10, perRow: 0.5},
+		func(db *sql.DB) error {
+			_, err := scanRawSQLPrimitive(db)
+			return err
+		},
+		func(db *sql.DB) error {
+			_, err := scanySQLPrimitive(db)
+			return err
+		},
+	)
+}
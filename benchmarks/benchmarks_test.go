@@ -0,0 +1,329 @@
+package benchmarks_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type benchUser struct {
+	ID    int64
+	Name  string
+	Email string
+	Age   int64
+	// The tag is for sqlx that maps fields to lower case names by default, scany maps it to the same column.
+	CreatedAt time.Time `db:"created_at"`
+}
+
+const (
+	benchQuery          = `SELECT id, name, email, age, created_at FROM users`
+	benchPrimitiveQuery = `SELECT id FROM users`
+)
+
+var (
+	ctx          = context.Background()
+	benchRowsNum = []int{1, 100, 10000}
+)
+
+func scanRawSQL(db *sql.DB) ([]*benchUser, error) {
+	rows, err := db.QueryContext(ctx, benchQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint: errcheck
+	var users []*benchUser
+	for rows.Next() {
+		u := &benchUser{}
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Age, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func scanRawSQLMap(db *sql.DB) ([]map[string]interface{}, error) {
+	rows, err := db.QueryContext(ctx, benchQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint: errcheck
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var results []map[string]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		scans := make([]interface{}, len(columns))
+		for i := range values {
+			scans[i] = &values[i]
+		}
+		if err := rows.Scan(scans...); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			m[column] = values[i]
+		}
+		results = append(results, m)
+	}
+	return results, rows.Err()
+}
+
+func scanRawSQLPrimitive(db *sql.DB) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, benchPrimitiveQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint: errcheck
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func scanySQLStruct(db *sql.DB) ([]*benchUser, error) {
+	var users []*benchUser
+	err := sqlscan.Select(ctx, db, &users, benchQuery)
+	return users, err
+}
+
+func scanySQLMap(db *sql.DB) ([]map[string]interface{}, error) {
+	var results []map[string]interface{}
+	err := sqlscan.Select(ctx, db, &results, benchQuery)
+	return results, err
+}
+
+func scanySQLPrimitive(db *sql.DB) ([]int64, error) {
+	var ids []int64
+	err := sqlscan.Select(ctx, db, &ids, benchPrimitiveQuery)
+	return ids, err
+}
+
+func scanSqlxStruct(db *sqlx.DB) ([]*benchUser, error) {
+	var users []*benchUser
+	err := db.SelectContext(ctx, &users, benchQuery)
+	return users, err
+}
+
+// scanSqlxMap uses MapScan, since sqlx can't select rows into a slice of maps.
+func scanSqlxMap(db *sqlx.DB) ([]map[string]interface{}, error) {
+	rows, err := db.QueryxContext(ctx, benchQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint: errcheck
+	var results []map[string]interface{}
+	for rows.Next() {
+		m := map[string]interface{}{}
+		if err := rows.MapScan(m); err != nil {
+			return nil, err
+		}
+		results = append(results, m)
+	}
+	return results, rows.Err()
+}
+
+func scanSqlxPrimitive(db *sqlx.DB) ([]int64, error) {
+	var ids []int64
+	err := db.SelectContext(ctx, &ids, benchPrimitiveQuery)
+	return ids, err
+}
+
+func getRawSQL(db *sql.DB) (*benchUser, error) {
+	u := &benchUser{}
+	err := db.QueryRowContext(ctx, benchQuery).Scan(&u.ID, &u.Name, &u.Email, &u.Age, &u.CreatedAt)
+	return u, err
+}
+
+func getRawSQLMap(db *sql.DB) (map[string]interface{}, error) {
+	results, err := scanRawSQLMap(db)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return results[0], nil
+}
+
+func getRawSQLPrimitive(db *sql.DB) (int64, error) {
+	var id int64
+	err := db.QueryRowContext(ctx, benchPrimitiveQuery).Scan(&id)
+	return id, err
+}
+
+func getScanyStruct(db *sql.DB) (*benchUser, error) {
+	u := &benchUser{}
+	err := sqlscan.Get(ctx, db, u, benchQuery)
+	return u, err
+}
+
+func getScanyMap(db *sql.DB) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	err := sqlscan.Get(ctx, db, &m, benchQuery)
+	return m, err
+}
+
+func getScanyPrimitive(db *sql.DB) (int64, error) {
+	var id int64
+	err := sqlscan.Get(ctx, db, &id, benchPrimitiveQuery)
+	return id, err
+}
+
+func getSqlxStruct(db *sqlx.DB) (*benchUser, error) {
+	u := &benchUser{}
+	err := db.GetContext(ctx, u, benchQuery)
+	return u, err
+}
+
+func getSqlxMap(db *sqlx.DB) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	err := db.QueryRowxContext(ctx, benchQuery).MapScan(m)
+	return m, err
+}
+
+func getSqlxPrimitive(db *sqlx.DB) (int64, error) {
+	var id int64
+	err := db.GetContext(ctx, &id, benchPrimitiveQuery)
+	return id, err
+}
+
+func newBenchSqlxDB(db *sql.DB) *sqlx.DB {
+	return sqlx.NewDb(db, "bench")
+}
+
+func BenchmarkStruct(b *testing.B) {
+	for _, rowsNum := range benchRowsNum {
+		db := newBenchDB(rowsNum)
+		b.Run(fmt.Sprintf("raw_sql/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanRawSQL(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scany/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanySQLStruct(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		dbx := newBenchSqlxDB(db)
+		b.Run(fmt.Sprintf("sqlx/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanSqlxStruct(dbx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	for _, rowsNum := range benchRowsNum {
+		db := newBenchDB(rowsNum)
+		b.Run(fmt.Sprintf("raw_sql/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanRawSQLMap(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scany/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanySQLMap(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		dbx := newBenchSqlxDB(db)
+		b.Run(fmt.Sprintf("sqlx/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanSqlxMap(dbx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPrimitive(b *testing.B) {
+	for _, rowsNum := range benchRowsNum {
+		db := newBenchDB(rowsNum)
+		b.Run(fmt.Sprintf("raw_sql/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanRawSQLPrimitive(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("scany/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanySQLPrimitive(db); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		dbx := newBenchSqlxDB(db)
+		b.Run(fmt.Sprintf("sqlx/rows_%d", rowsNum), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := scanSqlxPrimitive(dbx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	db := newBenchDB(1)
+	dbx := newBenchSqlxDB(db)
+	benchmarks := []struct {
+		name string
+		fn   func() error
+	}{
+		{name: "struct/raw_sql", fn: func() error { _, err := getRawSQL(db); return err }},
+		{name: "struct/scany", fn: func() error { _, err := getScanyStruct(db); return err }},
+		{name: "struct/sqlx", fn: func() error { _, err := getSqlxStruct(dbx); return err }},
+		{name: "map/raw_sql", fn: func() error { _, err := getRawSQLMap(db); return err }},
+		{name: "map/scany", fn: func() error { _, err := getScanyMap(db); return err }},
+		{name: "map/sqlx", fn: func() error { _, err := getSqlxMap(dbx); return err }},
+		{name: "primitive/raw_sql", fn: func() error { _, err := getRawSQLPrimitive(db); return err }},
+		{name: "primitive/scany", fn: func() error { _, err := getScanyPrimitive(db); return err }},
+		{name: "primitive/sqlx", fn: func() error { _, err := getSqlxPrimitive(dbx); return err }},
+	}
+	for _, bm := range benchmarks {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := bm.fn(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Package benchmarks contains benchmarks and allocation budget tests for scany.
+/*
+The package doesn't export anything, all the code lives in test files.
+Benchmarks use an in-process database/sql driver that generates rows on the fly,
+so they don't depend on a running database and measure scany overhead only.
+Every benchmark compares scany with raw database/sql and with sqlx for the same destination:
+a slice of structs, a slice of maps or a slice of primitives, and a single row of each in BenchmarkGet.
+
+Run benchmarks:
+
+	go test -run ^$ -bench . -benchmem ./benchmarks/
+
+Allocation budget tests run as part of the regular test suite,
+they fail if scany starts allocating more per row than the budget allows
+compared to scanning rows with raw database/sql.
+*/
+package benchmarks
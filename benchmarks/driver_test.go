@@ -0,0 +1,82 @@
+package benchmarks_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var benchCreatedAt = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var benchColumnValues = map[string]func(rowNum int) driver.Value{
+	"id":         func(rowNum int) driver.Value { return int64(rowNum) },
+	"name":       func(int) driver.Value { return "user name" },
+	"email":      func(int) driver.Value { return "user@example.com" },
+	"age":        func(int) driver.Value { return int64(30) },
+	"created_at": func(int) driver.Value { return benchCreatedAt },
+}
+
+// benchConnector is a database/sql connector that returns the given number of generated rows for any query.
+type benchConnector struct {
+	rowsNum int
+}
+
+func newBenchDB(rowsNum int) *sql.DB {
+	return sql.OpenDB(&benchConnector{rowsNum: rowsNum})
+}
+
+func (c *benchConnector) Connect(context.Context) (driver.Conn, error) {
+	return &benchConn{rowsNum: c.rowsNum}, nil
+}
+
+func (c *benchConnector) Driver() driver.Driver { return benchDriver{} }
+
+type benchDriver struct{}
+
+func (benchDriver) Open(string) (driver.Conn, error) { return &benchConn{}, nil }
+
+type benchConn struct {
+	rowsNum int
+}
+
+func (c *benchConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *benchConn) Close() error                        { return nil }
+func (c *benchConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+// QueryContext only understands queries in the form of "SELECT column1, column2 FROM ...",
+// columns must be listed in benchColumnValues.
+func (c *benchConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	selectList := strings.TrimPrefix(query, "SELECT ")
+	selectList = selectList[:strings.Index(selectList, " FROM")]
+	columns := strings.Split(selectList, ", ")
+	for _, column := range columns {
+		if _, ok := benchColumnValues[column]; !ok {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+	}
+	return &benchRows{columns: columns, rowsNum: c.rowsNum}, nil
+}
+
+type benchRows struct {
+	columns []string
+	rowsNum int
+	current int
+}
+
+func (r *benchRows) Columns() []string { return r.columns }
+func (r *benchRows) Close() error      { return nil }
+
+func (r *benchRows) Next(dest []driver.Value) error {
+	if r.current >= r.rowsNum {
+		return io.EOF
+	}
+	r.current++
+	for i, column := range r.columns {
+		dest[i] = benchColumnValues[column](r.current)
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package chscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Querier is something that chscan can query and get the driver.Rows from.
+// For example, it can be: driver.Conn.
+type Querier interface {
+	Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error)
+}
+
+var (
+	_ dbscan.Rows            = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows = &RowsAdapter{}
+)
+
+var _ Querier = driver.Conn(nil)
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(dst interface{}, rows driver.Rows) error {
+	return DefaultAPI.ScanAll(dst, rows)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(dst interface{}, rows driver.Rows) error {
+	return DefaultAPI.ScanOne(dst, rows)
+}
+
+// RowScanner is a wrapper around the dbscan.RowScanner type.
+// See dbscan.RowScanner for details.
+type RowScanner struct {
+	*dbscan.RowScanner
+}
+
+// NewRowScanner is a package-level helper function that uses the DefaultAPI object.
+// See API.NewRowScanner for details.
+func NewRowScanner(rows driver.Rows) *RowScanner {
+	return DefaultAPI.NewRowScanner(rows)
+}
+
+// ScanRow is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanRow for details.
+func ScanRow(dst interface{}, rows driver.Rows) error {
+	return DefaultAPI.ScanRow(dst, rows)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for chscan.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI *dbscan.API
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// Select is a high-level function that queries rows from Querier and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("scany: query multiple result rows: %w", err)
+	}
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, api.newRowsAdapter(rows)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// Get is a high-level function that queries rows from Querier and calls the ScanOne function.
+// See ScanOne for details.
+func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("scany: query one result row: %w", err)
+	}
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, api.newRowsAdapter(rows)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(dst interface{}, rows driver.Rows) error {
+	return api.dbscanAPI.ScanAll(dst, api.newRowsAdapter(rows))
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(dst interface{}, rows driver.Rows) error {
+	return api.dbscanAPI.ScanOne(dst, api.newRowsAdapter(rows))
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// NewRowScanner returns a new RowScanner instance.
+func (api *API) NewRowScanner(rows driver.Rows) *RowScanner {
+	ra := api.newRowsAdapter(rows)
+	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(ra)}
+}
+
+// ScanRow is a wrapper around the dbscan.ScanRow function.
+// See dbscan.ScanRow for details.
+func (api *API) ScanRow(dst interface{}, rows driver.Rows) error {
+	return api.dbscanAPI.ScanRow(dst, api.newRowsAdapter(rows))
+}
+
+// RowsAdapter makes driver.Rows compliant with the dbscan.Rows interface,
+// and converts values of composite columns into the types of destinations, see the package docs.
+// See dbscan.Rows for details.
+type RowsAdapter struct {
+	driver.Rows
+	api         *API
+	columnTypes []driver.ColumnType
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(rows driver.Rows) *RowsAdapter {
+	return &RowsAdapter{Rows: rows}
+}
+
+func (api *API) newRowsAdapter(rows driver.Rows) *RowsAdapter {
+	return &RowsAdapter{Rows: rows, api: api}
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	return ra.Rows.Columns(), nil
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+func (ra *RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	columnTypes := ra.getColumnTypes()
+	dbTypes := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		dbTypes[i] = ct.DatabaseTypeName()
+	}
+	return dbTypes, nil
+}
+
+// Scan implements the dbscan.Rows.Scan method. Values of composite columns are scanned into the types that
+// clickhouse-go uses for them and then converted into the destinations, other values are scanned as is.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	columnTypes := ra.getColumnTypes()
+	if len(dest) != len(columnTypes) {
+		return ra.Rows.Scan(dest...)
+	}
+	var converted []interface{}
+	var scanned []convertTarget
+	for i, d := range dest {
+		scanType := columnTypes[i].ScanType()
+		if !needsConversion(d, scanType) {
+			continue
+		}
+		if converted == nil {
+			// dest belongs to the caller, so it's copied instead of modified.
+			converted = make([]interface{}, len(dest))
+			copy(converted, dest)
+		}
+		target := newConvertTarget(i, d, scanType)
+		converted[i] = target.src.Interface()
+		scanned = append(scanned, target)
+	}
+	if converted == nil {
+		return ra.Rows.Scan(dest...)
+	}
+	if err := ra.Rows.Scan(converted...); err != nil {
+		return err
+	}
+	for _, target := range scanned {
+		if err := convert(ra.dbscanAPI(), target.dst.Elem(), target.src.Elem()); err != nil {
+			return fmt.Errorf("scany: convert column '%s' of type %s: %w",
+				columnTypes[target.index].Name(), columnTypes[target.index].DatabaseTypeName(), err)
+		}
+	}
+	return nil
+}
+
+func (ra *RowsAdapter) getColumnTypes() []driver.ColumnType {
+	if ra.columnTypes == nil {
+		ra.columnTypes = ra.Rows.ColumnTypes()
+	}
+	return ra.columnTypes
+}
+
+func (ra *RowsAdapter) dbscanAPI() *dbscan.API {
+	if ra.api != nil {
+		return ra.api.dbscanAPI
+	}
+	return DefaultAPI.dbscanAPI
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
+	api, err := NewDBScanAPI(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
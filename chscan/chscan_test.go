@@ -0,0 +1,158 @@
+package chscan_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/chscan"
+)
+
+var ctx = context.Background()
+
+type fakeColumn struct {
+	name   string
+	chType string
+	value  interface{}
+}
+
+type fakeColumnType struct {
+	fakeColumn
+}
+
+func (ct fakeColumnType) Name() string             { return ct.name }
+func (ct fakeColumnType) Nullable() bool           { return false }
+func (ct fakeColumnType) ScanType() reflect.Type   { return reflect.TypeOf(ct.value) }
+func (ct fakeColumnType) DatabaseTypeName() string { return ct.chType }
+
+// fakeRows returns a single row with values of the columns, like clickhouse-go, it scans values
+// only into destinations of the same type.
+type fakeRows struct {
+	driver.Rows
+	columns []fakeColumn
+	done    bool
+}
+
+func (r *fakeRows) Next() bool {
+	next := !r.done
+	r.done = true
+	return next
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		dstValue := reflect.ValueOf(d).Elem()
+		srcValue := reflect.ValueOf(r.columns[i].value)
+		if dstValue.Type() != srcValue.Type() {
+			return fmt.Errorf("converting %s to %s is unsupported", srcValue.Type(), dstValue.Type())
+		}
+		dstValue.Set(srcValue)
+	}
+	return nil
+}
+
+func (r *fakeRows) ColumnTypes() []driver.ColumnType {
+	columnTypes := make([]driver.ColumnType, len(r.columns))
+	for i, c := range r.columns {
+		columnTypes[i] = fakeColumnType{c}
+	}
+	return columnTypes
+}
+
+func (r *fakeRows) Columns() []string {
+	columns := make([]string, len(r.columns))
+	for i, c := range r.columns {
+		columns[i] = c.name
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Err() error   { return nil }
+
+type fakeQuerier struct {
+	columns []fakeColumn
+}
+
+func (q fakeQuerier) Query(context.Context, string, ...interface{}) (driver.Rows, error) {
+	return &fakeRows{columns: q.columns}, nil
+}
+
+type point struct {
+	X float64 `db:"x"`
+	Y float64 `db:"y"`
+}
+
+func TestGet_compositeColumns(t *testing.T) {
+	t.Parallel()
+	db := fakeQuerier{columns: []fakeColumn{
+		{name: "name", chType: "LowCardinality(String)", value: "triangle"},
+		{name: "points", chType: "Array(Tuple(x Float64, y Float64))", value: []map[string]interface{}{
+			{"x": float64(1), "y": float64(2)},
+			{"x": float64(3), "y": float64(4)},
+		}},
+		{name: "center", chType: "Tuple(Float64, Float64)", value: []interface{}{float64(2), float64(3)}},
+		{name: "tags", chType: "Map(String, Array(UInt32))", value: map[string][]uint32{"a": {1, 2}}},
+		{name: "ids", chType: "Array(Nullable(UInt8))", value: []*uint8{nil, new(uint8)}},
+	}}
+	type shape struct {
+		Name   string
+		Points []point
+		Center *point
+		Tags   map[string][]int64
+		IDs    []*int `db:"ids"`
+	}
+	zero := 0
+	expected := shape{
+		Name:   "triangle",
+		Points: []point{{X: 1, Y: 2}, {X: 3, Y: 4}},
+		Center: &point{X: 2, Y: 3},
+		Tags:   map[string][]int64{"a": {1, 2}},
+		IDs:    []*int{nil, &zero},
+	}
+
+	var got shape
+	err := chscan.Get(ctx, db, &got, `SELECT * FROM shapes`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_nativeTypes_scannedAsIs(t *testing.T) {
+	t.Parallel()
+	db := fakeQuerier{columns: []fakeColumn{
+		{name: "id", chType: "UInt64", value: uint64(1)},
+		{name: "data", chType: "String", value: []byte("bytes")},
+		{name: "tags", chType: "Array(String)", value: []string{"a", "b"}},
+	}}
+	type row struct {
+		ID   uint64
+		Data []byte
+		Tags []string
+	}
+	expected := []row{{ID: 1, Data: []byte("bytes"), Tags: []string{"a", "b"}}}
+
+	var got []row
+	err := chscan.Select(ctx, db, &got, `SELECT * FROM rows`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGet_notConvertible_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := fakeQuerier{columns: []fakeColumn{
+		{name: "tags", chType: "Array(String)", value: []string{"a"}},
+	}}
+
+	var got struct{ Tags []int }
+	err := chscan.Get(ctx, db, &got, `SELECT * FROM rows`)
+
+	assert.ErrorContains(t, err,
+		"scany: convert column 'tags' of type Array(String): element 0: value isn't convertible: string into int")
+}
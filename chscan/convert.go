@@ -0,0 +1,181 @@
+package chscan
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	sqlScannerType    = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	errNotConvertible = errors.New("value isn't convertible")
+)
+
+// convertTarget is the destination of a column along with the value of the column type it's scanned into first.
+type convertTarget struct {
+	index int
+	dst   reflect.Value
+	src   reflect.Value
+}
+
+func newConvertTarget(index int, dst interface{}, scanType reflect.Type) convertTarget {
+	return convertTarget{index: index, dst: reflect.ValueOf(dst), src: reflect.New(scanType)}
+}
+
+// needsConversion reports whether the value of a composite column must be converted into the destination,
+// since its type differs from the type that clickhouse-go scans the column into.
+func needsConversion(dst interface{}, scanType reflect.Type) bool {
+	dstValue := reflect.ValueOf(dst)
+	if scanType == nil || dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return false
+	}
+	dstType := dstValue.Type().Elem()
+	if dstType == scanType || dstType.Kind() == reflect.Interface || dstValue.Type().Implements(sqlScannerType) {
+		return false
+	}
+	return isComposite(scanType)
+}
+
+func isComposite(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		// []byte is the type of binary strings, not of arrays.
+		return t.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// convert sets dst to the src value: arrays are converted into slices, maps into maps,
+// tuples, that clickhouse-go scans into []interface{} or map[string]interface{} if they are named, into structs,
+// and numbers and strings into other types of the same kind.
+func convert(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	for src.Kind() == reflect.Interface || src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := convert(dbscanAPI, elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := convert(dbscanAPI, slice.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			break
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := convert(dbscanAPI, key, iter.Key()); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := convert(dbscanAPI, value, iter.Value()); err != nil {
+				return fmt.Errorf("value of key %v: %w", iter.Key(), err)
+			}
+			m.SetMapIndex(key, value)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.Struct:
+		switch src.Kind() {
+		case reflect.Slice:
+			return convertStructByPosition(dbscanAPI, dst, src)
+		case reflect.Map:
+			if src.Type().Key().Kind() == reflect.String {
+				return convertStructByName(dbscanAPI, dst, src)
+			}
+		}
+	default:
+		if isConvertibleKind(src.Kind(), dst.Kind()) {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s into %s", errNotConvertible, src.Type(), dst.Type())
+}
+
+// convertStructByPosition sets fields of an unnamed tuple to exported struct fields in the order they are declared,
+// except fields ignored via `db:"-"`.
+func convertStructByPosition(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	dst.Set(reflect.Zero(dst.Type()))
+	fieldIndex := 0
+	for i := 0; i < dst.NumField() && fieldIndex < src.Len(); i++ {
+		field := dst.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get("db") == "-" {
+			continue
+		}
+		if err := convert(dbscanAPI, dst.Field(i), src.Index(fieldIndex)); err != nil {
+			return fmt.Errorf("tuple field %d: %w", fieldIndex, err)
+		}
+		fieldIndex++
+	}
+	return nil
+}
+
+// convertStructByName sets fields of a named tuple to struct fields the same way dbscan maps columns to them.
+// Tuple fields without corresponding struct fields are skipped.
+func convertStructByName(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	dst.Set(reflect.Zero(dst.Type()))
+	iter := src.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		index, ok := dbscanAPI.FieldIndex(dst.Type(), name)
+		if !ok {
+			continue
+		}
+		if err := convert(dbscanAPI, dbscan.FieldByIndex(dst, index), iter.Value()); err != nil {
+			return fmt.Errorf("tuple field '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func isConvertibleKind(src, dst reflect.Kind) bool {
+	if src == reflect.String || dst == reflect.String {
+		return src == dst
+	}
+	return isNumberKind(src) && isNumberKind(dst)
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
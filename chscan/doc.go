@@ -0,0 +1,40 @@
+// Package chscan allows scanning data into Go structs and other composite types,
+// when working with ClickHouse via clickhouse-go v2 native interface.
+/*
+Essentially, chscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+chscan connects github.com/ClickHouse/clickhouse-go/v2 native interface with dbscan functionality.
+It contains adapters that are meant to work with driver.Rows and proxy all calls to dbscan.
+chscan provides all capabilities available in dbscan.
+It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+Querying rows
+
+chscan can query rows and work with driver.Conn directly.
+To support this it has two high-level functions Select and Get,
+they accept anything that implements Querier interface and query rows from it.
+
+Composite types
+
+clickhouse-go scans values only into Go types that match the column type exactly.
+chscan converts values of composite columns into the types of destinations:
+
+	type Point struct {
+		X float64 `db:"x"`
+		Y float64 `db:"y"`
+	}
+
+	type Shape struct {
+		Name   string
+		Points []Point            // Array(Tuple(x Float64, y Float64))
+		Tags   map[string][]int64 // Map(LowCardinality(String), Array(UInt32))
+	}
+
+Arrays are converted into slices, maps into maps and tuples into structs, recursively.
+Fields of named tuples are mapped to struct fields by name, the same way as columns, unnamed tuples by position,
+numbers and strings are converted between types of the same kind, e.g. UInt32 into int64.
+LowCardinality and Nullable columns are scanned as their underlying types, NULL values become nil pointers.
+
+chscan is a separate module, so the clickhouse-go dependency doesn't affect users of the other scany packages.
+*/
+package chscan
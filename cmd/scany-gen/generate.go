@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/internal/typemap"
+)
+
+const dbscanImportPath = "github.com/georgysavva/scany/v2/dbscan"
+
+// generate returns the source code of ScanRow methods of the types of the package in the dir.
+// The output file is excluded from the package, so stale generated code doesn't break type checking.
+func generate(dir string, typeNames []string, outputFile string) ([]byte, error) {
+	pkg, err := loadPackage(dir, outputFile)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{pkg: pkg, imports: map[string]string{dbscanImportPath: "dbscan"}}
+	var body bytes.Buffer
+	for _, typeName := range typeNames {
+		typeName = strings.TrimSpace(typeName)
+		named, err := lookupStruct(pkg, typeName)
+		if err != nil {
+			return nil, err
+		}
+		columns, err := g.columns(named)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", typeName, err)
+		}
+		g.writeScanRow(&body, named, columns)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by scany-gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name())
+	// Standard library imports go first, in their own group, like goimports does.
+	var std, other bytes.Buffer
+	for _, path := range sortedKeys(g.imports) {
+		group := &other
+		if !strings.Contains(strings.Split(path, "/")[0], ".") {
+			group = &std
+		}
+		if name := g.imports[path]; name == filepath.Base(path) || path == dbscanImportPath {
+			fmt.Fprintf(group, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(group, "\t%s %q\n", name, path)
+		}
+	}
+	out.WriteString("import (\n")
+	out.Write(std.Bytes())
+	if std.Len() > 0 {
+		out.WriteString("\n")
+	}
+	out.Write(other.Bytes())
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func loadPackage(dir, excludeFile string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("find package in %s: %w", dir, err)
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == excludeFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse package: %w", err)
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(buildPkg.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("type-check package: %w", err)
+	}
+	return pkg, nil
+}
+
+func lookupStruct(pkg *types.Package, typeName string) (*types.Named, error) {
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s isn't found in package %s", typeName, pkg.Name())
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || obj.(*types.TypeName).IsAlias() {
+		return nil, fmt.Errorf("%s must be a defined struct type", typeName)
+	}
+	if named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("generic type %s isn't supported", typeName)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s isn't a struct type", typeName)
+	}
+	return named, nil
+}
+
+type generator struct {
+	pkg *types.Package
+	// imports maps import paths of the generated code to package names.
+	imports map[string]string
+}
+
+// columns returns columns of the struct in the order dbscan maps them.
+func (g *generator) columns(structType types.Type) ([]typemap.Column, error) {
+	columns := typemap.Columns(structType)
+	for _, c := range columns {
+		if err := g.checkAccessible(c); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// checkAccessible makes sure that the generated code can refer to the field of the column.
+func (g *generator) checkAccessible(c typemap.Column) error {
+	for i, field := range c.Path {
+		if field.Exported() || field.Pkg() == g.pkg {
+			continue
+		}
+		if field.Embedded() && i < len(c.Path)-1 {
+			// Fields of unexported embedded structs are accessed as promoted fields.
+			continue
+		}
+		return fmt.Errorf("column '%s': field %s of package %s isn't accessible", c.Name, field.Name(), field.Pkg().Name())
+	}
+	return nil
+}
+
+func (g *generator) writeScanRow(w *bytes.Buffer, named *types.Named, columns []typemap.Column) {
+	typeName := named.Obj().Name()
+	recv := strings.ToLower(typeName[:1])
+	if recv == "i" {
+		// The i name is taken by the column index.
+		recv = "v"
+	}
+	fmt.Fprintf(w, "\nvar _ dbscan.ScanRower = (*%s)(nil)\n", typeName)
+	fmt.Fprintf(w, "\n// ScanRow implements the dbscan.ScanRower interface.\n")
+	fmt.Fprintf(w, "func (%s *%s) ScanRow(rows dbscan.Rows, columns []string, scans []interface{}) error {\n",
+		recv, typeName)
+	fmt.Fprintf(w, "for i, column := range columns {\nswitch column {\n")
+	for _, c := range columns {
+		fmt.Fprintf(w, "case %s:\n", strconv.Quote(c.Name))
+		// Allocate nil pointers to structs on the way to the field, including the field itself, like dbscan does.
+		selector := recv
+		for _, field := range c.Path {
+			if !field.Exported() && field.Pkg() != g.pkg {
+				continue
+			}
+			selector += "." + field.Name()
+			ptr, ok := field.Type().Underlying().(*types.Pointer)
+			if !ok {
+				continue
+			}
+			if _, ok := ptr.Elem().Underlying().(*types.Struct); ok {
+				fmt.Fprintf(w, "if %s == nil {\n%s = new(%s)\n}\n", selector, selector, g.typeString(ptr.Elem()))
+			}
+		}
+		fmt.Fprintf(w, "scans[i] = &%s\n", selector)
+	}
+	fmt.Fprintf(w, "default:\nscans[i] = dbscan.Discard\n}\n}\n")
+	fmt.Fprintf(w, "return rows.Scan(scans...)\n}\n")
+}
+
+// typeString returns the type as it's written in the generated code, adding imports of other packages.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		if name, ok := g.imports[pkg.Path()]; ok {
+			return name
+		}
+		name := pkg.Name()
+		for n := 2; g.hasImportName(name); n++ {
+			name = pkg.Name() + strconv.Itoa(n)
+		}
+		g.imports[pkg.Path()] = name
+		return name
+	})
+}
+
+func (g *generator) hasImportName(name string) bool {
+	for _, n := range g.imports {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,39 @@
+// Package example has structs with generated ScanRow methods, that tests of scany-gen scan rows into.
+package example
+
+import (
+	"database/sql"
+	"time"
+)
+
+//go:generate go run github.com/georgysavva/scany/v2/cmd/scany-gen -type User,Item
+
+// User covers nested, embedded and tagged fields.
+type User struct {
+	ID        int64
+	Name      string `db:"user_name"`
+	Email     sql.NullString
+	CreatedAt time.Time
+	DeletedAt *time.Time
+	Post      *Post
+	Address
+	Secret  string `db:"-"`
+	private string
+}
+
+// Post is a nested struct.
+type Post struct {
+	ID    int64
+	Title string
+}
+
+// Address is an embedded struct.
+type Address struct {
+	City string
+}
+
+// Item has the receiver name that the generated code must not use.
+type Item struct {
+	ID    int64
+	Price float64
+}
@@ -0,0 +1,95 @@
+package example_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/cmd/scany-gen/internal/example"
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+// newReflectionAPI returns an API with the same mapping that doesn't use generated code, since its mapper
+// isn't SnakeCaseMapper itself.
+func newReflectionAPI(t *testing.T) *dbscan.API {
+	t.Helper()
+	api, err := dbscan.NewAPI(
+		dbscan.WithFieldNameMapper(func(name string) string { return dbscan.SnakeCaseMapper(name) }),
+		dbscan.WithAllowUnknownColumns(true),
+	)
+	require.NoError(t, err)
+	return api
+}
+
+func TestScanRow_matchesReflection(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	columns := []string{"id", "user_name", "email", "created_at", "deleted_at", "post.id", "post.title", "city", "extra"}
+	values := [][]interface{}{
+		{int64(1), "foo", "foo@example.com", createdAt, createdAt, int64(10), "hello", "Berlin", "ignored"},
+		{int64(2), "bar", nil, createdAt, nil, nil, nil, "Paris", "ignored"},
+	}
+	api, err := dbscan.NewAPI(dbscan.WithAllowUnknownColumns(true))
+	require.NoError(t, err)
+
+	var generated, reflected []*example.User
+	require.NoError(t, api.ScanAll(&generated, dbscantest.NewRows(columns, values)))
+	require.NoError(t, newReflectionAPI(t).ScanAll(&reflected, dbscantest.NewRows(columns, values)))
+
+	assert.Equal(t, reflected, generated)
+	assert.Equal(t, &example.User{
+		ID:        1,
+		Name:      "foo",
+		Email:     sql.NullString{String: "foo@example.com", Valid: true},
+		CreatedAt: createdAt,
+		DeletedAt: &createdAt,
+		Post:      &example.Post{ID: 10, Title: "hello"},
+		Address:   example.Address{City: "Berlin"},
+	}, generated[0])
+}
+
+func TestScanRow_unknownColumn(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewRows([]string{"id", "extra"}, [][]interface{}{{int64(1), "x"}})
+
+	var got example.Item
+	err := dbscan.ScanOne(&got, rows)
+
+	assert.ErrorContains(t, err,
+		"scany: column: 'extra': no corresponding field found, or it's unexported in example.Item",
+	)
+}
+
+func BenchmarkScanAll(b *testing.B) {
+	columns := []string{"id", "price"}
+	values := make([][]interface{}, 1000)
+	for i := range values {
+		values[i] = []interface{}{int64(i), float64(i)}
+	}
+	reflectionAPI, err := dbscan.NewAPI(
+		dbscan.WithFieldNameMapper(func(name string) string { return dbscan.SnakeCaseMapper(name) }),
+	)
+	require.NoError(b, err)
+	for _, bc := range []struct {
+		name string
+		api  *dbscan.API
+	}{
+		{name: "generated", api: dbscan.DefaultAPI},
+		{name: "reflection", api: reflectionAPI},
+	} {
+		bc := bc
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var items []example.Item
+				if err := bc.api.ScanAll(&items, dbscantest.NewRows(columns, values)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
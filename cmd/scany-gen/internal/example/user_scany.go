@@ -0,0 +1,73 @@
+// Code generated by scany-gen; DO NOT EDIT.
+
+package example
+
+import (
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var _ dbscan.ScanRower = (*User)(nil)
+
+// ScanRow implements the dbscan.ScanRower interface.
+func (u *User) ScanRow(rows dbscan.Rows, columns []string, scans []interface{}) error {
+	for i, column := range columns {
+		switch column {
+		case "id":
+			scans[i] = &u.ID
+		case "user_name":
+			scans[i] = &u.Name
+		case "email":
+			scans[i] = &u.Email
+		case "created_at":
+			scans[i] = &u.CreatedAt
+		case "deleted_at":
+			if u.DeletedAt == nil {
+				u.DeletedAt = new(time.Time)
+			}
+			scans[i] = &u.DeletedAt
+		case "post":
+			if u.Post == nil {
+				u.Post = new(Post)
+			}
+			scans[i] = &u.Post
+		case "email.string":
+			scans[i] = &u.Email.String
+		case "email.valid":
+			scans[i] = &u.Email.Valid
+		case "post.id":
+			if u.Post == nil {
+				u.Post = new(Post)
+			}
+			scans[i] = &u.Post.ID
+		case "post.title":
+			if u.Post == nil {
+				u.Post = new(Post)
+			}
+			scans[i] = &u.Post.Title
+		case "city":
+			scans[i] = &u.Address.City
+		default:
+			scans[i] = dbscan.Discard
+		}
+	}
+	return rows.Scan(scans...)
+}
+
+var _ dbscan.ScanRower = (*Item)(nil)
+
+// ScanRow implements the dbscan.ScanRower interface.
+func (v *Item) ScanRow(rows dbscan.Rows, columns []string, scans []interface{}) error {
+	for i, column := range columns {
+		switch column {
+		case "id":
+			scans[i] = &v.ID
+		case "price":
+			scans[i] = &v.Price
+		default:
+			scans[i] = dbscan.Discard
+		}
+	}
+	return rows.Scan(scans...)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_exampleIsUpToDate(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join("internal", "example")
+	expected, err := os.ReadFile(filepath.Join(dir, "user_scany.go"))
+	require.NoError(t, err)
+
+	got, err := generate(dir, []string{"User", "Item"}, "user_scany.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(got), "run go generate ./cmd/scany-gen/internal/example")
+}
+
+func TestGenerate_invalidType(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := "package models\n\ntype Status string\n\ntype Box[T any] struct{ V T }\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o600))
+	cases := []struct {
+		typeName    string
+		expectedErr string
+	}{
+		{typeName: "Missing", expectedErr: "type Missing isn't found in package models"},
+		{typeName: "Status", expectedErr: "Status isn't a struct type"},
+		{typeName: "Box", expectedErr: "generic type Box isn't supported"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.typeName, func(t *testing.T) {
+			t.Parallel()
+			_, err := generate(dir, []string{tc.typeName}, "models_scany.go")
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestRun_writesOutputFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	src := "package models\n\ntype Post struct {\n\tID    int64\n\tTitle string `db:\"post_title\"`\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o600))
+	output := filepath.Join(dir, "post_scany.go")
+
+	err := run(dir, []string{"Post"}, output)
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "case \"post_title\":\n\t\t\tscans[i] = &p.Title\n")
+}
@@ -0,0 +1,64 @@
+// Command scany-gen generates reflection-free implementations of the dbscan.ScanRower interface
+// for structs, that dbscan uses instead of reflection to scan rows into them.
+/*
+Use it via go:generate in the package that declares the structs:
+
+	//go:generate go run github.com/georgysavva/scany/v2/cmd/scany-gen -type User,Post
+
+It writes the ScanRow methods of *User and *Post to user_scany.go, named after the first type,
+use -output to change the file name. Columns are mapped to fields by the default dbscan rules:
+the "db" struct tag, SnakeCaseMapper, the "." separator for nested structs and embedded structs
+that propagate their columns, so the generated code matches what dbscan does with reflection.
+Regenerate the code whenever the structs change.
+
+Usage:
+
+	scany-gen -type T1,T2 [-output file] [dir]
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names, required")
+	output := flag.String("output", "", "output file name, default <dir>/<first type in lower case>_scany.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: scany-gen -type T1,T2 [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = filepath.Join(dir, strings.ToLower(types[0])+"_scany.go")
+	}
+	if err := run(dir, types, outputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "scany-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, typeNames []string, outputPath string) error {
+	src, err := generate(dir, typeNames, filepath.Base(outputPath))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil { //nolint: gosec
+		return fmt.Errorf("write output file: %w", err)
+	}
+	return nil
+}
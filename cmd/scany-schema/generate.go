@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Styles of fields of nullable columns.
+const (
+	// nullPointer makes fields of nullable columns pointers, e.g. *string.
+	nullPointer = "pointer"
+	// nullGeneric makes fields of nullable columns sql.Null[T], that requires Go 1.22.
+	nullGeneric = "null"
+)
+
+type options struct {
+	// Package is the package name of the generated code.
+	Package string
+	// Nullable is the style of fields of nullable columns, nullPointer or nullGeneric.
+	Nullable string
+	// JSONType is the Go type of JSON columns.
+	JSONType string
+	// Singular makes struct names singular forms of table names, e.g. User for the users table.
+	Singular bool
+}
+
+// goTypes maps database types to Go types of fields, that both database/sql drivers and pgx scan into.
+// Keys are data types of information_schema.columns and PostgreSQL type names in lower case.
+var goTypes = map[string]string{
+	"smallint": "int16", "int2": "int16", "smallserial": "int16",
+	"tinyint": "uint8",
+	"integer": "int32", "int": "int32", "int4": "int32", "serial": "int32",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64",
+	"real": "float32", "float4": "float32",
+	"double precision": "float64", "float8": "float64", "float": "float64",
+	// NUMERIC values are strings, so they don't lose precision, scan them into a decimal type if needed.
+	"numeric": "string", "decimal": "string", "money": "string", "smallmoney": "string",
+	"boolean": "bool", "bool": "bool", "bit": "bool",
+	"text": "string", "character varying": "string", "varchar": "string", "character": "string",
+	"char": "string", "bpchar": "string", "nchar": "string", "nvarchar": "string", "ntext": "string",
+	"citext": "string", "name": "string", "uuid": "string", "uniqueidentifier": "string", "xml": "string",
+	"inet": "string", "cidr": "string", "macaddr": "string",
+	"date": "time.Time", "timestamp": "time.Time", "timestamptz": "time.Time",
+	"timestamp without time zone": "time.Time", "timestamp with time zone": "time.Time",
+	"datetime": "time.Time", "datetime2": "time.Time", "smalldatetime": "time.Time", "datetimeoffset": "time.Time",
+	"bytea": "[]byte", "binary": "[]byte", "varbinary": "[]byte", "image": "[]byte",
+}
+
+// Go types of columns that have no corresponding type in goTypes.
+const (
+	// enumType is the type of user-defined types in PostgreSQL, e.g. enums, that drivers return as text.
+	enumType = "string"
+	// unknownType is the type of all other columns, that accepts any value.
+	unknownType = "interface{}"
+)
+
+// generate returns the source code of structs with fields of the columns of the tables.
+func generate(tables []table, opts options) ([]byte, error) {
+	g := &generator{opts: opts, imports: map[string]bool{}}
+	var body bytes.Buffer
+	structNames := newNameSet()
+	for _, t := range tables {
+		name := structNames.add(structName(t.Name, opts.Singular))
+		fmt.Fprintf(&body, "\n// %s is a row of the %s table.\n", name, t.Name)
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+		fieldNames := newNameSet()
+		for _, c := range t.Columns {
+			fieldName := fieldNames.add(goName(c.ColumnName))
+			fmt.Fprintf(&body, "\t%s %s `db:%s`\n", fieldName, g.fieldType(c), strconv.Quote(c.ColumnName))
+		}
+		body.WriteString("}\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by scany-schema; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n", opts.Package)
+	if len(g.imports) > 0 {
+		out.WriteString("\nimport (\n")
+		for _, path := range sortedKeys(g.imports) {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(body.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+type generator struct {
+	opts options
+	// imports are import paths of the generated code.
+	imports map[string]bool
+}
+
+// fieldType returns the Go type of the field of the column.
+// Fields of nullable columns are pointers or sql.Null[T], unless the type is nil-able already, e.g. []byte.
+func (g *generator) fieldType(c column) string {
+	typ := g.baseType(c)
+	if !c.nullable() || isNilable(typ) {
+		return typ
+	}
+	if g.opts.Nullable == nullGeneric {
+		g.imports["database/sql"] = true
+		return "sql.Null[" + typ + "]"
+	}
+	return "*" + typ
+}
+
+func (g *generator) baseType(c column) string {
+	dataType := strings.ToLower(c.DataType)
+	var typ string
+	switch {
+	case dataType == "json" || dataType == "jsonb":
+		typ = g.opts.JSONType
+	case dataType == "array":
+		// PostgreSQL names array types after their elements with a leading underscore, e.g. "_int4".
+		elem, ok := goTypes[strings.TrimPrefix(strings.ToLower(c.UDTName), "_")]
+		if !ok {
+			return unknownType
+		}
+		typ = "[]" + elem
+	case dataType == "user-defined":
+		typ = enumType
+	default:
+		var ok bool
+		if typ, ok = goTypes[dataType]; !ok {
+			return unknownType
+		}
+	}
+	switch {
+	case strings.Contains(typ, "time.Time"):
+		g.imports["time"] = true
+	case strings.Contains(typ, "json."):
+		g.imports["encoding/json"] = true
+	}
+	return typ
+}
+
+// isNilable reports whether the type represents NULL as nil by itself.
+// json.RawMessage isn't one of them, since database/sql can't scan NULL into it.
+func isNilable(typ string) bool {
+	for _, prefix := range []string{"[]", "map[", "*", "interface{"} {
+		if strings.HasPrefix(typ, prefix) {
+			return true
+		}
+	}
+	return typ == "any"
+}
+
+// commonInitialisms are words that Go names spell in upper case, see https://go.dev/wiki/CodeReviewComments.
+var commonInitialisms = map[string]bool{
+	"acl": true, "api": true, "ascii": true, "cpu": true, "css": true, "dns": true, "eof": true, "guid": true,
+	"html": true, "http": true, "https": true, "id": true, "ip": true, "json": true, "sku": true, "sql": true,
+	"ssh": true, "tcp": true, "tls": true, "ttl": true, "udp": true, "ui": true, "uid": true, "uri": true,
+	"url": true, "utf8": true, "uuid": true, "vm": true, "xml": true,
+}
+
+// goName returns the exported Go name of the database name, e.g. UserID for "user_id".
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if commonInitialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		// Go names can't start with a digit.
+		s = "X" + s
+	}
+	return s
+}
+
+// structName returns the name of the struct of the table.
+func structName(tableName string, singular bool) string {
+	if singular {
+		tableName = singularize(tableName)
+	}
+	return goName(tableName)
+}
+
+// singularize returns the singular form of the last word of the English plural name,
+// e.g. "user_address" for "user_addresses". It covers only regular plurals.
+func singularize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + matchCase(name[len(name)-1], 'y')
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "shes"), strings.HasSuffix(lower, "ches"),
+		strings.HasSuffix(lower, "xes"), strings.HasSuffix(lower, "zzes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		return name
+	case strings.HasSuffix(lower, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name
+}
+
+// matchCase returns the lower case letter in the case of the example character.
+func matchCase(example, letter byte) string {
+	if example >= 'A' && example <= 'Z' {
+		return strings.ToUpper(string(letter))
+	}
+	return string(letter)
+}
+
+// nameSet makes names unique, adding numeric suffixes to repeated ones, e.g. for "user_id" and "userId" columns.
+type nameSet map[string]bool
+
+func newNameSet() nameSet {
+	return nameSet{}
+}
+
+func (s nameSet) add(name string) string {
+	unique := name
+	for i := 2; s[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	s[unique] = true
+	return unique
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+var testColumns = []column{
+	{TableName: "users", ColumnName: "id", DataType: "bigint", UDTName: "int8", IsNullable: "NO"},
+	{TableName: "users", ColumnName: "email", DataType: "text", UDTName: "text", IsNullable: "NO"},
+	{TableName: "users", ColumnName: "display_name", DataType: "character varying", UDTName: "varchar", IsNullable: "YES"},
+	{TableName: "users", ColumnName: "created_at", DataType: "timestamp with time zone", UDTName: "timestamptz",
+		IsNullable: "NO"},
+	{TableName: "categories", ColumnName: "tags", DataType: "ARRAY", UDTName: "_text", IsNullable: "YES"},
+	{TableName: "categories", ColumnName: "settings", DataType: "jsonb", UDTName: "jsonb", IsNullable: "YES"},
+	{TableName: "categories", ColumnName: "status", DataType: "USER-DEFINED", UDTName: "category_status",
+		IsNullable: "NO"},
+	{TableName: "categories", ColumnName: "location", DataType: "USER-DEFINED", UDTName: "geometry",
+		IsNullable: "YES"},
+	{TableName: "categories", ColumnName: "price", DataType: "numeric", UDTName: "numeric", IsNullable: "YES"},
+	{TableName: "categories", ColumnName: "tsv", DataType: "tsvector", UDTName: "tsvector", IsNullable: "YES"},
+}
+
+func testTables() []table {
+	return []table{
+		{Name: "users", Columns: testColumns[:4]},
+		{Name: "categories", Columns: testColumns[4:]},
+	}
+}
+
+func TestGenerate_pointers(t *testing.T) {
+	t.Parallel()
+	opts := options{Package: "models", Nullable: nullPointer, JSONType: "json.RawMessage", Singular: true}
+
+	got, err := generate(testTables(), opts)
+	require.NoError(t, err)
+
+	expected := "// Code generated by scany-schema; DO NOT EDIT.\n\n" +
+		"package models\n\n" +
+		"import (\n\t\"encoding/json\"\n\t\"time\"\n)\n\n" +
+		"// User is a row of the users table.\n" +
+		"type User struct {\n" +
+		"\tID          int64     `db:\"id\"`\n" +
+		"\tEmail       string    `db:\"email\"`\n" +
+		"\tDisplayName *string   `db:\"display_name\"`\n" +
+		"\tCreatedAt   time.Time `db:\"created_at\"`\n" +
+		"}\n\n" +
+		"// Category is a row of the categories table.\n" +
+		"type Category struct {\n" +
+		"\tTags     []string         `db:\"tags\"`\n" +
+		"\tSettings *json.RawMessage `db:\"settings\"`\n" +
+		"\tStatus   string           `db:\"status\"`\n" +
+		"\tLocation *string          `db:\"location\"`\n" +
+		"\tPrice    *string          `db:\"price\"`\n" +
+		"\tTsv      interface{}      `db:\"tsv\"`\n" +
+		"}\n"
+	assert.Equal(t, expected, string(got))
+}
+
+func TestGenerate_nullGeneric(t *testing.T) {
+	t.Parallel()
+	opts := options{Package: "db", Nullable: nullGeneric, JSONType: "map[string]interface{}"}
+
+	got, err := generate(testTables(), opts)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "import (\n\t\"database/sql\"\n\t\"time\"\n)\n")
+	assert.Contains(t, string(got), "type Users struct {\n")
+	assert.Contains(t, string(got), "DisplayName sql.Null[string] `db:\"display_name\"`\n")
+	assert.Contains(t, string(got), "Settings map[string]interface{} `db:\"settings\"`\n")
+}
+
+func TestGenerate_uniqueNames(t *testing.T) {
+	t.Parallel()
+	tables := []table{
+		{Name: "user", Columns: []column{
+			{ColumnName: "user_id", DataType: "int", IsNullable: "NO"},
+			{ColumnName: "UserID", DataType: "int", IsNullable: "NO"},
+			{ColumnName: "1st place", DataType: "bit", IsNullable: "NO"},
+		}},
+		{Name: "users", Columns: []column{{ColumnName: "id", DataType: "int", IsNullable: "NO"}}},
+	}
+
+	got, err := generate(tables, options{Package: "models", Nullable: nullPointer, Singular: true})
+	require.NoError(t, err)
+
+	assert.Contains(t, string(got), "type User struct {\n\tUserID    int32 `db:\"user_id\"`\n"+
+		"\tUserID2   int32 `db:\"UserID\"`\n\tX1stPlace bool  `db:\"1st place\"`\n")
+	assert.Contains(t, string(got), "type User2 struct {\n")
+}
+
+func TestGoName(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"id":           "ID",
+		"user_id":      "UserID",
+		"html_url":     "HTMLURL",
+		"FirstName":    "FirstName",
+		"order-number": "OrderNumber",
+		"2fa_enabled":  "X2faEnabled",
+		"":             "X",
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, goName(name), name)
+	}
+}
+
+func TestSingularize(t *testing.T) {
+	t.Parallel()
+	cases := map[string]string{
+		"users":          "user",
+		"categories":     "category",
+		"user_addresses": "user_address",
+		"boxes":          "box",
+		"branches":       "branch",
+		"status":         "status",
+		"analysis":       "analysis",
+		"access":         "access",
+		"CATEGORIES":     "CATEGORY",
+		"data":           "data",
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, singularize(name), name)
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	t.Parallel()
+	db := sqlscantest.NewQuerier()
+	defer db.Close() //nolint: errcheck
+	db.On("FROM information_schema.columns").Return(testColumns)
+
+	tables, err := introspect(context.Background(), db, "pgx", "public", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, testTables(), tables)
+	require.Len(t, db.Queries(), 1)
+	assert.Equal(t, []interface{}{"public"}, db.Queries()[0].Args)
+}
+
+func TestIntrospect_selectedTables(t *testing.T) {
+	t.Parallel()
+	db := sqlscantest.NewQuerier()
+	defer db.Close() //nolint: errcheck
+	db.On("FROM information_schema.columns").Return(testColumns)
+
+	tables, err := introspect(context.Background(), db, "sqlserver", "dbo", []string{"categories"})
+	require.NoError(t, err)
+	require.Len(t, tables, 1)
+	assert.Equal(t, "categories", tables[0].Name)
+	assert.Contains(t, db.Queries()[0].SQL, "table_schema = @p1")
+
+	_, err = introspect(context.Background(), db, "sqlserver", "dbo", []string{"missing"})
+	assert.EqualError(t, err, "table missing isn't found in schema dbo")
+}
+
+func TestIntrospect_unsupportedDriver(t *testing.T) {
+	t.Parallel()
+	_, err := introspect(context.Background(), sqlscantest.NewQuerier(), "sqlite3", "main", nil)
+	assert.EqualError(t, err, `driver "sqlite3" isn't supported`)
+}
@@ -0,0 +1,108 @@
+// Command scany-schema generates Go structs with fields of columns of database tables,
+// that scany scans rows of the tables into.
+/*
+It reads columns of tables from information_schema.columns of a live database
+and writes a struct per table with a field per column in the column order:
+
+	scany-schema -dsn postgres://localhost/app -package models -output models/tables.go
+
+Every field has the "db" struct tag with the column name, so dbscan maps it
+regardless of the field name and the column name mapper.
+Fields of nullable columns are pointers, e.g. *string, or sql.Null[T] with -nullable null, that requires Go 1.22.
+Slice fields, e.g. []byte, stay as is, since NULL is nil for them.
+JSON and JSONB columns are json.RawMessage fields, use -json to choose another type,
+e.g. map[string]interface{}, that pgx decodes JSON values into.
+NUMERIC columns are string fields, so values don't lose precision.
+Columns of types that scany-schema doesn't know are interface{} fields.
+
+Supported drivers are pgx, for PostgreSQL and CockroachDB, and sqlserver, for SQL Server.
+
+Usage:
+
+	scany-schema -dsn url [-driver pgx|sqlserver] [-schema name] [-tables t1,t2] [-package name]
+		[-nullable pointer|null] [-json type] [-singular=false] [-output file]
+*/
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+func main() {
+	dsn := flag.String("dsn", "", "database connection string, required")
+	driver := flag.String("driver", "pgx", "database/sql driver name, one of: "+strings.Join(driverNames(), ", "))
+	schema := flag.String("schema", "", "schema of the tables, default public for pgx and dbo for sqlserver")
+	tableNames := flag.String("tables", "", "comma-separated list of table names, default all tables of the schema")
+	pkg := flag.String("package", "models", "package name of the generated code")
+	nullable := flag.String("nullable", nullPointer, "type of fields of nullable columns: pointer or null for sql.Null[T]")
+	jsonType := flag.String("json", "json.RawMessage", "Go type of JSON columns")
+	singular := flag.Bool("singular", true, "name structs in the singular, e.g. User for the users table")
+	output := flag.String("output", "", "output file name, default standard output")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: scany-schema -dsn url [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *dsn == "" || flag.NArg() > 0 || (*nullable != nullPointer && *nullable != nullGeneric) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *schema == "" {
+		*schema = defaultSchemas[*driver]
+	}
+	var names []string
+	if *tableNames != "" {
+		names = strings.Split(*tableNames, ",")
+	}
+	opts := options{Package: *pkg, Nullable: *nullable, JSONType: *jsonType, Singular: *singular}
+	if err := run(context.Background(), *driver, *dsn, *schema, names, opts, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "scany-schema: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, driver, dsn, schema string, names []string, opts options, outputPath string) error {
+	if _, ok := columnsQueries[driver]; !ok {
+		return fmt.Errorf("driver %q isn't supported", driver)
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close() //nolint: errcheck
+	tables, err := introspect(ctx, db, driver, schema, names)
+	if err != nil {
+		return err
+	}
+	src, err := generate(tables, opts)
+	if err != nil {
+		return err
+	}
+	if outputPath == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(outputPath, src, 0o644) //nolint: gosec
+	}
+	if err != nil {
+		return fmt.Errorf("write output: %w", err)
+	}
+	return nil
+}
+
+func driverNames() []string {
+	names := make([]string, 0, len(columnsQueries))
+	for name := range columnsQueries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
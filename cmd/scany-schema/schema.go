@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// column is a column of a table as information_schema.columns describes it.
+type column struct {
+	TableName  string `db:"table_name"`
+	ColumnName string `db:"column_name"`
+	// DataType is the SQL type, e.g. "integer", "ARRAY" or "USER-DEFINED" in PostgreSQL.
+	DataType string `db:"data_type"`
+	// UDTName is the underlying type, e.g. "int4" or "_int4" for arrays, in PostgreSQL.
+	// Other databases don't have it, so it's the data type there.
+	UDTName    string `db:"udt_name"`
+	IsNullable string `db:"is_nullable"`
+}
+
+func (c column) nullable() bool {
+	return strings.EqualFold(c.IsNullable, "YES")
+}
+
+// table is a table or a view with its columns in their ordinal order.
+type table struct {
+	Name    string
+	Columns []column
+}
+
+// Queries that return columns of all tables in the schema, by driver name.
+var columnsQueries = map[string]string{
+	"pgx": `SELECT table_name, column_name, data_type, udt_name, is_nullable
+FROM information_schema.columns
+WHERE table_schema = $1
+ORDER BY table_name, ordinal_position`,
+	"sqlserver": `SELECT table_name, column_name, data_type, data_type AS udt_name, is_nullable
+FROM information_schema.columns
+WHERE table_schema = @p1
+ORDER BY table_name, ordinal_position`,
+}
+
+// defaultSchemas are the schemas that tables are in by default, by driver name.
+var defaultSchemas = map[string]string{
+	"pgx":       "public",
+	"sqlserver": "dbo",
+}
+
+// introspect returns tables of the schema, all of them or only the ones with the names, in the order of names.
+func introspect(ctx context.Context, db sqlscan.Querier, driver, schema string, names []string) ([]table, error) {
+	query, ok := columnsQueries[driver]
+	if !ok {
+		return nil, fmt.Errorf("driver %q isn't supported", driver)
+	}
+	var columns []column
+	if err := sqlscan.Select(ctx, db, &columns, query, schema); err != nil {
+		return nil, fmt.Errorf("query columns of schema %s: %w", schema, err)
+	}
+	var tables []table
+	byName := map[string]int{}
+	for _, c := range columns {
+		i, ok := byName[c.TableName]
+		if !ok {
+			i = len(tables)
+			byName[c.TableName] = i
+			tables = append(tables, table{Name: c.TableName})
+		}
+		tables[i].Columns = append(tables[i].Columns, c)
+	}
+	if len(names) == 0 {
+		return tables, nil
+	}
+	selected := make([]table, 0, len(names))
+	for _, name := range names {
+		i, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("table %s isn't found in schema %s", name, schema)
+		}
+		selected = append(selected, tables[i])
+	}
+	return selected, nil
+}
@@ -0,0 +1,295 @@
+package crdbscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+// Querier is something that crdbscan can query rows and start transactions with.
+// For example, it can be: *pgxpool.Pool or *pgx.Conn.
+// pgx.Tx isn't a Querier, since the retry loop restarts the whole transaction, use ExecuteTx instead.
+type Querier interface {
+	pgxscan.Querier
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+var (
+	_ Querier = &pgxpool.Pool{}
+	_ Querier = &pgx.Conn{}
+)
+
+// API is a wrapper around the pgxscan.API type.
+// See pgxscan.API for details.
+type API struct {
+	pgxAPI         *pgxscan.API
+	asOfSystemTime string
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// WithScanAPI sets the pgxscan.API object that rows are scanned with, the default one is pgxscan.DefaultAPI.
+func WithScanAPI(pgxAPI *pgxscan.API) APIOption {
+	return func(api *API) {
+		api.pgxAPI = pgxAPI
+	}
+}
+
+// WithAsOfSystemTime makes Get, Select and Each read data as of the time that expr evaluates to,
+// unless the context sets another one, see AsOfSystemTime.
+func WithAsOfSystemTime(expr string) APIOption {
+	return func(api *API) {
+		api.asOfSystemTime = expr
+	}
+}
+
+// NewAPI creates a new API object with provided list of options.
+func NewAPI(opts ...APIOption) (*API, error) {
+	api := &API{pgxAPI: pgxscan.DefaultAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	if api.pgxAPI == nil {
+		return nil, fmt.Errorf("scany: pgxscan API must not be nil")
+	}
+	return api, nil
+}
+
+type asOfSystemTimeKey struct{}
+
+// AsOfSystemTime returns a context that makes Get, Select and Each read data as of the time that expr evaluates to,
+// e.g. "'-10s'" or "follower_read_timestamp()". The expression is put into the query as is,
+// so it must not come from untrusted input. An empty expr makes queries read the current data.
+func AsOfSystemTime(ctx context.Context, expr string) context.Context {
+	return context.WithValue(ctx, asOfSystemTimeKey{}, expr)
+}
+
+// FollowerRead returns a context that makes Get, Select and Each read data as of follower_read_timestamp(),
+// so any replica, e.g. the nearest one, can serve the query.
+func FollowerRead(ctx context.Context) context.Context {
+	return AsOfSystemTime(ctx, "follower_read_timestamp()")
+}
+
+func (api *API) asOf(ctx context.Context) string {
+	if expr, ok := ctx.Value(asOfSystemTimeKey{}).(string); ok {
+		return expr
+	}
+	return api.asOfSystemTime
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// Get is the same as pgxscan.API.Get, but it reruns the query if it fails with a retryable error.
+// If the context or the API object sets AS OF SYSTEM TIME, the query runs in a read-only transaction as of that time.
+func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return api.read(ctx, db, func(q pgxscan.Querier) error {
+		return api.pgxAPI.Get(ctx, q, dst, query, args...)
+	})
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Select is the same as pgxscan.API.Select, but it reruns the query if it fails with a retryable error.
+// If the context or the API object sets AS OF SYSTEM TIME, the query runs in a read-only transaction as of that time.
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return api.read(ctx, db, func(q pgxscan.Querier) error {
+		return api.pgxAPI.Select(ctx, q, dst, query, args...)
+	}, dst)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See API.Each for details.
+func Each[T any](ctx context.Context, db Querier, fn func(row T) error, query string, args ...interface{}) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// Each is the same as pgxscan.API.Each, but it reruns the query if it fails with a retryable error.
+// The query may fail after some rows have been scanned, so fn can be called again for the same rows
+// and must tolerate that, e.g. by writing rows in an idempotent way.
+// If the context or the API object sets AS OF SYSTEM TIME, the query runs in a read-only transaction as of that time.
+func (api *API) Each(
+	ctx context.Context, db Querier, dst interface{}, fn func() error, query string, args ...interface{},
+) error {
+	return api.read(ctx, db, func(q pgxscan.Querier) error {
+		return api.pgxAPI.Each(ctx, q, dst, fn, query, args...)
+	})
+}
+
+// read runs fn with the retry loop for single statements. If reads must be served from the past,
+// fn runs in a read-only transaction, since CockroachDB sets the time of AS OF SYSTEM TIME per transaction.
+// Slices that dsts point to are truncated back to their length before the first attempt on every retry,
+// so rows appended by a failed attempt aren't kept.
+func (api *API) read(ctx context.Context, db Querier, fn func(q pgxscan.Querier) error, dsts ...interface{}) error {
+	sliceLens := make([]int, len(dsts))
+	for i, dst := range dsts {
+		sliceLens[i] = sliceLen(dst)
+	}
+	resetDsts := func() {
+		for i, dst := range dsts {
+			truncateSlice(dst, sliceLens[i])
+		}
+	}
+	expr := api.asOf(ctx)
+	if expr == "" {
+		return crdb.Execute(func() error {
+			resetDsts()
+			return fn(db)
+		})
+	}
+	return crdb.Execute(func() error {
+		resetDsts()
+		tx, err := db.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+		if err != nil {
+			return fmt.Errorf("scany: begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx) //nolint: errcheck
+		if _, err := tx.Exec(ctx, "SET TRANSACTION AS OF SYSTEM TIME "+expr); err != nil {
+			return fmt.Errorf("scany: set as of system time: %w", err)
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("scany: commit transaction: %w", err)
+		}
+		return nil
+	})
+}
+
+// sliceLen returns the length of the slice that dst points to, or -1 if dst isn't a pointer to a slice.
+func sliceLen(dst interface{}) int {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Slice {
+		return -1
+	}
+	return dstValue.Elem().Len()
+}
+
+// truncateSlice removes elements that were appended to the slice that dst points to after its length was n,
+// see sliceLen.
+func truncateSlice(dst interface{}, n int) {
+	if n < 0 {
+		return
+	}
+	sliceValue := reflect.ValueOf(dst).Elem()
+	if sliceValue.Len() > n {
+		sliceValue.Set(sliceValue.Slice(0, n))
+	}
+}
+
+// ErrTxManaged is returned by Tx.Commit and Tx.Rollback, since ExecuteTx ends the transaction itself.
+var ErrTxManaged = errors.New("scany: the transaction is committed or rolled back by ExecuteTx")
+
+// Tx is a wrapper around pgx.Tx that exposes pgxscan high-level functions bound to the transaction.
+// All pgx.Tx methods are available as well, but Commit and Rollback return ErrTxManaged,
+// fn returns nil to commit the transaction and an error to roll it back, see ExecuteTx.
+type Tx struct {
+	pgx.Tx
+	api *API
+}
+
+// Commit returns ErrTxManaged without committing the transaction.
+func (tx *Tx) Commit(context.Context) error {
+	return ErrTxManaged
+}
+
+// Rollback returns ErrTxManaged without rolling the transaction back.
+func (tx *Tx) Rollback(context.Context) error {
+	return ErrTxManaged
+}
+
+// Get is the same as pgxscan.API.Get executed within the transaction.
+func (tx *Tx) Get(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.pgxAPI.Get(ctx, tx.Tx, dst, query, args...)
+}
+
+// Select is the same as pgxscan.API.Select executed within the transaction.
+func (tx *Tx) Select(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.pgxAPI.Select(ctx, tx.Tx, dst, query, args...)
+}
+
+// Each is the same as pgxscan.API.Each executed within the transaction.
+func (tx *Tx) Each(ctx context.Context, dst interface{}, fn func() error, query string, args ...interface{}) error {
+	return tx.api.pgxAPI.Each(ctx, tx.Tx, dst, fn, query, args...)
+}
+
+// ExecuteTx is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecuteTx for details.
+func ExecuteTx(ctx context.Context, db Querier, txOptions pgx.TxOptions, fn func(tx *Tx) error) error {
+	return DefaultAPI.ExecuteTx(ctx, db, txOptions, fn)
+}
+
+// ExecuteTx begins a transaction with the options, runs fn in it and commits it.
+// It's the same as crdb.ExecuteTx, but for pgx: if fn or the commit fails with a retryable error,
+// the transaction is rolled back to the restart savepoint and fn runs again, otherwise the transaction
+// is rolled back and ExecuteTx returns the error:
+//
+//	err := crdbscan.ExecuteTx(ctx, db, pgx.TxOptions{}, func(tx *crdbscan.Tx) error {
+//		if err := tx.Get(ctx, &account, `SELECT * FROM accounts WHERE id = $1`, id); err != nil {
+//			return err
+//		}
+//		_, err := tx.Exec(ctx, `UPDATE accounts SET balance = $1 WHERE id = $2`, account.Balance+amount, id)
+//		return err
+//	})
+//
+// fn may run several times, so it must not have side effects beyond changes to the database,
+// and it must wrap errors with %w for retryable ones to be recognized. See crdb.ExecuteTx for details.
+func (api *API) ExecuteTx(ctx context.Context, db Querier, txOptions pgx.TxOptions, fn func(tx *Tx) error) error {
+	tx, err := db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	return crdb.ExecuteInTx(ctx, crdbTx{tx}, func() error {
+		return fn(&Tx{Tx: tx, api: api})
+	})
+}
+
+// crdbTx adapts pgx.Tx to the crdb.Tx interface that the retry loop works with.
+type crdbTx struct {
+	tx pgx.Tx
+}
+
+var _ crdb.Tx = crdbTx{}
+
+func (tx crdbTx) Exec(ctx context.Context, query string, args ...interface{}) error {
+	_, err := tx.tx.Exec(ctx, query, args...)
+	return err
+}
+
+func (tx crdbTx) Commit(ctx context.Context) error {
+	return tx.tx.Commit(ctx)
+}
+
+func (tx crdbTx) Rollback(ctx context.Context) error {
+	return tx.tx.Rollback(ctx)
+}
+
+func mustNewAPI() *API {
+	api, err := NewAPI()
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI()
@@ -0,0 +1,191 @@
+package crdbscan_test
+
+import (
+	"context"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/crdbscan"
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+var (
+	testDB *pgxpool.Pool
+	ctx    = context.Background()
+)
+
+type testModel struct {
+	Foo string
+	Bar string
+}
+
+const (
+	multipleRowsQuery = `
+		SELECT *
+		FROM (
+			VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2'), ('foo val 3', 'bar val 3')
+		) AS t (foo, bar)
+	`
+	singleRowsQuery = `
+		SELECT 'foo val' AS foo, 'bar val' AS bar
+	`
+)
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err := crdbscan.Select(ctx, testDB, &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+// flakyQuerier makes the rows of the first failures queries fail with a retryable error after they are read.
+type flakyQuerier struct {
+	*pgxpool.Pool
+	failures int
+}
+
+func (q *flakyQuerier) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := q.Pool.Query(ctx, query, args...)
+	if err != nil || q.failures == 0 {
+		return rows, err
+	}
+	q.failures--
+	return &retryableErrRows{Rows: rows}, nil
+}
+
+type retryableErrRows struct {
+	pgx.Rows
+}
+
+func (r *retryableErrRows) Err() error {
+	if err := r.Rows.Err(); err != nil {
+		return err
+	}
+	return &pgconn.PgError{Code: "40001", Message: "restart transaction"}
+}
+
+func TestSelect_withAppendToSlice_retryableErr_keepsExistingElements(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := pgxscan.NewDBScanAPI(dbscan.WithAppendToSlice(true))
+	require.NoError(t, err)
+	pgxAPI, err := pgxscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	api, err := crdbscan.NewAPI(crdbscan.WithScanAPI(pgxAPI))
+	require.NoError(t, err)
+	expected := []*testModel{
+		{Foo: "existing foo", Bar: "existing bar"},
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	got := []*testModel{{Foo: "existing foo", Bar: "existing bar"}}
+	err = api.Select(ctx, &flakyQuerier{Pool: testDB, failures: 1}, &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGet_asOfSystemTime(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := crdbscan.Get(crdbscan.AsOfSystemTime(ctx, "'-1us'"), testDB, &got, singleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+	var got []string
+
+	err := crdbscan.Each(ctx, testDB, func(row testModel) error {
+		got = append(got, row.Foo)
+		return nil
+	}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"foo val", "foo val 2", "foo val 3"}, got)
+}
+
+func TestExecuteTx_retryableErr_retries(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+
+	var got testModel
+	err := crdbscan.ExecuteTx(ctx, testDB, pgx.TxOptions{}, func(tx *crdbscan.Tx) error {
+		attempts++
+		if err := tx.Get(ctx, &got, singleRowsQuery); err != nil {
+			return err
+		}
+		if attempts == 1 {
+			return &pgconn.PgError{Code: "40001", Message: "restart transaction"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, got)
+}
+
+func TestExecuteTx_commitInFn_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	err := crdbscan.ExecuteTx(ctx, testDB, pgx.TxOptions{}, func(tx *crdbscan.Tx) error {
+		return tx.Commit(ctx)
+	})
+
+	assert.ErrorIs(t, err, crdbscan.ErrTxManaged)
+}
+
+func TestExecuteTx_otherErr_returnsErr(t *testing.T) {
+	t.Parallel()
+	attempts := 0
+
+	var got testModel
+	err := crdbscan.ExecuteTx(ctx, testDB, pgx.TxOptions{}, func(tx *crdbscan.Tx) error {
+		attempts++
+		return tx.Get(ctx, &got, `SELECT missing_column`)
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestMain(m *testing.M) {
+	exitCode := func() int {
+		flag.Parse()
+		ts, err := testserver.NewTestServer()
+		if err != nil {
+			panic(err)
+		}
+		defer ts.Stop()
+		testDB, err = pgxpool.New(ctx, ts.PGURL().String())
+		if err != nil {
+			panic(err)
+		}
+		defer testDB.Close()
+		return m.Run()
+	}()
+	os.Exit(exitCode)
+}
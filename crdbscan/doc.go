@@ -0,0 +1,26 @@
+// Package crdbscan allows scanning data into Go structs and other composite types,
+// when working with CockroachDB via pgx library native interface.
+/*
+Essentially, crdbscan is a wrapper around github.com/georgysavva/scany/v2/pgxscan package
+that retries queries and transactions on the errors CockroachDB asks clients to retry,
+using the retry loop of github.com/cockroachdb/cockroach-go/v2/crdb.
+It's encouraged to read pgxscan docs first to get familiar with how rows are scanned:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/pgxscan
+
+Querying rows
+
+crdbscan has the same high-level functions Get, Select and Each as pgxscan,
+they accept anything that implements Querier interface, e.g. *pgxpool.Pool or *pgx.Conn,
+and rerun the query if it fails with a retryable error.
+ExecuteTx runs a function in a transaction and restarts it on retryable errors,
+the function receives Tx that exposes Get, Select and Each bound to the transaction.
+
+Historical reads
+
+Reads can be served from the past with AS OF SYSTEM TIME, so they don't conflict with concurrent writes
+and may be served by the nearest replica. AsOfSystemTime and FollowerRead set the time for queries with the context,
+WithAsOfSystemTime sets it for all reads of the API object:
+
+	err := crdbscan.Select(crdbscan.FollowerRead(ctx), db, &users, `SELECT * FROM users`)
+*/
+package crdbscan
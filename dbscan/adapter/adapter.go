@@ -0,0 +1,136 @@
+// Package adapter helps to implement dbscan.Rows adapters for new database libraries.
+/*
+An adapter makes rows, a result or an iterator of a database library compliant with the dbscan.Rows interface,
+like sqlscan.RowsAdapter does for *sql.Rows. adapter provides the parts that most adapters repeat:
+
+  - RowsFromColumnsAndValues makes rows from a result that is already in memory,
+    e.g. a decoded JSON response of an HTTP API.
+  - Funcs makes rows from functions, with defaults for the ones the library doesn't need,
+    e.g. NextResultSet.
+  - Assign converts a value that the library returns into a destination that dbscan passes to Scan,
+    the way database/sql does it, so adapters don't need to handle every destination type.
+  - ScanArgsError, ColumnError and WrapError make errors that look the same in all adapters.
+
+The adaptertest package has a conformance test suite that adapters run to prove they work with dbscan:
+
+	func TestConformance(t *testing.T) {
+		adaptertest.Run(t, func(t *testing.T, f adaptertest.Fixture) dbscan.Rows {
+			return foodb.NewRowsAdapter(fakeResult(f.Columns, f.Rows))
+		})
+	}
+*/
+package adapter
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Funcs are functions that implement the dbscan.Rows methods of the same names, see New.
+// Columns, Next and Scan are required.
+type Funcs struct {
+	Columns func() ([]string, error)
+	Next    func() bool
+	Scan    func(dest ...interface{}) error
+	// Err defaults to a function that returns nil.
+	Err func() error
+	// Close defaults to a function that returns nil.
+	Close func() error
+	// NextResultSet defaults to a function that returns false.
+	NextResultSet func() bool
+}
+
+// New returns rows that call the functions. It panics if a required function is nil,
+// since it's a programming error of the adapter.
+func New(funcs Funcs) dbscan.Rows {
+	if funcs.Columns == nil || funcs.Next == nil || funcs.Scan == nil {
+		panic("scany: adapter.Funcs must have Columns, Next and Scan functions")
+	}
+	return &funcRows{funcs: funcs}
+}
+
+type funcRows struct {
+	funcs  Funcs
+	closed bool
+}
+
+// Close implements the dbscan.Rows.Close method, it calls Funcs.Close only once.
+func (r *funcRows) Close() error {
+	if r.closed || r.funcs.Close == nil {
+		r.closed = true
+		return nil
+	}
+	r.closed = true
+	return r.funcs.Close()
+}
+
+// Err implements the dbscan.Rows.Err method.
+func (r *funcRows) Err() error {
+	if r.funcs.Err == nil {
+		return nil
+	}
+	return r.funcs.Err()
+}
+
+// Next implements the dbscan.Rows.Next method, it returns false after Close.
+func (r *funcRows) Next() bool {
+	return !r.closed && r.funcs.Next()
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (r *funcRows) Columns() ([]string, error) {
+	return r.funcs.Columns()
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (r *funcRows) Scan(dest ...interface{}) error {
+	return r.funcs.Scan(dest...)
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+func (r *funcRows) NextResultSet() bool {
+	if r.closed || r.funcs.NextResultSet == nil {
+		return false
+	}
+	return r.funcs.NextResultSet()
+}
+
+// RowsFromColumnsAndValues returns rows with the columns and values of every row in the order of columns.
+// Values are assigned to destinations with Assign. Rows that have a different number of values than columns
+// make Scan return an error.
+func RowsFromColumnsAndValues(columns []string, values [][]interface{}) dbscan.Rows {
+	next := -1
+	return New(Funcs{
+		Columns: func() ([]string, error) {
+			return columns, nil
+		},
+		Next: func() bool {
+			if next < len(values) {
+				next++
+			}
+			return next < len(values)
+		},
+		Scan: func(dest ...interface{}) error {
+			if next < 0 || next >= len(values) {
+				return errScanWithoutNext
+			}
+			row := values[next]
+			if len(row) != len(columns) {
+				return fmt.Errorf("scany: row %d has %d values, not %d", next, len(row), len(columns))
+			}
+			if len(dest) != len(columns) {
+				return ScanArgsError(len(columns), len(dest))
+			}
+			for i, d := range dest {
+				if err := Assign(d, row[i]); err != nil {
+					return ColumnError(columns[i], err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+var errScanWithoutNext = errors.New("scany: scan called without calling Next")
@@ -0,0 +1,144 @@
+package adapter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/adapter"
+	"github.com/georgysavva/scany/v2/dbscan/adapter/adaptertest"
+)
+
+func TestRowsFromColumnsAndValues_conformance(t *testing.T) {
+	t.Parallel()
+	adaptertest.Run(t, func(t *testing.T, f adaptertest.Fixture) dbscan.Rows {
+		return adapter.RowsFromColumnsAndValues(f.Columns, f.Rows)
+	})
+}
+
+func TestNew_conformance(t *testing.T) {
+	t.Parallel()
+	adaptertest.Run(t, func(t *testing.T, f adaptertest.Fixture) dbscan.Rows {
+		// Values are kept as strings and bytes, like some libraries do, so Assign converts them.
+		rows := make([][]interface{}, len(f.Rows))
+		for i, row := range f.Rows {
+			rows[i] = make([]interface{}, len(row))
+			for j, v := range row {
+				switch v := v.(type) {
+				case int64:
+					rows[i][j] = int32(v)
+				case string:
+					rows[i][j] = []byte(v)
+				default:
+					rows[i][j] = v
+				}
+			}
+		}
+		next := -1
+		return adapter.New(adapter.Funcs{
+			Columns: func() ([]string, error) { return f.Columns, nil },
+			Next: func() bool {
+				next++
+				return next < len(rows)
+			},
+			Scan: func(dest ...interface{}) error {
+				if len(dest) != len(f.Columns) {
+					return adapter.ScanArgsError(len(f.Columns), len(dest))
+				}
+				for i, d := range dest {
+					if err := adapter.Assign(d, rows[next][i]); err != nil {
+						return adapter.ColumnError(f.Columns[i], err)
+					}
+				}
+				return nil
+			},
+		})
+	})
+}
+
+func TestNew_missingFuncs_panics(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() { adapter.New(adapter.Funcs{}) })
+}
+
+func TestAssign(t *testing.T) {
+	t.Parallel()
+	type name string
+	str := "foo"
+	cases := []struct {
+		name     string
+		dst      interface{}
+		src      interface{}
+		expected interface{}
+	}{
+		{name: "interface", dst: new(interface{}), src: int32(1), expected: int32(1)},
+		{name: "int32 into int64", dst: new(int64), src: int32(1), expected: int64(1)},
+		{name: "whole float into int", dst: new(int), src: 2.0, expected: 2},
+		{name: "uint into int", dst: new(int), src: uint8(3), expected: 3},
+		{name: "int into float", dst: new(float64), src: int64(4), expected: 4.0},
+		{name: "int into bool", dst: new(bool), src: int64(1), expected: true},
+		{name: "int into string", dst: new(string), src: int64(5), expected: "5"},
+		{name: "bytes into string", dst: new(string), src: []byte("foo"), expected: "foo"},
+		{name: "string into bytes", dst: new([]byte), src: "foo", expected: []byte("foo")},
+		{name: "string into named string", dst: new(name), src: "foo", expected: name("foo")},
+		{name: "string into pointer", dst: new(*string), src: "foo", expected: &str},
+		{name: "pointer into string", dst: new(string), src: &str, expected: "foo"},
+		{name: "nil into pointer", dst: &[]*string{&str}[0], src: nil, expected: (*string)(nil)},
+		{name: "nil into int", dst: &[]int{1}[0], src: nil, expected: 0},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := adapter.Assign(tc.dst, tc.src)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, reflectElem(tc.dst))
+		})
+	}
+}
+
+func TestAssign_notAssignable_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		dst         interface{}
+		src         interface{}
+		expectedErr string
+	}{
+		{name: "overflow", dst: new(int8), src: int64(300), expectedErr: "scany: value 300 of int64 doesn't fit into int8"},
+		{
+			name: "negative into uint", dst: new(uint), src: int64(-1),
+			expectedErr: "scany: value -1 of int64 doesn't fit into uint",
+		},
+		{name: "fraction into int", dst: new(int), src: 1.5, expectedErr: "scany: value 1.5 of float64 doesn't fit into int"},
+		{name: "string into int", dst: new(int), src: "foo", expectedErr: "scany: can't assign string to int"},
+		{name: "not a pointer", dst: 1, src: 1, expectedErr: "scany: destination must be a non-nil pointer, got: int"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := adapter.Assign(tc.dst, tc.src)
+
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	t.Parallel()
+	err := assert.AnError
+
+	assert.ErrorIs(t, adapter.WrapError("query rows", err), err)
+	assert.EqualError(t, adapter.WrapError("query rows", err), "scany: query rows: "+err.Error())
+	assert.NoError(t, adapter.WrapError("query rows", nil))
+}
+
+func reflectElem(ptr interface{}) interface{} {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
@@ -0,0 +1,163 @@
+// Package adaptertest is a conformance test suite for dbscan.Rows adapters, see Run.
+package adaptertest
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Fixture is a result set that rows of the adapter under test must return.
+// Values are int64, float64, string, bool or nil, rows of the adapter may return them as other types,
+// e.g. int32 or []byte, as long as they scan into fields of these types.
+type Fixture struct {
+	Columns []string
+	Rows    [][]interface{}
+}
+
+// NewRowsFunc returns new rows of the adapter under test with the columns and values of the fixture,
+// e.g. by querying them from a test database or by building a fake result of the database library.
+type NewRowsFunc func(t *testing.T, f Fixture) dbscan.Rows
+
+type user struct {
+	ID     int64
+	Name   string
+	Score  float64
+	Active bool
+	Note   *string
+}
+
+var usersFixture = Fixture{
+	Columns: []string{"id", "name", "score", "active", "note"},
+	Rows: [][]interface{}{
+		{int64(1), "foo", 1.5, true, "foo note"},
+		{int64(2), "bar", 2.5, false, nil},
+	},
+}
+
+func expectedUsers() []user {
+	note := "foo note"
+	return []user{
+		{ID: 1, Name: "foo", Score: 1.5, Active: true, Note: &note},
+		{ID: 2, Name: "bar", Score: 2.5},
+	}
+}
+
+// Run runs the conformance test suite for rows that newRows returns, as subtests of t.
+// It checks that dbscan scans the rows into structs, maps and primitive types,
+// and that the rows follow the dbscan.Rows contract: Next returns false after the last row and after Close,
+// Scan fails with the wrong number of destinations, and Err and Close return nil if nothing fails.
+func Run(t *testing.T, newRows NewRowsFunc) {
+	t.Helper()
+	t.Run("ScanAll into structs", func(t *testing.T) {
+		var got []user
+		if err := dbscan.ScanAll(&got, newRows(t, usersFixture)); err != nil {
+			t.Fatalf("ScanAll: %v", err)
+		}
+		if expected := expectedUsers(); !reflect.DeepEqual(got, expected) {
+			t.Errorf("ScanAll: got %+v, expected %+v", got, expected)
+		}
+	})
+	t.Run("ScanAll into maps", func(t *testing.T) {
+		var got []map[string]interface{}
+		if err := dbscan.ScanAll(&got, newRows(t, usersFixture)); err != nil {
+			t.Fatalf("ScanAll: %v", err)
+		}
+		if len(got) != len(usersFixture.Rows) {
+			t.Fatalf("ScanAll: got %d rows, expected %d", len(got), len(usersFixture.Rows))
+		}
+		expectedKeys := append([]string(nil), usersFixture.Columns...)
+		sort.Strings(expectedKeys)
+		for i, row := range got {
+			keys := make([]string, 0, len(row))
+			for k := range row {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, expectedKeys) {
+				t.Errorf("ScanAll: row %d has keys %v, expected %v", i, keys, expectedKeys)
+			}
+		}
+		if got[1]["note"] != nil {
+			t.Errorf("ScanAll: NULL value is %#v, expected nil", got[1]["note"])
+		}
+	})
+	t.Run("ScanAll into primitive types", func(t *testing.T) {
+		f := Fixture{Columns: []string{"name"}, Rows: [][]interface{}{{"foo"}, {"bar"}}}
+		var got []string
+		if err := dbscan.ScanAll(&got, newRows(t, f)); err != nil {
+			t.Fatalf("ScanAll: %v", err)
+		}
+		if expected := []string{"foo", "bar"}; !reflect.DeepEqual(got, expected) {
+			t.Errorf("ScanAll: got %v, expected %v", got, expected)
+		}
+	})
+	t.Run("ScanAll without rows", func(t *testing.T) {
+		f := Fixture{Columns: usersFixture.Columns}
+		var got []user
+		if err := dbscan.ScanAll(&got, newRows(t, f)); err != nil {
+			t.Fatalf("ScanAll: %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("ScanAll: got %+v, expected no rows", got)
+		}
+	})
+	t.Run("ScanOne", func(t *testing.T) {
+		f := Fixture{Columns: usersFixture.Columns, Rows: usersFixture.Rows[:1]}
+		var got user
+		if err := dbscan.ScanOne(&got, newRows(t, f)); err != nil {
+			t.Fatalf("ScanOne: %v", err)
+		}
+		if expected := expectedUsers()[0]; !reflect.DeepEqual(got, expected) {
+			t.Errorf("ScanOne: got %+v, expected %+v", got, expected)
+		}
+	})
+	t.Run("ScanOne without rows", func(t *testing.T) {
+		f := Fixture{Columns: usersFixture.Columns}
+		var got user
+		if err := dbscan.ScanOne(&got, newRows(t, f)); !dbscan.NotFound(err) {
+			t.Errorf("ScanOne: got error %v, expected dbscan.ErrNotFound", err)
+		}
+	})
+	t.Run("Next after the last row", func(t *testing.T) {
+		rows := newRows(t, usersFixture)
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		if n != len(usersFixture.Rows) {
+			t.Errorf("Next: got %d rows, expected %d", n, len(usersFixture.Rows))
+		}
+		if rows.Next() {
+			t.Error("Next: got true after the last row, expected false")
+		}
+		if err := rows.Err(); err != nil {
+			t.Errorf("Err: %v", err)
+		}
+		if err := rows.Close(); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	})
+	t.Run("Next after Close", func(t *testing.T) {
+		rows := newRows(t, usersFixture)
+		if err := rows.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		if rows.Next() {
+			t.Error("Next: got true after Close, expected false")
+		}
+	})
+	t.Run("Scan with the wrong number of destinations", func(t *testing.T) {
+		rows := newRows(t, usersFixture)
+		defer rows.Close()
+		if !rows.Next() {
+			t.Fatal("Next: got false, expected a row")
+		}
+		var id int64
+		if err := rows.Scan(&id); err == nil {
+			t.Error("Scan: got no error for 1 destination of 5 columns")
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package adapter
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Assign assigns the value src to the destination dst, that is a pointer, the way database/sql scans values:
+//
+//   - *interface{} destinations get src as is, sql.Scanner destinations scan it.
+//   - nil sets the destination to its zero value, e.g. a nil pointer.
+//   - Pointer destinations, e.g. **string, get a new value that src is assigned to.
+//   - Values of assignable and convertible types are assigned or converted, e.g. int32 to int64,
+//     numbers are checked for overflow, and floats are converted to integers only if they are whole numbers.
+//   - Numbers and bools are formatted into strings, strings and []byte are converted into each other,
+//     and integers into bools, with non-zero ones being true.
+func Assign(dst, src interface{}) error {
+	switch d := dst.(type) {
+	case *interface{}:
+		*d = src
+		return nil
+	case sql.Scanner:
+		return d.Scan(src)
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("scany: destination must be a non-nil pointer, got: %T", dst)
+	}
+	return assignValue(dstValue.Elem(), src)
+}
+
+func assignValue(dst reflect.Value, src interface{}) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Type().AssignableTo(dst.Type()) && !isBytes(srcValue) {
+		dst.Set(srcValue)
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return assignValue(dst, srcValue.Elem().Interface())
+	}
+	if ok, err := assignNumber(dst, srcValue); ok || err != nil {
+		return err
+	}
+	if ok := assignText(dst, srcValue); ok {
+		return nil
+	}
+	if srcValue.Type().ConvertibleTo(dst.Type()) && srcValue.Kind() == dst.Kind() {
+		// E.g. a named string type into a string.
+		dst.Set(srcValue.Convert(dst.Type()))
+		return nil
+	}
+	return fmt.Errorf("scany: can't assign %T to %s", src, dst.Type())
+}
+
+// assignNumber assigns numbers to numbers, bools and strings, and reports whether it did.
+func assignNumber(dst, src reflect.Value) (bool, error) {
+	switch src.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := src.Int()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(v) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetInt(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if v < 0 || dst.OverflowUint(uint64(v)) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetUint(uint64(v))
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(v))
+		case reflect.Bool:
+			dst.SetBool(v != 0)
+		case reflect.String:
+			dst.SetString(strconv.FormatInt(v, 10))
+		default:
+			return false, nil
+		}
+		return true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v := src.Uint()
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v > uint64(1<<63-1) || dst.OverflowInt(int64(v)) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetInt(int64(v))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if dst.OverflowUint(v) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetUint(v)
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(v))
+		case reflect.Bool:
+			dst.SetBool(v != 0)
+		case reflect.String:
+			dst.SetString(strconv.FormatUint(v, 10))
+		default:
+			return false, nil
+		}
+		return true, nil
+	case reflect.Float32, reflect.Float64:
+		v := src.Float()
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if dst.OverflowFloat(v) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetFloat(v)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v != float64(int64(v)) || dst.OverflowInt(int64(v)) {
+				return true, overflowError(src, dst)
+			}
+			dst.SetInt(int64(v))
+		case reflect.String:
+			dst.SetString(strconv.FormatFloat(v, 'g', -1, src.Type().Bits()))
+		default:
+			return false, nil
+		}
+		return true, nil
+	case reflect.Bool:
+		if dst.Kind() != reflect.String {
+			return false, nil
+		}
+		dst.SetString(strconv.FormatBool(src.Bool()))
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// assignText converts strings and byte slices into each other, and reports whether it did.
+// Byte slices are copied, since database libraries may reuse them for next rows.
+func assignText(dst, src reflect.Value) bool {
+	srcIsBytes, dstIsBytes := isBytes(src), isBytes(dst)
+	switch {
+	case src.Kind() == reflect.String && dst.Kind() == reflect.String:
+		dst.SetString(src.String())
+	case src.Kind() == reflect.String && dstIsBytes:
+		dst.SetBytes([]byte(src.String()))
+	case srcIsBytes && dst.Kind() == reflect.String:
+		dst.SetString(string(src.Bytes()))
+	case srcIsBytes && dstIsBytes:
+		dst.SetBytes(append([]byte(nil), src.Bytes()...))
+	default:
+		return false
+	}
+	return true
+}
+
+func isBytes(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+func overflowError(src, dst reflect.Value) error {
+	return fmt.Errorf("scany: value %v of %s doesn't fit into %s", src.Interface(), src.Type(), dst.Type())
+}
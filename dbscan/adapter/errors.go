@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"fmt"
+)
+
+// ScanArgsError returns the error of Scan with the wrong number of destinations,
+// e.g. "scany: expected 2 destination arguments in Scan, not 3".
+func ScanArgsError(expected, got int) error {
+	return fmt.Errorf("scany: expected %d destination arguments in Scan, not %d", expected, got)
+}
+
+// ColumnError wraps the error of scanning the column, e.g. "scany: column 'id': ...".
+// It returns nil if err is nil.
+func ColumnError(column string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("scany: column '%s': %w", column, err)
+}
+
+// WrapError wraps the error of the database library with the operation that failed, e.g. "scany: query rows: ...",
+// so errors.Is and errors.As still match the original error. It returns nil if err is nil.
+func WrapError(op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("scany: %s: %w", op, err)
+}
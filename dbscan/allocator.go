@@ -0,0 +1,111 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ElementAllocator allocates destination slice elements for ScanAll.
+// It allows recycling result memory between queries in high-throughput services.
+// ElementAllocator is only used when the destination is a slice of structs by a pointer, e.g. []*User,
+// since elements of a slice by value are allocated along with the slice itself.
+// Implementations must be safe for concurrent use.
+type ElementAllocator interface {
+	// Allocate returns a pointer to a zero value of the given struct type, e.g. *User for User type.
+	Allocate(elementType reflect.Type) interface{}
+	// Release takes back an element previously returned by Allocate.
+	// dbscan calls it for elements that ended up not being added to the destination slice, e.g. due to a scan error.
+	Release(element interface{})
+}
+
+// WithElementAllocator allows to allocate destination slice elements via a custom allocator.
+// By default dbscan allocates every element with reflect.New.
+// See ElementAllocator for details.
+func WithElementAllocator(allocator ElementAllocator) APIOption {
+	return func(api *API) {
+		api.elementAllocator = allocator
+	}
+}
+
+func (api *API) allocateElement(sliceMeta *sliceDestinationMeta) (reflect.Value, error) {
+	if !sliceMeta.elementByPtr || api.elementAllocator == nil {
+		return reflect.New(sliceMeta.elementBaseType), nil
+	}
+	element := reflect.ValueOf(api.elementAllocator.Allocate(sliceMeta.elementBaseType))
+	if !element.IsValid() || element.Type() != reflect.PtrTo(sliceMeta.elementBaseType) || element.IsNil() {
+		return reflect.Value{}, fmt.Errorf(
+			"scany: element allocator must return a non nil %v, got: %v",
+			reflect.PtrTo(sliceMeta.elementBaseType), element,
+		)
+	}
+	return element, nil
+}
+
+func (api *API) releaseElement(sliceMeta *sliceDestinationMeta, element reflect.Value) {
+	if sliceMeta.elementByPtr && api.elementAllocator != nil {
+		api.elementAllocator.Release(element.Interface())
+	}
+}
+
+// PoolAllocator is an ElementAllocator backed by a sync.Pool per struct type.
+type PoolAllocator struct {
+	pools sync.Map
+}
+
+var _ ElementAllocator = &PoolAllocator{}
+
+// NewPoolAllocator returns a new instance of the PoolAllocator.
+func NewPoolAllocator() *PoolAllocator {
+	return &PoolAllocator{}
+}
+
+// Allocate implements the ElementAllocator.Allocate method.
+// It reuses a previously released element if there is one.
+func (pa *PoolAllocator) Allocate(elementType reflect.Type) interface{} {
+	return pa.pool(elementType).Get()
+}
+
+// Release implements the ElementAllocator.Release method.
+// It resets the element to the zero value and puts it back to the pool.
+func (pa *PoolAllocator) Release(element interface{}) {
+	elementVal := reflect.ValueOf(element)
+	if elementVal.Kind() != reflect.Ptr || elementVal.IsNil() {
+		return
+	}
+	elementVal.Elem().Set(reflect.Zero(elementVal.Type().Elem()))
+	pa.pool(elementVal.Type().Elem()).Put(element)
+}
+
+// ReleaseAll releases all elements of the destination slice and resets the slice length to zero.
+// The destination must be a pointer to a slice of structs by a pointer, the same one that was passed to ScanAll.
+// Elements must not be used after releasing them.
+func (pa *PoolAllocator) ReleaseAll(dst interface{}) error {
+	dstVal, err := parseDestination(dst)
+	if err != nil {
+		return fmt.Errorf("scany: parsing destination: %w", err)
+	}
+	if dstVal.Kind() != reflect.Slice || dstVal.Type().Elem().Kind() != reflect.Ptr {
+		return newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: destination must be a slice of pointers, got: %v", dstVal.Type())
+	}
+	for i := 0; i < dstVal.Len(); i++ {
+		elementVal := dstVal.Index(i)
+		pa.Release(elementVal.Interface())
+		elementVal.Set(reflect.Zero(elementVal.Type()))
+	}
+	dstVal.SetLen(0)
+	return nil
+}
+
+func (pa *PoolAllocator) pool(elementType reflect.Type) *sync.Pool {
+	poolIface, ok := pa.pools.Load(elementType)
+	if !ok {
+		poolIface, _ = pa.pools.LoadOrStore(elementType, &sync.Pool{
+			New: func() interface{} {
+				return reflect.New(elementType).Interface()
+			},
+		})
+	}
+	return poolIface.(*sync.Pool)
+}
@@ -0,0 +1,110 @@
+package dbscan_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type countingAllocator struct {
+	*dbscan.PoolAllocator
+	allocated int
+	released  int
+}
+
+func (ca *countingAllocator) Allocate(elementType reflect.Type) interface{} {
+	ca.allocated++
+	return ca.PoolAllocator.Allocate(elementType)
+}
+
+func (ca *countingAllocator) Release(element interface{}) {
+	ca.released++
+	ca.PoolAllocator.Release(element)
+}
+
+func TestScanAll_withElementAllocator(t *testing.T) {
+	t.Parallel()
+	allocator := &countingAllocator{PoolAllocator: dbscan.NewPoolAllocator()}
+	api, err := getAPI(dbscan.WithElementAllocator(allocator))
+	require.NoError(t, err)
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, 3, allocator.allocated)
+	assert.Equal(t, 0, allocator.released)
+}
+
+func TestScanAll_withElementAllocatorScanError_releasesElement(t *testing.T) {
+	t.Parallel()
+	allocator := &countingAllocator{PoolAllocator: dbscan.NewPoolAllocator()}
+	api, err := getAPI(dbscan.WithElementAllocator(allocator))
+	require.NoError(t, err)
+
+	var got []*struct {
+		Foo string
+		Bar int
+	}
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+	require.Error(t, err)
+
+	assert.Len(t, got, 0)
+	assert.Equal(t, 1, allocator.allocated)
+	assert.Equal(t, 1, allocator.released)
+}
+
+func TestPoolAllocator_ReleaseAll(t *testing.T) {
+	t.Parallel()
+	allocator := dbscan.NewPoolAllocator()
+	api, err := getAPI(dbscan.WithElementAllocator(allocator))
+	require.NoError(t, err)
+
+	var got []*testModel
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+	require.NoError(t, err)
+	err = allocator.ReleaseAll(&got)
+	require.NoError(t, err)
+	assert.Len(t, got, 0)
+
+	err = api.ScanAll(&got, queryRows(t, singleRowsQuery))
+	require.NoError(t, err)
+	assert.Equal(t, []*testModel{{Foo: "foo val", Bar: "bar val"}}, got)
+}
+
+func TestPoolAllocator_ReleaseAll_invalidDestination_returnsErr(t *testing.T) {
+	t.Parallel()
+	allocator := dbscan.NewPoolAllocator()
+	expectedErr := "scany: destination must be a slice of pointers, got: []dbscan_test.testModel"
+
+	err := allocator.ReleaseAll(&[]testModel{})
+
+	assert.EqualError(t, err, expectedErr)
+}
+
+type invalidAllocator struct{}
+
+func (invalidAllocator) Allocate(reflect.Type) interface{} { return &struct{}{} }
+func (invalidAllocator) Release(interface{})               {}
+
+func TestScanAll_withInvalidElementAllocator_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithElementAllocator(invalidAllocator{}))
+	require.NoError(t, err)
+	expectedErr := "scanning: scany: element allocator must return a non nil *dbscan_test.testModel, got: &{}"
+
+	var got []*testModel
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+
+	assert.EqualError(t, err, expectedErr)
+}
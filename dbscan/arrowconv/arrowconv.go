@@ -0,0 +1,207 @@
+// Package arrowconv scans database rows directly into Apache Arrow record batches.
+/*
+arrowconv works with the abstract dbscan.Rows interface, so it can be used with any database library
+that dbscan supports, e.g. via sqlscan.NewRowsAdapter or pgxscan.NewRowsAdapter.
+Records can be handed off to analytics pipelines or Parquet writers as is.
+
+	rows, _ := db.Query(`SELECT id, name, created_at FROM users`)
+	records, err := arrowconv.ScanAll(ctx, sqlscan.NewRowsAdapter(rows))
+	// Release records once they are no longer needed.
+
+The Arrow schema is inferred from the database types of columns,
+which requires rows to implement dbscan.ColumnTypesRows, like the sqlscan and pgxscan adapters do.
+Alternatively, the schema can be inferred from a Go struct via WithStructSchema,
+or set explicitly via WithSchema.
+
+arrowconv is a separate module, so the Arrow dependency doesn't affect users of the other scany packages.
+*/
+package arrowconv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+const defaultBatchSize = 1024
+
+// API is the core type in arrowconv. It implements all the logic and exposes functionality available in the package.
+// With API type users can create a custom API instance and override default settings hence configure arrowconv.
+type API struct {
+	dbscanAPI  *dbscan.API
+	allocator  memory.Allocator
+	batchSize  int
+	schema     *arrow.Schema
+	structType reflect.Type
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates a new API object from dbscan.API instance with provided list of options.
+// dbscanAPI is used to map columns to struct fields, see WithStructSchema.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{
+		dbscanAPI: dbscanAPI,
+		allocator: memory.DefaultAllocator,
+		batchSize: defaultBatchSize,
+	}
+	for _, o := range opts {
+		o(api)
+	}
+	if api.batchSize < 1 {
+		return nil, fmt.Errorf("arrowconv: batch size must be positive, got: %d", api.batchSize)
+	}
+	if api.schema != nil && api.structType != nil {
+		return nil, fmt.Errorf("arrowconv: WithSchema and WithStructSchema can't be used together")
+	}
+	return api, nil
+}
+
+// WithAllocator allows to use a custom Arrow memory allocator.
+// The default one is memory.DefaultAllocator.
+func WithAllocator(allocator memory.Allocator) APIOption {
+	return func(api *API) {
+		api.allocator = allocator
+	}
+}
+
+// WithBatchSize sets the maximum number of rows in a record batch.
+// The default batch size is 1024 rows.
+func WithBatchSize(batchSize int) APIOption {
+	return func(api *API) {
+		api.batchSize = batchSize
+	}
+}
+
+// WithSchema makes arrowconv use the given schema instead of inferring it.
+// Schema fields are matched with columns by position, so their number must be the same.
+func WithSchema(schema *arrow.Schema) APIOption {
+	return func(api *API) {
+		api.schema = schema
+	}
+}
+
+// WithStructSchema makes arrowconv infer the schema from the given struct, e.g. User{} or &User{}.
+// Every column gets the Arrow type of the struct field that dbscan maps it to, see dbscan.API.FieldType.
+// Fields by a pointer and sql.Null* types are nullable.
+func WithStructSchema(dst interface{}) APIOption {
+	return func(api *API) {
+		t := reflect.TypeOf(dst)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		api.structType = t
+	}
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(ctx context.Context, rows dbscan.Rows) ([]arrow.Record, error) {
+	return DefaultAPI.ScanAll(ctx, rows)
+}
+
+// ScanBatches is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanBatches for details.
+func ScanBatches(ctx context.Context, rows dbscan.Rows, fn func(record arrow.Record) error) error {
+	return DefaultAPI.ScanBatches(ctx, rows, fn)
+}
+
+// ScanAll iterates all rows to the end and returns them as record batches.
+// The caller owns the returned records and must release them.
+// After iterating all rows, it closes them.
+func (api *API) ScanAll(ctx context.Context, rows dbscan.Rows) ([]arrow.Record, error) {
+	var records []arrow.Record
+	err := api.ScanBatches(ctx, rows, func(record arrow.Record) error {
+		record.Retain()
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		for _, record := range records {
+			record.Release()
+		}
+		return nil, err
+	}
+	return records, nil
+}
+
+// ScanBatches iterates all rows to the end and passes them to fn in record batches, see WithBatchSize.
+// The record is released after fn returns, fn must call Retain to keep it.
+// It checks the context between rows and aborts scanning as soon as the context is done.
+// An error returned from fn aborts scanning. After iterating all rows, it closes them.
+func (api *API) ScanBatches(ctx context.Context, rows dbscan.Rows, fn func(record arrow.Record) error) error {
+	defer rows.Close() //nolint: errcheck
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("arrowconv: get rows columns: %w", err)
+	}
+	schema, err := api.resolveSchema(rows, columns)
+	if err != nil {
+		return err
+	}
+	builder := array.NewRecordBuilder(api.allocator, schema)
+	defer builder.Release()
+
+	flush := func() error {
+		record := builder.NewRecord()
+		defer record.Release()
+		return fn(record)
+	}
+	values := make([]interface{}, len(columns))
+	scans := make([]interface{}, len(columns))
+	for i := range values {
+		scans[i] = &values[i]
+	}
+	var rowIndex, batchRows int
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("arrowconv: scanning aborted: %w", err)
+		}
+		if err := rows.Scan(scans...); err != nil {
+			return fmt.Errorf("arrowconv: scan row into raw values: %w", err)
+		}
+		for i, value := range values {
+			if err := appendValue(builder.Field(i), value); err != nil {
+				return fmt.Errorf("arrowconv: column '%s' at row %d: %w", columns[i], rowIndex, err)
+			}
+		}
+		rowIndex++
+		batchRows++
+		if batchRows >= api.batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			batchRows = 0
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("arrowconv: rows final error: %w", err)
+	}
+	if batchRows > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("arrowconv: close rows after processing: %w", err)
+	}
+	return nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(dbscan.DefaultAPI)
@@ -0,0 +1,158 @@
+package arrowconv_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/arrowconv"
+)
+
+type fakeRows struct {
+	columns []string
+	dbTypes []string
+	data    [][]interface{}
+	current int
+	closed  bool
+}
+
+func newFakeRows(columns, dbTypes []string, data ...[]interface{}) *fakeRows {
+	return &fakeRows{columns: columns, dbTypes: dbTypes, data: data, current: -1}
+}
+
+func (r *fakeRows) Close() error               { r.closed = true; return nil }
+func (r *fakeRows) Err() error                 { return nil }
+func (r *fakeRows) Next() bool                 { r.current++; return r.current < len(r.data) }
+func (r *fakeRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *fakeRows) NextResultSet() bool        { return false }
+func (r *fakeRows) ColumnDatabaseTypes() ([]string, error) {
+	if r.dbTypes == nil {
+		return nil, errors.New("column types aren't known")
+	}
+	return r.dbTypes, nil
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		*d.(*interface{}) = r.data[r.current][i]
+	}
+	return nil
+}
+
+var _ dbscan.Rows = &fakeRows{}
+
+func TestScanAll_databaseTypesSchema(t *testing.T) {
+	t.Parallel()
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+	api, err := arrowconv.NewAPI(dbscan.DefaultAPI, arrowconv.WithAllocator(mem), arrowconv.WithBatchSize(2))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := newFakeRows(
+		[]string{"id", "name", "active", "created_at"},
+		[]string{"int8", "text", "bool", "timestamptz"},
+		[]interface{}{int64(1), "Ann", true, createdAt},
+		[]interface{}{int64(2), nil, false, createdAt},
+		[]interface{}{int64(3), "Bob", nil, nil},
+	)
+
+	records, err := api.ScanAll(context.Background(), rows)
+	require.NoError(t, err)
+	defer func() {
+		for _, record := range records {
+			record.Release()
+		}
+	}()
+
+	require.Len(t, records, 2)
+	assert.True(t, rows.closed)
+	assert.EqualValues(t, 2, records[0].NumRows())
+	assert.EqualValues(t, 1, records[1].NumRows())
+	schema := records[0].Schema()
+	assert.Equal(t, arrow.PrimitiveTypes.Int64, schema.Field(0).Type)
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(1).Type)
+	assert.Equal(t, arrow.FixedWidthTypes.Boolean, schema.Field(2).Type)
+	assert.Equal(t, []int64{1, 2}, records[0].Column(0).(*array.Int64).Int64Values())
+	names := records[0].Column(1).(*array.String)
+	assert.Equal(t, "Ann", names.Value(0))
+	assert.True(t, names.IsNull(1))
+	createdAtValues := records[0].Column(3).(*array.Timestamp)
+	assert.Equal(t, arrow.Timestamp(createdAt.UnixMicro()), createdAtValues.Value(0))
+}
+
+func TestScanAll_structSchema(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		ID    int32
+		Email sql.NullString
+		Score *float64
+	}
+	api, err := arrowconv.NewAPI(dbscan.DefaultAPI, arrowconv.WithStructSchema(&user{}))
+	require.NoError(t, err)
+	rows := newFakeRows(
+		[]string{"id", "email", "score"}, nil,
+		[]interface{}{int64(1), "ann@example.com", 1.5},
+	)
+
+	records, err := api.ScanAll(context.Background(), rows)
+	require.NoError(t, err)
+	defer records[0].Release()
+
+	schema := records[0].Schema()
+	assert.Equal(t, arrow.PrimitiveTypes.Int32, schema.Field(0).Type)
+	assert.False(t, schema.Field(0).Nullable)
+	assert.Equal(t, arrow.BinaryTypes.String, schema.Field(1).Type)
+	assert.True(t, schema.Field(1).Nullable)
+	assert.Equal(t, arrow.PrimitiveTypes.Float64, schema.Field(2).Type)
+	assert.Equal(t, []int32{1}, records[0].Column(0).(*array.Int32).Int32Values())
+}
+
+func TestScanAll_returnsErr(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		ID int8
+	}
+	cases := []struct {
+		name        string
+		opts        []arrowconv.APIOption
+		rows        *fakeRows
+		expectedErr string
+	}{
+		{
+			name:        "column types aren't known",
+			rows:        newFakeRows([]string{"id"}, nil),
+			expectedErr: "arrowconv: get column database types: column types aren't known",
+		},
+		{
+			name:        "no corresponding field",
+			opts:        []arrowconv.APIOption{arrowconv.WithStructSchema(user{})},
+			rows:        newFakeRows([]string{"id", "email"}, nil),
+			expectedErr: "arrowconv: column 'email': no corresponding field found in arrowconv_test.user",
+		},
+		{
+			name:        "value overflows",
+			opts:        []arrowconv.APIOption{arrowconv.WithStructSchema(user{})},
+			rows:        newFakeRows([]string{"id"}, nil, []interface{}{int64(1000)}),
+			expectedErr: "arrowconv: column 'id' at row 0: value 1000 is out of range [-128, 127]",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := arrowconv.NewAPI(dbscan.DefaultAPI, tc.opts...)
+			require.NoError(t, err)
+			_, err = api.ScanAll(context.Background(), tc.rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
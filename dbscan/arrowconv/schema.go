@@ -0,0 +1,178 @@
+package arrowconv
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func (api *API) resolveSchema(rows dbscan.Rows, columns []string) (*arrow.Schema, error) {
+	switch {
+	case api.schema != nil:
+		if len(api.schema.Fields()) != len(columns) {
+			return nil, fmt.Errorf("arrowconv: schema has %d fields, but rows have %d columns",
+				len(api.schema.Fields()), len(columns),
+			)
+		}
+		return api.schema, nil
+	case api.structType != nil:
+		return api.structSchema(columns)
+	default:
+		return databaseTypesSchema(rows, columns)
+	}
+}
+
+func (api *API) structSchema(columns []string) (*arrow.Schema, error) {
+	if api.structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("arrowconv: struct schema must be inferred from a struct, got: %v", api.structType)
+	}
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fieldType, ok := api.dbscanAPI.FieldType(api.structType, column)
+		if !ok {
+			return nil, fmt.Errorf("arrowconv: column '%s': no corresponding field found in %v", column, api.structType)
+		}
+		dataType, nullable, ok := goTypeToArrow(fieldType)
+		if !ok {
+			return nil, fmt.Errorf("arrowconv: column '%s': unsupported field type %v", column, fieldType)
+		}
+		fields[i] = arrow.Field{Name: column, Type: dataType, Nullable: nullable}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	nullTypesArrow = map[reflect.Type]arrow.DataType{
+		reflect.TypeOf(sql.NullString{}):  arrow.BinaryTypes.String,
+		reflect.TypeOf(sql.NullInt64{}):   arrow.PrimitiveTypes.Int64,
+		reflect.TypeOf(sql.NullInt32{}):   arrow.PrimitiveTypes.Int32,
+		reflect.TypeOf(sql.NullInt16{}):   arrow.PrimitiveTypes.Int16,
+		reflect.TypeOf(sql.NullByte{}):    arrow.PrimitiveTypes.Uint8,
+		reflect.TypeOf(sql.NullFloat64{}): arrow.PrimitiveTypes.Float64,
+		reflect.TypeOf(sql.NullBool{}):    arrow.FixedWidthTypes.Boolean,
+		reflect.TypeOf(sql.NullTime{}):    &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"},
+	}
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+func goTypeToArrow(t reflect.Type) (dataType arrow.DataType, nullable bool, ok bool) {
+	if t.Kind() == reflect.Ptr {
+		dataType, _, ok = goTypeToArrow(t.Elem())
+		return dataType, true, ok
+	}
+	if dataType, ok := nullTypesArrow[t]; ok {
+		return dataType, true, true
+	}
+	if t == timeType {
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, false, true
+	}
+	switch t.Kind() { //nolint: exhaustive
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, false, true
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, false, true
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, false, true
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, false, true
+	case reflect.Int, reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, false, true
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, false, true
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, false, true
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, false, true
+	case reflect.Uint, reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, false, true
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, false, true
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, false, true
+	case reflect.String:
+		return arrow.BinaryTypes.String, false, true
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return arrow.BinaryTypes.Binary, true, true
+		}
+	}
+	if t.Implements(stringerType) || reflect.PtrTo(t).Implements(stringerType) {
+		return arrow.BinaryTypes.String, false, true
+	}
+	return nil, false, false
+}
+
+func databaseTypesSchema(rows dbscan.Rows, columns []string) (*arrow.Schema, error) {
+	dbTypes, err := columnDatabaseTypes(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(dbTypes) != len(columns) {
+		return nil, fmt.Errorf("arrowconv: rows have %d columns, but %d column types", len(columns), len(dbTypes))
+	}
+	fields := make([]arrow.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = arrow.Field{Name: column, Type: databaseTypeToArrow(dbTypes[i]), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+func columnDatabaseTypes(rows dbscan.Rows) ([]string, error) {
+	for r := rows; r != nil; {
+		if ctr, ok := r.(dbscan.ColumnTypesRows); ok {
+			dbTypes, err := ctr.ColumnDatabaseTypes()
+			if err != nil {
+				return nil, fmt.Errorf("arrowconv: get column database types: %w", err)
+			}
+			return dbTypes, nil
+		}
+		unwrapper, ok := r.(dbscan.RowsUnwrapper)
+		if !ok {
+			break
+		}
+		r = unwrapper.UnwrapRows()
+	}
+	return nil, fmt.Errorf("arrowconv: rows don't implement dbscan.ColumnTypesRows, " +
+		"use WithStructSchema or WithSchema to provide the schema")
+}
+
+// databaseTypeToArrow maps common database type names of PostgreSQL, MySQL, SQLite and others to Arrow types.
+// Unknown types are mapped to the string type.
+func databaseTypeToArrow(dbType string) arrow.DataType {
+	name := strings.ToLower(dbType)
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		// Strip the type parameters, e.g. "varchar(255)" or "numeric(10, 2)".
+		name = name[:i]
+	}
+	switch strings.TrimSpace(name) {
+	case "bool", "boolean":
+		return arrow.FixedWidthTypes.Boolean
+	case "int2", "smallint", "tinyint":
+		return arrow.PrimitiveTypes.Int16
+	case "int4", "int", "integer", "mediumint", "serial":
+		return arrow.PrimitiveTypes.Int32
+	case "int8", "bigint", "bigserial":
+		return arrow.PrimitiveTypes.Int64
+	case "float4", "real":
+		return arrow.PrimitiveTypes.Float32
+	case "float8", "double", "double precision", "float":
+		return arrow.PrimitiveTypes.Float64
+	case "bytea", "blob", "binary", "varbinary", "longblob", "mediumblob", "tinyblob":
+		return arrow.BinaryTypes.Binary
+	case "timestamptz", "timestamp with time zone":
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	case "timestamp", "timestamp without time zone", "datetime":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case "date":
+		return arrow.FixedWidthTypes.Date32
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
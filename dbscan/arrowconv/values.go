@@ -0,0 +1,253 @@
+package arrowconv
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+)
+
+// appendValue appends a value that the database library returns for *interface{} to the builder.
+func appendValue(builder array.Builder, value interface{}) error {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		value = v
+	}
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		v, ok := value.(bool)
+		if !ok {
+			return unsupportedValueErr(value, b.Type())
+		}
+		b.Append(v)
+	case *array.StringBuilder:
+		v, err := toString(value)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.BinaryBuilder:
+		switch v := value.(type) {
+		case []byte:
+			b.Append(v)
+		case string:
+			b.Append([]byte(v))
+		default:
+			return unsupportedValueErr(value, b.Type())
+		}
+	case *array.TimestampBuilder:
+		t, ok := value.(time.Time)
+		if !ok {
+			return unsupportedValueErr(value, b.Type())
+		}
+		b.Append(toTimestamp(t, b.Type().(*arrow.TimestampType).Unit))
+	case *array.Date32Builder:
+		t, ok := value.(time.Time)
+		if !ok {
+			return unsupportedValueErr(value, b.Type())
+		}
+		b.Append(arrow.Date32FromTime(t))
+	default:
+		return appendNumber(builder, value)
+	}
+	return nil
+}
+
+func appendNumber(builder array.Builder, value interface{}) error {
+	switch b := builder.(type) {
+	case *array.Int8Builder:
+		v, err := toInt(value, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return err
+		}
+		b.Append(int8(v))
+	case *array.Int16Builder:
+		v, err := toInt(value, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return err
+		}
+		b.Append(int16(v))
+	case *array.Int32Builder:
+		v, err := toInt(value, math.MinInt32, math.MaxInt32)
+		if err != nil {
+			return err
+		}
+		b.Append(int32(v))
+	case *array.Int64Builder:
+		v, err := toInt(value, math.MinInt64, math.MaxInt64)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.Uint8Builder:
+		v, err := toUint(value, math.MaxUint8)
+		if err != nil {
+			return err
+		}
+		b.Append(uint8(v))
+	case *array.Uint16Builder:
+		v, err := toUint(value, math.MaxUint16)
+		if err != nil {
+			return err
+		}
+		b.Append(uint16(v))
+	case *array.Uint32Builder:
+		v, err := toUint(value, math.MaxUint32)
+		if err != nil {
+			return err
+		}
+		b.Append(uint32(v))
+	case *array.Uint64Builder:
+		v, err := toUint(value, math.MaxUint64)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.Float32Builder:
+		v, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		b.Append(float32(v))
+	case *array.Float64Builder:
+		v, err := toFloat(value)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	default:
+		return fmt.Errorf("unsupported Arrow type %v", builder.Type())
+	}
+	return nil
+}
+
+func unsupportedValueErr(value interface{}, dataType arrow.DataType) error {
+	return fmt.Errorf("cannot append value of type %T to %v", value, dataType)
+}
+
+func toInt(value interface{}, minValue, maxValue int64) (int64, error) {
+	v := reflect.ValueOf(value)
+	var result int64
+	switch v.Kind() { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		result = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("value %d overflows int64", v.Uint())
+		}
+		result = int64(v.Uint())
+	case reflect.String, reflect.Slice:
+		// Some drivers, e.g. MySQL without parseTime, return numbers as text.
+		s, err := toString(value)
+		if err != nil {
+			return 0, err
+		}
+		if result, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("cannot convert value of type %T to an integer", value)
+	}
+	if result < minValue || result > maxValue {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", result, minValue, maxValue)
+	}
+	return result, nil
+}
+
+func toUint(value interface{}, maxValue uint64) (uint64, error) {
+	v := reflect.ValueOf(value)
+	var result uint64
+	switch v.Kind() { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, fmt.Errorf("value %d is negative", v.Int())
+		}
+		result = uint64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		result = v.Uint()
+	case reflect.String, reflect.Slice:
+		s, err := toString(value)
+		if err != nil {
+			return 0, err
+		}
+		if result, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("cannot convert value of type %T to an unsigned integer", value)
+	}
+	if result > maxValue {
+		return 0, fmt.Errorf("value %d is out of range [0, %d]", result, maxValue)
+	}
+	return result, nil
+}
+
+func toFloat(value interface{}) (float64, error) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() { //nolint: exhaustive
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.String, reflect.Slice:
+		s, err := toString(value)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.ParseFloat(s, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert value of type %T to a float", value)
+	}
+}
+
+func toString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	switch reflect.ValueOf(value).Kind() { //nolint: exhaustive
+	case reflect.Map, reflect.Slice:
+		// Database libraries decode JSON columns into maps and slices.
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+func toTimestamp(t time.Time, unit arrow.TimeUnit) arrow.Timestamp {
+	switch unit {
+	case arrow.Second:
+		return arrow.Timestamp(t.Unix())
+	case arrow.Millisecond:
+		return arrow.Timestamp(t.UnixMilli())
+	case arrow.Microsecond:
+		return arrow.Timestamp(t.UnixMicro())
+	default:
+		return arrow.Timestamp(t.UnixNano())
+	}
+}
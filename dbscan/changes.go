@@ -0,0 +1,144 @@
+package dbscan
+
+import (
+	"context"
+	"reflect"
+)
+
+// ChangeTracker keeps values that ScanOne scanned into a struct, see WithChangeTracking.
+// Embed it into a struct to track changes of that struct's fields, it isn't mapped to any column:
+//
+//	type User struct {
+//		dbscan.ChangeTracker
+//		ID    string
+//		Email string
+//	}
+type ChangeTracker struct {
+	original map[string]interface{}
+}
+
+var changeTrackerType = reflect.TypeOf(ChangeTracker{})
+
+// WithChangeTracking makes ScanOne record the scanned values in the ChangeTracker embedded into the destination,
+// so Changed can tell which columns were modified since, e.g. to build a minimal UPDATE statement.
+// Destinations that don't embed ChangeTracker are scanned as usual.
+func WithChangeTracking() APIOption {
+	return func(api *API) {
+		api.changeTracking = true
+	}
+}
+
+// Changed is a package-level helper function that uses the DefaultAPI object.
+// See API.Changed for details.
+func Changed(original, modified interface{}) ([]string, error) {
+	return DefaultAPI.Changed(original, modified)
+}
+
+// Changed returns columns whose field values differ between two structs of the same type,
+// in the order listed by API.Columns, except for source columns of composed fields.
+// If original embeds ChangeTracker that holds values recorded by ScanOne, see WithChangeTracking,
+// they are compared instead of the current field values of original.
+// So a struct scanned with change tracking can be compared with itself after modifications:
+//
+//	err := api.ScanOne(&user, rows)
+//	user.Email = "new@example.com"
+//	changed, err := api.Changed(&user, &user) // [email]
+func (api *API) Changed(original, modified interface{}) ([]string, error) {
+	columns, originalValues, modifiedValues, err := api.compareStructs(original, modified)
+	if err != nil {
+		return nil, err
+	}
+	var changed []string
+	for i, column := range columns {
+		if !reflect.DeepEqual(originalValues[i], modifiedValues[i]) {
+			changed = append(changed, column)
+		}
+	}
+	return changed, nil
+}
+
+// compareStructs returns columns of two structs of the same type along with values of both structs,
+// values recorded by ChangeTracker take precedence over the current values of original.
+func (api *API) compareStructs(
+	original, modified interface{},
+) (columns []string, originalValues, modifiedValues []interface{}, err error) {
+	originalValue, err := api.sourceStruct(original)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	modifiedValue, err := api.sourceStruct(modified)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	structType := originalValue.Type()
+	if modifiedValue.Type() != structType {
+		return nil, nil, nil, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: can't compare %v with %v, structs must be of the same type", structType, modifiedValue.Type(),
+		)
+	}
+	if columns, err = api.structColumns(structType, false); err != nil {
+		return nil, nil, nil, err
+	}
+	modifiedValues, err = api.fieldValues(context.Background(), modifiedValue, columns, false /* encrypt. */)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var tracker ChangeTracker
+	if i := changeTrackerIndex(structType); i >= 0 {
+		tracker = originalValue.Field(i).Interface().(ChangeTracker)
+	}
+	if tracker.original != nil {
+		originalValues = make([]interface{}, len(columns))
+		for i, column := range columns {
+			originalValues[i] = tracker.original[column]
+		}
+		return columns, originalValues, modifiedValues, nil
+	}
+	originalValues, err = api.fieldValues(context.Background(), originalValue, columns, false /* encrypt. */)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return columns, originalValues, modifiedValues, nil
+}
+
+// recordOriginal saves the current field values into the ChangeTracker embedded into the destination, if any.
+func (api *API) recordOriginal(dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	structValue := dstValue.Elem()
+	trackerIndex := changeTrackerIndex(structValue.Type())
+	if trackerIndex < 0 {
+		return nil
+	}
+	tracker := structValue.Field(trackerIndex).Addr().Interface().(*ChangeTracker)
+	columns, err := api.structColumns(structValue.Type(), false)
+	if err != nil {
+		return err
+	}
+	values, err := api.fieldValues(context.Background(), structValue, columns, false /* encrypt. */)
+	if err != nil {
+		return err
+	}
+	tracker.original = make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		if b, ok := values[i].([]byte); ok {
+			// The field can be modified in place, keep a copy.
+			values[i] = append([]byte(nil), b...)
+		}
+		tracker.original[column] = values[i]
+	}
+	return nil
+}
+
+// changeTrackerIndex returns the index of ChangeTracker embedded into the struct, or -1 if there is none.
+func changeTrackerIndex(structType reflect.Type) int {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Anonymous && field.Type == changeTrackerType {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,60 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestChanged_withChangeTracking(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		dbscan.ChangeTracker
+		Foo string
+		Bar *string
+		Baz []byte
+	}
+	api, err := getAPI(dbscan.WithChangeTracking())
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS foo, NULL AS bar, 'baz val'::BYTES AS baz`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+	unchanged, err := api.Changed(&got, &got)
+	require.NoError(t, err)
+	got.Bar = makeStrPtr("bar val")
+	got.Baz[0] = 'B'
+	changed, err := api.Changed(&got, &got)
+	require.NoError(t, err)
+
+	assert.Empty(t, unchanged)
+	assert.Equal(t, []string{"bar", "baz"}, changed)
+}
+
+func TestChanged_withoutTrackedValues_comparesStructs(t *testing.T) {
+	t.Parallel()
+	original := testModel{Foo: "foo val", Bar: "bar val"}
+	modified := original
+	modified.Bar = "new bar val"
+
+	changed, err := dbscan.Changed(&original, modified)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bar"}, changed)
+}
+
+func TestChanged_differentTypes_returnsErr(t *testing.T) {
+	t.Parallel()
+	type other struct {
+		Foo string
+	}
+
+	_, err := dbscan.Changed(testModel{}, other{})
+
+	expectedErr := "scany: can't compare dbscan_test.testModel with dbscan_test.other, structs must be of the same type"
+	assert.EqualError(t, err, expectedErr)
+}
@@ -0,0 +1,61 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Collector is a destination for ScanAll that receives rows one by one instead of a slice,
+// so results can stream straight into ring buffers, LRU caches or custom containers.
+type Collector interface {
+	// NewElement returns a pointer to a new element that dbscan scans the next row into, e.g. &User{}.
+	// It must return a pointer to the same type every time.
+	NewElement() interface{}
+	// Collect receives the element returned by NewElement once the row is scanned into it.
+	// An error returned from Collect aborts scanning.
+	Collect(dst interface{}) error
+}
+
+// collectRows is the processRows counterpart for Collector destinations.
+// Parallel decoding, element allocators and duplicates removal don't apply to collectors.
+// Closing rows on exit is up to the caller.
+func (api *API) collectRows(
+	ctx context.Context, collector Collector, rows Rows, closeRows bool, start startScannerFunc,
+) error {
+	rs := api.newRowScanner(rows)
+	if start != nil {
+		rs.start = start
+	}
+	var rowsAffected, rowIndex int
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("scany: scanning aborted: %w", err)
+		}
+		if api.maxRows > 0 && rowsAffected >= api.maxRows {
+			if api.maxRowsReturnErr {
+				return api.tooManyRowsErr()
+			}
+			break
+		}
+		element := collector.NewElement()
+		if v := reflect.ValueOf(element); v.Kind() != reflect.Ptr || v.IsNil() {
+			return newSentinelErrorf(ErrUnsupportedDestination,
+				"scany: collector must return a non nil pointer from NewElement, got: %T", element,
+			)
+		}
+		err := rs.ScanContext(ctx, element)
+		rowIndex++
+		if err != nil {
+			if err := api.handleRowError(rowIndex-1, fmt.Errorf("scanning: %w", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := collector.Collect(element); err != nil {
+			return fmt.Errorf("scany: collect row: %w", err)
+		}
+		rowsAffected++
+	}
+	return finishRows(rows, closeRows)
+}
@@ -0,0 +1,52 @@
+package dbscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCollector struct {
+	collected []*testModel
+	err       error
+}
+
+func (c *testCollector) NewElement() interface{} {
+	return &testModel{}
+}
+
+func (c *testCollector) Collect(dst interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.collected = append(c.collected, dst.(*testModel))
+	return nil
+}
+
+func TestScanAll_collector(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+	collector := &testCollector{}
+
+	err := testAPI.ScanAll(collector, rows)
+	require.NoError(t, err)
+
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	assert.Equal(t, expected, collector.collected)
+}
+
+func TestScanAll_collectorFails_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+	collector := &testCollector{err: errors.New("collector is full")}
+
+	err := testAPI.ScanAll(collector, rows)
+
+	assert.EqualError(t, err, "scany: collect row: collector is full")
+}
@@ -0,0 +1,154 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ColumnsOption is a function type that changes how Columns lists columns.
+type ColumnsOption func(o *columnsOptions)
+
+type columnsOptions struct {
+	prefix    string
+	exclude   map[string]bool
+	tagOption string
+}
+
+// ColumnsPrefix makes Columns prepend the given prefix to every column, e.g. a table alias like "u.".
+func ColumnsPrefix(prefix string) ColumnsOption {
+	return func(o *columnsOptions) {
+		o.prefix = prefix
+	}
+}
+
+// ColumnsExclude makes Columns skip the given columns, e.g. columns that are generated by the database.
+// Columns are matched before the prefix is applied.
+func ColumnsExclude(columns ...string) ColumnsOption {
+	return func(o *columnsOptions) {
+		if o.exclude == nil {
+			o.exclude = make(map[string]bool, len(columns))
+		}
+		for _, column := range columns {
+			o.exclude[column] = true
+		}
+	}
+}
+
+// ColumnsTagged makes Columns list only columns of fields that have the given option in the struct tag,
+// e.g. ColumnsTagged("pk") for fields tagged as `db:"id,pk"`.
+// It allows libraries built on top of dbscan to define their own tag options.
+func ColumnsTagged(option string) ColumnsOption {
+	return func(o *columnsOptions) {
+		o.tagOption = option
+	}
+}
+
+// Columns is a package-level helper function that uses the DefaultAPI object.
+// See API.Columns for details.
+func Columns(v interface{}, opts ...ColumnsOption) ([]string, error) {
+	return DefaultAPI.Columns(v, opts...)
+}
+
+// Columns returns the columns that dbscan maps to fields of the given struct, e.g. User{} or &User{},
+// in the order the fields are declared, with fields of nested and embedded structs in place of the struct.
+// Source columns of composed fields are listed in place of the composed field, see WithFieldComposer.
+// It allows to build queries like SELECT col1, col2 that are guaranteed to match the struct:
+//
+//	columns, _ := dbscan.Columns(&User{}, dbscan.ColumnsPrefix("u."), dbscan.ColumnsExclude("password"))
+//	query := "SELECT " + strings.Join(columns, ", ") + " FROM users u"
+//
+// Note that columns of nested structs contain the column separator, e.g. "post.id",
+// so they must be quoted or aliased in the query.
+func (api *API) Columns(v interface{}, opts ...ColumnsOption) ([]string, error) {
+	structType := reflect.TypeOf(v)
+	for structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: columns can only be listed for a struct, got: %T", v,
+		)
+	}
+	var o columnsOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	allColumns, err := api.structColumns(structType, true)
+	if err != nil {
+		return nil, err
+	}
+	var tagged map[string]bool
+	if o.tagOption != "" {
+		tagged = make(map[string]bool)
+		for _, fieldIndex := range api.fieldsWithTagOption(structType, o.tagOption) {
+			tagged[fmt.Sprint(fieldIndex)] = true
+		}
+	}
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	columns := make([]string, 0, len(allColumns))
+	for _, column := range allColumns {
+		if o.exclude[column] {
+			continue
+		}
+		if tagged != nil {
+			fieldIndex, ok := columnToFieldIndex[column]
+			if !ok || !tagged[fmt.Sprint(fieldIndex)] {
+				continue
+			}
+		}
+		columns = append(columns, o.prefix+column)
+	}
+	return columns, nil
+}
+
+// structColumns returns columns of the struct in the order fields are declared.
+// withComposerSources adds source columns of composed fields in place of the composed field.
+func (api *API) structColumns(structType reflect.Type, withComposerSources bool) ([]string, error) {
+	composed := api.getComposedFields(structType)
+	if composed.err != nil {
+		return nil, composed.err
+	}
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	parents := make(map[string]bool)
+	for _, fieldIndex := range columnToFieldIndex {
+		for k := 1; k < len(fieldIndex); k++ {
+			parents[fmt.Sprint(fieldIndex[:k])] = true
+		}
+	}
+	columnOrder := make(map[string][]int, len(columnToFieldIndex))
+	for column, fieldIndex := range columnToFieldIndex {
+		// Nested structs are listed by their fields only.
+		if !parents[fmt.Sprint(fieldIndex)] {
+			columnOrder[column] = fieldIndex
+		}
+	}
+	if withComposerSources {
+		for _, field := range composed.fields {
+			// Source columns of a composer go in place of the composed field, unless they are mapped to fields.
+			for k, column := range field.composer.columns {
+				if _, ok := columnOrder[column]; !ok {
+					columnOrder[column] = append(append([]int(nil), field.fieldIndex...), k)
+				}
+			}
+		}
+	}
+	columns := make([]string, 0, len(columnOrder))
+	for column := range columnOrder {
+		columns = append(columns, column)
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return lessFieldIndex(columnOrder[columns[i]], columnOrder[columns[j]])
+	})
+	return columns, nil
+}
+
+// lessFieldIndex orders field indexes the way fields are declared, nested fields go in place of their struct.
+func lessFieldIndex(a, b []int) bool {
+	for k := 0; k < len(a) && k < len(b); k++ {
+		if a[k] != b[k] {
+			return a[k] < b[k]
+		}
+	}
+	return len(a) < len(b)
+}
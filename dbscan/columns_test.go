@@ -0,0 +1,79 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestColumns(t *testing.T) {
+	t.Parallel()
+	type Base struct {
+		ID        string `db:"id,pk"`
+		CreatedAt string
+	}
+	type Post struct {
+		Title string
+	}
+	type user struct {
+		Base
+		Email    string `db:"email_address,pk"`
+		Password string
+		Ignored  string `db:"-"`
+		Post     *Post
+		Hash     []byte `db:",rowhash"`
+		FullName string `db:"-,compose=full_name"`
+		Age      int
+	}
+	cases := []struct {
+		name     string
+		opts     []dbscan.ColumnsOption
+		expected []string
+	}{
+		{
+			name: "all columns in declaration order",
+			expected: []string{
+				"id", "created_at", "email_address", "password", "post.title", "first_name", "last_name", "age",
+			},
+		},
+		{
+			name:     "tagged",
+			opts:     []dbscan.ColumnsOption{dbscan.ColumnsTagged("pk")},
+			expected: []string{"id", "email_address"},
+		},
+		{
+			name: "with prefix and exclusions",
+			opts: []dbscan.ColumnsOption{dbscan.ColumnsPrefix("u."), dbscan.ColumnsExclude("password", "post.title")},
+			expected: []string{
+				"u.id", "u.created_at", "u.email_address", "u.first_name", "u.last_name", "u.age",
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(
+				dbscan.WithFieldComposer("full_name", []string{"first_name", "last_name"}, fullNameComposer),
+			)
+			require.NoError(t, err)
+
+			got, err := api.Columns(&user{}, tc.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestColumns_nonStruct_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := dbscan.Columns(map[string]interface{}{})
+
+	assert.ErrorIs(t, err, dbscan.ErrUnsupportedDestination)
+	assert.EqualError(t, err, "scany: columns can only be listed for a struct, got: map[string]interface {}")
+}
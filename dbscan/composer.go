@@ -0,0 +1,187 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const composeTagOption = "compose="
+
+// FieldComposerFunc derives a field value from several columns of a row.
+// It receives values of the source columns keyed by the column name.
+// If a source column is mapped to a struct field, the value is the scanned field value,
+// otherwise it's the value that the underlying database library returns for *interface{}.
+// The returned value must be assignable or safely convertible to the composed field,
+// or the field must implement Scan(src interface{}) error.
+type FieldComposerFunc func(values map[string]interface{}) (interface{}, error)
+
+// WithFieldComposer registers a named composer that derives a field from the given columns,
+// e.g. FullName from "first_name" and "last_name".
+// The field references the composer in the struct tag and is ignored for the column mapping:
+//
+//	type User struct {
+//		FirstName string
+//		FullName  string `db:"-,compose=full_name"`
+//	}
+//
+// Source columns don't need a corresponding struct field, like "last_name" in the example above.
+// The composer is called after the row is scanned and transforms are applied, before AfterScan hooks.
+// If none of the source columns are present in rows, the field is left untouched,
+// if only some of them are present, dbscan returns an error.
+// Registering a composer with the same name replaces the previous one.
+func WithFieldComposer(name string, columns []string, fn FieldComposerFunc) APIOption {
+	return func(api *API) {
+		if api.composers == nil {
+			api.composers = make(map[string]*fieldComposer)
+		}
+		api.composers[name] = &fieldComposer{columns: append([]string(nil), columns...), fn: fn}
+	}
+}
+
+type fieldComposer struct {
+	columns []string
+	fn      FieldComposerFunc
+}
+
+type composedField struct {
+	fieldIndex []int
+	name       string
+	composer   *fieldComposer
+}
+
+type structComposers struct {
+	fields []*composedField
+	// sources contains the composer name of every source column.
+	sources map[string]string
+	err     error
+}
+
+// getComposedFields returns fields of the struct that reference a composer.
+// The result is cached per struct type.
+func (api *API) getComposedFields(structType reflect.Type) *structComposers {
+	cachedIface, ok := api.composersCache.Load(structType)
+	if !ok {
+		cachedIface, _ = api.composersCache.LoadOrStore(structType, api.buildComposedFields(structType))
+	}
+	return cachedIface.(*structComposers)
+}
+
+func (api *API) buildComposedFields(structType reflect.Type) *structComposers {
+	sc := &structComposers{sources: make(map[string]string)}
+	indexes := api.fieldsWithTag(structType, func(_ string, options []string) bool {
+		return composeOption(options) != ""
+	})
+	for _, fieldIndex := range indexes {
+		field := structFieldByIndex(structType, fieldIndex)
+		tag, _ := api.lookupStructTag(field)
+		_, options := parseStructTag(tag)
+		name := composeOption(options)
+		composer, ok := api.composers[name]
+		if !ok {
+			path, _ := structFieldPath(structType, fieldIndex)
+			sc.err = newSentinelErrorf(ErrUnsupportedDestination,
+				"scany: field %s: unknown composer '%s', see WithFieldComposer", path, name,
+			)
+			return sc
+		}
+		sc.fields = append(sc.fields, &composedField{fieldIndex: fieldIndex, name: name, composer: composer})
+		for _, column := range composer.columns {
+			sc.sources[column] = name
+		}
+	}
+	return sc
+}
+
+func composeOption(options []string) string {
+	for _, option := range options {
+		if strings.HasPrefix(option, composeTagOption) {
+			return strings.TrimPrefix(option, composeTagOption)
+		}
+	}
+	return ""
+}
+
+type plannedComposer struct {
+	*composedField
+	// positions contains the index in rows columns of every source column.
+	positions []int
+}
+
+// planComposers binds composed fields of the struct to positions of their source columns in rows.
+// It also returns which columns have to be scanned into raw values,
+// because they don't have a corresponding struct field.
+func (api *API) planComposers(
+	structType reflect.Type, columns []string, columnToFieldIndex map[string][]int,
+) ([]*plannedComposer, []bool, error) {
+	sc := api.getComposedFields(structType)
+	if sc.err != nil || len(sc.fields) == 0 {
+		return nil, nil, sc.err
+	}
+	positions := make(map[string]int, len(columns))
+	for i, column := range columns {
+		positions[column] = i
+	}
+	var composers []*plannedComposer
+	var rawColumns []bool
+	for _, field := range sc.fields {
+		pc := &plannedComposer{composedField: field}
+		var missing string
+		for _, column := range field.composer.columns {
+			i, ok := positions[column]
+			if !ok {
+				missing = column
+				continue
+			}
+			pc.positions = append(pc.positions, i)
+		}
+		if len(pc.positions) == 0 {
+			continue
+		}
+		if missing != "" {
+			path, _ := structFieldPath(structType, field.fieldIndex)
+			return nil, nil, newSentinelErrorf(ErrColumnMismatch,
+				"scany: field %s: composer '%s' requires column '%s'", path, field.name, missing,
+			)
+		}
+		for _, i := range pc.positions {
+			if _, ok := columnToFieldIndex[columns[i]]; ok {
+				continue
+			}
+			if rawColumns == nil {
+				rawColumns = make([]bool, len(columns))
+			}
+			rawColumns[i] = true
+		}
+		composers = append(composers, pc)
+	}
+	return composers, rawColumns, nil
+}
+
+// composeFields sets composed fields from the scanned row.
+// rawValues contains values of columns without a corresponding struct field.
+func composeFields(
+	structValue reflect.Value, composers []*plannedComposer, columns []string, fieldIndexes [][]int,
+	rawValues []interface{},
+) error {
+	for _, pc := range composers {
+		values := make(map[string]interface{}, len(pc.positions))
+		for _, i := range pc.positions {
+			if fieldIndexes[i] != nil {
+				values[columns[i]] = structValue.FieldByIndex(fieldIndexes[i]).Interface()
+				continue
+			}
+			values[columns[i]] = rawValues[i]
+		}
+		result, err := pc.composer.fn(values)
+		if err == nil {
+			initializeNested(structValue, pc.fieldIndex)
+			err = assignRawValue(structValue.FieldByIndex(pc.fieldIndex), result)
+		}
+		if err != nil {
+			path, _ := structFieldPath(structValue.Type(), pc.fieldIndex)
+			return fmt.Errorf("scany: compose field %s: %w", path, err)
+		}
+	}
+	return nil
+}
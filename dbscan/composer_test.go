@@ -0,0 +1,127 @@
+package dbscan_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func fullNameComposer(values map[string]interface{}) (interface{}, error) {
+	if values["last_name"] == nil {
+		return nil, errors.New("last name is NULL")
+	}
+	return fmt.Sprintf("%v %v", values["first_name"], values["last_name"]), nil
+}
+
+func TestScanAll_fieldComposer(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		FirstName string
+		FullName  string `db:"-,compose=full_name"`
+	}
+	cases := []struct {
+		name string
+		opts []dbscan.APIOption
+	}{
+		{name: "sequential"},
+		{name: "parallel decoding", opts: []dbscan.APIOption{dbscan.WithParallelDecoding(2, 1)}},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			opts := append(tc.opts,
+				dbscan.WithFieldComposer("full_name", []string{"first_name", "last_name"}, fullNameComposer),
+			)
+			api, err := getAPI(opts...)
+			require.NoError(t, err)
+			rows := queryRows(t, `
+				SELECT * FROM (
+					VALUES ('Ann', 'Lee'), ('Bob', 'Ray')
+				) AS t (first_name, last_name)
+			`)
+			var got []dst
+
+			err = api.ScanAll(&got, rows)
+			require.NoError(t, err)
+
+			expected := []dst{
+				{FirstName: "Ann", FullName: "Ann Lee"},
+				{FirstName: "Bob", FullName: "Bob Ray"},
+			}
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestScanOne_fieldComposer_sourceColumnsAbsent(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Foo      string
+		FullName string `db:"-,compose=full_name"`
+	}
+	api, err := getAPI(dbscan.WithFieldComposer("full_name", []string{"first_name", "last_name"}, fullNameComposer))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS foo`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, dst{Foo: "foo val"}, got)
+}
+
+func TestScanOne_fieldComposer_returnsErr(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		FullName string `db:"-,compose=full_name"`
+	}
+	type unknownDst struct {
+		FullName string `db:"-,compose=unknown"`
+	}
+	cases := []struct {
+		name        string
+		query       string
+		dst         interface{}
+		expectedErr string
+	}{
+		{
+			name:  "unknown composer",
+			query: `SELECT 'Ann' AS first_name`,
+			dst:   &unknownDst{},
+			expectedErr: "scanning: doing scan: starting: scany: field unknownDst.FullName: " +
+				"unknown composer 'unknown', see WithFieldComposer",
+		},
+		{
+			name:  "source column is missing",
+			query: `SELECT 'Ann' AS first_name`,
+			dst:   &dst{},
+			expectedErr: "scanning: doing scan: starting: scany: field dst.FullName: " +
+				"composer 'full_name' requires column 'last_name'",
+		},
+		{
+			name:        "composer fails",
+			query:       `SELECT 'Ann' AS first_name, NULL AS last_name`,
+			dst:         &dst{},
+			expectedErr: "scanning: doing scan: scanFn: scany: compose field dst.FullName: last name is NULL",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(
+				dbscan.WithFieldComposer("full_name", []string{"first_name", "last_name"}, fullNameComposer),
+			)
+			require.NoError(t, err)
+			rows := queryRows(t, tc.query)
+			err = api.ScanOne(tc.dst, rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ConcurrentScanner is safe for use from multiple goroutines.
+// It compiles the mapping between columns and the destination once per destination type and set of columns
+// and shares it between all rows it scans, e.g. partitions of one query that are read in parallel.
+// Unlike RowScanner, it isn't bound to particular rows, nor to a single destination type.
+//
+// Note that API methods are safe for concurrent use as well and cache the mapping too,
+// but only for a few sets of columns per destination type. ConcurrentScanner keeps the mapping
+// for every set of columns it has seen.
+type ConcurrentScanner struct {
+	api   *API
+	plans sync.Map
+}
+
+type scanPlanKey struct {
+	dstType reflect.Type
+	columns string
+}
+
+// NewConcurrentScanner is a package-level helper function that uses the DefaultAPI object.
+// See API.NewConcurrentScanner for details.
+func NewConcurrentScanner() *ConcurrentScanner {
+	return DefaultAPI.NewConcurrentScanner()
+}
+
+// NewConcurrentScanner returns a new instance of the ConcurrentScanner.
+func (api *API) NewConcurrentScanner() *ConcurrentScanner {
+	return &ConcurrentScanner{api: api}
+}
+
+// NewRowScanner returns a RowScanner that uses the shared mapping.
+// Just like any RowScanner, the returned one must be used by a single goroutine.
+func (cs *ConcurrentScanner) NewRowScanner(rows Rows) *RowScanner {
+	rs := cs.api.NewRowScanner(rows)
+	rs.start = cs.start
+	return rs
+}
+
+// ScanAll is the same as API.ScanAll, but it uses the shared mapping.
+func (cs *ConcurrentScanner) ScanAll(dst interface{}, rows Rows) error {
+	return cs.ScanAllContext(context.Background(), dst, rows)
+}
+
+// ScanAllContext is the same as API.ScanAllContext, but it uses the shared mapping.
+func (cs *ConcurrentScanner) ScanAllContext(ctx context.Context, dst interface{}, rows Rows) error {
+	rows = cs.api.wrapRows(rows)
+	return cs.api.processRows(ctx, dst, rows, true /* multipleRows. */, true /* closeRows. */, cs.start)
+}
+
+// ScanOne is the same as API.ScanOne, but it uses the shared mapping.
+func (cs *ConcurrentScanner) ScanOne(dst interface{}, rows Rows) error {
+	return cs.ScanOneContext(context.Background(), dst, rows)
+}
+
+// ScanOneContext is the same as API.ScanOneContext, but it uses the shared mapping.
+func (cs *ConcurrentScanner) ScanOneContext(ctx context.Context, dst interface{}, rows Rows) error {
+	rows = cs.api.wrapRows(rows)
+	return cs.api.processRows(ctx, dst, rows, false /* multipleRows. */, true /* closeRows. */, cs.start)
+}
+
+func (cs *ConcurrentScanner) start(rs *RowScanner, dstValue reflect.Value) error {
+	columns, err := rs.rows.Columns()
+	if err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	key := scanPlanKey{dstType: dstValue.Type(), columns: strings.Join(columns, "\x00")}
+	planIface, ok := cs.plans.Load(key)
+	if !ok {
+		// Errors aren't cached, the next rows with the same columns get the error again.
+		plan, err := cs.api.compileScanPlan(dstValue.Type(), columns)
+		if err != nil {
+			return err
+		}
+		planIface, _ = cs.plans.LoadOrStore(key, plan)
+	}
+	plan := planIface.(*scanPlan)
+	cs.api.logMapping(dstValue.Type(), plan.columns, plan.kind, plan.columnToFieldIndex)
+	rs.applyScanPlan(plan)
+	return nil
+}
@@ -0,0 +1,64 @@
+package dbscan_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentScanner_ScanAll_fromMultipleGoroutines(t *testing.T) {
+	t.Parallel()
+	cs := testAPI.NewConcurrentScanner()
+	const partitions = 4
+	results := make([][]*testModel, partitions)
+	errs := make([]error, partitions)
+	var wg sync.WaitGroup
+	for i := 0; i < partitions; i++ {
+		rows := queryRows(t, multipleRowsQuery)
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = cs.ScanAll(&results[i], rows)
+		}(i)
+	}
+	wg.Wait()
+
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	for i := 0; i < partitions; i++ {
+		require.NoError(t, errs[i])
+		assert.Equal(t, expected, results[i])
+	}
+}
+
+func TestConcurrentScanner_differentDestinations(t *testing.T) {
+	t.Parallel()
+	cs := testAPI.NewConcurrentScanner()
+	var gotStruct testModel
+	var gotMap map[string]interface{}
+
+	err := cs.ScanOne(&gotStruct, queryRows(t, singleRowsQuery))
+	require.NoError(t, err)
+	err = cs.ScanOne(&gotMap, queryRows(t, singleRowsQuery))
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, gotStruct)
+	assert.Equal(t, map[string]interface{}{"foo": "foo val", "bar": "bar val"}, gotMap)
+}
+
+func TestConcurrentScanner_mismatchedColumns_returnsErr(t *testing.T) {
+	t.Parallel()
+	cs := testAPI.NewConcurrentScanner()
+	var got testModel
+
+	err := cs.ScanOne(&got, queryRows(t, `SELECT 'foo val' AS foo, 'qux val' AS qux`))
+
+	expectedErr := "scanning: doing scan: starting: scany: column: 'qux': no corresponding field found, " +
+		"or it's unexported in dbscan_test.testModel"
+	assert.EqualError(t, err, expectedErr)
+}
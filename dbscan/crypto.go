@@ -0,0 +1,82 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+const encryptedTagOption = "encrypted"
+
+// CryptoProvider decrypts and encrypts values of fields marked with the "encrypted" tag option,
+// e.g. `db:"ssn,encrypted"`. The column name is passed along, so a provider can choose a key per column.
+// Implementations must be safe for concurrent use if parallel decoding is enabled.
+type CryptoProvider interface {
+	Decrypt(ctx context.Context, column string, ciphertext []byte) ([]byte, error)
+	Encrypt(ctx context.Context, column string, plaintext []byte) ([]byte, error)
+}
+
+// WithCryptoProvider sets the provider for fields marked with the "encrypted" tag option.
+// dbscan scans the ciphertext into such field and replaces it with the decrypted value,
+// before any transforms of the field are applied.
+// Encrypted fields must be strings or byte slices, optionally by a pointer;
+// NULL values are left as is.
+func WithCryptoProvider(provider CryptoProvider) APIOption {
+	return func(api *API) {
+		api.cryptoProvider = provider
+	}
+}
+
+func isEncryptableType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType.Kind() == reflect.String ||
+		(fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.Uint8)
+}
+
+// decryptField replaces the ciphertext in the field with the plaintext,
+// fieldVal must already be dereferenced.
+func (api *API) decryptField(ctx context.Context, column string, fieldVal reflect.Value) error {
+	var ciphertext []byte
+	if fieldVal.Kind() == reflect.String {
+		ciphertext = []byte(fieldVal.String())
+	} else {
+		if fieldVal.IsNil() {
+			return nil
+		}
+		ciphertext = fieldVal.Bytes()
+	}
+	plaintext, err := api.cryptoProvider.Decrypt(ctx, column, ciphertext)
+	if err != nil {
+		return fmt.Errorf("scany: decrypt column '%s': %w", column, err)
+	}
+	if fieldVal.Kind() == reflect.String {
+		fieldVal.SetString(string(plaintext))
+	} else {
+		fieldVal.SetBytes(plaintext)
+	}
+	return nil
+}
+
+// encryptField returns the ciphertext of the value in the field, fieldVal must already be dereferenced.
+// The ciphertext has the same type as the field, so it's stored the way decryptField expects to scan it.
+func (api *API) encryptField(ctx context.Context, column string, fieldVal reflect.Value) (interface{}, error) {
+	var plaintext []byte
+	if fieldVal.Kind() == reflect.String {
+		plaintext = []byte(fieldVal.String())
+	} else {
+		if fieldVal.IsNil() {
+			return nil, nil
+		}
+		plaintext = fieldVal.Bytes()
+	}
+	ciphertext, err := api.cryptoProvider.Encrypt(ctx, column, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("scany: encrypt column '%s': %w", column, err)
+	}
+	if fieldVal.Kind() == reflect.String {
+		return string(ciphertext), nil
+	}
+	return ciphertext, nil
+}
@@ -0,0 +1,87 @@
+package dbscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// shiftCrypto is a toy provider that shifts every byte by one.
+type shiftCrypto struct{}
+
+func (shiftCrypto) Decrypt(ctx context.Context, column string, ciphertext []byte) ([]byte, error) {
+	if column == "broken" {
+		return nil, errors.New("bad key")
+	}
+	plaintext := make([]byte, len(ciphertext))
+	for i, b := range ciphertext {
+		plaintext[i] = b - 1
+	}
+	return plaintext, nil
+}
+
+func (shiftCrypto) Encrypt(ctx context.Context, column string, plaintext []byte) ([]byte, error) {
+	ciphertext := make([]byte, len(plaintext))
+	for i, b := range plaintext {
+		ciphertext[i] = b + 1
+	}
+	return ciphertext, nil
+}
+
+func TestScanOne_encryptedFields(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		SSN   string  `db:"ssn,encrypted,transform=upper"`
+		Note  *string `db:"note,encrypted"`
+		Plain string
+	}
+	api, err := getAPI(dbscan.WithCryptoProvider(shiftCrypto{}))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'bcd' AS ssn, NULL AS note, 'bcd' AS plain`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, dst{SSN: "ABC", Plain: "bcd"}, got)
+}
+
+func TestScanOne_encryptedFields_returnsErr(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Broken string `db:"broken,encrypted"`
+	}
+	cases := []struct {
+		name        string
+		opts        []dbscan.APIOption
+		expectedErr string
+	}{
+		{
+			name: "no crypto provider",
+			expectedErr: "scanning: doing scan: starting: scany: field dst.Broken is encrypted, " +
+				"but crypto provider isn't set, see WithCryptoProvider",
+		},
+		{
+			name:        "decryption fails",
+			opts:        []dbscan.APIOption{dbscan.WithCryptoProvider(shiftCrypto{})},
+			expectedErr: "scanning: doing scan: scanFn: scany: decrypt column 'broken': bad key",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.opts...)
+			require.NoError(t, err)
+			rows := queryRows(t, `SELECT 'bcd' AS broken`)
+			var got dst
+			err = api.ScanOne(&got, rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
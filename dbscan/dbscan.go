@@ -1,6 +1,7 @@
 package dbscan
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -38,6 +39,24 @@ func ScanAllSets(dsts []interface{}, rows Rows) error {
 	return DefaultAPI.ScanAllSets(dsts, rows)
 }
 
+// ScanAllContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllContext for details.
+func ScanAllContext(ctx context.Context, dst interface{}, rows Rows) error {
+	return DefaultAPI.ScanAllContext(ctx, dst, rows)
+}
+
+// ScanOneContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOneContext for details.
+func ScanOneContext(ctx context.Context, dst interface{}, rows Rows) error {
+	return DefaultAPI.ScanOneContext(ctx, dst, rows)
+}
+
+// ScanAllSetsContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSetsContext for details.
+func ScanAllSetsContext(ctx context.Context, dsts []interface{}, rows Rows) error {
+	return DefaultAPI.ScanAllSetsContext(ctx, dsts, rows)
+}
+
 // NameMapperFunc is a function type that maps a struct field name to the database column name.
 type NameMapperFunc func(string) string
 
@@ -57,14 +76,47 @@ func SnakeCaseMapper(str string) string {
 // With API type users can create a custom API instance and override default settings hence configure dbscan.
 // API should not be copied after first use.
 type API struct {
-	structTagKey          string
-	columnSeparator       string
-	fieldMapperFn         NameMapperFunc
-	scannableTypesOption  []interface{}
-	scannableTypesReflect []reflect.Type
-	allowUnknownColumns   bool
+	structTagKeys           []string
+	columnSeparator         string
+	fieldMapperFn           NameMapperFunc
+	scannableTypesOption    []interface{}
+	scannableTypesReflect   []reflect.Type
+	allowUnknownColumns     bool
+	decodeWorkers           int
+	decodeBatchSize         int
+	elementAllocator        ElementAllocator
+	maxRows                 int
+	maxRowsReturnErr        bool
+	rowErrorHandler         RowErrorHandlerFunc
+	debugLogger             DebugLogger
+	rowsMiddlewares         []RowsMiddleware
+	transforms              map[string]TransformFunc
+	cryptoProvider          CryptoProvider
+	distinct                bool
+	distinctConsecutiveOnly bool
+	distinctKeyColumns      []string
+	maxStructDepth          int
+	startHooks              []StartHookFunc
+	appendToSlice           bool
+	changeTracking          bool
+	composers               map[string]*fieldComposer
+	stripTableQualifiers    bool
+	columnNormalizers       []ColumnNormalizer
+	fieldScanners           map[string]FieldScannerFunc
 	// columnToIndexFieldMapCache stores a map of reflect.Type -> map[string][]int
 	columnToIndexFieldMapCache sync.Map
+	// scanHooksCache stores a map of reflect.Type -> *scanHooks
+	scanHooksCache sync.Map
+	// transformsCache stores a map of reflect.Type -> *structTransforms
+	transformsCache sync.Map
+	// rowHashFieldCache stores a map of reflect.Type -> row hash field lookup result
+	rowHashFieldCache sync.Map
+	// composersCache stores a map of reflect.Type -> *structComposers
+	composersCache sync.Map
+	// fieldScannersCache stores a map of reflect.Type -> *structFieldScanners
+	fieldScannersCache sync.Map
+	// scanPlansCache stores a map of reflect.Type -> []cachedScanPlan
+	scanPlansCache sync.Map
 }
 
 // APIOption is a function type that changes API configuration.
@@ -73,10 +125,11 @@ type APIOption func(api *API)
 // NewAPI creates a new API object with provided list of options.
 func NewAPI(opts ...APIOption) (*API, error) {
 	api := &API{
-		structTagKey:        "db",
+		structTagKeys:       []string{"db"},
 		columnSeparator:     ".",
 		fieldMapperFn:       SnakeCaseMapper,
 		allowUnknownColumns: false,
+		transforms:          defaultTransforms(),
 	}
 	for _, o := range opts {
 		o(api)
@@ -103,8 +156,17 @@ func NewAPI(opts ...APIOption) (*API, error) {
 // WithStructTagKey allows to use a custom struct tag key.
 // The default tag key is `db`.
 func WithStructTagKey(tagKey string) APIOption {
+	return WithStructTagKeys(tagKey)
+}
+
+// WithStructTagKeys allows to use multiple struct tag keys in the order of priority,
+// e.g. WithStructTagKeys("db", "json") uses the `db` tag if it's present and falls back to the `json` tag.
+// It allows structs shared between API and persistence layers to be mapped without duplicating tags.
+// A fallback tag with an empty name, like `json:",omitempty"`, is treated as absent,
+// so the field is mapped by the next tag key or by its name.
+func WithStructTagKeys(tagKeys ...string) APIOption {
 	return func(api *API) {
-		api.structTagKey = tagKey
+		api.structTagKeys = append([]string(nil), tagKeys...)
 	}
 }
 
@@ -152,6 +214,67 @@ func WithAllowUnknownColumns(allowUnknownColumns bool) APIOption {
 	}
 }
 
+// WithAppendToSlice makes ScanAll append rows to the destination slice instead of resetting it first.
+// It allows accumulating results of multiple queries, e.g. pages of a paginated query, into one slice.
+// If scanning fails, the elements that the slice had before are kept.
+// Limits set with WithMaxRows and duplicates removed with WithDistinct only consider rows of the current query.
+func WithAppendToSlice(appendToSlice bool) APIOption {
+	return func(api *API) {
+		api.appendToSlice = appendToSlice
+	}
+}
+
+// AppendsToSlice reports whether ScanAll appends rows to the destination slice, see WithAppendToSlice.
+func (api *API) AppendsToSlice() bool {
+	return api.appendToSlice
+}
+
+// WithMaxStructDepth limits how deep dbscan traverses nested and embedded structs
+// when it maps columns to struct fields.
+// By default, the depth isn't limited, but a struct type isn't traversed again inside itself,
+// so self-referential types like `type Node struct{ Parent *Node }` get the "parent" field skipped.
+// With the max depth set, recursive types are expanded until the depth is reached instead,
+// e.g. for the depth of 2 "parent.id" and "parent.parent.id" columns are mapped for the Node type.
+// A depth less than 1 restores the default behavior.
+func WithMaxStructDepth(depth int) APIOption {
+	return func(api *API) {
+		api.maxStructDepth = depth
+	}
+}
+
+// WithMaxRows limits the number of rows that ScanAll and ScanAllSets scan into a single destination.
+// It protects from accidentally materializing unbounded result sets.
+// Once the limit is reached, dbscan stops iterating rows.
+// If returnErr is true and rows contain more than maxRows rows,
+// dbscan returns an error that wraps ErrTooManyRows, the destination still contains the first maxRows rows.
+// Otherwise, the result is silently truncated.
+// Zero or negative maxRows means no limit, which is the default.
+func WithMaxRows(maxRows int, returnErr bool) APIOption {
+	return func(api *API) {
+		api.maxRows = maxRows
+		api.maxRowsReturnErr = returnErr
+	}
+}
+
+// RowErrorHandlerFunc is a function type that handles an error that occurred while scanning a single row.
+// rowIndex is the zero-based index of the row in the result set.
+// If the function returns nil, the row is skipped and scanning continues with the next row.
+// Otherwise, scanning is aborted with the returned error.
+type RowErrorHandlerFunc func(rowIndex int, err error) error
+
+// WithRowErrorHandler allows ScanAll and ScanAllSets to skip rows that failed to scan,
+// e.g. due to malformed JSON or a failed conversion, instead of aborting the whole result set.
+// The handler can log the error and return nil to skip the row, or return an error to abort.
+// Note that some database libraries close rows on a scan error, e.g. pgx does this,
+// in that case there are no more rows to continue with, and the error is returned as the rows final error.
+// In the parallel decoding mode the handler might be called concurrently and not in order of rows.
+// By default, dbscan aborts scanning on the first row error.
+func WithRowErrorHandler(handler RowErrorHandlerFunc) APIOption {
+	return func(api *API) {
+		api.rowErrorHandler = handler
+	}
+}
+
 // ScanAll iterates all rows to the end. After iterating it closes the rows,
 // and propagates any errors that could pop up.
 // It expects that destination should be a slice. For each row it scans data and appends it to the destination slice.
@@ -172,8 +295,18 @@ func WithAllowUnknownColumns(allowUnknownColumns bool) APIOption {
 //
 // Before starting, ScanAll resets the destination slice,
 // so if it's not empty it will overwrite all existing elements.
+//
+// Instead of a slice, the destination can be a Collector that receives rows one by one,
+// see Collector for details.
 func (api *API) ScanAll(dst interface{}, rows Rows) error {
-	return api.processRows(dst, rows, true /* multipleRows. */, true /* closeRows. */)
+	return api.ScanAllContext(context.Background(), dst, rows)
+}
+
+// ScanAllContext is the same as ScanAll, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+// It allows cancelling long scans without waiting for the database library to notice the cancellation.
+func (api *API) ScanAllContext(ctx context.Context, dst interface{}, rows Rows) error {
+	return api.processRows(ctx, dst, api.wrapRows(rows), true /* multipleRows. */, true /* closeRows. */, nil)
 }
 
 // ScanOne iterates all rows to the end and makes sure that there was exactly one row
@@ -182,15 +315,28 @@ func (api *API) ScanAll(dst interface{}, rows Rows) error {
 // and propagates any errors that could pop up.
 // It scans data from that single row into the destination.
 func (api *API) ScanOne(dst interface{}, rows Rows) error {
-	return api.processRows(dst, rows, false /* multipleRows. */, true /* closeRows. */)
+	return api.ScanOneContext(context.Background(), dst, rows)
+}
+
+// ScanOneContext is the same as ScanOne, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+func (api *API) ScanOneContext(ctx context.Context, dst interface{}, rows Rows) error {
+	return api.processRows(ctx, dst, api.wrapRows(rows), false /* multipleRows. */, true /* closeRows. */, nil)
 }
 
 // ScanAllSets iterates all rows to the end and scans data into each destination.
 // Multiple destinations is supported by multiple result sets.
 func (api *API) ScanAllSets(dsts []interface{}, rows Rows) error {
+	return api.ScanAllSetsContext(context.Background(), dsts, rows)
+}
+
+// ScanAllSetsContext is the same as ScanAllSets, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+func (api *API) ScanAllSetsContext(ctx context.Context, dsts []interface{}, rows Rows) error {
+	rows = api.wrapRows(rows)
 	defer rows.Close() //nolint: errcheck
 	for i, dst := range dsts {
-		if err := api.processRows(dst, rows, true, false /* closeRows */); err != nil {
+		if err := api.processRows(ctx, dst, rows, true, false /* closeRows */, nil); err != nil {
 			return fmt.Errorf("error processing destination %d: %w", i, err)
 		}
 		if !rows.NextResultSet() {
@@ -206,51 +352,84 @@ func NotFound(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
 
-// ErrNotFound is returned by ScanOne if there were no rows.
-var ErrNotFound = errors.New("scany: no row was found")
-
 type sliceDestinationMeta struct {
 	val             reflect.Value
 	elementBaseType reflect.Type
 	elementByPtr    bool
+	// scannedFrom is the index of the first element scanned from the current rows.
+	scannedFrom int
 }
 
-func (api *API) processRows(dst interface{}, rows Rows, multipleRows, closeRows bool) error {
+// processRows uses start to initialize RowScanner, if it's nil the default one is used.
+func (api *API) processRows(
+	ctx context.Context, dst interface{}, rows Rows, multipleRows, closeRows bool, start startScannerFunc,
+) error {
 	if closeRows {
 		defer rows.Close() //nolint: errcheck
 	}
 	var sliceMeta *sliceDestinationMeta
+	if collector, ok := dst.(Collector); ok && multipleRows {
+		return api.collectRows(ctx, collector, rows, closeRows, start)
+	}
 	if multipleRows {
 		var err error
 		sliceMeta, err = api.parseSliceDestination(dst)
 		if err != nil {
 			return fmt.Errorf("parsing slice destination: %w", err)
 		}
-		// Make sure slice is empty.
-		sliceMeta.val.Set(sliceMeta.val.Slice(0, 0))
+		if api.appendToSlice {
+			sliceMeta.scannedFrom = sliceMeta.val.Len()
+		} else {
+			// Make sure slice is empty.
+			sliceMeta.val.Set(sliceMeta.val.Slice(0, 0))
+		}
+		if api.useParallelDecoding(sliceMeta) {
+			if err := api.scanAllParallel(ctx, sliceMeta, rows); err != nil {
+				return err
+			}
+			if err := finishRows(rows, closeRows); err != nil {
+				return err
+			}
+			return api.finishSlice(sliceMeta)
+		}
 	}
-	rs := api.NewRowScanner(rows)
-	var rowsAffected int
+	rs := api.newRowScanner(rows)
+	if start != nil {
+		rs.start = start
+	}
+	var rowsAffected, rowIndex int
 	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("scany: scanning aborted: %w", err)
+		}
+		if multipleRows && api.maxRows > 0 && rowsAffected >= api.maxRows {
+			if api.maxRowsReturnErr {
+				return api.tooManyRowsErr()
+			}
+			break
+		}
 		var err error
 		if multipleRows {
-			err = scanSliceElement(rs, sliceMeta)
+			err = scanSliceElement(ctx, rs, sliceMeta)
 		} else {
-			err = rs.Scan(dst)
+			err = rs.ScanContext(ctx, dst)
 		}
+		rowIndex++
 		if err != nil {
-			return fmt.Errorf("scanning: %w", err)
+			err = fmt.Errorf("scanning: %w", err)
+			if !multipleRows {
+				return err
+			}
+			if err := api.handleRowError(rowIndex-1, err); err != nil {
+				return err
+			}
+			continue
 		}
 		rowsAffected++
 	}
 
-	if err := rows.Err(); err != nil {
-		return fmt.Errorf("scany: rows final error: %w", err)
-	}
-	if closeRows {
-		if err := rows.Close(); err != nil {
-			return fmt.Errorf("scany: close rows after processing: %w", err)
-		}
+	if err := finishRows(rows, closeRows); err != nil {
+		return err
 	}
 
 	exactlyOneRow := !multipleRows
@@ -258,7 +437,42 @@ func (api *API) processRows(dst interface{}, rows Rows, multipleRows, closeRows
 		if rowsAffected == 0 {
 			return ErrNotFound
 		} else if rowsAffected > 1 {
-			return fmt.Errorf("scany: expected 1 row, got: %d", rowsAffected)
+			return newSentinelErrorf(ErrTooManyRows, "scany: expected 1 row, got: %d", rowsAffected)
+		}
+		if api.changeTracking {
+			return api.recordOriginal(dst)
+		}
+		return nil
+	}
+	return api.finishSlice(sliceMeta)
+}
+
+// finishSlice post-processes the destination slice once all rows are scanned.
+func (api *API) finishSlice(sliceMeta *sliceDestinationMeta) error {
+	if api.distinct {
+		return api.removeDuplicates(sliceMeta)
+	}
+	return nil
+}
+
+func (api *API) handleRowError(rowIndex int, err error) error {
+	if api.rowErrorHandler == nil {
+		return err
+	}
+	return api.rowErrorHandler(rowIndex, err)
+}
+
+func (api *API) tooManyRowsErr() error {
+	return fmt.Errorf("%w: rows number exceeds the limit of %d", ErrTooManyRows, api.maxRows)
+}
+
+func finishRows(rows Rows, closeRows bool) error {
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("scany: rows final error: %w", err)
+	}
+	if closeRows {
+		if err := rows.Close(); err != nil {
+			return fmt.Errorf("scany: close rows after processing: %w", err)
 		}
 	}
 	return nil
@@ -273,7 +487,7 @@ func (api *API) parseSliceDestination(dst interface{}) (*sliceDestinationMeta, e
 	dstType := dstValue.Type()
 
 	if dstValue.Kind() != reflect.Slice {
-		return nil, fmt.Errorf(
+		return nil, newSentinelErrorf(ErrUnsupportedDestination,
 			"scany: destination must be a slice, got: %v", dstType,
 		)
 	}
@@ -302,21 +516,27 @@ func (api *API) parseSliceDestination(dst interface{}) (*sliceDestinationMeta, e
 	return meta, nil
 }
 
-func scanSliceElement(rs *RowScanner, sliceMeta *sliceDestinationMeta) error {
+func scanSliceElement(ctx context.Context, rs *RowScanner, sliceMeta *sliceDestinationMeta) error {
 	s := sliceMeta.val
 	l := s.Len()
 	growSliceByOne(s)
 	var dstValPtr reflect.Value
 	if sliceMeta.elementByPtr {
-		dstValPtr = reflect.New(sliceMeta.elementBaseType)
+		var err error
+		dstValPtr, err = rs.api.allocateElement(sliceMeta)
+		if err != nil {
+			s.SetLen(l)
+			return err
+		}
 		s.Index(l).Set(dstValPtr)
 	} else {
 		dstValPtr = s.Index(l).Addr()
 	}
-	if err := rs.Scan(dstValPtr.Interface()); err != nil {
+	if err := rs.ScanContext(ctx, dstValPtr.Interface()); err != nil {
 		// Undo growing the slice. Zero the value to ensure it doesn't retain garbage.
 		s.Index(l).Set(reflect.Zero(s.Type().Elem()))
 		s.SetLen(l)
+		rs.api.releaseElement(sliceMeta, dstValPtr)
 		return fmt.Errorf("scanning: %w", err)
 	}
 	return nil
@@ -367,10 +587,11 @@ func parseDestination(dst interface{}) (reflect.Value, error) {
 	dstVal := reflect.ValueOf(dst)
 
 	if !dstVal.IsValid() || (dstVal.Kind() == reflect.Ptr && dstVal.IsNil()) {
-		return reflect.Value{}, fmt.Errorf("scany: destination must be a non nil pointer")
+		return reflect.Value{}, newSentinelErrorf(ErrUnsupportedDestination, "scany: destination must be a non nil pointer")
 	}
 	if dstVal.Kind() != reflect.Ptr {
-		return reflect.Value{}, fmt.Errorf("scany: destination must be a pointer, got: %v", dstVal.Type())
+		return reflect.Value{}, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: destination must be a pointer, got: %v", dstVal.Type())
 	}
 
 	dstVal = dstVal.Elem()
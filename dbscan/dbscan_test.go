@@ -3,7 +3,9 @@ package dbscan_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"flag"
+	"fmt"
 	"os"
 	"testing"
 
@@ -230,6 +232,27 @@ func TestScanAll_nonEmptySlice_resetsDestinationSlice(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
+func TestScanAll_withAppendToSlice_appendsToDestinationSlice(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithAppendToSlice(true))
+	require.NoError(t, err)
+	expected := []*testModel{
+		{Foo: "foo existing val", Bar: "bar existing val"},
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+		{Foo: "foo val", Bar: "bar val"},
+	}
+
+	got := []*testModel{{Foo: "foo existing val", Bar: "bar existing val"}}
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+	require.NoError(t, err)
+	err = api.ScanAll(&got, queryRows(t, singleRowsQuery))
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
 func TestScanAll_nonSliceDestination_returnsErr(t *testing.T) {
 	t.Parallel()
 	rows := queryRows(t, multipleRowsQuery)
@@ -266,6 +289,105 @@ func TestScanAll_closedRows(t *testing.T) {
 	assert.Len(t, got, 0)
 }
 
+func TestScanAll_withMaxRows_truncatesResult(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithMaxRows(2, false))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	var got []*testModel
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAll_withMaxRowsReturnErr_returnsTooManyRowsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithMaxRows(2, true))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+	expectedErr := "scany: too many rows: rows number exceeds the limit of 2"
+
+	var got []*testModel
+	err = api.ScanAll(&got, rows)
+
+	assert.EqualError(t, err, expectedErr)
+	assert.ErrorIs(t, err, dbscan.ErrTooManyRows)
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAll_withMaxRowsNotExceeded(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithMaxRows(3, true))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+
+	var got []*testModel
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Len(t, got, 3)
+}
+
+func TestScanAllContext(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err := testAPI.ScanAllContext(ctx, &got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAllContext_canceledContext_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	expectedErr := "scany: scanning aborted: context canceled"
+
+	var got []*testModel
+	err := testAPI.ScanAllContext(canceledCtx, &got, rows)
+
+	assert.EqualError(t, err, expectedErr)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, got, 0)
+}
+
+func TestScanAll_withRowErrorHandler_abortsWithHandlerErr(t *testing.T) {
+	t.Parallel()
+	handlerErr := errors.New("handler error")
+	api, err := getAPI(dbscan.WithRowErrorHandler(func(rowIndex int, err error) error {
+		return fmt.Errorf("row %d: %w", rowIndex, handlerErr)
+	}))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+
+	var got []struct {
+		Foo string
+		Bar int
+	}
+	err = api.ScanAll(&got, rows)
+
+	assert.EqualError(t, err, "row 0: handler error")
+	assert.ErrorIs(t, err, handlerErr)
+}
+
 func TestScanOne(t *testing.T) {
 	t.Parallel()
 	rows := queryRows(t, singleRowsQuery)
@@ -303,6 +425,19 @@ func TestScanOne_multipleRows_returnsErr(t *testing.T) {
 	assert.EqualError(t, err, expectedErr)
 }
 
+func TestScanOneContext_canceledContext_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, singleRowsQuery)
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	dst := &testModel{}
+	err := testAPI.ScanOneContext(canceledCtx, dst, rows)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, dbscan.NotFound(err))
+}
+
 func TestScanRow(t *testing.T) {
 	t.Parallel()
 	rows := queryRows(t, singleRowsQuery)
@@ -393,6 +528,26 @@ func TestScanRow_withAllowUnknownColumns_unknownColumnType(t *testing.T) {
 	assert.Equal(t, expected, *got)
 }
 
+func TestScanOne_withStructTagKeys_fallsBackToSecondaryTag(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		ID        string `db:"user_id" json:"id"`
+		FirstName string `json:"fname,omitempty"`
+		LastName  string `json:",omitempty"`
+		Password  string `json:"-"`
+	}
+	api, err := getAPI(dbscan.WithStructTagKeys("db", "json"))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'id val' AS user_id, 'Ann' AS fname, 'Lee' AS last_name`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	expected := dst{ID: "id val", FirstName: "Ann", LastName: "Lee"}
+	assert.Equal(t, expected, got)
+}
+
 func TestMain(m *testing.M) {
 	exitCode := func() int {
 		flag.Parse()
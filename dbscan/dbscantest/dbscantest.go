@@ -0,0 +1,227 @@
+// Package dbscantest provides in-memory dbscan.Rows for unit tests of scanning logic,
+// so they don't need a live database.
+/*
+NewRows returns rows with the columns and values, that dbscan scans like rows of a database:
+
+	rows := dbscantest.NewRows([]string{"id", "name"}, [][]interface{}{
+		{int64(1), "foo"},
+		{int64(2), "bar"},
+	})
+	var users []*User
+	err := dbscan.ScanAll(&users, rows)
+	// rows.Closed() is true.
+
+Values are assigned to destinations with adapter.Assign, the same conversions that database/sql does.
+Errors can be injected into every method to test error handling, e.g. WithScanErr for a row that fails to scan,
+and Closed and CloseCalls report whether the code under test closes rows.
+
+Golden files
+
+To test scanning logic against production-shaped data offline, Golden records real query results,
+columns and raw values, to a JSON golden file once and replays them as Rows afterwards, e.g. in CI:
+
+	rows := dbscantest.Golden(t, "testdata/users.golden.json", func() (dbscan.Rows, error) {
+		rows, err := db.QueryContext(ctx, `SELECT * FROM users`)
+		return rows, err
+	})
+
+Set the SCANY_UPDATE_GOLDEN environment variable to record golden files again.
+*/
+package dbscantest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/adapter"
+)
+
+var (
+	_ dbscan.Rows            = &Rows{}
+	_ dbscan.ColumnTypesRows = &Rows{}
+)
+
+// Rows are in-memory rows that implement the dbscan.Rows and dbscan.ColumnTypesRows interfaces.
+// Rows aren't safe for concurrent use, like rows of database libraries.
+type Rows struct {
+	sets       []resultSet
+	set        int
+	next       int
+	columnsErr error
+	dbTypesErr error
+	closeErr   error
+	closeCalls int
+	// scanErrs are errors of scanning rows of the first result set by their indexes.
+	scanErrs map[int]error
+	// nextErr is the error that Err returns once nextErrAt rows of the current result set are iterated.
+	nextErr   error
+	nextErrAt int
+}
+
+type resultSet struct {
+	columns []string
+	dbTypes []string
+	values  [][]interface{}
+}
+
+// NewRows returns new rows with the columns and values of every row in the order of columns.
+func NewRows(columns []string, values [][]interface{}) *Rows {
+	return &Rows{sets: []resultSet{{columns: columns, values: values}}, next: -1, nextErrAt: -1}
+}
+
+// WithResultSet adds a result set that NextResultSet moves to, see dbscan.ScanAllSets.
+func (r *Rows) WithResultSet(columns []string, values [][]interface{}) *Rows {
+	r.sets = append(r.sets, resultSet{columns: columns, values: values})
+	return r
+}
+
+// WithDatabaseTypes sets database types of columns of the last result set, that ColumnDatabaseTypes returns,
+// e.g. to test the context of dbscan.ScanError.
+func (r *Rows) WithDatabaseTypes(dbTypes ...string) *Rows {
+	r.sets[len(r.sets)-1].dbTypes = dbTypes
+	return r
+}
+
+// WithColumnsErr makes Columns return the error.
+func (r *Rows) WithColumnsErr(err error) *Rows {
+	r.columnsErr = err
+	return r
+}
+
+// WithDatabaseTypesErr makes ColumnDatabaseTypes return the error.
+func (r *Rows) WithDatabaseTypesErr(err error) *Rows {
+	r.dbTypesErr = err
+	return r
+}
+
+// WithScanErr makes Scan return the error for the row with the index, starting from 0, of the first result set.
+func (r *Rows) WithScanErr(row int, err error) *Rows {
+	if r.scanErrs == nil {
+		r.scanErrs = make(map[int]error)
+	}
+	r.scanErrs[row] = err
+	return r
+}
+
+// WithErr makes the iteration fail after the number of rows, like a broken connection does:
+// Next returns false and Err returns the error.
+func (r *Rows) WithErr(afterRows int, err error) *Rows {
+	r.nextErrAt = afterRows
+	r.nextErr = err
+	return r
+}
+
+// WithCloseErr makes Close return the error.
+func (r *Rows) WithCloseErr(err error) *Rows {
+	r.closeErr = err
+	return r
+}
+
+// Closed reports whether Close was called.
+func (r *Rows) Closed() bool {
+	return r.closeCalls > 0
+}
+
+// CloseCalls returns the number of Close calls.
+func (r *Rows) CloseCalls() int {
+	return r.closeCalls
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (r *Rows) Close() error {
+	r.closeCalls++
+	return r.closeErr
+}
+
+// Err implements the dbscan.Rows.Err method.
+func (r *Rows) Err() error {
+	if r.failed() {
+		return r.nextErr
+	}
+	return nil
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (r *Rows) Next() bool {
+	if r.Closed() || r.set >= len(r.sets) || r.failed() {
+		return false
+	}
+	values := r.sets[r.set].values
+	if r.next < len(values) {
+		r.next++
+	}
+	if r.failed() {
+		return false
+	}
+	return r.next < len(values)
+}
+
+// failed reports whether the iteration reached the row of the error that WithErr injects.
+func (r *Rows) failed() bool {
+	return r.nextErr != nil && r.set == 0 && r.next >= r.nextErrAt
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (r *Rows) Columns() ([]string, error) {
+	if r.columnsErr != nil {
+		return nil, r.columnsErr
+	}
+	if r.set >= len(r.sets) {
+		return nil, errors.New("scany: no result set")
+	}
+	return r.sets[r.set].columns, nil
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+// Types are empty strings unless WithDatabaseTypes sets them.
+func (r *Rows) ColumnDatabaseTypes() ([]string, error) {
+	if r.dbTypesErr != nil {
+		return nil, r.dbTypesErr
+	}
+	if r.set >= len(r.sets) {
+		return nil, errors.New("scany: no result set")
+	}
+	set := r.sets[r.set]
+	if set.dbTypes != nil {
+		return set.dbTypes, nil
+	}
+	return make([]string, len(set.columns)), nil
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (r *Rows) Scan(dest ...interface{}) error {
+	if r.Closed() {
+		return errors.New("scany: rows are closed")
+	}
+	if r.set >= len(r.sets) || r.next < 0 || r.next >= len(r.sets[r.set].values) {
+		return errors.New("scany: scan called without calling Next")
+	}
+	if err, ok := r.scanErrs[r.next]; ok && r.set == 0 {
+		return err
+	}
+	set := r.sets[r.set]
+	row := set.values[r.next]
+	if len(row) != len(set.columns) {
+		return fmt.Errorf("scany: row %d has %d values, not %d", r.next, len(row), len(set.columns))
+	}
+	if len(dest) != len(set.columns) {
+		return adapter.ScanArgsError(len(set.columns), len(dest))
+	}
+	for i, d := range dest {
+		if err := adapter.Assign(d, row[i]); err != nil {
+			return adapter.ColumnError(set.columns[i], err)
+		}
+	}
+	return nil
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+func (r *Rows) NextResultSet() bool {
+	if r.Closed() || r.set+1 >= len(r.sets) {
+		return false
+	}
+	r.set++
+	r.next = -1
+	return true
+}
@@ -0,0 +1,132 @@
+package dbscantest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/adapter/adaptertest"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+type user struct {
+	ID   int64
+	Name string
+}
+
+func TestNewRows_conformance(t *testing.T) {
+	t.Parallel()
+	adaptertest.Run(t, func(t *testing.T, f adaptertest.Fixture) dbscan.Rows {
+		return dbscantest.NewRows(f.Columns, f.Rows)
+	})
+}
+
+func TestRows_closeTracking(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewRows([]string{"id", "name"}, [][]interface{}{
+		{int64(1), "foo"},
+		{int64(2), "bar"},
+	})
+	assert.False(t, rows.Closed())
+
+	var got []user
+	err := dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []user{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}, got)
+	assert.Positive(t, rows.CloseCalls())
+}
+
+func TestRows_errorInjection(t *testing.T) {
+	t.Parallel()
+	errInjected := errors.New("injected")
+	values := [][]interface{}{{int64(1), "foo"}, {int64(2), "bar"}}
+	cases := []struct {
+		name  string
+		setup func(rows *dbscantest.Rows)
+	}{
+		{name: "columns", setup: func(rows *dbscantest.Rows) { rows.WithColumnsErr(errInjected) }},
+		{name: "scan", setup: func(rows *dbscantest.Rows) { rows.WithScanErr(1, errInjected) }},
+		{name: "iteration", setup: func(rows *dbscantest.Rows) { rows.WithErr(1, errInjected) }},
+		{name: "close", setup: func(rows *dbscantest.Rows) { rows.WithCloseErr(errInjected) }},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := dbscantest.NewRows([]string{"id", "name"}, values)
+			tc.setup(rows)
+
+			var got []user
+			err := dbscan.ScanAll(&got, rows)
+
+			assert.ErrorIs(t, err, errInjected)
+			assert.True(t, rows.Closed())
+		})
+	}
+}
+
+func TestRows_WithErr_stopsIteration(t *testing.T) {
+	t.Parallel()
+	errInjected := errors.New("injected")
+	rows := dbscantest.NewRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}, {int64(3)}}).
+		WithErr(2, errInjected)
+
+	var n int
+	for rows.Next() {
+		n++
+	}
+
+	assert.Equal(t, 2, n)
+	assert.ErrorIs(t, rows.Err(), errInjected)
+}
+
+func TestRows_ColumnDatabaseTypes(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewRows([]string{"id", "name"}, nil).WithDatabaseTypes("INT8", "TEXT")
+
+	got, err := rows.ColumnDatabaseTypes()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"INT8", "TEXT"}, got)
+}
+
+func TestRows_WithResultSet(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewRows([]string{"id", "name"}, [][]interface{}{{int64(1), "foo"}}).
+		WithResultSet([]string{"count"}, [][]interface{}{{int64(10)}})
+
+	var users []user
+	var counts []int
+	err := dbscan.ScanAllSets([]interface{}{&users, &counts}, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []user{{ID: 1, Name: "foo"}}, users)
+	assert.Equal(t, []int{10}, counts)
+	assert.Positive(t, rows.CloseCalls())
+}
+
+func TestNewRowsFromStructs(t *testing.T) {
+	t.Parallel()
+	expected := []*user{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}
+	rows, err := dbscantest.NewRowsFromStructs(expected)
+	require.NoError(t, err)
+
+	var got []*user
+	err = dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestStructRows_singleStruct(t *testing.T) {
+	t.Parallel()
+	columns, values, err := dbscantest.StructRows(user{ID: 1, Name: "foo"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"id", "name"}, columns)
+	assert.Equal(t, [][]interface{}{{int64(1), "foo"}}, values)
+}
@@ -0,0 +1,226 @@
+package dbscantest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// UpdateGoldenEnv is the environment variable that makes Golden record golden files again, e.g.:
+//
+//	SCANY_UPDATE_GOLDEN=1 go test ./...
+const UpdateGoldenEnv = "SCANY_UPDATE_GOLDEN"
+
+// goldenFile is the JSON content of a golden file.
+// Strings, booleans and NULL are plain JSON values, other values are objects with the type as the only key:
+// {"int64": 1}, {"uint64": 1}, {"float64": 1.5}, {"bytes": "<base64>"} and {"time": "<RFC 3339>"}.
+type goldenFile struct {
+	Columns []string        `json:"columns"`
+	Types   []string        `json:"types,omitempty"`
+	Rows    [][]goldenValue `json:"rows"`
+}
+
+type goldenValue struct {
+	v interface{}
+}
+
+// Golden returns rows that replay the golden file at the path, e.g. "testdata/users.golden.json".
+// If the file doesn't exist or the UpdateGoldenEnv environment variable is set, Golden first calls query
+// to get real rows, e.g. of a staging database, and records them to the file, see RecordGolden.
+// It fails the test if the file can't be recorded or loaded.
+func Golden(tb testing.TB, path string, query func() (dbscan.Rows, error)) *Rows {
+	tb.Helper()
+	_, err := os.Stat(path)
+	if os.Getenv(UpdateGoldenEnv) != "" || errors.Is(err, os.ErrNotExist) {
+		rows, err := query()
+		if err != nil {
+			tb.Fatalf("scany: query rows of golden file %s: %v", path, err)
+		}
+		if err := RecordGolden(path, rows); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	rows, err := LoadGolden(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return rows
+}
+
+// RecordGolden reads all rows and writes their columns, database types, if rows implement dbscan.ColumnTypesRows,
+// and raw values to the golden file at the path, creating its directory. It closes the rows.
+// Values are read into interface{} destinations, so the file has the values that the database library returns,
+// values of types that implement driver.Valuer are converted with it.
+func RecordGolden(path string, rows dbscan.Rows) (err error) {
+	defer func() {
+		if closeErr := rows.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("scany: close rows: %w", closeErr)
+		}
+	}()
+	var f goldenFile
+	if f.Columns, err = rows.Columns(); err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	if ctr, ok := rows.(dbscan.ColumnTypesRows); ok {
+		if f.Types, err = ctr.ColumnDatabaseTypes(); err != nil {
+			return fmt.Errorf("scany: get column database types: %w", err)
+		}
+	}
+	f.Rows = [][]goldenValue{}
+	for rows.Next() {
+		values := make([]interface{}, len(f.Columns))
+		dest := make([]interface{}, len(values))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("scany: scan row %d: %w", len(f.Rows), err)
+		}
+		row := make([]goldenValue, len(values))
+		for i, v := range values {
+			if row[i].v, err = goldenEncode(v); err != nil {
+				return fmt.Errorf("scany: row %d: column '%s': %w", len(f.Rows), f.Columns[i], err)
+			}
+		}
+		f.Rows = append(f.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("scany: rows final error: %w", err)
+	}
+	data, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return fmt.Errorf("scany: encode golden file %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("scany: create golden file directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint: gosec
+		return fmt.Errorf("scany: write golden file: %w", err)
+	}
+	return nil
+}
+
+// LoadGolden returns rows that replay the golden file at the path, see RecordGolden.
+func LoadGolden(path string) (*Rows, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("scany: read golden file: %w", err)
+	}
+	var f goldenFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("scany: decode golden file %s: %w", path, err)
+	}
+	values := make([][]interface{}, len(f.Rows))
+	for i, row := range f.Rows {
+		values[i] = make([]interface{}, len(row))
+		for j, v := range row {
+			values[i][j] = v.v
+		}
+	}
+	rows := NewRows(f.Columns, values)
+	if f.Types != nil {
+		rows.WithDatabaseTypes(f.Types...)
+	}
+	return rows, nil
+}
+
+func (gv goldenValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gv.v)
+}
+
+func (gv *goldenValue) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(data, []byte("{")) {
+		return json.Unmarshal(data, &gv.v)
+	}
+	var typed map[string]json.RawMessage
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	if len(typed) != 1 {
+		return fmt.Errorf("scany: golden value must have exactly one type key: %s", data)
+	}
+	for typ, raw := range typed {
+		v, err := goldenDecode(typ, raw)
+		if err != nil {
+			return fmt.Errorf("scany: decode golden %s value %s: %w", typ, raw, err)
+		}
+		gv.v = v
+	}
+	return nil
+}
+
+// goldenEncode returns the JSON representation of the value.
+func goldenEncode(v interface{}) (interface{}, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			return nil, fmt.Errorf("scany: get driver value of %T: %w", valuer, err)
+		}
+	}
+	switch v := v.(type) {
+	case nil, string, bool:
+		return v, nil
+	case []byte:
+		return map[string]string{"bytes": base64.StdEncoding.EncodeToString(v)}, nil
+	case time.Time:
+		return map[string]string{"time": v.Format(time.RFC3339Nano)}, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]int64{"int64": rv.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]uint64{"uint64": rv.Uint()}, nil
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return map[string]string{"float64": strconv.FormatFloat(f, 'g', -1, 64)}, nil
+		}
+		return map[string]float64{"float64": rv.Float()}, nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	}
+	return nil, fmt.Errorf("scany: can't record value of %T in a golden file", v)
+}
+
+// goldenDecode returns the value of the type from its JSON representation.
+func goldenDecode(typ string, raw json.RawMessage) (interface{}, error) {
+	switch typ {
+	case "int64":
+		return strconv.ParseInt(string(raw), 10, 64)
+	case "uint64":
+		return strconv.ParseUint(string(raw), 10, 64)
+	case "float64":
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			return strconv.ParseFloat(s, 64)
+		}
+		return strconv.ParseFloat(string(raw), 64)
+	case "bytes":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.DecodeString(s)
+	case "time":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	}
+	return nil, fmt.Errorf("scany: unknown golden value type %q", typ)
+}
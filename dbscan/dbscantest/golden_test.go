@@ -0,0 +1,104 @@
+package dbscantest_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+func TestRecordGolden_LoadGolden_roundTrip(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "testdata", "values.golden.json")
+	columns := []string{"str", "int", "uint", "float", "inf", "bool", "bytes", "time", "null"}
+	values := [][]interface{}{{
+		"foo", int64(-1), uint64(math.MaxUint64), 1.5, math.Inf(1), true, []byte{0, 1},
+		time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), nil,
+	}}
+	dbTypes := []string{"TEXT", "INT8", "", "FLOAT8", "", "BOOL", "BYTEA", "", ""}
+	rows := dbscantest.NewRows(columns, values).WithDatabaseTypes(dbTypes...)
+
+	err := dbscantest.RecordGolden(path, rows)
+	require.NoError(t, err)
+	assert.True(t, rows.Closed())
+	loaded, err := dbscantest.LoadGolden(path)
+	require.NoError(t, err)
+
+	var got []map[string]interface{}
+	err = dbscan.ScanAll(&got, loaded)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	for i, column := range columns {
+		assert.Equal(t, values[0][i], got[0][column], column)
+	}
+	loadedTypes, err := loaded.ColumnDatabaseTypes()
+	require.NoError(t, err)
+	assert.Equal(t, dbTypes, loadedTypes)
+}
+
+func TestRecordGolden_unsupportedValue(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "values.golden.json")
+	rows := dbscantest.NewRows([]string{"tags"}, [][]interface{}{{[]string{"foo"}}})
+
+	err := dbscantest.RecordGolden(path, rows)
+
+	assert.EqualError(t, err, "scany: row 0: column 'tags': scany: can't record value of []string in a golden file")
+	assert.NoFileExists(t, path)
+}
+
+func TestGolden_replaysCommittedFile(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.Golden(t, filepath.Join("testdata", "users.golden.json"), func() (dbscan.Rows, error) {
+		t.Fatal("query must not be called for an existing golden file")
+		return nil, nil
+	})
+
+	var got []user
+	err := dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []user{{ID: 1, Name: "foo"}, {ID: 2, Name: "bar"}}, got)
+}
+
+func TestGolden_recordsMissingFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "users.golden.json")
+	var calls int
+	query := func() (dbscan.Rows, error) {
+		calls++
+		return dbscantest.NewRows([]string{"id", "name"}, [][]interface{}{{int64(1), "foo"}}), nil
+	}
+
+	dbscantest.Golden(t, path, query)
+	rows := dbscantest.Golden(t, path, query)
+
+	var got []user
+	err := dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+	assert.Equal(t, []user{{ID: 1, Name: "foo"}}, got)
+	assert.Equal(t, 1, calls)
+	assert.FileExists(t, path)
+}
+
+func TestGolden_updateEnv_recordsAgain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.golden.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"columns": ["id"], "rows": [[{"int64": 1}]]}`), 0o600))
+	t.Setenv(dbscantest.UpdateGoldenEnv, "1")
+
+	rows := dbscantest.Golden(t, path, func() (dbscan.Rows, error) {
+		return dbscantest.NewRows([]string{"id"}, [][]interface{}{{int64(2)}}), nil
+	})
+
+	var got []int64
+	err := dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, got)
+}
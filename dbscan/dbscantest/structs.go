@@ -0,0 +1,57 @@
+package dbscantest
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// StructRows returns columns and values of rows with data of the struct or the slice of structs,
+// or pointers to them, following the same mapping rules as scanning does, see dbscan.Columns and dbscan.Values.
+// Values of fields that implement driver.Valuer, e.g. sql.NullString, are converted with it as drivers do,
+// so they scan back into the same types.
+func StructRows(src interface{}) (columns []string, values [][]interface{}, err error) {
+	srcValue := reflect.ValueOf(src)
+	for srcValue.Kind() == reflect.Ptr && !srcValue.IsNil() {
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Slice {
+		columns, values, err := StructRows([]interface{}{src})
+		return columns, values, err
+	}
+	elemType := srcValue.Type().Elem()
+	if srcValue.Len() > 0 && elemType.Kind() == reflect.Interface {
+		elemType = srcValue.Index(0).Elem().Type()
+	}
+	columns, err = dbscan.Columns(reflect.New(elemType).Interface())
+	if err != nil {
+		return nil, nil, err
+	}
+	values = make([][]interface{}, srcValue.Len())
+	for i := range values {
+		row, err := dbscan.Values(srcValue.Index(i).Interface(), columns...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scany: row %d: %w", i, err)
+		}
+		for j, v := range row {
+			if valuer, ok := v.(driver.Valuer); ok {
+				if row[j], err = valuer.Value(); err != nil {
+					return nil, nil, fmt.Errorf("scany: row %d: column '%s': %w", i, columns[j], err)
+				}
+			}
+		}
+		values[i] = row
+	}
+	return columns, values, nil
+}
+
+// NewRowsFromStructs returns new rows with data of the struct or the slice of structs, see StructRows.
+func NewRowsFromStructs(src interface{}) (*Rows, error) {
+	columns, values, err := StructRows(src)
+	if err != nil {
+		return nil, err
+	}
+	return NewRows(columns, values), nil
+}
@@ -0,0 +1,78 @@
+package dbscan
+
+import (
+	"reflect"
+)
+
+// DebugLogger receives the mapping decisions that dbscan makes, see WithDebugLogger for details.
+// The standard *log.Logger satisfies this interface.
+type DebugLogger interface {
+	Printf(format string, args ...interface{})
+}
+
+// WithDebugLogger enables reporting of how columns are mapped to the destination.
+// For every rows instance dbscan logs, before scanning the first row, which column is mapped to which field,
+// which fields are decoded by the database library itself (e.g. JSON into maps and slices)
+// and which columns are skipped.
+// It's meant for diagnosing fields that silently stay zero and shouldn't be enabled in production.
+func WithDebugLogger(logger DebugLogger) APIOption {
+	return func(api *API) {
+		api.debugLogger = logger
+	}
+}
+
+func (api *API) logMapping(
+	dstType reflect.Type, columns []string, kind destinationKind, columnToFieldIndex map[string][]int,
+) {
+	if api.debugLogger == nil {
+		return
+	}
+	switch kind {
+	case structDestination:
+		for _, column := range columns {
+			fieldIndex, ok := columnToFieldIndex[column]
+			if !ok {
+				if name, isSource := api.getComposedFields(dstType).sources[column]; isSource {
+					api.debugLogger.Printf("scany: %v: column '%s' -> composer '%s'", dstType, column, name)
+					continue
+				}
+				api.debugLogger.Printf("scany: %v: column '%s' skipped: no corresponding field", dstType, column)
+				continue
+			}
+			path, fieldType := structFieldPath(dstType, fieldIndex)
+			if isDecodedByLibrary(fieldType) {
+				api.debugLogger.Printf(
+					"scany: %v: column '%s' -> field %s (%v), decoded by the database library, e.g. from JSON",
+					dstType, column, path, fieldType,
+				)
+				continue
+			}
+			api.debugLogger.Printf("scany: %v: column '%s' -> field %s (%v)", dstType, column, path, fieldType)
+		}
+	case mapDestination:
+		for _, column := range columns {
+			api.debugLogger.Printf("scany: %v: column '%s' -> map key '%s' (%v)", dstType, column, column, dstType.Elem())
+		}
+	default:
+		api.debugLogger.Printf("scany: %v: column '%s' -> value", dstType, columns[0])
+	}
+}
+
+// isDecodedByLibrary reports whether dbscan passes the field to the database library
+// that has to decode a composite value into it, as opposed to scalars and types implementing Scan.
+func isDecodedByLibrary(fieldType reflect.Type) bool {
+	if reflect.PtrTo(fieldType).Implements(reflect.TypeOf((*valueScanner)(nil)).Elem()) {
+		return false
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() { //nolint: exhaustive
+	case reflect.Map, reflect.Interface, reflect.Array:
+		return true
+	case reflect.Slice:
+		return fieldType.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
@@ -0,0 +1,43 @@
+package dbscan_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestWithDebugLogger(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Foo  string
+		Tags []string
+	}
+	logger := &recordingLogger{}
+	api, err := getAPI(dbscan.WithDebugLogger(logger), dbscan.WithAllowUnknownColumns(true))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS foo, ARRAY['a'] AS tags, 'bar val' AS bar`)
+
+	var got dst
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	expected := []string{
+		"scany: dbscan_test.dst: column 'foo' -> field dst.Foo (string)",
+		"scany: dbscan_test.dst: column 'tags' -> field dst.Tags ([]string), " +
+			"decoded by the database library, e.g. from JSON",
+		"scany: dbscan_test.dst: column 'bar' skipped: no corresponding field",
+	}
+	assert.Equal(t, expected, logger.lines)
+}
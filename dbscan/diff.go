@@ -0,0 +1,79 @@
+package dbscan
+
+import (
+	"context"
+	"reflect"
+)
+
+// ColumnChange holds values of a column before and after a change, see Diff.
+type ColumnChange struct {
+	Old interface{}
+	New interface{}
+}
+
+// Diff is a package-level helper function that uses the DefaultAPI object.
+// See API.Diff for details.
+func Diff[T any](a, b T) (map[string]ColumnChange, error) {
+	return DefaultAPI.Diff(a, b)
+}
+
+// Diff returns old and new values of every column whose field value differs between two structs of the same type,
+// with columns and values matching what Values returns, so they are consistent with how rows are persisted,
+// e.g. to record per-column changes in an audit log.
+// Values of encrypted fields are compared in plaintext, but returned encrypted via the crypto provider,
+// see WithCryptoProvider.
+// If a embeds ChangeTracker that holds values recorded by ScanOne, they are used as old values, see Changed.
+func (api *API) Diff(a, b interface{}) (map[string]ColumnChange, error) {
+	return api.DiffContext(context.Background(), a, b)
+}
+
+// DiffContext is the same as Diff, but passes the context to the crypto provider.
+func (api *API) DiffContext(ctx context.Context, a, b interface{}) (map[string]ColumnChange, error) {
+	columns, oldValues, newValues, err := api.compareStructs(a, b)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[string]ColumnChange)
+	for i, column := range columns {
+		if !reflect.DeepEqual(oldValues[i], newValues[i]) {
+			changes[column] = ColumnChange{Old: oldValues[i], New: newValues[i]}
+		}
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+	structType := reflect.TypeOf(b)
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	changedColumns := make([]string, 0, len(changes))
+	for column := range changes {
+		changedColumns = append(changedColumns, column)
+	}
+	transforms, err := api.getFieldTransforms(structType, changedColumns, api.getColumnToFieldIndexMap(structType))
+	if err != nil {
+		return nil, err
+	}
+	for _, transform := range transforms {
+		if !transform.decrypt {
+			continue
+		}
+		change := changes[transform.column]
+		if change.Old, err = api.encryptValue(ctx, transform.column, change.Old); err != nil {
+			return nil, err
+		}
+		if change.New, err = api.encryptValue(ctx, transform.column, change.New); err != nil {
+			return nil, err
+		}
+		changes[transform.column] = change
+	}
+	return changes, nil
+}
+
+// encryptValue encrypts a non-NULL value of an encrypted field.
+func (api *API) encryptValue(ctx context.Context, column string, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return api.encryptField(ctx, column, reflect.ValueOf(value))
+}
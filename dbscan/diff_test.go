@@ -0,0 +1,40 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	before := testModel{Foo: "foo val", Bar: "bar val"}
+	after := testModel{Foo: "foo val", Bar: "new bar val"}
+
+	got, err := dbscan.Diff(before, after)
+	require.NoError(t, err)
+
+	expected := map[string]dbscan.ColumnChange{"bar": {Old: "bar val", New: "new bar val"}}
+	assert.Equal(t, expected, got)
+}
+
+func TestDiff_encryptedFields_returnsCiphertext(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		ID  string
+		SSN *string `db:"ssn,encrypted"`
+	}
+	api, err := getAPI(dbscan.WithCryptoProvider(shiftCrypto{}))
+	require.NoError(t, err)
+	before := dst{ID: "id val"}
+	after := dst{ID: "id val", SSN: makeStrPtr("abc")}
+
+	got, err := api.Diff(&before, &after)
+	require.NoError(t, err)
+
+	expected := map[string]dbscan.ColumnChange{"ssn": {Old: nil, New: "bcd"}}
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,144 @@
+package dbscan
+
+import (
+	"hash/fnv"
+	"reflect"
+)
+
+// WithDistinct makes ScanAll drop duplicate rows from the destination slice,
+// which is handy when a JOIN fans out parent rows.
+// If consecutiveOnly is true, only duplicates that directly follow each other are dropped,
+// which is cheap and enough for ordered results, otherwise all duplicates are dropped.
+// Rows are compared by values of the given key columns, or by the whole element if no key columns are provided.
+// The first row of duplicates is kept. Note that the row limit set by WithMaxRows applies before deduplication.
+func WithDistinct(consecutiveOnly bool, keyColumns ...string) APIOption {
+	return func(api *API) {
+		api.distinct = true
+		api.distinctConsecutiveOnly = consecutiveOnly
+		api.distinctKeyColumns = keyColumns
+	}
+}
+
+type distinctKey struct {
+	hash   uint64
+	values []interface{}
+}
+
+func (api *API) distinctKeyFn(sliceMeta *sliceDestinationMeta) (func(elem reflect.Value) distinctKey, error) {
+	if len(api.distinctKeyColumns) == 0 {
+		return func(elem reflect.Value) distinctKey {
+			return newDistinctKey([]reflect.Value{elem})
+		}, nil
+	}
+	elemType := sliceMeta.elementBaseType
+	switch {
+	case elemType.Kind() == reflect.Struct && !api.isScannableType(elemType):
+		columnToFieldIndex := api.getColumnToFieldIndexMap(elemType)
+		fieldIndexes := make([][]int, len(api.distinctKeyColumns))
+		for i, column := range api.distinctKeyColumns {
+			fieldIndex, ok := columnToFieldIndex[column]
+			if !ok {
+				return nil, newSentinelErrorf(ErrColumnMismatch,
+					"scany: distinct key column '%s': no corresponding field found in %v", column, elemType,
+				)
+			}
+			fieldIndexes[i] = fieldIndex
+		}
+		return func(elem reflect.Value) distinctKey {
+			values := make([]reflect.Value, len(fieldIndexes))
+			for i, fieldIndex := range fieldIndexes {
+				values[i] = fieldByIndexOrZero(elem, fieldIndex)
+			}
+			return newDistinctKey(values)
+		}, nil
+	case elemType.Kind() == reflect.Map:
+		return func(elem reflect.Value) distinctKey {
+			values := make([]reflect.Value, len(api.distinctKeyColumns))
+			for i, column := range api.distinctKeyColumns {
+				values[i] = elem.MapIndex(reflect.ValueOf(column))
+				if !values[i].IsValid() {
+					values[i] = reflect.Zero(elemType.Elem())
+				}
+			}
+			return newDistinctKey(values)
+		}, nil
+	default:
+		return nil, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: distinct key columns require a slice of structs or maps, got element type: %v", elemType,
+		)
+	}
+}
+
+// fieldByIndexOrZero returns the zero value if a nested struct by a pointer on the way is nil.
+func fieldByIndexOrZero(structValue reflect.Value, fieldIndex []int) reflect.Value {
+	field, err := structValue.FieldByIndexErr(fieldIndex)
+	if err != nil {
+		return reflect.Zero(structFieldByIndex(structValue.Type(), fieldIndex).Type)
+	}
+	return field
+}
+
+func newDistinctKey(values []reflect.Value) distinctKey {
+	h := fnv.New64a()
+	key := distinctKey{values: make([]interface{}, len(values))}
+	for i, v := range values {
+		hashValue(h, v)
+		key.values[i] = v.Interface()
+	}
+	key.hash = h.Sum64()
+	return key
+}
+
+func (k distinctKey) equal(other distinctKey) bool {
+	return k.hash == other.hash && reflect.DeepEqual(k.values, other.values)
+}
+
+// removeDuplicates filters the destination slice in place.
+func (api *API) removeDuplicates(sliceMeta *sliceDestinationMeta) error {
+	keyFn, err := api.distinctKeyFn(sliceMeta)
+	if err != nil {
+		return err
+	}
+	s := sliceMeta.val
+	var prev distinctKey
+	seen := map[uint64][]distinctKey{}
+	// Elements that the slice had before scanning are never dropped, see WithAppendToSlice.
+	kept := sliceMeta.scannedFrom
+	for i := sliceMeta.scannedFrom; i < s.Len(); i++ {
+		elem := s.Index(i)
+		base := elem
+		if sliceMeta.elementByPtr {
+			base = elem.Elem()
+		}
+		key := keyFn(base)
+		duplicate := false
+		if api.distinctConsecutiveOnly {
+			duplicate = i > sliceMeta.scannedFrom && key.equal(prev)
+			prev = key
+		} else {
+			for _, other := range seen[key.hash] {
+				if key.equal(other) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				seen[key.hash] = append(seen[key.hash], key)
+			}
+		}
+		if duplicate {
+			api.releaseElement(sliceMeta, elem)
+			continue
+		}
+		if kept != i {
+			s.Index(kept).Set(elem)
+		}
+		kept++
+	}
+	// Zero the tail, so the backing array doesn't retain dropped elements.
+	for i := kept; i < s.Len(); i++ {
+		s.Index(i).Set(reflect.Zero(s.Type().Elem()))
+	}
+	s.SetLen(kept)
+	return nil
+}
@@ -0,0 +1,77 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanAll_withDistinct(t *testing.T) {
+	t.Parallel()
+	query := `
+		SELECT *
+		FROM (
+			VALUES ('foo 1', 'bar 1'), ('foo 1', 'bar 1'), ('foo 2', 'bar 2'), ('foo 1', 'bar 3'), ('foo 1', 'bar 1')
+		) AS t (foo, bar)
+	`
+	cases := []struct {
+		name     string
+		option   dbscan.APIOption
+		expected []*testModel
+	}{
+		{
+			name:   "consecutive full row",
+			option: dbscan.WithDistinct(true),
+			expected: []*testModel{
+				{Foo: "foo 1", Bar: "bar 1"},
+				{Foo: "foo 2", Bar: "bar 2"},
+				{Foo: "foo 1", Bar: "bar 3"},
+				{Foo: "foo 1", Bar: "bar 1"},
+			},
+		},
+		{
+			name:   "all full row",
+			option: dbscan.WithDistinct(false),
+			expected: []*testModel{
+				{Foo: "foo 1", Bar: "bar 1"},
+				{Foo: "foo 2", Bar: "bar 2"},
+				{Foo: "foo 1", Bar: "bar 3"},
+			},
+		},
+		{
+			name:   "all by key column",
+			option: dbscan.WithDistinct(false, "foo"),
+			expected: []*testModel{
+				{Foo: "foo 1", Bar: "bar 1"},
+				{Foo: "foo 2", Bar: "bar 2"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.option)
+			require.NoError(t, err)
+			var got []*testModel
+			err = api.ScanAll(&got, queryRows(t, query))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestScanAll_withDistinct_unknownKeyColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithDistinct(false, "baz"))
+	require.NoError(t, err)
+	var got []testModel
+
+	err = api.ScanAll(&got, queryRows(t, multipleRowsQuery))
+
+	expectedErr := "scany: distinct key column 'baz': no corresponding field found in dbscan_test.testModel"
+	assert.EqualError(t, err, expectedErr)
+}
@@ -22,16 +22,61 @@ The main feature of dbscan is the ability to scan rows data into structs.
 
 By default, to get the corresponding database column, dbscan translates the struct field name to snake case.
 To override this behavior, specify the column name in the `db` field tag.
+To reuse tags of another library, e.g. `json`, as a fallback for fields without the `db` tag, see WithStructTagKeys.
 In the example above User struct is mapped to the following columns: "user_id", "first_name", "email".
 
 If selected rows contain a column that doesn't have a corresponding struct field, dbscan returns an error,
 this forces to only select data from the database that the application needs.
+dbscan validates all columns before scanning the first row and returns MappingError
+that lists every mismatched column, so all of them can be fixed at once.
+To check a struct against a known query's columns without scanning any rows, e.g. in tests or at startup,
+use ValidateDestination.
+To build the select list of a query from the struct itself, use Columns,
+it returns the columns in the order fields are declared.
+Values does the inverse of scanning and extracts field values in the order of columns,
+e.g. to build arguments of an INSERT query. NamedArgs returns the same values keyed by columns.
+To update only modified columns, embed ChangeTracker into the struct and see WithChangeTracking and Changed.
+Diff returns old and new values of changed columns, e.g. for audit logs.
 
 dbscan supports commas "," in the struct tag name.
 That makes it compatible with the struct tag formats of other libraries.
 dbscan splits the tag name by "," and uses the first part as the column name.
 So `db:"user_id,other_tag_value"` struct tag is equivalent to `db:"user_id"` for dbscan.
 
+Some drivers return fully qualified column names, e.g. "users.email",
+see WithTableQualifierStripping to match them with struct fields.
+Other driver-specific naming, like quoted or upper case column names,
+can be fixed centrally with a chain of normalizers, see WithColumnNormalizers.
+
+Field transforms
+
+Simple normalization of scanned values doesn't require a hook, a field can list named transforms in the tag,
+they are applied in order after the column value is scanned into the field:
+
+	type User struct {
+		Email string `db:"email,transform=trim,transform=lower"`
+	}
+
+"lower", "upper" and "trim" transforms are available by default, custom ones are registered via WithTransform.
+
+Fields with sensitive data can be stored encrypted and decrypted transparently during scanning,
+mark such fields with the "encrypted" tag option, e.g. `db:"ssn,encrypted"`, and see WithCryptoProvider for details.
+
+A field can also be derived from several columns, e.g. FullName from "first_name" and "last_name",
+ignore such a field via `db:"-,compose=full_name"` and register the composer via WithFieldComposer.
+
+Row hash
+
+Change detection and sync jobs can get a stable hash of every scanned row without re-serializing structs.
+Mark a uint64 or string field with the "rowhash" tag option, the field isn't mapped to any column,
+dbscan sets it to the hash of values scanned into the other fields:
+
+	type User struct {
+		ID    string
+		Email string
+		Hash  uint64 `db:",rowhash"`
+	}
+
 Reusing structs
 
 dbscan works recursively. A struct can contain embedded or nested structs as well.
@@ -84,6 +129,12 @@ You can also use the empty tag `db:""` to remove the prefix of a nested struct.
 UserPostComment struct is mapped to the following columns:
 "user.user_id", "user.email", "p.id", "p.text", "comment_body".
 
+Self-referential types like `type Node struct{ Parent *Node }` are safe to use,
+dbscan doesn't traverse a struct type again inside itself, see WithMaxStructDepth to expand such types.
+
+Instantiated generic structs, e.g. Page[User], are handled the same way as regular structs,
+including embedded generic structs, which are mapped without a prefix.
+
 NULLs and custom types
 
 dbscan supports custom types and NULLs perfectly.
@@ -109,10 +160,14 @@ and if the database library supports a type, dbscan supports it automatically, f
 User struct is valid, and every field will be scanned correctly, the only condition for this
 is that your database library can handle *string, CustomNullInt, CustomData and *CustomData types.
 
+If a single field needs custom decoding, and its type doesn't implement sql.Scanner,
+register a field scanner and reference it in the tag, e.g. `db:"geom,scanner=wkb"`, see WithFieldScanner.
+
 Ignored struct fields
 
 In order for dbscan to work with a field, it must be exported. Unexported fields will be ignored.
-The only exception is embedded structs. The type that is embedded might be unexported.
+The only exception is embedded structs. The type that is embedded might be unexported,
+unless it's embedded by a pointer, since dbscan can't initialize such a pointer.
 
 It's possible to mark a field as ignored for dbscan explicitly. To do this set `db:"-"` struct tag.
 By the way, it works for nested and embedded structs as well, for example:
@@ -159,6 +214,25 @@ UserPost.User.UserID will receive data from the "user_id" column and UserPost.Po
 Note that you can't access it as UserPost.UserID though. it's an error for Go, and
 you need to use the full version: UserPost.User.UserID
 
+Scan hooks
+
+If the destination or any nested struct implements BeforeScanner or AfterScanner,
+dbscan calls BeforeScan before the row is scanned and AfterScan after the row is populated.
+It's a convenient place to validate data, compute derived fields or decrypt values, for example:
+
+	type User struct {
+		Email       string
+		EmailDomain string `db:"-"`
+	}
+
+	func (u *User) AfterScan(ctx context.Context) error {
+		u.EmailDomain = u.Email[strings.LastIndex(u.Email, "@")+1:]
+		return nil
+	}
+
+AfterScan hooks of nested structs are called before the hook of the outer struct.
+An error returned from a hook aborts scanning, unless a row error handler skips the row, see WithRowErrorHandler.
+
 Scanning into map
 
 Apart from scanning into structs, dbscan can handle maps,
@@ -175,6 +249,14 @@ Map type isn't limited to map[string]interface{},
 it can be any map with a string key, e.g., map[string]string or map[string]int,
 if all column values have the same specific type.
 
+To build a lookup table keyed by one of the columns, use ScanPivot:
+
+	// Query rows from the database that implements dbscan.Rows interface.
+	var rows dbscan.Rows
+
+	usersByID, err := dbscan.ScanPivot(rows, "id")
+	// usersByID["42"]["email"] contains the email of the user with id 42.
+
 Scanning into other types
 
 If the destination isn't a struct nor a map, dbscan handles it as a single column scan,
@@ -187,11 +269,28 @@ dbscan ensures that rows contain exactly one column and scans destination from t
 	dbscan.ScanAll(&results, rows)
 	// results variable not contains data from all rows single column.
 
+To rename columns or reject a query before scanning begins, see WithStartHook.
+
 Duplicate columns
 
 Rows must not contain duplicate columns otherwise, dbscan won't be able to decide
 from which column to select and will return an error.
 
+Errors
+
+dbscan wraps sentinel errors into returned errors, so it's possible to check them with errors.Is:
+ErrNotFound, ErrTooManyRows, ErrColumnMismatch and ErrUnsupportedDestination.
+Not found errors of sqlscan and pgxscan, sql.ErrNoRows and pgx.ErrNoRows, match ErrNotFound as well,
+it's also available as scany.ErrNotFound. Integration packages can do the same for their libraries via WrapNotFound.
+When a database value can't be scanned into a struct field,
+dbscan returns ScanError that contains the column name, its database type, the Go field path and the row index,
+given that Rows can tell which column failed, see ScanErrorColumnRows and ColumnTypesRows for details.
+
+Debugging column mapping
+
+If a field silently stays zero, WithDebugLogger makes dbscan log which column is mapped to which field,
+which fields are decoded by the database library and which columns are skipped.
+
 Support for Row type
 
 dbscan doesn't support a single row type like Row, which you might see in many database libraries.
@@ -205,12 +304,54 @@ Rows processing
 ScanAll and ScanOne functions take care of rows processing,
 they iterate rows to the end and close them after that.
 Client code doesn't need to bother with that. It just passes rows to dbscan.
+ScanAll resets the destination slice, to accumulate results of multiple queries in one slice,
+see WithAppendToSlice.
+To stream rows into a custom container, like a ring buffer or a cache, pass a Collector to ScanAll.
+Export endpoints can stream rows straight into JSON or CSV without intermediate structs,
+see ScanToJSON and ScanToCSV.
+
+Rows middleware
+
+To add cross-cutting behavior like column masking, value redaction or row counting to every scan,
+wrap rows with a middleware, see WithRowsMiddleware for details.
 
 Manual rows iteration
 
 It's possible to manually control rows iteration but still use all scanning features of dbscan,
 see RowScanner for details.
 
+Removing duplicate rows
+
+When a JOIN fans out parent rows, ScanAll can drop duplicates by a key column or by the whole row,
+see WithDistinct for details.
+
+Sharing mapping between goroutines
+
+Partitioned queries that are read in parallel can share one compiled mapping via ConcurrentScanner,
+which is safe for use from multiple goroutines, see ConcurrentScanner for details.
+
+Parallel decoding
+
+When decoding rows is CPU-heavy, e.g. structs with many fields or fields that unmarshal JSON,
+ScanAll can decode rows into a slice of structs concurrently, see WithParallelDecoding for details.
+One goroutine reads rows into batches of raw values, and several workers decode them into structs,
+the order of rows is preserved.
+
+Generated scanners
+
+For hot paths, structs can scan rows into their fields without reflection by implementing ScanRower.
+The scany-gen tool generates such implementations via go:generate:
+
+	//go:generate go run github.com/georgysavva/scany/v2/cmd/scany-gen -type User
+
+dbscan uses them when available, see ScanRower for the conditions.
+
+Recycling result memory
+
+When the destination is a slice of structs by a pointer, ScanAll allocates every element separately.
+High-throughput services can recycle those elements between queries via a custom allocator,
+see WithElementAllocator and PoolAllocator for details.
+
 Overriding default settings
 
 dbscan has API type, which you can use to set custom settings, see API for details.
@@ -221,5 +362,9 @@ dbscan can be used with any database library with a concept of rows and can impl
 It's pretty likely that your rows type already implements the Rows interface as-is.
 For example, this is true for the standard *sql.Rows type.
 Or you just need a thin adapter as it is done for pgx.Rows in pgxscan, see pgxscan.RowsAdapter for details.
+The adapter package has helpers to build such adapters, and its adaptertest package has a conformance test suite
+that proves an adapter works with dbscan.
+To unit test scanning logic without a database, the dbscantest package has in-memory rows,
+with injected errors and tracking of Close calls, and golden files that record real rows to replay them.
 */
 package dbscan
@@ -0,0 +1,249 @@
+package dbscan
+
+import (
+	"bufio"
+	"context"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// ScanToJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToJSON for details.
+func ScanToJSON(w io.Writer, rows Rows) error {
+	return DefaultAPI.ScanToJSON(w, rows)
+}
+
+// ScanToCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToCSV for details.
+func ScanToCSV(w io.Writer, rows Rows) error {
+	return DefaultAPI.ScanToCSV(w, rows)
+}
+
+// ScanToJSON iterates all rows to the end and streams them into w as a JSON array of objects,
+// without building intermediate structs or maps, e.g. for export endpoints serving millions of rows.
+// Object keys are the columns in the order rows return them,
+// and values are encoded with json.Marshal from the values
+// that the underlying database library returns for *interface{}.
+// Valid UTF-8 []byte values are written as strings, other []byte values are base64 encoded as usual.
+// Columns are resolved the same way as for map destinations, e.g. WithColumnNormalizers are applied.
+// Data written before an error stays in w. After iterating all rows, it closes them.
+func (api *API) ScanToJSON(w io.Writer, rows Rows) error {
+	return api.ScanToJSONContext(context.Background(), w, rows)
+}
+
+// ScanToJSONContext is the same as ScanToJSON, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+func (api *API) ScanToJSONContext(ctx context.Context, w io.Writer, rows Rows) error {
+	return api.encodeRows(ctx, rows, &jsonRowEncoder{w: bufio.NewWriter(w)})
+}
+
+// ScanToCSV iterates all rows to the end and streams them into w as CSV,
+// without building intermediate structs or maps, e.g. for export endpoints serving millions of rows.
+// The first record is the header with the column names.
+// NULL values are written as empty fields, time.Time values in the RFC 3339 format,
+// values implementing encoding.TextMarshaler via MarshalText and other values via fmt.Sprint.
+// Columns are resolved the same way as for map destinations, e.g. WithColumnNormalizers are applied.
+// Data written before an error stays in w. After iterating all rows, it closes them.
+func (api *API) ScanToCSV(w io.Writer, rows Rows) error {
+	return api.ScanToCSVContext(context.Background(), w, rows)
+}
+
+// ScanToCSVContext is the same as ScanToCSV, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+func (api *API) ScanToCSVContext(ctx context.Context, w io.Writer, rows Rows) error {
+	return api.encodeRows(ctx, rows, &csvRowEncoder{w: csv.NewWriter(w)})
+}
+
+type rowEncoder interface {
+	begin(columns []string) error
+	encode(values []interface{}) error
+	end() error
+}
+
+func (api *API) encodeRows(ctx context.Context, rows Rows, enc rowEncoder) error {
+	rows = api.wrapRows(rows)
+	defer rows.Close() //nolint: errcheck
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	columns, err = api.resolveColumns(reflect.TypeOf(map[string]interface{}(nil)), columns)
+	if err != nil {
+		return err
+	}
+	if err := api.validateColumns(columns, nil, nil); err != nil {
+		return err
+	}
+	if err := enc.begin(columns); err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	scans := make([]interface{}, len(columns))
+	for i := range values {
+		scans[i] = &values[i]
+	}
+	var rowsRead int
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("scany: scanning aborted: %w", err)
+		}
+		if api.maxRows > 0 && rowsRead >= api.maxRows {
+			if api.maxRowsReturnErr {
+				return api.tooManyRowsErr()
+			}
+			break
+		}
+		if err := rows.Scan(scans...); err != nil {
+			return fmt.Errorf("scany: scan row into raw values: %w", err)
+		}
+		if err := enc.encode(values); err != nil {
+			return err
+		}
+		rowsRead++
+	}
+	if err := finishRows(rows, true /* closeRows. */); err != nil {
+		return err
+	}
+	return enc.end()
+}
+
+type jsonRowEncoder struct {
+	w       *bufio.Writer
+	columns []string
+	keys    [][]byte
+	started bool
+}
+
+func (e *jsonRowEncoder) begin(columns []string) error {
+	e.columns = columns
+	e.keys = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return fmt.Errorf("scany: write JSON: %w", err)
+		}
+		e.keys[i] = key
+	}
+	return e.writeByte('[')
+}
+
+func (e *jsonRowEncoder) encode(values []interface{}) error {
+	if e.started {
+		if err := e.writeByte(','); err != nil {
+			return err
+		}
+	}
+	e.started = true
+	if err := e.writeByte('{'); err != nil {
+		return err
+	}
+	for i, value := range values {
+		if b, ok := value.([]byte); ok && utf8.Valid(b) {
+			value = string(b)
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("scany: write JSON: column '%s': %w", e.columns[i], err)
+		}
+		if i > 0 {
+			if err := e.writeByte(','); err != nil {
+				return err
+			}
+		}
+		if err := e.write(e.keys[i], []byte{':'}, data); err != nil {
+			return err
+		}
+	}
+	return e.writeByte('}')
+}
+
+func (e *jsonRowEncoder) end() error {
+	if err := e.writeByte(']'); err != nil {
+		return err
+	}
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("scany: write JSON: %w", err)
+	}
+	return nil
+}
+
+func (e *jsonRowEncoder) writeByte(b byte) error {
+	if err := e.w.WriteByte(b); err != nil {
+		return fmt.Errorf("scany: write JSON: %w", err)
+	}
+	return nil
+}
+
+func (e *jsonRowEncoder) write(parts ...[]byte) error {
+	for _, p := range parts {
+		if _, err := e.w.Write(p); err != nil {
+			return fmt.Errorf("scany: write JSON: %w", err)
+		}
+	}
+	return nil
+}
+
+type csvRowEncoder struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (e *csvRowEncoder) begin(columns []string) error {
+	e.record = make([]string, len(columns))
+	if err := e.w.Write(columns); err != nil {
+		return fmt.Errorf("scany: write CSV: %w", err)
+	}
+	return nil
+}
+
+func (e *csvRowEncoder) encode(values []interface{}) error {
+	for i, value := range values {
+		field, err := csvField(value)
+		if err != nil {
+			return fmt.Errorf("scany: write CSV: %w", err)
+		}
+		e.record[i] = field
+	}
+	if err := e.w.Write(e.record); err != nil {
+		return fmt.Errorf("scany: write CSV: %w", err)
+	}
+	return nil
+}
+
+func (e *csvRowEncoder) end() error {
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("scany: write CSV: %w", err)
+	}
+	return nil
+}
+
+func csvField(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,77 @@
+package dbscan_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanToJSON(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `
+		SELECT * FROM (
+			VALUES ('foo val', 'bar val', true), ('foo val 2', NULL, false)
+		) AS t (foo, bar, baz)
+	`)
+	var buf bytes.Buffer
+
+	err := testAPI.ScanToJSON(&buf, rows)
+	require.NoError(t, err)
+
+	expected := `[{"foo":"foo val","bar":"bar val","baz":true},{"foo":"foo val 2","bar":null,"baz":false}]`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestScanToJSON_noRows(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT NULL AS foo LIMIT 0`)
+	var buf bytes.Buffer
+
+	err := testAPI.ScanToJSON(&buf, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[]", buf.String())
+}
+
+func TestScanToCSV(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `
+		SELECT * FROM (
+			VALUES ('foo, val', 'bar val', true), ('foo val 2', NULL, false)
+		) AS t (foo, bar, baz)
+	`)
+	var buf bytes.Buffer
+
+	err := testAPI.ScanToCSV(&buf, rows)
+	require.NoError(t, err)
+
+	expected := "foo,bar,baz\n\"foo, val\",bar val,true\nfoo val 2,,false\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestScanToCSV_withColumnNormalizers(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithColumnNormalizers(dbscan.LowerCaseNormalizer))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS "FOO"`)
+	var buf bytes.Buffer
+
+	err = api.ScanToCSV(&buf, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo\nfoo val\n", buf.String())
+}
+
+func TestScanToJSON_duplicateColumns_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT 'foo val' AS foo, 'foo val 2' AS foo`)
+	var buf bytes.Buffer
+
+	err := testAPI.ScanToJSON(&buf, rows)
+
+	assert.EqualError(t, err, "scany: rows contain a duplicate column 'foo'")
+}
@@ -0,0 +1,66 @@
+package dbscan
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors that dbscan wraps into returned errors.
+// Use errors.Is to check for them instead of matching error messages.
+var (
+	// ErrNotFound is returned by ScanOne if there were no rows.
+	ErrNotFound = errors.New("scany: no row was found")
+	// ErrTooManyRows is returned by ScanOne if there was more than one row,
+	// and by ScanAll if rows contain more rows than the limit set via WithMaxRows.
+	ErrTooManyRows = errors.New("scany: too many rows")
+	// ErrColumnMismatch is returned if rows columns don't match the destination,
+	// e.g. a column has no corresponding struct field, or rows contain duplicate columns.
+	ErrColumnMismatch = errors.New("scany: columns don't match the destination")
+	// ErrUnsupportedDestination is returned if dbscan can't scan into the destination type,
+	// e.g. it's not a pointer, or it's a map with a non-string key.
+	ErrUnsupportedDestination = errors.New("scany: unsupported destination")
+)
+
+// sentinelError keeps the original error message and allows to check the sentinel via errors.Is.
+type sentinelError struct {
+	msg      string
+	sentinel error
+}
+
+func newSentinelErrorf(sentinel error, format string, args ...interface{}) error {
+	return &sentinelError{msg: fmt.Sprintf(format, args...), sentinel: sentinel}
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+func (e *sentinelError) Unwrap() error {
+	return e.sentinel
+}
+
+// WrapNotFound wraps a driver-specific not found error, e.g. sql.ErrNoRows or pgx.ErrNoRows,
+// so errors.Is matches it with ErrNotFound as well as with the original error.
+// The error message stays the same. It allows integration packages to report not found errors uniformly.
+func WrapNotFound(err error) error {
+	if err == nil || errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return &notFoundError{err: err}
+}
+
+type notFoundError struct {
+	err error
+}
+
+func (e *notFoundError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notFoundError) Unwrap() error {
+	return e.err
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
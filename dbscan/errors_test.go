@@ -0,0 +1,101 @@
+package dbscan_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		query       string
+		scanFn      func(dst interface{}, rows dbscan.Rows) error
+		dst         interface{}
+		expectedErr error
+	}{
+		{
+			name:        "no rows",
+			query:       `SELECT NULL AS foo, NULL AS bar LIMIT 0`,
+			scanFn:      testAPI.ScanOne,
+			dst:         &testModel{},
+			expectedErr: dbscan.ErrNotFound,
+		},
+		{
+			name:        "more than one row",
+			query:       multipleRowsQuery,
+			scanFn:      testAPI.ScanOne,
+			dst:         &testModel{},
+			expectedErr: dbscan.ErrTooManyRows,
+		},
+		{
+			name:        "column without corresponding field",
+			query:       multipleRowsQuery,
+			scanFn:      testAPI.ScanAll,
+			dst:         &[]struct{ Foo string }{},
+			expectedErr: dbscan.ErrColumnMismatch,
+		},
+		{
+			name:        "duplicate columns",
+			query:       `SELECT 'foo val' AS foo, 'foo val' AS foo`,
+			scanFn:      testAPI.ScanOne,
+			dst:         &testModel{},
+			expectedErr: dbscan.ErrColumnMismatch,
+		},
+		{
+			name:        "primitive type with multiple columns",
+			query:       singleRowsQuery,
+			scanFn:      testAPI.ScanOne,
+			dst:         new(string),
+			expectedErr: dbscan.ErrColumnMismatch,
+		},
+		{
+			name:        "non pointer destination",
+			query:       singleRowsQuery,
+			scanFn:      testAPI.ScanOne,
+			dst:         testModel{},
+			expectedErr: dbscan.ErrUnsupportedDestination,
+		},
+		{
+			name:        "non slice destination",
+			query:       multipleRowsQuery,
+			scanFn:      testAPI.ScanAll,
+			dst:         &testModel{},
+			expectedErr: dbscan.ErrUnsupportedDestination,
+		},
+		{
+			name:        "map with non string key",
+			query:       singleRowsQuery,
+			scanFn:      testAPI.ScanOne,
+			dst:         &map[int]interface{}{},
+			expectedErr: dbscan.ErrUnsupportedDestination,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := queryRows(t, tc.query)
+			err := tc.scanFn(tc.dst, rows)
+			assert.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestWrapNotFound(t *testing.T) {
+	t.Parallel()
+	driverErr := errors.New("driver: no rows")
+
+	err := dbscan.WrapNotFound(fmt.Errorf("query: %w", driverErr))
+
+	assert.EqualError(t, err, "query: driver: no rows")
+	assert.ErrorIs(t, err, driverErr)
+	assert.ErrorIs(t, err, dbscan.ErrNotFound)
+	assert.True(t, dbscan.NotFound(err))
+	assert.NoError(t, dbscan.WrapNotFound(nil))
+}
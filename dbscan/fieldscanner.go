@@ -0,0 +1,109 @@
+package dbscan
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const scannerTagOption = "scanner="
+
+// FieldScannerFunc scans a column value into a single struct field.
+// dst is a pointer to the field and src is the value that the underlying database library provides,
+// the same way as sql.Scanner.Scan receives it.
+type FieldScannerFunc func(dst interface{}, src interface{}) error
+
+// WithFieldScanner registers a named scanner that can be referenced in the struct tag of a field,
+// e.g. `db:"geom,scanner=wkb"`.
+// It allows to scan one odd column without implementing sql.Scanner for the whole field type.
+// The scanner is passed to the database library as a destination that implements sql.Scanner,
+// so the library must support such destinations, like database/sql and pgx do.
+// Registering a scanner with the same name replaces the previous one.
+func WithFieldScanner(name string, fn FieldScannerFunc) APIOption {
+	return func(api *API) {
+		if api.fieldScanners == nil {
+			api.fieldScanners = make(map[string]FieldScannerFunc)
+		}
+		api.fieldScanners[name] = fn
+	}
+}
+
+type structFieldScanners struct {
+	byColumn map[string]FieldScannerFunc
+	err      error
+}
+
+// getFieldScanners returns scanners for the given columns, a column without a scanner has nil one.
+// It returns nil if none of the columns have a scanner.
+func (api *API) getFieldScanners(
+	structType reflect.Type, columns []string, columnToFieldIndex map[string][]int,
+) ([]FieldScannerFunc, error) {
+	scannersIface, ok := api.fieldScannersCache.Load(structType)
+	if !ok {
+		scannersIface, _ = api.fieldScannersCache.LoadOrStore(
+			structType, api.buildFieldScanners(structType, columnToFieldIndex),
+		)
+	}
+	scanners := scannersIface.(*structFieldScanners)
+	if scanners.err != nil || len(scanners.byColumn) == 0 {
+		return nil, scanners.err
+	}
+	var result []FieldScannerFunc
+	for i, column := range columns {
+		fn, ok := scanners.byColumn[column]
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = make([]FieldScannerFunc, len(columns))
+		}
+		result[i] = fn
+	}
+	return result, nil
+}
+
+func (api *API) buildFieldScanners(structType reflect.Type, columnToFieldIndex map[string][]int) *structFieldScanners {
+	result := &structFieldScanners{}
+	// Iterate columns in a stable order, so the same error is reported every time.
+	columns := make([]string, 0, len(columnToFieldIndex))
+	for column := range columnToFieldIndex {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		fieldIndex := columnToFieldIndex[column]
+		tag, ok := api.lookupStructTag(structFieldByIndex(structType, fieldIndex))
+		if !ok {
+			continue
+		}
+		_, options := parseStructTag(tag)
+		for _, option := range options {
+			if !strings.HasPrefix(option, scannerTagOption) {
+				continue
+			}
+			name := strings.TrimPrefix(option, scannerTagOption)
+			fn, ok := api.fieldScanners[name]
+			if !ok {
+				path, _ := structFieldPath(structType, fieldIndex)
+				return &structFieldScanners{err: newSentinelErrorf(ErrUnsupportedDestination,
+					"scany: field %s: unknown scanner '%s', see WithFieldScanner", path, name,
+				)}
+			}
+			if result.byColumn == nil {
+				result.byColumn = map[string]FieldScannerFunc{}
+			}
+			result.byColumn[column] = fn
+		}
+	}
+	return result
+}
+
+// fieldScannerDst adapts FieldScannerFunc to the sql.Scanner interface.
+type fieldScannerDst struct {
+	fn  FieldScannerFunc
+	dst interface{}
+}
+
+func (s *fieldScannerDst) Scan(src interface{}) error {
+	return s.fn(s.dst, src)
+}
@@ -0,0 +1,82 @@
+package dbscan_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type point struct {
+	X string
+	Y string
+}
+
+func scanPoint(dst interface{}, src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unsupported point source: %T", src)
+	}
+	parts := strings.SplitN(s, " ", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid point: %q", s)
+	}
+	*dst.(*point) = point{X: parts[0], Y: parts[1]}
+	return nil
+}
+
+func TestScanAll_fieldScanner(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Foo  string
+		Geom point `db:"geom,scanner=point"`
+	}
+	cases := []struct {
+		name string
+		opts []dbscan.APIOption
+	}{
+		{name: "sequential"},
+		{name: "parallel decoding", opts: []dbscan.APIOption{dbscan.WithParallelDecoding(2, 1)}},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(append(tc.opts, dbscan.WithFieldScanner("point", scanPoint))...)
+			require.NoError(t, err)
+			rows := queryRows(t, `
+				SELECT * FROM (
+					VALUES ('foo val', '1 2'), ('foo val 2', '3 4')
+				) AS t (foo, geom)
+			`)
+			var got []dst
+
+			err = api.ScanAll(&got, rows)
+			require.NoError(t, err)
+
+			expected := []dst{
+				{Foo: "foo val", Geom: point{X: "1", Y: "2"}},
+				{Foo: "foo val 2", Geom: point{X: "3", Y: "4"}},
+			}
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestScanOne_fieldScanner_unknownScanner_returnsErr(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Geom point `db:"geom,scanner=unknown"`
+	}
+	rows := queryRows(t, `SELECT '1 2' AS geom`)
+	var got dst
+
+	err := testAPI.ScanOne(&got, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: field dst.Geom: unknown scanner 'unknown', see WithFieldScanner"
+	assert.EqualError(t, err, expectedErr)
+}
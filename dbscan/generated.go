@@ -0,0 +1,61 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScanRower is implemented by pointers to structs that scan rows into their fields without reflection,
+// e.g. with code generated by the scany-gen tool, see github.com/georgysavva/scany/v2/cmd/scany-gen.
+//
+// dbscan still maps and validates columns once per query as usual, but then it calls ScanRow for every row
+// instead of looking up fields via reflection. It does so only if the API maps columns by default rules,
+// that generated code follows: the "db" struct tag key, the "." column separator, SnakeCaseMapper
+// and unlimited struct depth, and if the struct has no fields that need reflection after scanning, i.e. fields
+// with transforms, encrypted, composed, row hash and field scanner fields. Parallel decoding doesn't use it.
+type ScanRower interface {
+	// ScanRow scans the current row into fields of the struct. columns are the row columns
+	// after normalization, scans has the same length and can be reused between rows.
+	// Columns without a corresponding field must be scanned into Discard,
+	// dbscan has already returned an error for them unless unknown columns are allowed.
+	ScanRow(rows Rows, columns []string, scans []interface{}) error
+}
+
+// Discard is a scan destination that discards the value of the column.
+var Discard interface{} = &noOpScanType{}
+
+var scanRowerType = reflect.TypeOf((*ScanRower)(nil)).Elem()
+
+// useScanRower reports whether rows can be scanned into the struct via the ScanRower interface.
+func (api *API) useScanRower(structType reflect.Type, plan *scanPlan) bool {
+	if !reflect.PtrTo(structType).Implements(scanRowerType) {
+		return false
+	}
+	defaultMapping := len(api.structTagKeys) == 1 && api.structTagKeys[0] == "db" && api.columnSeparator == "." &&
+		reflect.ValueOf(api.fieldMapperFn).Pointer() == reflect.ValueOf(SnakeCaseMapper).Pointer() &&
+		api.maxStructDepth == 0
+	return defaultMapping && plan.transforms == nil && plan.rowHash == nil && plan.composers == nil &&
+		plan.rawColumns == nil && plan.fieldScanners == nil
+}
+
+func (rs *RowScanner) scanRower(structValue reflect.Value) error {
+	if rs.scans == nil {
+		rs.scans = make([]interface{}, len(rs.columns))
+	}
+	scanRower := structValue.Addr().Interface().(ScanRower)
+	if err := scanRower.ScanRow(rs.rows, rs.columns, rs.scans); err != nil {
+		return rs.structScanErr(structValue, err)
+	}
+	return nil
+}
+
+// structScanErr wraps the error of scanning the row into struct fields,
+// it returns ScanError if rows report the column that caused the error.
+func (rs *RowScanner) structScanErr(structValue reflect.Value, err error) error {
+	if i, ok := scanErrorColumn(rs.rows, err); ok && i >= 0 && i < len(rs.columns) {
+		if fieldIndex, ok := rs.columnToFieldIndex[rs.columns[i]]; ok {
+			return newScanError(columnDatabaseTypes(rs.rows), structValue.Type(), rs.columns, i, fieldIndex, rs.rowIndex, err)
+		}
+	}
+	return fmt.Errorf("scany: scan row into struct fields: %w", err)
+}
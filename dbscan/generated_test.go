@@ -0,0 +1,74 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+type scanRowerModel struct {
+	Foo      string
+	Bar      string
+	scanRows int
+}
+
+func (m *scanRowerModel) ScanRow(rows dbscan.Rows, columns []string, scans []interface{}) error {
+	m.scanRows++
+	for i, column := range columns {
+		switch column {
+		case "foo":
+			scans[i] = &m.Foo
+		case "bar":
+			scans[i] = &m.Bar
+		default:
+			scans[i] = dbscan.Discard
+		}
+	}
+	return rows.Scan(scans...)
+}
+
+func TestScanRower_used(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithAllowUnknownColumns(true))
+	require.NoError(t, err)
+	rows := dbscantest.NewRows([]string{"foo", "bar", "baz"}, [][]interface{}{{"foo val", "bar val", "baz val"}})
+	var got scanRowerModel
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, scanRowerModel{Foo: "foo val", Bar: "bar val", scanRows: 1}, got)
+}
+
+func TestScanRower_notUsedWithCustomMapping(t *testing.T) {
+	t.Parallel()
+	snakeCase := func(name string) string { return dbscan.SnakeCaseMapper(name) }
+	cases := []struct {
+		name string
+		opt  dbscan.APIOption
+	}{
+		{name: "tag key", opt: dbscan.WithStructTagKey("json")},
+		{name: "column separator", opt: dbscan.WithColumnSeparator("__")},
+		{name: "field name mapper", opt: dbscan.WithFieldNameMapper(snakeCase)},
+		{name: "max struct depth", opt: dbscan.WithMaxStructDepth(1)},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.opt)
+			require.NoError(t, err)
+			rows := dbscantest.NewRows([]string{"foo", "bar"}, [][]interface{}{{"foo val", "bar val"}})
+			var got scanRowerModel
+
+			err = api.ScanOne(&got, rows)
+			require.NoError(t, err)
+
+			assert.Equal(t, scanRowerModel{Foo: "foo val", Bar: "bar val"}, got)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type genericWrapper[T any] struct {
+	Value T
+	Note  string
+}
+
+type GenericAudit[T any] struct {
+	CreatedBy T
+}
+
+type genericPage[T any] struct {
+	*GenericAudit[string]
+	genericWrapper[T] `db:"wrapper"`
+	Item              T
+	Counter           genericWrapper[int]
+}
+
+func TestScanAll_genericStruct(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `
+		SELECT 'admin' AS created_by, 'foo val' AS "wrapper.value.foo", 'note' AS "wrapper.note",
+			'bar val' AS "item.bar", 1 AS "counter.value", 'counter note' AS "counter.note"
+	`)
+	var got []*genericPage[testModel]
+
+	err := testAPI.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	expected := []*genericPage[testModel]{
+		{
+			GenericAudit:   &GenericAudit[string]{CreatedBy: "admin"},
+			genericWrapper: genericWrapper[testModel]{Value: testModel{Foo: "foo val"}, Note: "note"},
+			Item:           testModel{Bar: "bar val"},
+			Counter:        genericWrapper[int]{Value: 1, Note: "counter note"},
+		},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOne_differentInstantiationsOfGenericStruct(t *testing.T) {
+	t.Parallel()
+	var gotString genericWrapper[string]
+	var gotInt genericWrapper[int]
+
+	err := testAPI.ScanOne(&gotString, queryRows(t, `SELECT 'foo val' AS value, 'note' AS note`))
+	require.NoError(t, err)
+	// Every instantiation is a separate type, so the cached mapping of the first one must not be reused.
+	err = testAPI.ScanOne(&gotInt, queryRows(t, `SELECT 1 AS value`))
+	require.NoError(t, err)
+
+	assert.Equal(t, genericWrapper[string]{Value: "foo val", Note: "note"}, gotString)
+	assert.Equal(t, genericWrapper[int]{Value: 1}, gotInt)
+}
+
+func TestScanOne_unexportedStructEmbeddedByPtr_returnsErr(t *testing.T) {
+	t.Parallel()
+	type unexportedAudit struct {
+		CreatedBy string
+	}
+	dst := &struct {
+		*unexportedAudit
+		Foo string
+	}{}
+	rows := queryRows(t, `SELECT 'foo val' AS foo, 'admin' AS created_by`)
+
+	err := testAPI.ScanOne(dst, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: column: 'created_by': no corresponding field found, " +
+		"or it's unexported in struct { *dbscan_test.unexportedAudit; Foo string }"
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestScanOne_genericStruct_unknownColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT 1 AS value, 'baz val' AS baz`)
+	var got genericWrapper[int]
+
+	err := testAPI.ScanOne(&got, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: column: 'baz': no corresponding field found, " +
+		"or it's unexported in dbscan_test.genericWrapper[int]"
+	assert.EqualError(t, err, expectedErr)
+}
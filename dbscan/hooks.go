@@ -0,0 +1,140 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// BeforeScanner is implemented by destinations that need to run code before a row is scanned into them.
+type BeforeScanner interface {
+	BeforeScan(ctx context.Context) error
+}
+
+// AfterScanner is implemented by destinations that need to run code after a row is scanned into them,
+// e.g. to validate the data, compute derived fields or decrypt values.
+type AfterScanner interface {
+	AfterScan(ctx context.Context) error
+}
+
+var (
+	beforeScannerType = reflect.TypeOf((*BeforeScanner)(nil)).Elem()
+	afterScannerType  = reflect.TypeOf((*AfterScanner)(nil)).Elem()
+)
+
+// scanHooks contains index paths to the destination itself (nil path) and to the nested structs
+// that implement hook interfaces, ordered from the outermost to the innermost struct.
+type scanHooks struct {
+	before [][]int
+	after  [][]int
+}
+
+func (api *API) getScanHooks(dstType reflect.Type) *scanHooks {
+	hooksIface, ok := api.scanHooksCache.Load(dstType)
+	if ok {
+		return hooksIface.(*scanHooks)
+	}
+	hooks := api.buildScanHooks(dstType)
+	hooksIface, _ = api.scanHooksCache.LoadOrStore(dstType, hooks)
+	return hooksIface.(*scanHooks)
+}
+
+func (api *API) buildScanHooks(dstType reflect.Type) *scanHooks {
+	hooks := &scanHooks{}
+	type hookTraversal struct {
+		typ       reflect.Type
+		index     []int
+		ancestors map[reflect.Type]bool
+	}
+	queue := []hookTraversal{{typ: dstType, ancestors: map[reflect.Type]bool{}}}
+	for len(queue) > 0 {
+		traversal := queue[0]
+		queue = queue[1:]
+		ptrType := reflect.PtrTo(traversal.typ)
+		if ptrType.Implements(beforeScannerType) {
+			hooks.before = append(hooks.before, traversal.index)
+		}
+		if ptrType.Implements(afterScannerType) {
+			hooks.after = append(hooks.after, traversal.index)
+		}
+		if traversal.typ.Kind() != reflect.Struct || api.isScannableType(traversal.typ) {
+			continue
+		}
+		ancestors := withAncestor(traversal.ancestors, traversal.typ)
+		for i := 0; i < traversal.typ.NumField(); i++ {
+			field := traversal.typ.Field(i)
+			// Hooks can't be called via reflection on unexported fields, including embedded ones,
+			// methods of embedded structs are promoted to the outer struct though.
+			if field.PkgPath != "" {
+				continue
+			}
+			if tag, ok := api.lookupStructTag(field); ok {
+				if name, _ := parseStructTag(tag); name == "-" {
+					continue
+				}
+			}
+			childType := field.Type
+			if childType.Kind() == reflect.Ptr {
+				childType = childType.Elem()
+			}
+			// Recursive types would never end, call hooks only on the first occurrence.
+			if childType.Kind() != reflect.Struct || ancestors[childType] {
+				continue
+			}
+			index := make([]int, 0, len(traversal.index)+1)
+			index = append(index, traversal.index...)
+			index = append(index, i)
+			queue = append(queue, hookTraversal{typ: childType, index: index, ancestors: ancestors})
+		}
+	}
+	return hooks
+}
+
+// callBeforeScan calls hooks from the outermost to the innermost struct.
+// Nested structs by a nil pointer are skipped, since they aren't initialized yet.
+func (h *scanHooks) callBeforeScan(ctx context.Context, dstValue reflect.Value) error {
+	for _, index := range h.before {
+		v, ok := hookTarget(dstValue, index)
+		if !ok {
+			continue
+		}
+		if err := v.Addr().Interface().(BeforeScanner).BeforeScan(ctx); err != nil {
+			return fmt.Errorf("scany: BeforeScan hook on %v: %w", v.Type(), err)
+		}
+	}
+	return nil
+}
+
+// callAfterScan calls hooks from the innermost to the outermost struct,
+// so the outer struct can rely on the nested ones being ready.
+func (h *scanHooks) callAfterScan(ctx context.Context, dstValue reflect.Value) error {
+	for i := len(h.after) - 1; i >= 0; i-- {
+		v, ok := hookTarget(dstValue, h.after[i])
+		if !ok {
+			continue
+		}
+		if err := v.Addr().Interface().(AfterScanner).AfterScan(ctx); err != nil {
+			return fmt.Errorf("scany: AfterScan hook on %v: %w", v.Type(), err)
+		}
+	}
+	return nil
+}
+
+func hookTarget(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if v.Kind() == reflect.Ptr && len(index) > 0 {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
@@ -0,0 +1,71 @@
+package dbscan_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hookedNested struct {
+	Bar      string
+	BarUpper string `db:"-"`
+}
+
+func (n *hookedNested) AfterScan(ctx context.Context) error {
+	n.BarUpper = strings.ToUpper(n.Bar)
+	return nil
+}
+
+type hookedModel struct {
+	Foo    string
+	Nested *hookedNested `db:""`
+	Calls  []string      `db:"-"`
+}
+
+func (m *hookedModel) BeforeScan(ctx context.Context) error {
+	m.Calls = append(m.Calls, "before")
+	return nil
+}
+
+func (m *hookedModel) AfterScan(ctx context.Context) error {
+	if m.Foo == "" {
+		return errors.New("foo is required")
+	}
+	m.Calls = append(m.Calls, "after "+m.Nested.BarUpper)
+	return nil
+}
+
+func TestScanAll_hooksAreCalled(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+	var got []*hookedModel
+
+	err := testAPI.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	expected := make([]*hookedModel, 0, 3)
+	for _, suffix := range []string{"", " 2", " 3"} {
+		barUpper := "BAR VAL" + suffix
+		expected = append(expected, &hookedModel{
+			Foo:    "foo val" + suffix,
+			Nested: &hookedNested{Bar: "bar val" + suffix, BarUpper: barUpper},
+			Calls:  []string{"before", "after " + barUpper},
+		})
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOne_afterScanHookError_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT '' AS foo, 'bar val' AS bar`)
+	var got hookedModel
+
+	err := testAPI.ScanOne(&got, rows)
+
+	expectedErr := "scanning: doing scan: scany: AfterScan hook on dbscan_test.hookedModel: foo is required"
+	assert.EqualError(t, err, expectedErr)
+}
@@ -0,0 +1,72 @@
+package dbscan
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MappingError lists every column that doesn't match the destination,
+// e.g. columns without a corresponding struct field and duplicate columns.
+// dbscan validates the whole set of columns before scanning the first row,
+// so all problems with a query can be fixed in one iteration.
+type MappingError struct {
+	Errors []error
+}
+
+// Error implements the error interface.
+// If there is a single mismatched column, the message is the same as the message of the underlying error.
+func (e *MappingError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = strings.TrimPrefix(err.Error(), "scany: ")
+	}
+	return fmt.Sprintf("scany: %d columns don't match the destination: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Is reports whether any of the underlying errors matches the target.
+// It allows to use errors.Is with MappingError, e.g. errors.Is(err, ErrColumnMismatch).
+func (e *MappingError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateColumns checks columns for duplicates and, if columnToFieldIndex isn't nil,
+// for columns without a corresponding struct field.
+// It returns a MappingError that lists all mismatched columns or nil.
+func (api *API) validateColumns(columns []string, structType reflect.Type, columnToFieldIndex map[string][]int) error {
+	var errs []error
+	// Value is true for columns already reported as duplicates, so each duplicate is reported once.
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if reported, ok := seen[column]; ok {
+			if !reported {
+				errs = append(errs, newSentinelErrorf(ErrColumnMismatch, "scany: rows contain a duplicate column '%s'", column))
+				seen[column] = true
+			}
+			continue
+		}
+		seen[column] = false
+		if columnToFieldIndex == nil || api.allowUnknownColumns {
+			continue
+		}
+		if _, ok := api.getComposedFields(structType).sources[column]; ok {
+			continue
+		}
+		if _, ok := columnToFieldIndex[column]; !ok {
+			errs = append(errs, api.noCorrespondingFieldErr(column, structType, columnToFieldIndex))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MappingError{Errors: errs}
+}
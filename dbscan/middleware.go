@@ -0,0 +1,47 @@
+package dbscan
+
+// RowsMiddleware wraps rows before dbscan starts scanning them.
+// It allows implementing cross-cutting features like column masking, value redaction or row counting
+// in one place instead of every call site, see WithRowsMiddleware for details.
+type RowsMiddleware func(rows Rows) Rows
+
+// RowsUnwrapper is implemented by rows returned from a RowsMiddleware to expose the rows they wrap.
+// dbscan uses it to find optional interfaces like ColumnTypesRows and ScanErrorColumnRows
+// that the wrapped rows implement, so a middleware doesn't need to forward them.
+type RowsUnwrapper interface {
+	UnwrapRows() Rows
+}
+
+// WithRowsMiddleware adds middlewares that wrap rows passed to
+// ScanAll, ScanOne, ScanAllSets, ScanRow and NewRowScanner.
+// Middlewares are applied in the given order, so the first one is the outermost,
+// i.e. it sees calls first and observes results of all others.
+// The option can be used multiple times, middlewares from the next calls are wrapped by the previous ones.
+func WithRowsMiddleware(middlewares ...RowsMiddleware) APIOption {
+	return func(api *API) {
+		api.rowsMiddlewares = append(api.rowsMiddlewares, middlewares...)
+	}
+}
+
+func (api *API) wrapRows(rows Rows) Rows {
+	for i := len(api.rowsMiddlewares) - 1; i >= 0; i-- {
+		rows = api.rowsMiddlewares[i](rows)
+	}
+	return rows
+}
+
+// unwrapRows returns rows followed by all rows they wrap, from the outermost to the innermost.
+func unwrapRows(rows Rows) []Rows {
+	chain := []Rows{rows}
+	for {
+		unwrapper, ok := rows.(RowsUnwrapper)
+		if !ok {
+			return chain
+		}
+		rows = unwrapper.UnwrapRows()
+		if rows == nil {
+			return chain
+		}
+		chain = append(chain, rows)
+	}
+}
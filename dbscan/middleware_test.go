@@ -0,0 +1,82 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type countingRows struct {
+	dbscan.Rows
+	name  string
+	calls *[]string
+}
+
+func (r *countingRows) Next() bool {
+	*r.calls = append(*r.calls, r.name)
+	return r.Rows.Next()
+}
+
+func (r *countingRows) UnwrapRows() dbscan.Rows {
+	return r.Rows
+}
+
+type redactingRows struct {
+	dbscan.Rows
+}
+
+func (r *redactingRows) Scan(dest ...interface{}) error {
+	if err := r.Rows.Scan(dest...); err != nil {
+		return err
+	}
+	for _, d := range dest {
+		if s, ok := d.(*string); ok {
+			*s = "***"
+		}
+	}
+	return nil
+}
+
+func TestWithRowsMiddleware_appliedInOrder(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	counting := func(name string) dbscan.RowsMiddleware {
+		return func(rows dbscan.Rows) dbscan.Rows {
+			return &countingRows{Rows: rows, name: name, calls: &calls}
+		}
+	}
+	api, err := getAPI(dbscan.WithRowsMiddleware(counting("first"), counting("second")))
+	require.NoError(t, err)
+	rows := queryRows(t, singleRowsQuery)
+	var got testModel
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, got)
+	assert.Equal(t, []string{"first", "second", "first", "second"}, calls)
+}
+
+func TestWithRowsMiddleware_changesScannedValues(t *testing.T) {
+	t.Parallel()
+	redacting := func(rows dbscan.Rows) dbscan.Rows {
+		return &redactingRows{Rows: rows}
+	}
+	api, err := getAPI(dbscan.WithRowsMiddleware(redacting))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+	var got []*testModel
+
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	expected := []*testModel{
+		{Foo: "***", Bar: "***"},
+		{Foo: "***", Bar: "***"},
+		{Foo: "***", Bar: "***"},
+	}
+	assert.Equal(t, expected, got)
+}
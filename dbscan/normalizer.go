@@ -0,0 +1,62 @@
+package dbscan
+
+import (
+	"strings"
+)
+
+// ColumnNormalizer converts a column name returned by rows before it's matched with the destination,
+// see WithColumnNormalizers.
+type ColumnNormalizer func(column string) string
+
+// WithColumnNormalizers registers a chain of column normalizers that fix driver-specific column naming centrally.
+// Normalizers are applied in order to every column before it's matched with the destination,
+// including map destinations, where normalized columns are used as map keys.
+// The option can be used multiple times, normalizers are appended to the chain.
+// They are applied before table qualifiers are stripped and start hooks are called,
+// see WithTableQualifierStripping and WithStartHook.
+func WithColumnNormalizers(normalizers ...ColumnNormalizer) APIOption {
+	return func(api *API) {
+		api.columnNormalizers = append(api.columnNormalizers, normalizers...)
+	}
+}
+
+// TrimQuotesNormalizer removes identifier quotes: double quotes, backticks and square brackets,
+// from both ends of every part of a qualified column name, e.g. `"users"."email"` becomes "users.email".
+func TrimQuotesNormalizer(column string) string {
+	parts := strings.Split(column, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(part, "\"`[]")
+	}
+	return strings.Join(parts, ".")
+}
+
+// LowerCaseNormalizer converts a column name to lower case.
+func LowerCaseNormalizer(column string) string {
+	return strings.ToLower(column)
+}
+
+// StripPrefixNormalizer returns a normalizer that removes the given prefix from column names that have it.
+func StripPrefixNormalizer(prefix string) ColumnNormalizer {
+	return func(column string) string {
+		return strings.TrimPrefix(column, prefix)
+	}
+}
+
+// ReplaceNormalizer returns a normalizer that replaces all occurrences of from with to in column names,
+// e.g. ReplaceNormalizer(" ", "_") converts "first name" to "first_name".
+func ReplaceNormalizer(from, to string) ColumnNormalizer {
+	return func(column string) string {
+		return strings.ReplaceAll(column, from, to)
+	}
+}
+
+func (api *API) normalizeColumns(columns []string) []string {
+	normalized := make([]string, len(columns))
+	for i, column := range columns {
+		for _, normalizer := range api.columnNormalizers {
+			column = normalizer(column)
+		}
+		normalized[i] = column
+	}
+	return normalized
+}
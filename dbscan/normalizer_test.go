@@ -0,0 +1,66 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanOne_columnNormalizers(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		FirstName string
+		Email     string
+	}
+	api, err := getAPI(
+		dbscan.WithColumnNormalizers(dbscan.TrimQuotesNormalizer, dbscan.LowerCaseNormalizer),
+		dbscan.WithColumnNormalizers(dbscan.StripPrefixNormalizer("usr_"), dbscan.ReplaceNormalizer(" ", "_")),
+	)
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'Ann' AS "[USR_First Name]", 'ann@example.com' AS "USR_EMAIL"`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, dst{FirstName: "Ann", Email: "ann@example.com"}, got)
+}
+
+func TestScanOne_columnNormalizers_beforeTableQualifierStripping(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(
+		dbscan.WithColumnNormalizers(dbscan.TrimQuotesNormalizer),
+		dbscan.WithTableQualifierStripping(),
+	)
+	require.NoError(t, err)
+	rows := queryRows(t, "SELECT 'foo val' AS \"`t1`.`foo`\", 'bar val' AS \"`t1`.`bar`\"")
+	var got testModel
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, got)
+}
+
+func TestTrimQuotesNormalizer(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		column   string
+		expected string
+	}{
+		{column: `"users"."email"`, expected: "users.email"},
+		{column: "`email`", expected: "email"},
+		{column: "[dbo].[users].[email]", expected: "dbo.users.email"},
+		{column: "email", expected: "email"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.column, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, dbscan.TrimQuotesNormalizer(tc.column))
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package dbscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const defaultDecodeBatchSize = 256
+
+// WithParallelDecoding enables the pipeline mode for ScanAll when the destination is a slice of structs.
+// In this mode a single goroutine reads rows into batches of raw values,
+// and the given number of workers decode those batches into structs concurrently.
+// The order of rows is preserved in the destination slice.
+// It's beneficial when decoding is CPU-heavy, e.g. structs with many fields or fields that unmarshal JSON.
+//
+// Since rows are scanned into raw values first, field types must either implement Scan(src interface{}) error
+// or be directly assignable from the values that the underlying database library returns for *interface{}.
+// Numbers are converted into other number types like database/sql does it, values that don't fit
+// into the field or lose precision fail with the same error as in serial scanning.
+// If workers is less than 2, the pipeline mode is disabled.
+// If batchSize is less than 1, the default batch size of 256 rows is used.
+func WithParallelDecoding(workers, batchSize int) APIOption {
+	return func(api *API) {
+		api.decodeWorkers = workers
+		api.decodeBatchSize = batchSize
+		if api.decodeBatchSize < 1 {
+			api.decodeBatchSize = defaultDecodeBatchSize
+		}
+	}
+}
+
+type decodeBatch struct {
+	index      int
+	rowIndexes []int
+	values     [][]interface{}
+	elements   []reflect.Value
+	err        error
+}
+
+func (api *API) useParallelDecoding(sliceMeta *sliceDestinationMeta) bool {
+	return api.decodeWorkers > 1 &&
+		sliceMeta.elementBaseType.Kind() == reflect.Struct &&
+		!api.isScannableType(sliceMeta.elementBaseType)
+}
+
+func (api *API) scanAllParallel(ctx context.Context, sliceMeta *sliceDestinationMeta, rows Rows) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	var plan *decodePlan
+	resolvedColumns, mappingErr := api.resolveColumns(sliceMeta.elementBaseType, columns)
+	if mappingErr == nil {
+		plan, mappingErr = api.newDecodePlan(sliceMeta.elementBaseType, resolvedColumns)
+	}
+	if plan != nil {
+		plan.dbTypes = columnDatabaseTypes(rows)
+	}
+
+	jobs := make(chan *decodeBatch)
+	results := make(chan *decodeBatch)
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	var wg sync.WaitGroup
+	for i := 0; i < api.decodeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range jobs {
+				batch.err = api.decodeRawBatch(ctx, plan, batch, sliceMeta)
+				results <- batch
+			}
+		}()
+	}
+	collected := make(chan []*decodeBatch)
+	go func() {
+		var batches []*decodeBatch
+		for batch := range results {
+			batches = append(batches, batch)
+			if batch.err != nil {
+				stopOnce.Do(func() { close(stop) })
+			}
+		}
+		collected <- batches
+	}()
+
+	readErr := api.readRawBatches(ctx, rows, len(columns), mappingErr, jobs, stop)
+	close(jobs)
+	wg.Wait()
+	close(results)
+	batches := <-collected
+	// Too many rows error still leaves the rows within the limit in the destination.
+	if readErr != nil && !errors.Is(readErr, ErrTooManyRows) {
+		api.releaseBatches(sliceMeta, batches)
+		return readErr
+	}
+
+	sort.Slice(batches, func(i, j int) bool { return batches[i].index < batches[j].index })
+	for _, batch := range batches {
+		if batch.err != nil {
+			api.releaseBatches(sliceMeta, batches)
+			return fmt.Errorf("scanning: %w", batch.err)
+		}
+	}
+	s := sliceMeta.val
+	for _, batch := range batches {
+		for _, elemPtr := range batch.elements {
+			if sliceMeta.elementByPtr {
+				s.Set(reflect.Append(s, elemPtr))
+			} else {
+				s.Set(reflect.Append(s, elemPtr.Elem()))
+			}
+		}
+	}
+	return readErr
+}
+
+func (api *API) releaseBatches(sliceMeta *sliceDestinationMeta, batches []*decodeBatch) {
+	for _, batch := range batches {
+		for _, elemPtr := range batch.elements {
+			api.releaseElement(sliceMeta, elemPtr)
+		}
+	}
+}
+
+// decodePlan contains everything that workers need to decode raw values of a row into a struct.
+type decodePlan struct {
+	columns []string
+	dbTypes []string
+	// fieldIndexes contains nil field index for columns that are skipped.
+	fieldIndexes [][]int
+	transforms   []*fieldTransform
+	rowHash      *rowHashField
+	hooks        *scanHooks
+	composers    []*plannedComposer
+	// fieldScanners contains nil scanner for columns without the "scanner" tag option.
+	fieldScanners []FieldScannerFunc
+}
+
+// newDecodePlan reports mismatched columns just like RowScanner does.
+// The error is returned lazily by readRawBatches, only once there is at least one row.
+func (api *API) newDecodePlan(structType reflect.Type, columns []string) (*decodePlan, error) {
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	if err := api.getComposedFields(structType).err; err != nil {
+		return nil, err
+	}
+	if err := api.validateColumns(columns, structType, columnToFieldIndex); err != nil {
+		return nil, err
+	}
+	transforms, err := api.getFieldTransforms(structType, columns, columnToFieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	rowHash, err := api.getRowHashField(structType)
+	if err != nil {
+		return nil, err
+	}
+	composers, _, err := api.planComposers(structType, columns, columnToFieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	fieldScanners, err := api.getFieldScanners(structType, columns, columnToFieldIndex)
+	if err != nil {
+		return nil, err
+	}
+	api.logMapping(structType, columns, structDestination, columnToFieldIndex)
+	plan := &decodePlan{
+		columns:       columns,
+		fieldIndexes:  make([][]int, len(columns)),
+		transforms:    transforms,
+		rowHash:       rowHash,
+		hooks:         api.getScanHooks(structType),
+		composers:     composers,
+		fieldScanners: fieldScanners,
+	}
+	for i, column := range columns {
+		plan.fieldIndexes[i] = columnToFieldIndex[column]
+	}
+	return plan, nil
+}
+
+func (api *API) readRawBatches(
+	ctx context.Context, rows Rows, columnsNum int, mappingErr error, jobs chan<- *decodeBatch, stop <-chan struct{},
+) error {
+	batch := &decodeBatch{}
+	var rowsRead, rowIndex int
+	send := func() bool {
+		select {
+		case jobs <- batch:
+		case <-stop:
+			return false
+		}
+		batch = &decodeBatch{index: batch.index + 1}
+		return true
+	}
+	var limitErr error
+	for rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("scany: scanning aborted: %w", err)
+		}
+		if api.maxRows > 0 && rowsRead >= api.maxRows {
+			if api.maxRowsReturnErr {
+				limitErr = api.tooManyRowsErr()
+			}
+			break
+		}
+		if mappingErr != nil {
+			return fmt.Errorf("scanning: %w", mappingErr)
+		}
+		values := make([]interface{}, columnsNum)
+		scans := make([]interface{}, columnsNum)
+		for i := range values {
+			scans[i] = &values[i]
+		}
+		rowIndex++
+		if err := rows.Scan(scans...); err != nil {
+			err = fmt.Errorf("scanning: scany: scan row into raw values: %w", err)
+			if err := api.handleRowError(rowIndex-1, err); err != nil {
+				return err
+			}
+			continue
+		}
+		batch.values = append(batch.values, values)
+		batch.rowIndexes = append(batch.rowIndexes, rowIndex-1)
+		rowsRead++
+		if len(batch.values) >= api.decodeBatchSize && !send() {
+			return nil
+		}
+	}
+	if len(batch.values) > 0 {
+		send()
+	}
+	return limitErr
+}
+
+func (api *API) decodeRawBatch(
+	ctx context.Context, plan *decodePlan, batch *decodeBatch, sliceMeta *sliceDestinationMeta,
+) error {
+	batch.elements = make([]reflect.Value, 0, len(batch.values))
+	for i, values := range batch.values {
+		elemPtr, err := api.allocateElement(sliceMeta)
+		if err != nil {
+			return err
+		}
+		if err := api.decodeRow(ctx, plan, elemPtr.Elem(), values, batch.rowIndexes[i]); err != nil {
+			api.releaseElement(sliceMeta, elemPtr)
+			if err := api.handleRowError(batch.rowIndexes[i], err); err != nil {
+				return err
+			}
+			continue
+		}
+		batch.elements = append(batch.elements, elemPtr)
+	}
+	// Raw values are no longer needed, let them be garbage collected early.
+	batch.values = nil
+	return nil
+}
+
+func (api *API) decodeRow(
+	ctx context.Context, p *decodePlan, structValue reflect.Value, values []interface{}, rowIndex int,
+) error {
+	if err := p.hooks.callBeforeScan(ctx, structValue); err != nil {
+		return err
+	}
+	if columnIndex, err := decodeRawRow(structValue, values, p.fieldIndexes, p.fieldScanners); err != nil {
+		return newScanError(
+			p.dbTypes, structValue.Type(), p.columns, columnIndex, p.fieldIndexes[columnIndex], rowIndex, err,
+		)
+	}
+	if p.rowHash != nil {
+		p.rowHash.setRowHash(structValue, p.columns, p.fieldIndexes)
+	}
+	if err := api.applyTransforms(ctx, structValue, p.transforms); err != nil {
+		return err
+	}
+	if err := composeFields(structValue, p.composers, p.columns, p.fieldIndexes, values); err != nil {
+		return err
+	}
+	return p.hooks.callAfterScan(ctx, structValue)
+}
+
+// decodeRawRow returns the index of the column that failed to decode along with the error.
+func decodeRawRow(
+	structValue reflect.Value, values []interface{}, fieldIndexes [][]int, fieldScanners []FieldScannerFunc,
+) (int, error) {
+	for j, fieldIndex := range fieldIndexes {
+		if fieldIndex == nil {
+			continue
+		}
+		initializeNested(structValue, fieldIndex)
+		fieldVal := structValue.FieldByIndex(fieldIndex)
+		if fieldScanners != nil && fieldScanners[j] != nil {
+			if err := fieldScanners[j](fieldVal.Addr().Interface(), values[j]); err != nil {
+				return j, err
+			}
+			continue
+		}
+		if err := assignRawValue(fieldVal, values[j]); err != nil {
+			return j, err
+		}
+	}
+	return 0, nil
+}
+
+type valueScanner interface {
+	Scan(src interface{}) error
+}
+
+func assignRawValue(dst reflect.Value, src interface{}) error {
+	if scanner, ok := dst.Addr().Interface().(valueScanner); ok {
+		return scanner.Scan(src)
+	}
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		value := reflect.New(dst.Type().Elem())
+		if err := assignRawValue(value.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(value)
+		return nil
+	}
+	srcVal := reflect.ValueOf(src)
+	srcType := srcVal.Type()
+	switch {
+	case srcType.AssignableTo(dst.Type()):
+		dst.Set(srcVal)
+	case isNumberKind(srcType.Kind()) && isNumberKind(dst.Kind()):
+		return convertNumber(dst, srcVal)
+	case isSafeConversion(srcType, dst.Type()):
+		dst.Set(srcVal.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("cannot assign value of type %v into %v", srcType, dst.Type())
+	}
+	return nil
+}
+
+// isSafeConversion reports whether the value can be converted without losing data.
+// Numbers aren't safe, they are converted with range and exactness checks by convertNumber.
+func isSafeConversion(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+	// Allow conversions between string and []byte including named types, but not int -> string.
+	isBytes := func(t reflect.Type) bool {
+		return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+	}
+	fromText := from.Kind() == reflect.String || isBytes(from)
+	toText := to.Kind() == reflect.String || isBytes(to)
+	return fromText && toText
+}
+
+// convertNumber converts the number into the number destination the same way database/sql does it
+// for serial scanning: via the string form of the number, so values that overflow the destination
+// or lose their fractional part return the same error instead of being silently truncated.
+func convertNumber(dst, src reflect.Value) error {
+	var s string
+	switch src.Kind() { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(src.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		s = strconv.FormatUint(src.Uint(), 10)
+	default:
+		s = strconv.FormatFloat(src.Float(), 'g', -1, src.Type().Bits())
+	}
+	var err error
+	switch dst.Kind() { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if i, err = strconv.ParseInt(s, 10, dst.Type().Bits()); err == nil {
+			dst.SetInt(i)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var u uint64
+		if u, err = strconv.ParseUint(s, 10, dst.Type().Bits()); err == nil {
+			dst.SetUint(u)
+		}
+	default:
+		var f float64
+		if f, err = strconv.ParseFloat(s, dst.Type().Bits()); err == nil {
+			dst.SetFloat(f)
+		}
+	}
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		return fmt.Errorf("converting driver.Value type %v (%q) to a %v: %w", src.Type(), s, dst.Kind(), err)
+	}
+	return nil
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k { //nolint: exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
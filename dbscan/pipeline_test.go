@@ -0,0 +1,145 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanAll_withParallelDecoding(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(4, 2))
+	require.NoError(t, err)
+	type Destination struct {
+		Foo      string
+		Bar      *string
+		FooJSON  *CustomScannableType
+		Nested   *FooNested
+		Position int64
+	}
+	rows := queryRows(t, `
+		SELECT foo, bar, foo_json, 'nested val' AS "nested.foo_nested", position
+		FROM (
+			VALUES ('foo val', 'bar val', '{"key1": "foo val 1", "key2": "bar val 1"}', 1),
+				('foo val 2', NULL, NULL, 2),
+				('foo val 3', 'bar val 3', '{"key1": "foo val 3", "key2": "bar val 3"}', 3)
+		) AS t (foo, bar, foo_json, position)
+		ORDER BY position
+	`)
+	expected := []*Destination{
+		{
+			Foo:      "foo val",
+			Bar:      makeStrPtr("bar val"),
+			FooJSON:  &CustomScannableType{Key1: "foo val 1", Key2: "bar val 1"},
+			Nested:   &FooNested{FooNested: "nested val"},
+			Position: 1,
+		},
+		{
+			Foo:      "foo val 2",
+			Nested:   &FooNested{FooNested: "nested val"},
+			Position: 2,
+		},
+		{
+			Foo:      "foo val 3",
+			Bar:      makeStrPtr("bar val 3"),
+			FooJSON:  &CustomScannableType{Key1: "foo val 3", Key2: "bar val 3"},
+			Nested:   &FooNested{FooNested: "nested val"},
+			Position: 3,
+		},
+	}
+
+	var got []*Destination
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAll_withParallelDecodingPreservesOrder(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(8, 3))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT i AS position FROM generate_series(1, 1000) AS i ORDER BY i`)
+
+	var got []struct{ Position int64 }
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	require.Len(t, got, 1000)
+	for i, v := range got {
+		assert.Equal(t, int64(i+1), v.Position)
+	}
+}
+
+func TestScanAll_withParallelDecodingInvalidValue_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(2, 1))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+	expectedErr := "scanning: scany: column \"bar\" (text) -> field Bar (int) at row 0: " +
+		"cannot assign value of type string into int"
+
+	var got []struct {
+		Foo string
+		Bar int
+	}
+	err = api.ScanAll(&got, rows)
+
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestScanAll_withParallelDecodingUnknownColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(2, 1))
+	require.NoError(t, err)
+	rows := queryRows(t, multipleRowsQuery)
+	expectedErr := "scanning: scany: column: 'bar': no corresponding field found, or it's unexported in struct { Foo string }"
+
+	var got []struct{ Foo string }
+	err = api.ScanAll(&got, rows)
+
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestScanAll_withParallelDecodingLossyNumber_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(2, 1))
+	require.NoError(t, err)
+	cases := []struct {
+		name        string
+		query       string
+		dst         interface{}
+		expectedErr string
+	}{
+		{
+			name:        "overflow",
+			query:       `SELECT 300 AS position`,
+			dst:         &[]struct{ Position int8 }{},
+			expectedErr: `converting driver.Value type int64 ("300") to a int8: value out of range`,
+		},
+		{
+			name:        "fraction",
+			query:       `SELECT 1.9::FLOAT8 AS position`,
+			dst:         &[]struct{ Position int }{},
+			expectedErr: `converting driver.Value type float64 ("1.9") to a int: invalid syntax`,
+		},
+		{
+			name:        "negative into unsigned",
+			query:       `SELECT -1 AS position`,
+			dst:         &[]struct{ Position uint }{},
+			expectedErr: `converting driver.Value type int64 ("-1") to a uint: invalid syntax`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := queryRows(t, tc.query)
+			err := api.ScanAll(tc.dst, rows)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
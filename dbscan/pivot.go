@@ -0,0 +1,54 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScanPivot is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanPivot for details.
+func ScanPivot(rows Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return DefaultAPI.ScanPivot(rows, keyColumn)
+}
+
+// ScanPivot iterates all rows to the end and builds a lookup table from them.
+// The outer map is keyed by values of keyColumn, and the inner maps are keyed by the remaining columns.
+// Key values are converted to strings, []byte values as is and other values via fmt.Sprint.
+// It returns an error if the key column is missing, if a key is NULL or if two rows have the same key.
+// After iterating all rows, it closes them.
+func (api *API) ScanPivot(rows Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return api.ScanPivotContext(context.Background(), rows, keyColumn)
+}
+
+// ScanPivotContext is the same as ScanPivot, but it checks the context between rows
+// and aborts scanning as soon as the context is done, returning the context error.
+func (api *API) ScanPivotContext(
+	ctx context.Context, rows Rows, keyColumn string,
+) (map[string]map[string]interface{}, error) {
+	var records []map[string]interface{}
+	if err := api.ScanAllContext(ctx, &records, rows); err != nil {
+		return nil, err
+	}
+	result := make(map[string]map[string]interface{}, len(records))
+	for i, record := range records {
+		keyValue, ok := record[keyColumn]
+		if !ok {
+			return nil, newSentinelErrorf(ErrColumnMismatch, "scany: pivot key column '%s' not found", keyColumn)
+		}
+		if keyValue == nil {
+			return nil, fmt.Errorf("scany: pivot key column '%s' is NULL at row %d", keyColumn, i)
+		}
+		var key string
+		if b, ok := keyValue.([]byte); ok {
+			key = string(b)
+		} else {
+			key = fmt.Sprint(keyValue)
+		}
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("scany: duplicate pivot key '%s' at row %d", key, i)
+		}
+		delete(record, keyColumn)
+		result[key] = record
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package dbscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanPivot(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+
+	got, err := testAPI.ScanPivot(rows, "foo")
+	require.NoError(t, err)
+
+	expected := map[string]map[string]interface{}{
+		"foo val":   {"bar": "bar val"},
+		"foo val 2": {"bar": "bar val 2"},
+		"foo val 3": {"bar": "bar val 3"},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanPivot_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		query       string
+		keyColumn   string
+		expectedErr string
+	}{
+		{
+			name:        "key column not found",
+			query:       multipleRowsQuery,
+			keyColumn:   "baz",
+			expectedErr: "scany: pivot key column 'baz' not found",
+		},
+		{
+			name:        "duplicate key",
+			query:       `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val', 'bar val 2')) AS t (foo, bar)`,
+			keyColumn:   "foo",
+			expectedErr: "scany: duplicate pivot key 'foo val' at row 1",
+		},
+		{
+			name:        "NULL key",
+			query:       `SELECT NULL AS foo, 'bar val' AS bar`,
+			keyColumn:   "foo",
+			expectedErr: "scany: pivot key column 'foo' is NULL at row 0",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := queryRows(t, tc.query)
+			_, err := testAPI.ScanPivot(rows, tc.keyColumn)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestScanPivot_keyColumnNotFound_isColumnMismatch(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, multipleRowsQuery)
+
+	_, err := testAPI.ScanPivot(rows, "baz")
+
+	assert.True(t, errors.Is(err, dbscan.ErrColumnMismatch))
+}
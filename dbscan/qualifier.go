@@ -0,0 +1,57 @@
+package dbscan
+
+import (
+	"reflect"
+	"strings"
+)
+
+// WithTableQualifierStripping makes dbscan strip table qualifiers from column names before matching them,
+// e.g. "users.email" or "t1.email" becomes "email".
+// It's useful for drivers that return fully qualified column names, like ClickHouse or some ODBC sources.
+//
+// For struct destinations, a qualified name that is mapped to a field as-is, e.g. a field of a nested struct,
+// is kept. Otherwise, qualifiers are stripped one by one from the left, until the name matches a field.
+// If no name matches, the column is kept as-is, so the mismatch error reports the original name.
+// For other destinations, all qualifiers are stripped.
+// Columns from different tables with the same name become duplicates, so they have to be aliased in the query.
+func WithTableQualifierStripping() APIOption {
+	return func(api *API) {
+		api.stripTableQualifiers = true
+	}
+}
+
+func (api *API) stripQualifiers(dstType reflect.Type, columns []string) []string {
+	isStruct := dstType.Kind() == reflect.Struct && !api.isScannableType(dstType)
+	stripped := make([]string, len(columns))
+	for i, column := range columns {
+		stripped[i] = column
+		if !strings.Contains(column, ".") {
+			continue
+		}
+		if !isStruct {
+			stripped[i] = column[strings.LastIndex(column, ".")+1:]
+			continue
+		}
+		for name := column; ; {
+			if api.isKnownColumn(dstType, name) {
+				stripped[i] = name
+				break
+			}
+			dot := strings.Index(name, ".")
+			if dot < 0 {
+				break
+			}
+			name = name[dot+1:]
+		}
+	}
+	return stripped
+}
+
+// isKnownColumn reports whether the column is mapped to a field of the struct or used by a composer.
+func (api *API) isKnownColumn(structType reflect.Type, column string) bool {
+	if _, ok := api.getColumnToFieldIndexMap(structType)[column]; ok {
+		return true
+	}
+	_, ok := api.getComposedFields(structType).sources[column]
+	return ok
+}
@@ -0,0 +1,82 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanOne_tableQualifierStripping(t *testing.T) {
+	t.Parallel()
+	type Post struct {
+		ID   string
+		Text string
+	}
+	type dst struct {
+		ID    string
+		Email string
+		Post  Post
+	}
+	api, err := getAPI(dbscan.WithTableQualifierStripping())
+	require.NoError(t, err)
+	rows := queryRows(t, `
+		SELECT 'id val' AS "public.users.id", 'email val' AS "u.email",
+			'post id val' AS "post.id", 'post text val' AS "p.post.text"
+	`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	expected := dst{ID: "id val", Email: "email val", Post: Post{ID: "post id val", Text: "post text val"}}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOne_tableQualifierStripping_map(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithTableQualifierStripping())
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS "t1.foo", 'bar val' AS "public.t2.bar"`)
+	var got map[string]interface{}
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"foo": "foo val", "bar": "bar val"}, got)
+}
+
+func TestScanOne_tableQualifierStripping_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		query       string
+		expectedErr string
+	}{
+		{
+			name:  "no corresponding field",
+			query: `SELECT 'foo val' AS "t1.foo", 'baz val' AS "t1.baz"`,
+			expectedErr: "scanning: doing scan: starting: scany: column: 't1.baz': " +
+				"no corresponding field found, or it's unexported in dbscan_test.testModel",
+		},
+		{
+			name:        "duplicate column",
+			query:       `SELECT 'foo val' AS "t1.foo", 'foo val' AS "t2.foo", 'bar val' AS bar`,
+			expectedErr: "scanning: doing scan: starting: scany: rows contain a duplicate column 'foo'",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(dbscan.WithTableQualifierStripping())
+			require.NoError(t, err)
+			rows := queryRows(t, tc.query)
+			var got testModel
+			err = api.ScanOne(&got, rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type treeNode struct {
+	ID     string
+	Parent *treeNode
+}
+
+func TestScanOne_selfReferentialStruct_cutsCycle(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT 'foo val' AS id`)
+	var got treeNode
+
+	err := testAPI.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, treeNode{ID: "foo val"}, got)
+}
+
+func TestScanOne_selfReferentialStruct_nestedColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, `SELECT 'foo val' AS id, 'bar val' AS "parent.id"`)
+	var got treeNode
+
+	err := testAPI.ScanOne(&got, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: column: 'parent.id': no corresponding field found, " +
+		"or it's unexported in dbscan_test.treeNode; struct field 'parent' isn't traversed deeper, " +
+		"see WithMaxStructDepth"
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestScanOne_withMaxStructDepth_expandsRecursiveStruct(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithMaxStructDepth(2))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS id, 'bar val' AS "parent.id", 'baz val' AS "parent.parent.id"`)
+	var got treeNode
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	expected := treeNode{ID: "foo val", Parent: &treeNode{ID: "bar val", Parent: &treeNode{ID: "baz val"}}}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOne_withMaxStructDepth_deeperColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithMaxStructDepth(1))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS id, 'baz val' AS "parent.parent.id"`)
+	var got treeNode
+
+	err = api.ScanOne(&got, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: column: 'parent.parent.id': no corresponding field found, " +
+		"or it's unexported in dbscan_test.treeNode; struct field 'parent.parent' isn't traversed deeper, " +
+		"see WithMaxStructDepth"
+	assert.EqualError(t, err, expectedErr)
+}
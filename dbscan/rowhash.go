@@ -0,0 +1,201 @@
+package dbscan
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+const rowHashTagOption = "rowhash"
+
+type rowHashField struct {
+	fieldIndex []int
+	hex        bool
+}
+
+// getRowHashField returns nil if the struct doesn't have a field marked with the "rowhash" tag option,
+// e.g. `db:",rowhash"`. Such field isn't mapped to any column, dbscan sets it to the hash of the scanned row.
+func (api *API) getRowHashField(structType reflect.Type) (*rowHashField, error) {
+	type cached struct {
+		field *rowHashField
+		err   error
+	}
+	cachedIface, ok := api.rowHashFieldCache.Load(structType)
+	if !ok {
+		field, err := api.buildRowHashField(structType)
+		cachedIface, _ = api.rowHashFieldCache.LoadOrStore(structType, &cached{field: field, err: err})
+	}
+	c := cachedIface.(*cached)
+	return c.field, c.err
+}
+
+func (api *API) buildRowHashField(structType reflect.Type) (*rowHashField, error) {
+	indexes := api.fieldsWithTagOption(structType, rowHashTagOption)
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+	if len(indexes) > 1 {
+		return nil, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: struct %v must have at most one rowhash field, got: %d", structType, len(indexes),
+		)
+	}
+	path, fieldType := structFieldPath(structType, indexes[0])
+	switch fieldType.Kind() { //nolint: exhaustive
+	case reflect.Uint64:
+		return &rowHashField{fieldIndex: indexes[0]}, nil
+	case reflect.String:
+		return &rowHashField{fieldIndex: indexes[0], hex: true}, nil
+	default:
+		return nil, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: rowhash field %s must be uint64 or string, got: %v", path, fieldType,
+		)
+	}
+}
+
+// setRowHash computes a stable hash of the values scanned into fields mapped to the columns.
+// Columns that are skipped don't affect the hash. The hash is computed before transforms are applied,
+// so it's the same for sequential and parallel decoding.
+func (f *rowHashField) setRowHash(structValue reflect.Value, columns []string, fieldIndexes [][]int) {
+	h := fnv.New64a()
+	for i, column := range columns {
+		if fieldIndexes[i] == nil {
+			continue
+		}
+		writeHashString(h, column)
+		hashValue(h, structValue.FieldByIndex(fieldIndexes[i]))
+	}
+	initializeNested(structValue, f.fieldIndex)
+	field := structValue.FieldByIndex(f.fieldIndex)
+	sum := h.Sum64()
+	if f.hex {
+		field.SetString(strconv.FormatUint(sum, 16))
+	} else {
+		field.SetUint(sum)
+	}
+}
+
+var (
+	valuerType            = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	hashKindSeparator     = []byte{0}
+	hashNilValue          = []byte{1}
+	hashNonNilValuePrefix = []byte{2}
+)
+
+func writeHashString(h hash.Hash64, s string) {
+	var lenBuf [8]byte
+	binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(s)))
+	_, _ = h.Write(lenBuf[:])
+	_, _ = h.Write([]byte(s))
+}
+
+func writeHashUint(h hash.Hash64, u uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], u)
+	_, _ = h.Write(buf[:])
+}
+
+// hashValue writes a canonical representation of the value,
+// which doesn't depend on memory addresses or map iteration order.
+func hashValue(h hash.Hash64, v reflect.Value) {
+	_, _ = h.Write(hashKindSeparator)
+	writeHashUint(h, uint64(v.Kind()))
+	if v.Type().Implements(valuerType) && !(v.Kind() == reflect.Ptr && v.IsNil()) {
+		if value, err := v.Interface().(driver.Valuer).Value(); err == nil {
+			if value == nil {
+				_, _ = h.Write(hashNilValue)
+				return
+			}
+			hashValue(h, reflect.ValueOf(value))
+			return
+		}
+	}
+	if v.Type().Implements(binaryMarshalerType) && v.Kind() != reflect.Ptr {
+		if data, err := v.Interface().(encoding.BinaryMarshaler).MarshalBinary(); err == nil {
+			writeHashString(h, string(data))
+			return
+		}
+	}
+	switch v.Kind() { //nolint: exhaustive
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			_, _ = h.Write(hashNilValue)
+			return
+		}
+		_, _ = h.Write(hashNonNilValuePrefix)
+		hashValue(h, v.Elem())
+	case reflect.String:
+		writeHashString(h, v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			writeHashUint(h, 1)
+		} else {
+			writeHashUint(h, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeHashUint(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeHashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeHashUint(h, math.Float64bits(v.Float()))
+	case reflect.Slice:
+		if v.IsNil() {
+			_, _ = h.Write(hashNilValue)
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeHashString(h, string(v.Bytes()))
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		writeHashUint(h, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			_, _ = h.Write(hashNilValue)
+			return
+		}
+		keys := v.MapKeys()
+		keyStrings := make([]string, len(keys))
+		for i, key := range keys {
+			keyStrings[i] = fmt.Sprint(key.Interface())
+		}
+		sort.Sort(keysByString{keys: keys, strings: keyStrings})
+		writeHashUint(h, uint64(len(keys)))
+		for i, key := range keys {
+			writeHashString(h, keyStrings[i])
+			hashValue(h, v.MapIndex(key))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			hashValue(h, v.Field(i))
+		}
+	default:
+		writeHashString(h, fmt.Sprint(v.Interface()))
+	}
+}
+
+type keysByString struct {
+	keys    []reflect.Value
+	strings []string
+}
+
+func (k keysByString) Len() int           { return len(k.keys) }
+func (k keysByString) Less(i, j int) bool { return k.strings[i] < k.strings[j] }
+func (k keysByString) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.strings[i], k.strings[j] = k.strings[j], k.strings[i]
+}
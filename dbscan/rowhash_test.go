@@ -0,0 +1,65 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type rowHashModel struct {
+	Foo  string
+	Bar  *string
+	Hash uint64 `db:",rowhash"`
+}
+
+const rowHashQuery = `
+	SELECT *
+	FROM (
+		VALUES ('foo val', 'bar val'), ('foo val', 'bar val'), ('foo val', NULL)
+	) AS t (foo, bar)
+`
+
+func TestScanAll_rowHash(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, rowHashQuery)
+	var got []*rowHashModel
+
+	err := testAPI.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	require.Len(t, got, 3)
+	assert.NotZero(t, got[0].Hash)
+	assert.Equal(t, got[0].Hash, got[1].Hash)
+	assert.NotEqual(t, got[0].Hash, got[2].Hash)
+}
+
+func TestScanAll_rowHash_sameForParallelDecoding(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithParallelDecoding(2, 1))
+	require.NoError(t, err)
+	var sequential, parallel []*rowHashModel
+
+	err = testAPI.ScanAll(&sequential, queryRows(t, rowHashQuery))
+	require.NoError(t, err)
+	err = api.ScanAll(&parallel, queryRows(t, rowHashQuery))
+	require.NoError(t, err)
+
+	assert.Equal(t, sequential, parallel)
+}
+
+func TestScanOne_rowHash_invalidFieldType_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := queryRows(t, singleRowsQuery)
+	dst := &struct {
+		testModel
+		Hash int `db:",rowhash"`
+	}{}
+
+	err := testAPI.ScanOne(dst, rows)
+
+	expectedErr := "scanning: doing scan: starting: scany: rowhash field Hash must be uint64 or string, got: int"
+	assert.EqualError(t, err, expectedErr)
+}
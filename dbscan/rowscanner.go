@@ -1,6 +1,7 @@
 package dbscan
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
@@ -35,6 +36,15 @@ type RowScanner struct {
 	scanFn             func(dstVal reflect.Value) error
 	start              startScannerFunc
 	scans              []any
+	rowIndex           int
+	hooks              *scanHooks
+	transforms         []*fieldTransform
+	rowHash            *rowHashField
+	fieldIndexes       [][]int
+	composers          []*plannedComposer
+	rawColumns         []bool
+	rawValues          []interface{}
+	fieldScanners      []*fieldScannerDst
 }
 
 // NewRowScanner is a package-level helper function that uses the DefaultAPI object.
@@ -44,7 +54,12 @@ func NewRowScanner(rows Rows) *RowScanner {
 }
 
 // NewRowScanner returns a new instance of the RowScanner.
+// Rows are wrapped by middlewares, see WithRowsMiddleware.
 func (api *API) NewRowScanner(rows Rows) *RowScanner {
+	return api.newRowScanner(api.wrapRows(rows))
+}
+
+func (api *API) newRowScanner(rows Rows) *RowScanner {
 	return &RowScanner{
 		api:   api,
 		rows:  rows,
@@ -56,71 +71,259 @@ func (api *API) NewRowScanner(rows Rows) *RowScanner {
 // On the first call it caches expensive reflection work and uses it the future calls.
 // See RowScanner for details.
 func (rs *RowScanner) Scan(dst interface{}) error {
+	return rs.ScanContext(context.Background(), dst)
+}
+
+// ScanContext is the same as Scan, but it passes the context to BeforeScan and AfterScan hooks
+// of the destination, see BeforeScanner and AfterScanner for details.
+func (rs *RowScanner) ScanContext(ctx context.Context, dst interface{}) error {
 	dstVal, err := parseDestination(dst)
 	if err != nil {
 		return fmt.Errorf("parsing destination: %w", err)
 	}
-	if err := rs.doScan(dstVal); err != nil {
+	if err := rs.doScan(ctx, dstVal); err != nil {
 		return fmt.Errorf("doing scan: %w", err)
 	}
 	return nil
 }
 
-func (rs *RowScanner) doScan(dstValue reflect.Value) error {
+func (rs *RowScanner) doScan(ctx context.Context, dstValue reflect.Value) error {
 	if !rs.started {
 		if err := rs.start(rs, dstValue); err != nil {
 			return fmt.Errorf("starting: %w", err)
 		}
+		rs.hooks = rs.api.getScanHooks(dstValue.Type())
 		rs.started = true
 	}
-	if err := rs.scanFn(dstValue); err != nil {
+	if err := rs.hooks.callBeforeScan(ctx, dstValue); err != nil {
+		rs.rowIndex++
+		return err
+	}
+	err := rs.scanFn(dstValue)
+	if err == nil {
+		err = rs.postProcess(ctx, dstValue)
+	}
+	rs.rowIndex++
+	if err != nil {
 		return fmt.Errorf("scanFn: %w", err)
 	}
-	return nil
+	return rs.hooks.callAfterScan(ctx, dstValue)
 }
 
 func startScanner(rs *RowScanner, dstValue reflect.Value) error {
-	var err error
-	rs.columns, err = rs.rows.Columns()
+	columns, err := rs.rows.Columns()
 	if err != nil {
 		return fmt.Errorf("scany: get rows columns: %w", err)
 	}
-	if err := rs.ensureDistinctColumns(); err != nil {
-		return fmt.Errorf("duplicate columns: %w", err)
+	plan, err := rs.api.getScanPlan(dstValue.Type(), columns)
+	if err != nil {
+		return err
 	}
-	dstKind := dstValue.Kind()
-	dstType := dstValue.Type()
-	isScannable := rs.api.isScannableType(dstType)
-	if isScannable && len(rs.columns) == 1 {
-		rs.scanFn = rs.scanPrimitive
-		return nil
+	rs.api.logMapping(dstValue.Type(), plan.columns, plan.kind, plan.columnToFieldIndex)
+	rs.applyScanPlan(plan)
+	return nil
+}
+
+// maxCachedScanPlans limits the number of distinct sets of columns that the API caches plans for
+// per destination type, so ad-hoc queries with generated aliases don't grow the cache forever.
+const maxCachedScanPlans = 8
+
+// cachedScanPlan is the plan compiled for the columns that the rows returned, before they are resolved.
+type cachedScanPlan struct {
+	columns []string
+	plan    *scanPlan
+}
+
+// getScanPlan returns the plan from the cache or compiles it for the destination type and columns.
+func (api *API) getScanPlan(dstType reflect.Type, columns []string) (*scanPlan, error) {
+	var cached []cachedScanPlan
+	if cachedIface, ok := api.scanPlansCache.Load(dstType); ok {
+		cached = cachedIface.([]cachedScanPlan)
+		for _, c := range cached {
+			if equalColumns(c.columns, columns) {
+				return c.plan, nil
+			}
+		}
+	}
+	// Errors aren't cached, the next rows with the same columns get the error again.
+	plan, err := api.compileScanPlan(dstType, columns)
+	if err != nil {
+		return nil, err
+	}
+	// The cached list is never modified in place, scanners in other goroutines might be reading it.
+	// Concurrent misses might overwrite each other's plans, that only costs recompiling them later.
+	if len(cached) >= maxCachedScanPlans {
+		cached = cached[1:]
+	}
+	updated := make([]cachedScanPlan, len(cached), len(cached)+1)
+	copy(updated, cached)
+	updated = append(updated, cachedScanPlan{columns: append([]string(nil), columns...), plan: plan})
+	api.scanPlansCache.Store(dstType, updated)
+	return plan, nil
+}
+
+func equalColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
 	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
 
-	if dstKind == reflect.Struct {
-		rs.columnToFieldIndex = rs.api.getColumnToFieldIndexMap(dstType)
+// scanPlan contains everything RowScanner needs to scan rows with the given columns into the destination type.
+// It's immutable once compiled, so it can be shared between scanners, see ConcurrentScanner.
+type scanPlan struct {
+	columns            []string
+	kind               destinationKind
+	columnToFieldIndex map[string][]int
+	mapElementType     reflect.Type
+	transforms         []*fieldTransform
+	rowHash            *rowHashField
+	fieldIndexes       [][]int
+	composers          []*plannedComposer
+	// rawColumns marks columns that are scanned into raw values for composers.
+	rawColumns []bool
+	// fieldScanners contains nil scanner for columns without the "scanner" tag option.
+	fieldScanners []FieldScannerFunc
+	// scanRower is true if rows are scanned into the struct via the ScanRower interface.
+	scanRower bool
+}
+
+func (api *API) compileScanPlan(dstType reflect.Type, columns []string) (*scanPlan, error) {
+	columns, err := api.resolveColumns(dstType, columns)
+	if err != nil {
+		return nil, err
+	}
+	kind, columnToFieldIndex, err := api.resolveDestination(dstType, columns)
+	if err != nil {
+		return nil, err
+	}
+	plan := &scanPlan{columns: columns, kind: kind}
+	switch kind {
+	case structDestination:
+		plan.columnToFieldIndex = columnToFieldIndex
+		plan.transforms, err = api.getFieldTransforms(dstType, columns, columnToFieldIndex)
+		if err != nil {
+			return nil, err
+		}
+		plan.rowHash, err = api.getRowHashField(dstType)
+		if err != nil {
+			return nil, err
+		}
+		plan.composers, plan.rawColumns, err = api.planComposers(dstType, columns, columnToFieldIndex)
+		if err != nil {
+			return nil, err
+		}
+		plan.fieldScanners, err = api.getFieldScanners(dstType, columns, columnToFieldIndex)
+		if err != nil {
+			return nil, err
+		}
+		plan.fieldIndexes = make([][]int, len(columns))
+		for i, column := range columns {
+			plan.fieldIndexes[i] = columnToFieldIndex[column]
+		}
+		plan.scanRower = api.useScanRower(dstType, plan)
+	case mapDestination:
+		plan.mapElementType = dstType.Elem()
+	}
+	return plan, nil
+}
+
+func (rs *RowScanner) applyScanPlan(plan *scanPlan) {
+	rs.columns = plan.columns
+	switch plan.kind {
+	case structDestination:
+		rs.columnToFieldIndex = plan.columnToFieldIndex
+		rs.transforms = plan.transforms
+		rs.rowHash = plan.rowHash
+		rs.fieldIndexes = plan.fieldIndexes
+		rs.composers = plan.composers
+		rs.rawColumns = plan.rawColumns
+		if plan.fieldScanners != nil {
+			rs.fieldScanners = make([]*fieldScannerDst, len(plan.fieldScanners))
+			for i, fn := range plan.fieldScanners {
+				if fn != nil {
+					rs.fieldScanners[i] = &fieldScannerDst{fn: fn}
+				}
+			}
+		}
 		rs.scanFn = rs.scanStruct
-		return nil
+		if plan.scanRower {
+			rs.scanFn = rs.scanRower
+		}
+	case mapDestination:
+		rs.mapElementType = plan.mapElementType
+		rs.scanFn = rs.scanMap
+	default:
+		rs.scanFn = rs.scanPrimitive
+	}
+}
+
+// postProcess runs steps that need the whole row to be scanned into the struct.
+func (rs *RowScanner) postProcess(ctx context.Context, structValue reflect.Value) error {
+	if rs.rowHash != nil {
+		rs.rowHash.setRowHash(structValue, rs.columns, rs.fieldIndexes)
+	}
+	if err := rs.api.applyTransforms(ctx, structValue, rs.transforms); err != nil {
+		return err
+	}
+	return composeFields(structValue, rs.composers, rs.columns, rs.fieldIndexes, rs.rawValues)
+}
+
+type destinationKind int
+
+const (
+	primitiveDestination destinationKind = iota
+	structDestination
+	mapDestination
+)
+
+// resolveDestination decides how rows with the given columns are scanned into the destination type
+// and makes sure that the columns match it.
+// For struct destinations it also returns the column to field index map.
+func (api *API) resolveDestination(dstType reflect.Type, columns []string) (destinationKind, map[string][]int, error) {
+	dstKind := dstType.Kind()
+	isScannable := api.isScannableType(dstType)
+	isStruct := dstKind == reflect.Struct && !(isScannable && len(columns) == 1)
+	var columnToFieldIndex map[string][]int
+	if isStruct {
+		columnToFieldIndex = api.getColumnToFieldIndexMap(dstType)
+		if err := api.getComposedFields(dstType).err; err != nil {
+			return 0, nil, err
+		}
+	}
+	// Validate all columns up front, so the error lists every mismatched column at once.
+	if err := api.validateColumns(columns, dstType, columnToFieldIndex); err != nil {
+		return 0, nil, err
+	}
+	if isScannable && len(columns) == 1 {
+		return primitiveDestination, nil, nil
+	}
+
+	if isStruct {
+		return structDestination, columnToFieldIndex, nil
 	}
 
 	if dstKind == reflect.Map {
 		if dstType.Key().Kind() != reflect.String {
-			return fmt.Errorf(
+			return 0, nil, newSentinelErrorf(ErrUnsupportedDestination,
 				"scany: invalid type %v: map must have string key, got: %v",
 				dstType, dstType.Key(),
 			)
 		}
-		rs.mapElementType = dstType.Elem()
-		rs.scanFn = rs.scanMap
-		return nil
+		return mapDestination, nil, nil
 	}
 
-	if len(rs.columns) == 1 {
-		rs.scanFn = rs.scanPrimitive
-		return nil
+	if len(columns) == 1 {
+		return primitiveDestination, nil, nil
 	}
-	return fmt.Errorf(
+	return 0, nil, newSentinelErrorf(ErrColumnMismatch,
 		"scany: to scan into a primitive type, columns number must be exactly 1, got: %d",
-		len(rs.columns),
+		len(columns),
 	)
 }
 
@@ -134,7 +337,14 @@ func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 	if rs.scans == nil {
 		rs.scans = make([]interface{}, len(rs.columns))
 	}
+	if rs.rawColumns != nil && rs.rawValues == nil {
+		rs.rawValues = make([]interface{}, len(rs.columns))
+	}
 	for i, column := range rs.columns {
+		if rs.rawColumns != nil && rs.rawColumns[i] {
+			rs.scans[i] = &rs.rawValues[i]
+			continue
+		}
 		fieldIndex, ok := rs.columnToFieldIndex[column]
 		if !ok {
 			if rs.api.allowUnknownColumns {
@@ -142,10 +352,7 @@ func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 				rs.scans[i] = &tmp
 				continue
 			}
-			return fmt.Errorf(
-				"scany: column: '%s': no corresponding field found, or it's unexported in %v",
-				column, structValue.Type(),
-			)
+			return rs.api.noCorrespondingFieldErr(column, structValue.Type(), rs.columnToFieldIndex)
 		}
 		// Struct may contain embedded structs by ptr that defaults to nil.
 		// In order to scan values into a nested field,
@@ -153,10 +360,15 @@ func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 		initializeNested(structValue, fieldIndex)
 
 		fieldVal := structValue.FieldByIndex(fieldIndex)
+		if rs.fieldScanners != nil && rs.fieldScanners[i] != nil {
+			rs.fieldScanners[i].dst = fieldVal.Addr().Interface()
+			rs.scans[i] = rs.fieldScanners[i]
+			continue
+		}
 		rs.scans[i] = fieldVal.Addr().Interface()
 	}
 	if err := rs.rows.Scan(rs.scans...); err != nil {
-		return fmt.Errorf("scany: scan row into struct fields: %w", err)
+		return rs.structScanErr(structValue, err)
 	}
 	return nil
 }
@@ -199,14 +411,3 @@ func (rs *RowScanner) scanPrimitive(value reflect.Value) error {
 	}
 	return nil
 }
-
-func (rs *RowScanner) ensureDistinctColumns() error {
-	seen := make(map[string]struct{}, len(rs.columns))
-	for _, column := range rs.columns {
-		if _, ok := seen[column]; ok {
-			return fmt.Errorf("scany: rows contain a duplicate column '%s'", column)
-		}
-		seen[column] = struct{}{}
-	}
-	return nil
-}
@@ -3,6 +3,7 @@ package dbscan_test
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -510,7 +511,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 			dst: &struct {
 				Bar string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo': no corresponding field found, or it's unexported in " +
 				"struct { Bar string }",
 		},
 		{
@@ -522,7 +523,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 				foo string
 				Bar string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo': no corresponding field found, or it's unexported in " +
 				"struct { foo string; Bar string }",
 		},
 		{
@@ -533,7 +534,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 			dst: &struct {
 				Foo string `db:"-"`
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo': no corresponding field found, or it's unexported in " +
 				"struct { Foo string \"db:\\\"-\\\"\" }",
 		},
 		{
@@ -547,7 +548,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 				Foo       string
 				Bar       string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo_nested.foo_nested': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo_nested.foo_nested': no corresponding field found, or it's unexported in " +
 				"struct { fooNested dbscan_test.FooNested; Foo string; Bar string }",
 		},
 		{
@@ -558,7 +559,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 			dst: &struct {
 				FooNested `db:"-"`
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo_nested': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo_nested': no corresponding field found, or it's unexported in " +
 				"struct { dbscan_test.FooNested \"db:\\\"-\\\"\" }",
 		},
 		{
@@ -569,9 +570,21 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 			dst: &struct {
 				FooNested FooNested `db:"-"`
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo_nested.foo_nested': no corresponding field found, or it's unexported in " +
+			expectedErr: "doing scan: starting: scany: column: 'foo_nested.foo_nested': no corresponding field found, or it's unexported in " +
 				"struct { FooNested dbscan_test.FooNested \"db:\\\"-\\\"\" }",
 		},
+		{
+			name: "column with a typo suggests the closest field",
+			query: `
+				SELECT 'foo val' AS foo_colum, 'bar val' AS bar_column
+			`,
+			dst: &struct {
+				FooColumn string
+				BarColumn string
+			}{},
+			expectedErr: "doing scan: starting: scany: column: 'foo_colum': no corresponding field found, or it's unexported in " +
+				"struct { FooColumn string; BarColumn string }; did you mean 'foo_column'?",
+		},
 		{
 			name: "field type does not match with column type",
 			query: `
@@ -581,7 +594,8 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 				Foo int
 				Bar string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: scan row into struct fields: can't scan into dest[0]: cannot scan text (OID 25) in text format into *int",
+			expectedErr: "doing scan: scanFn: scany: column \"foo\" (text) -> field Foo (int) at row 0: " +
+				"can't scan into dest[0]: cannot scan text (OID 25) in text format into *int",
 		},
 		{
 			name: "non struct embedded field",
@@ -592,7 +606,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 				string `db:"string"`
 				Foo    string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'string': no corresponding field found, " +
+			expectedErr: "doing scan: starting: scany: column: 'string': no corresponding field found, " +
 				"or it's unexported in struct { string \"db:\\\"string\\\"\"; Foo string }",
 		},
 		{
@@ -604,7 +618,7 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 				JSONObj `db:"foo_json"`
 				Foo     string
 			}{},
-			expectedErr: "doing scan: scanFn: scany: column: 'foo_json': no corresponding field found, " +
+			expectedErr: "doing scan: starting: scany: column: 'foo_json': no corresponding field found, " +
 				"or it's unexported in struct { dbscan_test.JSONObj \"db:\\\"foo_json\\\"\"; Foo string }",
 		},
 	}
@@ -619,6 +633,40 @@ func TestRowScanner_Scan_invalidStructDestination_returnsErr(t *testing.T) {
 	}
 }
 
+func TestRowScanner_Scan_invalidValue_returnsScanErr(t *testing.T) {
+	t.Parallel()
+	type Profile struct {
+		Age int
+	}
+	type User struct {
+		Name    string
+		Profile *Profile
+	}
+	rows := queryRows(t, `
+		SELECT *
+		FROM (
+			VALUES ('foo val', 30), ('foo val 2', NULL)
+		) AS t (name, "profile.age")
+	`)
+	defer rows.Close() //nolint: errcheck
+	rs := testAPI.NewRowScanner(rows)
+	var err error
+	for rows.Next() {
+		dst := &User{}
+		if err = rs.Scan(dst); err != nil {
+			break
+		}
+	}
+
+	var scanErr *dbscan.ScanError
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, "profile.age", scanErr.Column)
+	assert.Equal(t, "int8", scanErr.DatabaseType)
+	assert.Equal(t, "User.Profile.Age", scanErr.FieldPath)
+	assert.Equal(t, reflect.TypeOf(0), scanErr.FieldType)
+	assert.Equal(t, 1, scanErr.Row)
+}
+
 func TestRowScanner_Scan_mapDestination(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -934,13 +982,34 @@ func TestRowScanner_Scan_rowsContainDuplicateColumns_returnsErr(t *testing.T) {
 				SELECT 'foo val' AS foo, 'foo val' AS foo
 			`
 			rows := queryRows(t, query)
-			expectedErr := "doing scan: starting: duplicate columns: scany: rows contain a duplicate column 'foo'"
+			expectedErr := "doing scan: starting: scany: rows contain a duplicate column 'foo'"
 			err := scan(t, tc.dst, rows)
 			assert.EqualError(t, err, expectedErr)
 		})
 	}
 }
 
+func TestRowScanner_Scan_multipleMismatchedColumns_returnsMappingErr(t *testing.T) {
+	t.Parallel()
+	query := `
+		SELECT 'foo val' AS foo, 'bar val' AS bar, 'baz val' AS baz, 'foo val 2' AS foo
+	`
+	rows := queryRows(t, query)
+	expectedErr := "doing scan: starting: scany: 3 columns don't match the destination: " +
+		"column: 'bar': no corresponding field found, or it's unexported in struct { Foo string }; " +
+		"column: 'baz': no corresponding field found, or it's unexported in struct { Foo string }; " +
+		"rows contain a duplicate column 'foo'"
+
+	dst := &struct{ Foo string }{}
+	err := scan(t, dst, rows)
+
+	assert.EqualError(t, err, expectedErr)
+	assert.ErrorIs(t, err, dbscan.ErrColumnMismatch)
+	var mappingErr *dbscan.MappingError
+	require.ErrorAs(t, err, &mappingErr)
+	assert.Len(t, mappingErr.Errors, 3)
+}
+
 func TestRowScanner_Scan_invalidDst_returnsErr(t *testing.T) {
 	t.Parallel()
 	cases := []struct {
@@ -0,0 +1,119 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ColumnTypesRows is an optional interface that Rows can implement
+// to expose the database type names of columns, e.g. "TIMESTAMPTZ" or "text".
+// dbscan uses it to provide a richer context in ScanError.
+type ColumnTypesRows interface {
+	ColumnDatabaseTypes() ([]string, error)
+}
+
+// ScanErrorColumnRows is an optional interface that Rows can implement
+// to report which column caused the error returned from Rows.Scan.
+// It returns false if the column can't be determined.
+// dbscan uses it to return ScanError instead of the bare database library error.
+type ScanErrorColumnRows interface {
+	ScanErrorColumn(err error) (columnIndex int, ok bool)
+}
+
+// ScanError is returned when a database value can't be scanned into a struct field.
+// It contains the context of the failed value.
+type ScanError struct {
+	// Column is the name of the column that failed to scan.
+	Column string
+	// DatabaseType is the database type name of the column.
+	// It's empty if Rows don't implement ColumnTypesRows.
+	DatabaseType string
+	// FieldPath is the path to the destination struct field, e.g. "User.Profile.CreatedAt".
+	FieldPath string
+	// FieldType is the Go type of the destination struct field.
+	FieldType reflect.Type
+	// Row is the zero-based index of the row.
+	Row int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+// The message looks like: `column "created_at" (TIMESTAMPTZ) -> field User.Profile.CreatedAt (string) at row 42`.
+func (e *ScanError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "scany: column %q", e.Column)
+	if e.DatabaseType != "" {
+		fmt.Fprintf(&b, " (%s)", e.DatabaseType)
+	}
+	fmt.Fprintf(&b, " -> field %s (%v) at row %d: %v", e.FieldPath, e.FieldType, e.Row, e.Err)
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+func newScanError(dbTypes []string, structType reflect.Type, columns []string, columnIndex int, fieldIndex []int,
+	rowIndex int, err error,
+) *ScanError {
+	fieldPath, fieldType := structFieldPath(structType, fieldIndex)
+	scanErr := &ScanError{
+		Column:    columns[columnIndex],
+		FieldPath: fieldPath,
+		FieldType: fieldType,
+		Row:       rowIndex,
+		Err:       err,
+	}
+	if columnIndex < len(dbTypes) {
+		scanErr.DatabaseType = dbTypes[columnIndex]
+	}
+	return scanErr
+}
+
+// columnDatabaseTypes returns nil if rows don't expose database types of columns.
+func columnDatabaseTypes(rows Rows) []string {
+	for _, r := range unwrapRows(rows) {
+		ctr, ok := r.(ColumnTypesRows)
+		if !ok {
+			continue
+		}
+		dbTypes, err := ctr.ColumnDatabaseTypes()
+		if err != nil {
+			return nil
+		}
+		return dbTypes
+	}
+	return nil
+}
+
+func scanErrorColumn(rows Rows, err error) (int, bool) {
+	for _, r := range unwrapRows(rows) {
+		if secr, ok := r.(ScanErrorColumnRows); ok {
+			return secr.ScanErrorColumn(err)
+		}
+	}
+	return 0, false
+}
+
+// structFieldPath returns the human-readable path to the field and the field type,
+// e.g. "User.Profile.CreatedAt". The path isn't prefixed with the struct name if the struct is anonymous.
+func structFieldPath(structType reflect.Type, fieldIndex []int) (string, reflect.Type) {
+	var parts []string
+	if structType.Name() != "" {
+		parts = append(parts, structType.Name())
+	}
+	t := structType
+	var field reflect.StructField
+	for _, i := range fieldIndex {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		field = t.Field(i)
+		parts = append(parts, field.Name)
+		t = field.Type
+	}
+	return strings.Join(parts, "."), field.Type
+}
@@ -0,0 +1,44 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// StartHookFunc is called once per rows before scanning begins.
+// It receives the destination type, i.e. the slice element type for ScanAll,
+// and the columns that rows return, and it returns the columns that dbscan maps to the destination.
+// The hook can rename columns, e.g. to match struct tags, or reject the query by returning an error.
+// The returned columns are matched with row values by position, so their number must be the same.
+type StartHookFunc func(dstType reflect.Type, columns []string) ([]string, error)
+
+// WithStartHook allows advanced users to intercept the column resolution,
+// see StartHookFunc for details. The option can be used multiple times, hooks are called in order.
+func WithStartHook(hook StartHookFunc) APIOption {
+	return func(api *API) {
+		api.startHooks = append(api.startHooks, hook)
+	}
+}
+
+// resolveColumns returns the columns that are mapped to the destination type.
+// Columns are normalized and table qualifiers are stripped before start hooks are called,
+// see WithColumnNormalizers and WithTableQualifierStripping.
+func (api *API) resolveColumns(dstType reflect.Type, columns []string) ([]string, error) {
+	if len(api.columnNormalizers) > 0 {
+		columns = api.normalizeColumns(columns)
+	}
+	if api.stripTableQualifiers {
+		columns = api.stripQualifiers(dstType, columns)
+	}
+	for _, hook := range api.startHooks {
+		resolved, err := hook(dstType, columns)
+		if err != nil {
+			return nil, fmt.Errorf("scany: start hook: %w", err)
+		}
+		if len(resolved) != len(columns) {
+			return nil, fmt.Errorf("scany: start hook must return %d columns, got: %d", len(columns), len(resolved))
+		}
+		columns = resolved
+	}
+	return columns, nil
+}
@@ -0,0 +1,71 @@
+package dbscan_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanAll_withStartHook_renamesColumns(t *testing.T) {
+	t.Parallel()
+	var gotType reflect.Type
+	hook := func(dstType reflect.Type, columns []string) ([]string, error) {
+		gotType = dstType
+		renamed := make([]string, len(columns))
+		for i, column := range columns {
+			renamed[i] = strings.TrimPrefix(column, "legacy_")
+		}
+		return renamed, nil
+	}
+	api, err := getAPI(dbscan.WithStartHook(hook))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT 'foo val' AS legacy_foo, 'bar val' AS bar`)
+	var got []*testModel
+
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*testModel{{Foo: "foo val", Bar: "bar val"}}, got)
+	assert.Equal(t, reflect.TypeOf(testModel{}), gotType)
+}
+
+func TestScanOne_withStartHook_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		hook        dbscan.StartHookFunc
+		expectedErr string
+	}{
+		{
+			name: "hook fails",
+			hook: func(dstType reflect.Type, columns []string) ([]string, error) {
+				return nil, errors.New("unexpected query")
+			},
+			expectedErr: "scanning: doing scan: starting: scany: start hook: unexpected query",
+		},
+		{
+			name: "columns number changed",
+			hook: func(dstType reflect.Type, columns []string) ([]string, error) {
+				return columns[:1], nil
+			},
+			expectedErr: "scanning: doing scan: starting: scany: start hook must return 2 columns, got: 1",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(dbscan.WithStartHook(tc.hook))
+			require.NoError(t, err)
+			var got testModel
+			err = api.ScanOne(&got, queryRows(t, singleRowsQuery))
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
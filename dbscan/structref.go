@@ -9,6 +9,51 @@ type toTraverse struct {
 	Type         reflect.Type
 	IndexPrefix  []int
 	ColumnPrefix string
+	Depth        int
+	// Ancestors contains struct types on the path to this one, it's used to detect cycles.
+	Ancestors map[reflect.Type]bool
+}
+
+// FieldType returns the type of the struct field that the column is mapped to.
+// It allows tools built on top of dbscan to learn destination types of columns, e.g. to infer a schema,
+// following the same mapping rules as scanning does. It returns false if there is no corresponding field.
+func (api *API) FieldType(structType reflect.Type, column string) (reflect.Type, bool) {
+	if structType.Kind() != reflect.Struct {
+		return nil, false
+	}
+	fieldIndex, ok := api.getColumnToFieldIndexMap(structType)[column]
+	if !ok {
+		return nil, false
+	}
+	_, fieldType := structFieldPath(structType, fieldIndex)
+	return fieldType, true
+}
+
+// FieldIndex returns the index sequence of the struct field that the column is mapped to,
+// see reflect.Value.FieldByIndex. Nested structs on the path may be pointers that must be allocated.
+// It allows libraries built on top of dbscan to populate fields by column names, e.g. from composite types.
+// It returns false if there is no corresponding field.
+func (api *API) FieldIndex(structType reflect.Type, column string) ([]int, bool) {
+	if structType.Kind() != reflect.Struct {
+		return nil, false
+	}
+	fieldIndex, ok := api.getColumnToFieldIndexMap(structType)[column]
+	return fieldIndex, ok
+}
+
+// FieldByIndex is like reflect.Value.FieldByIndex, but it allocates nil pointers to nested structs on the path.
+// It returns the field of the struct value v by the index sequence that FieldIndex returns, v must be settable.
+func FieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
 }
 
 func (api *API) getColumnToFieldIndexMap(structType reflect.Type) map[string][]int {
@@ -26,7 +71,13 @@ func (api *API) getColumnToFieldIndexMap(structType reflect.Type) map[string][]i
 func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][]int {
 	result := make(map[string][]int, structType.NumField())
 	var queue []*toTraverse
-	queue = append(queue, &toTraverse{Type: structType, IndexPrefix: nil, ColumnPrefix: ""})
+	queue = append(queue, &toTraverse{
+		Type:         structType,
+		IndexPrefix:  nil,
+		ColumnPrefix: "",
+		Depth:        0,
+		Ancestors:    map[reflect.Type]bool{structType: true},
+	})
 	for len(queue) > 0 {
 		traversal := queue[0]
 		queue = queue[1:]
@@ -38,15 +89,24 @@ func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][
 				// Field is unexported, skip it.
 				continue
 			}
+			if isUnexportedEmbeddedPtr(field) {
+				// dbscan can't initialize a nil pointer to an unexported embedded struct, skip it.
+				continue
+			}
 
-			dbTag, dbTagPresent := field.Tag.Lookup(api.structTagKey)
+			dbTag, dbTagPresent := api.lookupStructTag(field)
+			var tagOptions []string
 			if dbTagPresent {
-				dbTag = strings.Split(dbTag, ",")[0]
+				dbTag, tagOptions = parseStructTag(dbTag)
 			}
 			if dbTag == "-" {
 				// Field is ignored, skip it.
 				continue
 			}
+			if hasTagOption(tagOptions, rowHashTagOption) {
+				// Field is populated by dbscan itself, it's not mapped to any column.
+				continue
+			}
 
 			index := make([]int, 0, len(traversal.IndexPrefix)+len(field.Index))
 			index = append(index, traversal.IndexPrefix...)
@@ -68,7 +128,7 @@ func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][
 			if field.Type.Kind() == reflect.Ptr {
 				childType = field.Type.Elem()
 			}
-			if childType.Kind() == reflect.Struct {
+			if childType.Kind() == reflect.Struct && api.shouldTraverse(traversal, childType) {
 				if field.Anonymous {
 					// If "db" tag is present for embedded struct
 					// use it with "." to prefix all column from the embedded struct.
@@ -80,6 +140,8 @@ func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][
 					Type:         childType,
 					IndexPrefix:  index,
 					ColumnPrefix: columnPrefix,
+					Depth:        traversal.Depth + 1,
+					Ancestors:    withAncestor(traversal.Ancestors, childType),
 				})
 			}
 		}
@@ -88,6 +150,130 @@ func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][
 	return result
 }
 
+// lookupStructTag returns the value of the first struct tag key that is present on the field,
+// see WithStructTagKeys.
+func (api *API) lookupStructTag(field reflect.StructField) (string, bool) {
+	for i, key := range api.structTagKeys {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		if i > 0 {
+			if name, _ := parseStructTag(tag); name == "" {
+				continue
+			}
+		}
+		return tag, true
+	}
+	return "", false
+}
+
+// parseStructTag splits the tag value into the column name and options that follow it,
+// e.g. `db:"email,transform=lower"` is parsed to "email" and ["transform=lower"].
+func parseStructTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+func isUnexportedEmbeddedPtr(field reflect.StructField) bool {
+	return field.Anonymous && field.PkgPath != "" && field.Type.Kind() == reflect.Ptr
+}
+
+func hasTagOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldsWithTagOption returns indexes of all fields, including fields of embedded and nested structs,
+// that have the given option in the struct tag. Fields are ordered from the outermost to the innermost struct.
+func (api *API) fieldsWithTagOption(structType reflect.Type, option string) [][]int {
+	return api.fieldsWithTag(structType, func(name string, options []string) bool {
+		return name != "-" && hasTagOption(options, option)
+	})
+}
+
+// fieldsWithTag is like fieldsWithTagOption, but it returns fields whose tag matches the given function.
+// Fields ignored via `db:"-"` can still match, but structs ignored this way aren't traversed.
+func (api *API) fieldsWithTag(structType reflect.Type, match func(name string, options []string) bool) [][]int {
+	type traversal struct {
+		typ       reflect.Type
+		index     []int
+		ancestors map[reflect.Type]bool
+	}
+	var result [][]int
+	queue := []traversal{{typ: structType, ancestors: map[reflect.Type]bool{structType: true}}}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		for i := 0; i < t.typ.NumField(); i++ {
+			field := t.typ.Field(i)
+			if (field.PkgPath != "" && !field.Anonymous) || isUnexportedEmbeddedPtr(field) {
+				continue
+			}
+			tag, _ := api.lookupStructTag(field)
+			name, options := parseStructTag(tag)
+			index := make([]int, 0, len(t.index)+1)
+			index = append(index, t.index...)
+			index = append(index, i)
+			if match(name, options) {
+				result = append(result, index)
+				continue
+			}
+			if name == "-" {
+				continue
+			}
+			childType := field.Type
+			if childType.Kind() == reflect.Ptr {
+				childType = childType.Elem()
+			}
+			if childType.Kind() != reflect.Struct || t.ancestors[childType] {
+				continue
+			}
+			queue = append(queue, traversal{typ: childType, index: index, ancestors: withAncestor(t.ancestors, childType)})
+		}
+	}
+	return result
+}
+
+// structFieldByIndex is like reflect.Type.FieldByIndex, but it handles nested structs by a pointer.
+func structFieldByIndex(structType reflect.Type, fieldIndex []int) reflect.StructField {
+	var field reflect.StructField
+	t := structType
+	for _, i := range fieldIndex {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		field = t.Field(i)
+		t = field.Type
+	}
+	return field
+}
+
+// shouldTraverse protects from infinite traversal of self-referential types, e.g. `type Node struct{ Parent *Node }`.
+// By default, a struct type isn't traversed again if it's already on the path, i.e. cycles are cut.
+// If the max depth is set, recursive types are expanded until the depth is reached.
+func (api *API) shouldTraverse(parent *toTraverse, childType reflect.Type) bool {
+	if api.maxStructDepth > 0 {
+		return parent.Depth < api.maxStructDepth
+	}
+	return !parent.Ancestors[childType]
+}
+
+// withAncestor returns a copy of the ancestors set with the type added to it.
+// Every traversal path gets its own set, so sibling fields of the same type aren't treated as cycles.
+func withAncestor(ancestors map[reflect.Type]bool, t reflect.Type) map[reflect.Type]bool {
+	result := make(map[reflect.Type]bool, len(ancestors)+1)
+	for a := range ancestors {
+		result[a] = true
+	}
+	result[t] = true
+	return result
+}
+
 func (api *API) buildColumn(parts ...string) string {
 	var notEmptyParts []string
 	for _, p := range parts {
@@ -0,0 +1,115 @@
+package dbscan
+
+import (
+	"reflect"
+	"strings"
+)
+
+// noCorrespondingFieldErr builds the error for a column that isn't mapped to any struct field.
+// It suggests the closest known column if there is one, to make typos easier to spot in large structs.
+func (api *API) noCorrespondingFieldErr(
+	column string, structType reflect.Type, columnToFieldIndex map[string][]int,
+) error {
+	if prefix, ok := api.untraversedPrefix(column, structType, columnToFieldIndex); ok {
+		return newSentinelErrorf(ErrColumnMismatch,
+			"scany: column: '%s': no corresponding field found, or it's unexported in %v; "+
+				"struct field '%s' isn't traversed deeper, see WithMaxStructDepth",
+			column, structType, prefix,
+		)
+	}
+	if suggestion, ok := closestColumn(column, columnToFieldIndex); ok {
+		return newSentinelErrorf(ErrColumnMismatch,
+			"scany: column: '%s': no corresponding field found, or it's unexported in %v; did you mean '%s'?",
+			column, structType, suggestion,
+		)
+	}
+	return newSentinelErrorf(ErrColumnMismatch,
+		"scany: column: '%s': no corresponding field found, or it's unexported in %v",
+		column, structType,
+	)
+}
+
+// untraversedPrefix finds the struct field that the column belongs to, if the field wasn't traversed
+// because of the recursion protection, see WithMaxStructDepth.
+func (api *API) untraversedPrefix(
+	column string, structType reflect.Type, columnToFieldIndex map[string][]int,
+) (string, bool) {
+	if api.columnSeparator == "" {
+		return "", false
+	}
+	parts := strings.Split(column, api.columnSeparator)
+	for i := len(parts) - 1; i > 0; i-- {
+		prefix := strings.Join(parts[:i], api.columnSeparator)
+		fieldIndex, ok := columnToFieldIndex[prefix]
+		if !ok {
+			continue
+		}
+		fieldType := structFieldByIndex(structType, fieldIndex).Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() != reflect.Struct || api.isScannableType(fieldType) {
+			return "", false
+		}
+		for known := range columnToFieldIndex {
+			if strings.HasPrefix(known, prefix+api.columnSeparator) {
+				// The struct is traversed, the column is just missing in it.
+				return "", false
+			}
+		}
+		return prefix, fieldType.NumField() > 0
+	}
+	return "", false
+}
+
+// closestColumn returns the known column with the smallest edit distance to the given column.
+// Columns that are too far from the given one aren't considered to be a typo.
+func closestColumn(column string, columnToFieldIndex map[string][]int) (string, bool) {
+	maxDistance := len(column) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	var best string
+	bestDistance := maxDistance + 1
+	for candidate := range columnToFieldIndex {
+		distance := editDistance(column, candidate)
+		// Break ties alphabetically, so the suggestion doesn't depend on the map iteration order.
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best, bestDistance <= maxDistance
+}
+
+// editDistance computes the Levenshtein distance between two strings.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+func minInt(first int, rest ...int) int {
+	result := first
+	for _, v := range rest {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
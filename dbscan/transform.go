@@ -0,0 +1,199 @@
+package dbscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const transformTagOption = "transform="
+
+// TransformFunc converts a scanned field value, e.g. normalizes it.
+// It receives the field value and returns the new one, which must be assignable to the field.
+// For fields by a pointer it receives the pointed value and isn't called if the pointer is nil.
+type TransformFunc func(value interface{}) (interface{}, error)
+
+// WithTransform registers a named transform that can be referenced in the struct tag,
+// e.g. `db:"email,transform=lower"`.
+// Transforms are applied to the field after the column value is scanned into it.
+// A field can have multiple transforms, e.g. `db:"email,transform=trim,transform=lower"`,
+// they are applied in the order they are listed.
+// The following transforms are registered by default for string fields: "lower", "upper" and "trim".
+// Registering a transform with the same name replaces the previous one.
+func WithTransform(name string, fn TransformFunc) APIOption {
+	return func(api *API) {
+		api.transforms[name] = fn
+	}
+}
+
+func defaultTransforms() map[string]TransformFunc {
+	return map[string]TransformFunc{
+		"lower": stringTransform(strings.ToLower),
+		"upper": stringTransform(strings.ToUpper),
+		"trim":  stringTransform(strings.TrimSpace),
+	}
+}
+
+func stringTransform(fn func(string) string) TransformFunc {
+	return func(value interface{}) (interface{}, error) {
+		v := reflect.ValueOf(value)
+		if v.Kind() != reflect.String {
+			return nil, fmt.Errorf("expected a string, got: %T", value)
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.SetString(fn(v.String()))
+		return result.Interface(), nil
+	}
+}
+
+type fieldTransform struct {
+	column     string
+	fieldIndex []int
+	decrypt    bool
+	names      []string
+	fns        []TransformFunc
+}
+
+type structTransforms struct {
+	byColumn map[string]*fieldTransform
+	err      error
+}
+
+// getFieldTransforms returns transforms for fields that are mapped to the given columns.
+func (api *API) getFieldTransforms(
+	structType reflect.Type, columns []string, columnToFieldIndex map[string][]int,
+) ([]*fieldTransform, error) {
+	transformsIface, ok := api.transformsCache.Load(structType)
+	if !ok {
+		transformsIface, _ = api.transformsCache.LoadOrStore(
+			structType, api.buildStructTransforms(structType, columnToFieldIndex),
+		)
+	}
+	transforms := transformsIface.(*structTransforms)
+	if transforms.err != nil {
+		return nil, transforms.err
+	}
+	if len(transforms.byColumn) == 0 {
+		return nil, nil
+	}
+	var result []*fieldTransform
+	for _, column := range columns {
+		if transform, ok := transforms.byColumn[column]; ok {
+			result = append(result, transform)
+		}
+	}
+	return result, nil
+}
+
+func (api *API) buildStructTransforms(structType reflect.Type, columnToFieldIndex map[string][]int) *structTransforms {
+	result := &structTransforms{}
+	// Iterate columns in a stable order, so the same error is reported every time.
+	columns := make([]string, 0, len(columnToFieldIndex))
+	for column := range columnToFieldIndex {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		fieldIndex := columnToFieldIndex[column]
+		field := structFieldByIndex(structType, fieldIndex)
+		tag, ok := api.lookupStructTag(field)
+		if !ok {
+			continue
+		}
+		_, options := parseStructTag(tag)
+		path, _ := structFieldPath(structType, fieldIndex)
+		var transform *fieldTransform
+		for _, option := range options {
+			if option != encryptedTagOption && !strings.HasPrefix(option, transformTagOption) {
+				continue
+			}
+			if transform == nil {
+				transform = &fieldTransform{column: column, fieldIndex: fieldIndex}
+			}
+			if option == encryptedTagOption {
+				if err := api.checkEncryptedField(path, field.Type); err != nil {
+					return &structTransforms{err: err}
+				}
+				transform.decrypt = true
+				continue
+			}
+			name := strings.TrimPrefix(option, transformTagOption)
+			fn, ok := api.transforms[name]
+			if !ok {
+				return &structTransforms{err: newSentinelErrorf(ErrUnsupportedDestination,
+					"scany: field %s: unknown transform '%s'", path, name,
+				)}
+			}
+			transform.names = append(transform.names, name)
+			transform.fns = append(transform.fns, fn)
+		}
+		if transform != nil {
+			if result.byColumn == nil {
+				result.byColumn = map[string]*fieldTransform{}
+			}
+			result.byColumn[column] = transform
+		}
+	}
+	return result
+}
+
+func (api *API) checkEncryptedField(path string, fieldType reflect.Type) error {
+	if api.cryptoProvider == nil {
+		return newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: field %s is encrypted, but crypto provider isn't set, see WithCryptoProvider", path,
+		)
+	}
+	if !isEncryptableType(fieldType) {
+		return newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: encrypted field %s must be a string or []byte, got: %v", path, fieldType,
+		)
+	}
+	return nil
+}
+
+func (api *API) applyTransforms(ctx context.Context, structValue reflect.Value, transforms []*fieldTransform) error {
+	for _, transform := range transforms {
+		fieldVal := structValue.FieldByIndex(transform.fieldIndex)
+		if fieldVal.Kind() == reflect.Ptr {
+			if fieldVal.IsNil() {
+				continue
+			}
+			fieldVal = fieldVal.Elem()
+		}
+		if transform.decrypt {
+			if err := api.decryptField(ctx, transform.column, fieldVal); err != nil {
+				return err
+			}
+		}
+		for i, fn := range transform.fns {
+			if err := applyTransform(fieldVal, fn); err != nil {
+				path, _ := structFieldPath(structValue.Type(), transform.fieldIndex)
+				return fmt.Errorf("scany: transform '%s' on field %s: %w", transform.names[i], path, err)
+			}
+		}
+	}
+	return nil
+}
+
+func applyTransform(fieldVal reflect.Value, fn TransformFunc) error {
+	result, err := fn(fieldVal.Interface())
+	if err != nil {
+		return err
+	}
+	resultVal := reflect.ValueOf(result)
+	switch {
+	case !resultVal.IsValid():
+		fieldVal.Set(reflect.Zero(fieldVal.Type()))
+	case resultVal.Type().AssignableTo(fieldVal.Type()):
+		fieldVal.Set(resultVal)
+	case isNumberKind(resultVal.Kind()) && isNumberKind(fieldVal.Kind()):
+		return convertNumber(fieldVal, resultVal)
+	case isSafeConversion(resultVal.Type(), fieldVal.Type()):
+		fieldVal.Set(resultVal.Convert(fieldVal.Type()))
+	default:
+		return fmt.Errorf("result of type %v isn't assignable to %v", resultVal.Type(), fieldVal.Type())
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestScanOne_fieldTransforms(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Email  string  `db:"email,transform=trim,transform=lower"`
+		Name   *string `db:"name,transform=upper"`
+		Suffix string  `db:"suffix,transform=reverse"`
+	}
+	reverse := func(value interface{}) (interface{}, error) {
+		runes := []rune(value.(string))
+		for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+			runes[i], runes[j] = runes[j], runes[i]
+		}
+		return string(runes), nil
+	}
+	api, err := getAPI(dbscan.WithTransform("reverse", reverse))
+	require.NoError(t, err)
+	rows := queryRows(t, `SELECT '  Foo@Example.COM ' AS email, 'bar' AS name, 'abc' AS suffix`)
+	var got dst
+
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	expected := dst{Email: "foo@example.com", Name: makeStrPtr("BAR"), Suffix: "cba"}
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOne_fieldTransforms_nilPointerIsSkipped(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Name *string `db:"name,transform=upper"`
+	}
+	rows := queryRows(t, `SELECT NULL AS name`)
+	var got dst
+
+	err := testAPI.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Nil(t, got.Name)
+}
+
+func TestScanOne_fieldTransforms_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		dst         interface{}
+		expectedErr string
+	}{
+		{
+			name: "unknown transform",
+			dst: &struct {
+				Foo string `db:"foo,transform=unknown"`
+			}{},
+			expectedErr: "scanning: doing scan: starting: scany: field Foo: unknown transform 'unknown'",
+		},
+		{
+			name: "transform fails",
+			dst: &struct {
+				Foo int `db:"foo,transform=lower"`
+			}{},
+			expectedErr: "scanning: doing scan: scanFn: scany: transform 'lower' on field Foo: expected a string, got: int",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := queryRows(t, `SELECT 1 AS foo`)
+			err := testAPI.ScanOne(tc.dst, rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
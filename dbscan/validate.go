@@ -0,0 +1,43 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ValidateDestination is a package-level helper function that uses the DefaultAPI object.
+// See API.ValidateDestination for details.
+func ValidateDestination(dst interface{}, columns []string) error {
+	return DefaultAPI.ValidateDestination(dst, columns)
+}
+
+// ValidateDestination checks that rows with the given columns can be scanned into the destination
+// without querying the database, it applies exactly the same rules as ScanOne and ScanAll do.
+// It's useful in tests and startup checks to catch mismatches between a query and a struct early.
+//
+// dst can be the destination for ScanOne or ScanAll, in the latter case the slice element type is validated.
+// So a pointer to a slice is always treated as a ScanAll destination, unless the slice type is scannable.
+// Start hooks are applied to the columns, see WithStartHook.
+// Values of dst aren't modified, so it's fine to pass a pointer to a zero value, e.g. &[]*User{} or new(User).
+func (api *API) ValidateDestination(dst interface{}, columns []string) error {
+	dstValue, err := parseDestination(dst)
+	if err != nil {
+		return fmt.Errorf("scany: parsing destination: %w", err)
+	}
+	dstType := dstValue.Type()
+	if dstValue.Kind() == reflect.Slice && !api.isScannableType(dstType) {
+		sliceMeta, err := api.parseSliceDestination(dst)
+		if err != nil {
+			return err
+		}
+		dstType = sliceMeta.elementBaseType
+	}
+	columns, err = api.resolveColumns(dstType, columns)
+	if err != nil {
+		return err
+	}
+	if _, _, err := api.resolveDestination(dstType, columns); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package dbscan_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestValidateDestination_Succeeds(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name    string
+		dst     interface{}
+		columns []string
+	}{
+		{
+			name:    "struct",
+			dst:     &testModel{},
+			columns: []string{"foo", "bar"},
+		},
+		{
+			name:    "slice of structs by ptr",
+			dst:     &[]*testModel{},
+			columns: []string{"foo", "bar"},
+		},
+		{
+			name:    "slice of structs by value",
+			dst:     &[]testModel{},
+			columns: []string{"bar"},
+		},
+		{
+			name:    "map",
+			dst:     &[]map[string]interface{}{},
+			columns: []string{"foo", "bar", "baz"},
+		},
+		{
+			name:    "primitive",
+			dst:     &[]string{},
+			columns: []string{"foo"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := testAPI.ValidateDestination(tc.dst, tc.columns)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateDestination_Fails(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		dst         interface{}
+		columns     []string
+		expectedErr error
+		errString   string
+	}{
+		{
+			name:        "unknown column",
+			dst:         &[]*testModel{},
+			columns:     []string{"foo", "qux"},
+			expectedErr: dbscan.ErrColumnMismatch,
+			errString: "scany: column: 'qux': no corresponding field found, " +
+				"or it's unexported in dbscan_test.testModel",
+		},
+		{
+			name:        "duplicate columns",
+			dst:         &testModel{},
+			columns:     []string{"foo", "foo"},
+			expectedErr: dbscan.ErrColumnMismatch,
+			errString:   "scany: rows contain a duplicate column 'foo'",
+		},
+		{
+			name:        "primitive with multiple columns",
+			dst:         new(string),
+			columns:     []string{"foo", "bar"},
+			expectedErr: dbscan.ErrColumnMismatch,
+			errString:   "scany: to scan into a primitive type, columns number must be exactly 1, got: 2",
+		},
+		{
+			name:        "map with non string key",
+			dst:         &map[int]interface{}{},
+			columns:     []string{"foo"},
+			expectedErr: dbscan.ErrUnsupportedDestination,
+			errString:   "scany: invalid type map[int]interface {}: map must have string key, got: int",
+		},
+		{
+			name:        "non pointer destination",
+			dst:         testModel{},
+			columns:     []string{"foo"},
+			expectedErr: dbscan.ErrUnsupportedDestination,
+			errString:   "scany: parsing destination: scany: destination must be a pointer, got: dbscan_test.testModel",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := testAPI.ValidateDestination(tc.dst, tc.columns)
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.EqualError(t, err, tc.errString)
+		})
+	}
+}
+
+func TestAPI_FieldType(t *testing.T) {
+	t.Parallel()
+	type Nested struct {
+		ID int64
+	}
+	type dst struct {
+		Foo    string
+		Bar    *string
+		Nested Nested
+	}
+	dstType := reflect.TypeOf(dst{})
+
+	fooType, ok := testAPI.FieldType(dstType, "foo")
+	require.True(t, ok)
+	assert.Equal(t, reflect.TypeOf(""), fooType)
+
+	barType, ok := testAPI.FieldType(dstType, "bar")
+	require.True(t, ok)
+	assert.Equal(t, reflect.TypeOf((*string)(nil)), barType)
+
+	nestedIDType, ok := testAPI.FieldType(dstType, "nested.id")
+	require.True(t, ok)
+	assert.Equal(t, reflect.TypeOf(int64(0)), nestedIDType)
+
+	_, ok = testAPI.FieldType(dstType, "baz")
+	assert.False(t, ok)
+}
+
+func TestFieldByIndex_allocatesNestedStructs(t *testing.T) {
+	t.Parallel()
+	type Nested struct {
+		ID int64
+	}
+	type dst struct {
+		Foo    string
+		Nested *Nested
+	}
+	var got dst
+	fieldIndex, ok := testAPI.FieldIndex(reflect.TypeOf(got), "nested.id")
+	require.True(t, ok)
+
+	dbscan.FieldByIndex(reflect.ValueOf(&got).Elem(), fieldIndex).SetInt(1)
+
+	assert.Equal(t, dst{Nested: &Nested{ID: 1}}, got)
+}
@@ -0,0 +1,163 @@
+package dbscan
+
+import (
+	"context"
+	"reflect"
+)
+
+// Values is a package-level helper function that uses the DefaultAPI object.
+// See API.Values for details.
+func Values(src interface{}, columns ...string) ([]interface{}, error) {
+	return DefaultAPI.Values(src, columns...)
+}
+
+// ValuesContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ValuesContext for details.
+func ValuesContext(ctx context.Context, src interface{}, columns ...string) ([]interface{}, error) {
+	return DefaultAPI.ValuesContext(ctx, src, columns...)
+}
+
+// Values is the inverse of scanning: it extracts values of struct fields in the order of the given columns,
+// following the same mapping rules as scanning does. It's handy to build arguments for INSERT and UPDATE queries:
+//
+//	columns, _ := dbscan.Columns(&user)
+//	args, _ := dbscan.Values(&user, columns...)
+//
+// If no columns are given, values of all columns listed by API.Columns are returned,
+// except for source columns of composed fields, since a composer can't be reversed.
+// Nil pointers, including pointers to nested structs, give NULL values, other pointers are dereferenced.
+// Values of fields marked with the "encrypted" tag option are encrypted via the crypto provider,
+// see WithCryptoProvider. Other values are returned as is, and it's up to the database library to encode them.
+func (api *API) Values(src interface{}, columns ...string) ([]interface{}, error) {
+	return api.ValuesContext(context.Background(), src, columns...)
+}
+
+// ValuesContext is the same as Values, but passes the context to the crypto provider.
+func (api *API) ValuesContext(ctx context.Context, src interface{}, columns ...string) ([]interface{}, error) {
+	structValue, err := api.sourceStruct(src)
+	if err != nil {
+		return nil, err
+	}
+	structType := structValue.Type()
+	if len(columns) == 0 {
+		if columns, err = api.structColumns(structType, false); err != nil {
+			return nil, err
+		}
+	}
+	return api.fieldValues(ctx, structValue, columns, true /* encrypt. */)
+}
+
+// fieldValues returns values of fields that are mapped to the columns,
+// encrypt makes it encrypt values of encrypted fields.
+func (api *API) fieldValues(
+	ctx context.Context, structValue reflect.Value, columns []string, encrypt bool,
+) ([]interface{}, error) {
+	structType := structValue.Type()
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	for _, column := range columns {
+		if _, ok := columnToFieldIndex[column]; !ok {
+			return nil, newSentinelErrorf(ErrColumnMismatch,
+				"scany: column: '%s': no corresponding field found, or it's unexported in %v", column, structType,
+			)
+		}
+	}
+	encrypted := make(map[string]bool)
+	if encrypt {
+		transforms, err := api.getFieldTransforms(structType, columns, columnToFieldIndex)
+		if err != nil {
+			return nil, err
+		}
+		for _, transform := range transforms {
+			if transform.decrypt {
+				encrypted[transform.column] = true
+			}
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		fieldVal, err := structValue.FieldByIndexErr(columnToFieldIndex[column])
+		if err != nil {
+			// A nested struct by a pointer on the way is nil.
+			continue
+		}
+		if fieldVal.Kind() == reflect.Ptr {
+			if fieldVal.IsNil() {
+				continue
+			}
+			fieldVal = fieldVal.Elem()
+		}
+		if encrypted[column] {
+			if values[i], err = api.encryptField(ctx, column, fieldVal); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		values[i] = fieldVal.Interface()
+	}
+	return values, nil
+}
+
+// sourceStruct returns the struct value that src holds directly or by a pointer.
+func (api *API) sourceStruct(src interface{}) (reflect.Value, error) {
+	structValue := reflect.ValueOf(src)
+	for structValue.Kind() == reflect.Ptr {
+		if structValue.IsNil() {
+			return reflect.Value{}, newSentinelErrorf(ErrUnsupportedDestination,
+				"scany: source must be a non-nil pointer to a struct, got: %T", src,
+			)
+		}
+		structValue = structValue.Elem()
+	}
+	if structValue.Kind() != reflect.Struct {
+		return reflect.Value{}, newSentinelErrorf(ErrUnsupportedDestination,
+			"scany: source must be a struct, got: %T", src,
+		)
+	}
+	return structValue, nil
+}
+
+// NamedArgs is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedArgs for details.
+func NamedArgs(src interface{}) (map[string]interface{}, error) {
+	return DefaultAPI.NamedArgs(src)
+}
+
+// NamedArgsContext is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedArgsContext for details.
+func NamedArgsContext(ctx context.Context, src interface{}) (map[string]interface{}, error) {
+	return DefaultAPI.NamedArgsContext(ctx, src)
+}
+
+// NamedArgs returns a map from columns to values of struct fields, the same values that Values returns.
+// The map can be used as pgx.NamedArgs or with sqlx-style named queries:
+//
+//	args, _ := dbscan.NamedArgs(&user)
+//	_, err := conn.Exec(ctx, `INSERT INTO users (id, email) VALUES (@id, @email)`, pgx.NamedArgs(args))
+//
+// Note that columns of nested structs contain the column separator, e.g. "post.id",
+// which pgx doesn't allow in a named argument.
+func (api *API) NamedArgs(src interface{}) (map[string]interface{}, error) {
+	return api.NamedArgsContext(context.Background(), src)
+}
+
+// NamedArgsContext is the same as NamedArgs, but passes the context to the crypto provider.
+func (api *API) NamedArgsContext(ctx context.Context, src interface{}) (map[string]interface{}, error) {
+	structValue, err := api.sourceStruct(src)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := api.structColumns(structValue.Type(), false)
+	if err != nil {
+		return nil, err
+	}
+	values, err := api.ValuesContext(ctx, src, columns...)
+	if err != nil {
+		return nil, err
+	}
+	args := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		args[column] = values[i]
+	}
+	return args, nil
+}
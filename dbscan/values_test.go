@@ -0,0 +1,123 @@
+package dbscan_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	type Post struct {
+		Title string
+	}
+	type user struct {
+		ID    int
+		Email sql.NullString
+		SSN   string  `db:"ssn,encrypted"`
+		Note  *string `db:"note"`
+		Post  *Post
+		Age   int `db:"-"`
+	}
+	api, err := getAPI(dbscan.WithCryptoProvider(shiftCrypto{}))
+	require.NoError(t, err)
+	src := &user{ID: 1, Email: sql.NullString{String: "ann@example.com", Valid: true}, SSN: "abc", Note: makeStrPtr("n")}
+	cases := []struct {
+		name     string
+		columns  []string
+		expected []interface{}
+	}{
+		{
+			name:     "all columns",
+			expected: []interface{}{1, src.Email, "bcd", "n", nil},
+		},
+		{
+			name:     "given columns",
+			columns:  []string{"post.title", "ssn", "id"},
+			expected: []interface{}{nil, "bcd", 1},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := api.Values(src, tc.columns...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestValues_returnsErr(t *testing.T) {
+	t.Parallel()
+	type user struct {
+		ID  int
+		SSN string `db:"ssn,encrypted"`
+	}
+	cases := []struct {
+		name        string
+		src         interface{}
+		columns     []string
+		expectedErr string
+	}{
+		{
+			name:        "unknown column",
+			src:         user{},
+			columns:     []string{"id", "email"},
+			expectedErr: "scany: column: 'email': no corresponding field found, or it's unexported in dbscan_test.user",
+		},
+		{
+			name:        "crypto provider isn't set",
+			src:         user{},
+			expectedErr: "scany: field user.SSN is encrypted, but crypto provider isn't set, see WithCryptoProvider",
+		},
+		{
+			name:        "not a struct",
+			src:         map[string]interface{}{},
+			expectedErr: "scany: source must be a struct, got: map[string]interface {}",
+		},
+		{
+			name:        "nil pointer",
+			src:         (*user)(nil),
+			expectedErr: "scany: source must be a non-nil pointer to a struct, got: *dbscan_test.user",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := dbscan.Values(tc.src, tc.columns...)
+
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestNamedArgs(t *testing.T) {
+	t.Parallel()
+	type Post struct {
+		Title string
+	}
+	type user struct {
+		ID   int
+		SSN  string `db:"ssn,encrypted"`
+		Post Post
+		Age  int `db:"-"`
+	}
+	api, err := getAPI(dbscan.WithCryptoProvider(shiftCrypto{}))
+	require.NoError(t, err)
+	src := user{ID: 1, SSN: "abc", Post: Post{Title: "hello"}, Age: 30}
+
+	got, err := api.NamedArgs(src)
+	require.NoError(t, err)
+
+	expected := map[string]interface{}{"id": 1, "ssn": "bcd", "post.title": "hello"}
+	assert.Equal(t, expected, got)
+}
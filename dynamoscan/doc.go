@@ -0,0 +1,40 @@
+// Package dynamoscan allows scanning DynamoDB items into Go structs and other composite types,
+// when working with github.com/aws/aws-sdk-go-v2/service/dynamodb.
+/*
+Essentially, dynamoscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+It treats a list of items, e.g. a page of query or scan output, as rows with a column per attribute,
+so the same structs with "db" tags can be used with relational databases and DynamoDB.
+It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+dynamoscan is a separate module, so applications that don't use DynamoDB don't depend on the AWS SDK.
+
+Querying items
+
+SelectQuery and SelectScan run a query or a scan, go through all pages of the output
+and scan the items into a slice. GetItem gets a single item, or returns an error that NotFound reports:
+
+	type User struct {
+		ID      string `db:"pk"`
+		Name    string
+		Address *Address // M, decoded with the "db" tags of Address
+	}
+
+	var users []*User
+	err := dynamoscan.SelectQuery(ctx, client, &users, &dynamodb.QueryInput{
+		TableName:                 aws.String("users"),
+		KeyConditionExpression:    aws.String("pk = :pk"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":pk": &types.AttributeValueMemberS{Value: id}},
+	})
+
+Items that are already fetched are scanned with ScanAll and ScanOne.
+
+Items and columns
+
+Columns are the names of attributes of all items, attributes that an item doesn't have
+set their fields to zero values, like NULL values. Unknown columns are allowed by default,
+see NewDBScanAPI, since items often have attributes that aren't mapped, e.g. sort keys.
+Attribute values are converted into the fields with the attributevalue decoder,
+that reads "db" struct tags of nested structs by default, see WithDecoderOptions.
+*/
+package dynamoscan
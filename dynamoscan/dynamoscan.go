@@ -0,0 +1,320 @@
+package dynamoscan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Item is a DynamoDB item, that dynamoscan scans like a row with a column per attribute.
+type Item = map[string]types.AttributeValue
+
+// ItemGetter is something that dynamoscan can get a single item from.
+// For example, it can be: *dynamodb.Client.
+type ItemGetter interface {
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (
+		*dynamodb.GetItemOutput, error,
+	)
+}
+
+var (
+	_ ItemGetter              = &dynamodb.Client{}
+	_ dynamodb.QueryAPIClient = &dynamodb.Client{}
+	_ dynamodb.ScanAPIClient  = &dynamodb.Client{}
+)
+
+var _ dbscan.Rows = &RowsAdapter{}
+
+// SelectQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectQuery for details.
+func SelectQuery(
+	ctx context.Context, client dynamodb.QueryAPIClient, dst interface{}, input *dynamodb.QueryInput,
+) error {
+	return DefaultAPI.SelectQuery(ctx, client, dst, input)
+}
+
+// SelectScan is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectScan for details.
+func SelectScan(
+	ctx context.Context, client dynamodb.ScanAPIClient, dst interface{}, input *dynamodb.ScanInput,
+) error {
+	return DefaultAPI.SelectScan(ctx, client, dst, input)
+}
+
+// GetItem is a package-level helper function that uses the DefaultAPI object.
+// See API.GetItem for details.
+func GetItem(ctx context.Context, client ItemGetter, dst interface{}, input *dynamodb.GetItemInput) error {
+	return DefaultAPI.GetItem(ctx, client, dst, input)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(dst interface{}, items []Item) error {
+	return DefaultAPI.ScanAll(dst, items)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(dst interface{}, items []Item) error {
+	return DefaultAPI.ScanOne(dst, items)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for dynamoscan.
+// Unlike other scany packages, unknown columns are allowed by default, since items of the same table
+// often have different attributes, e.g. keys and type markers of a single-table design.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	opts = append([]dbscan.APIOption{dbscan.WithAllowUnknownColumns(true)}, opts...)
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI  *dbscan.API
+	decoderOpt func(*attributevalue.DecoderOptions)
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// WithDecoderOptions sets the function that changes options of the attributevalue decoder
+// that converts attribute values into destinations. By default, the decoder reads the "db" struct tags
+// of nested structs, along with the "dynamodbav" ones, so the same structs work with relational databases.
+func WithDecoderOptions(fn func(*attributevalue.DecoderOptions)) APIOption {
+	return func(api *API) {
+		api.decoderOpt = fn
+	}
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// Create dbscanAPI with NewDBScanAPI, so attributes without struct fields are ignored.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{
+		dbscanAPI: dbscanAPI,
+		decoderOpt: func(o *attributevalue.DecoderOptions) {
+			o.TagKey = "db"
+		},
+	}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// SelectQuery is a high-level function that queries items with all pages of input from DynamoDB,
+// and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) SelectQuery(
+	ctx context.Context, client dynamodb.QueryAPIClient, dst interface{}, input *dynamodb.QueryInput,
+) error {
+	var items []Item
+	paginator := dynamodb.NewQueryPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("scany: query items: %w", err)
+		}
+		items = append(items, page.Items...)
+	}
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, api.NewRowsAdapter(items)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// SelectScan is a high-level function that scans a table or an index with all pages of input from DynamoDB,
+// and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) SelectScan(
+	ctx context.Context, client dynamodb.ScanAPIClient, dst interface{}, input *dynamodb.ScanInput,
+) error {
+	var items []Item
+	paginator := dynamodb.NewScanPaginator(client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("scany: scan items: %w", err)
+		}
+		items = append(items, page.Items...)
+	}
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, api.NewRowsAdapter(items)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// GetItem is a high-level function that gets an item from DynamoDB and calls the ScanOne function.
+// If the item doesn't exist, it returns an error that NotFound reports.
+// See ScanOne for details.
+func (api *API) GetItem(ctx context.Context, client ItemGetter, dst interface{}, input *dynamodb.GetItemInput) error {
+	output, err := client.GetItem(ctx, input)
+	if err != nil {
+		return fmt.Errorf("scany: get item: %w", err)
+	}
+	var items []Item
+	if output.Item != nil {
+		items = []Item{output.Item}
+	}
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, api.NewRowsAdapter(items)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// Items are usually the Items of a DynamoDB query or scan output.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(dst interface{}, items []Item) error {
+	return api.dbscanAPI.ScanAll(dst, api.NewRowsAdapter(items))
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(dst interface{}, items []Item) error {
+	return api.dbscanAPI.ScanOne(dst, api.NewRowsAdapter(items))
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// RowsAdapter makes a list of items compliant with the dbscan.Rows interface.
+// Columns are the names of all attributes of the items in sorted order,
+// attributes that an item doesn't have are scanned as NULL values, that set destinations to zero values.
+// Attribute values are converted into destinations with the attributevalue decoder.
+// See dbscan.Rows for details.
+type RowsAdapter struct {
+	api     *API
+	items   []Item
+	columns []string
+	current Item
+	closed  bool
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(items []Item) *RowsAdapter {
+	return DefaultAPI.NewRowsAdapter(items)
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance that decodes values as configured in the API.
+func (api *API) NewRowsAdapter(items []Item) *RowsAdapter {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, item := range items {
+		for name := range item {
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return &RowsAdapter{api: api, items: items, columns: columns}
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *RowsAdapter) Next() bool {
+	if ra.closed || len(ra.items) == 0 {
+		ra.current = nil
+		return false
+	}
+	ra.current, ra.items = ra.items[0], ra.items[1:]
+	return true
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	return ra.columns, nil
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if ra.current == nil {
+		return errors.New("scany: scan called without calling Next")
+	}
+	if len(dest) != len(ra.columns) {
+		return fmt.Errorf("scany: expected %d destination arguments in Scan, not %d", len(ra.columns), len(dest))
+	}
+	for i, d := range dest {
+		value, ok := ra.current[ra.columns[i]]
+		if !ok {
+			if err := setZero(d); err != nil {
+				return fmt.Errorf("scany: attribute '%s': %w", ra.columns[i], err)
+			}
+			continue
+		}
+		if err := ra.decode(value, d); err != nil {
+			return fmt.Errorf("scany: decode attribute '%s': %w", ra.columns[i], err)
+		}
+	}
+	return nil
+}
+
+// decode decodes the attribute value into dst, sql.Scanner destinations, e.g. the ones dbscan uses
+// to skip unknown columns, scan the value that the decoder produces for interface{}, e.g. a string or a float64.
+func (ra *RowsAdapter) decode(value types.AttributeValue, dst interface{}) error {
+	scanner, ok := dst.(sql.Scanner)
+	if !ok {
+		return attributevalue.UnmarshalWithOptions(value, dst, ra.api.decoderOpt)
+	}
+	var src interface{}
+	if err := attributevalue.UnmarshalWithOptions(value, &src, ra.api.decoderOpt); err != nil {
+		return err
+	}
+	return scanner.Scan(src)
+}
+
+// Err implements the dbscan.Rows.Err method, items are in memory, so it always returns nil.
+func (ra *RowsAdapter) Err() error {
+	return nil
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (ra *RowsAdapter) Close() error {
+	ra.closed = true
+	return nil
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+func setZero(dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got: %T", dst)
+	}
+	dstValue.Elem().Set(reflect.Zero(dstValue.Elem().Type()))
+	return nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
@@ -0,0 +1,137 @@
+package dynamoscan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dynamoscan"
+)
+
+var ctx = context.Background()
+
+// fakeClient returns pages of items for queries and scans, and the first item for GetItem.
+type fakeClient struct {
+	pages [][]dynamoscan.Item
+}
+
+func (c *fakeClient) Query(
+	_ context.Context, input *dynamodb.QueryInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.QueryOutput, error) {
+	items, lastKey := c.page(input.ExclusiveStartKey)
+	return &dynamodb.QueryOutput{Items: items, LastEvaluatedKey: lastKey}, nil
+}
+
+func (c *fakeClient) Scan(
+	_ context.Context, input *dynamodb.ScanInput, _ ...func(*dynamodb.Options),
+) (*dynamodb.ScanOutput, error) {
+	items, lastKey := c.page(input.ExclusiveStartKey)
+	return &dynamodb.ScanOutput{Items: items, LastEvaluatedKey: lastKey}, nil
+}
+
+func (c *fakeClient) GetItem(
+	context.Context, *dynamodb.GetItemInput, ...func(*dynamodb.Options),
+) (*dynamodb.GetItemOutput, error) {
+	if len(c.pages) == 0 || len(c.pages[0]) == 0 {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	return &dynamodb.GetItemOutput{Item: c.pages[0][0]}, nil
+}
+
+// page returns the page that startKey points to, keys are page numbers.
+func (c *fakeClient) page(startKey dynamoscan.Item) ([]dynamoscan.Item, dynamoscan.Item) {
+	i := 0
+	if n, ok := startKey["page"].(*types.AttributeValueMemberN); ok {
+		i = int(n.Value[0] - '0')
+	}
+	if i+1 == len(c.pages) {
+		return c.pages[i], nil
+	}
+	return c.pages[i], dynamoscan.Item{"page": &types.AttributeValueMemberN{Value: string(rune('0' + i + 1))}}
+}
+
+type address struct {
+	City string `db:"city"`
+}
+
+type user struct {
+	ID      string   `db:"pk"`
+	Name    string   `db:"name"`
+	Age     int      `db:"age"`
+	Tags    []string `db:"tags"`
+	Address *address `db:"address"`
+}
+
+func s(v string) types.AttributeValue { return &types.AttributeValueMemberS{Value: v} }
+
+func TestSelectQuery_allPages(t *testing.T) {
+	t.Parallel()
+	client := &fakeClient{pages: [][]dynamoscan.Item{
+		{{
+			"pk": s("user#1"), "sk": s("profile"), "name": s("foo"), "age": &types.AttributeValueMemberN{Value: "30"},
+			"tags":    &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+			"address": &types.AttributeValueMemberM{Value: dynamoscan.Item{"city": s("Paris")}},
+		}},
+		{{"pk": s("user#2"), "name": s("bar"), "age": &types.AttributeValueMemberNULL{Value: true}}},
+	}}
+	expected := []*user{
+		{ID: "user#1", Name: "foo", Age: 30, Tags: []string{"a", "b"}, Address: &address{City: "Paris"}},
+		{ID: "user#2", Name: "bar"},
+	}
+
+	var got []*user
+	err := dynamoscan.SelectQuery(ctx, client, &got, &dynamodb.QueryInput{TableName: aws.String("users")})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectScan_mapDestination(t *testing.T) {
+	t.Parallel()
+	client := &fakeClient{pages: [][]dynamoscan.Item{
+		{{"pk": s("user#1"), "age": &types.AttributeValueMemberN{Value: "30"}}},
+	}}
+	expected := []map[string]interface{}{{"pk": "user#1", "age": float64(30)}}
+
+	var got []map[string]interface{}
+	err := dynamoscan.SelectScan(ctx, client, &got, &dynamodb.ScanInput{TableName: aws.String("users")})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetItem(t *testing.T) {
+	t.Parallel()
+	client := &fakeClient{pages: [][]dynamoscan.Item{{{"pk": s("user#1"), "name": s("foo")}}}}
+
+	var got user
+	err := dynamoscan.GetItem(ctx, client, &got, &dynamodb.GetItemInput{TableName: aws.String("users")})
+	require.NoError(t, err)
+
+	assert.Equal(t, user{ID: "user#1", Name: "foo"}, got)
+}
+
+func TestGetItem_missingItem_returnsNotFound(t *testing.T) {
+	t.Parallel()
+	client := &fakeClient{}
+
+	var got user
+	err := dynamoscan.GetItem(ctx, client, &got, &dynamodb.GetItemInput{TableName: aws.String("users")})
+
+	assert.True(t, dynamoscan.NotFound(err))
+}
+
+func TestScanAll_notDecodable_returnsErr(t *testing.T) {
+	t.Parallel()
+	items := []dynamoscan.Item{{"age": s("thirty")}}
+
+	var got []user
+	err := dynamoscan.ScanAll(&got, items)
+
+	assert.ErrorContains(t, err, "scany: decode attribute 'age'")
+}
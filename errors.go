@@ -0,0 +1,13 @@
+package scany
+
+import "github.com/georgysavva/scany/v2/dbscan"
+
+// ErrNotFound is the not found error that all scany packages report, when a single row is requested,
+// but there were no rows. Errors returned by sqlscan and pgxscan match it via errors.Is,
+// as well as the library-specific sql.ErrNoRows and pgx.ErrNoRows:
+//
+//	err := sqlscan.Get(ctx, db, &user, `SELECT * FROM users WHERE id = $1`, id)
+//	if errors.Is(err, scany.ErrNotFound) {
+//		// The same check works for pgxscan.Get.
+//	}
+var ErrNotFound = dbscan.ErrNotFound
@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/cockroachdb/cockroach-go/v2 v2.2.0
 	github.com/jackc/pgx/v5 v5.0.0
+	github.com/jmoiron/sqlx v1.3.5
 	github.com/microsoft/go-mssqldb v1.6.0
 	github.com/stretchr/testify v1.8.4
 )
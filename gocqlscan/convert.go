@@ -0,0 +1,209 @@
+package gocqlscan
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/gocql/gocql"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	unmarshalerType    = reflect.TypeOf((*gocql.Unmarshaler)(nil)).Elem()
+	udtUnmarshalerType = reflect.TypeOf((*gocql.UDTUnmarshaler)(nil)).Elem()
+	errNotConvertible  = errors.New("value isn't convertible")
+)
+
+// convertTarget is the destination of a column along with the value of the type that gocql uses for the column,
+// that it's scanned into first. Tuple columns are scanned into elems, a value per tuple element.
+type convertTarget struct {
+	index int
+	dst   reflect.Value
+	src   reflect.Value
+	elems []interface{}
+}
+
+func newConvertTarget(index int, dst interface{}, typ gocql.TypeInfo) (convertTarget, error) {
+	target := convertTarget{index: index, dst: reflect.ValueOf(dst)}
+	if _, ok := typ.(gocql.TupleTypeInfo); ok {
+		return target, nil
+	}
+	src, err := typ.NewWithError()
+	if err != nil {
+		return target, err
+	}
+	target.src = reflect.ValueOf(src)
+	return target, nil
+}
+
+func (t convertTarget) convert(dbscanAPI *dbscan.API) error {
+	if t.dst.Kind() != reflect.Ptr || t.dst.IsNil() {
+		return nil
+	}
+	src := t.src
+	if t.elems != nil {
+		values := make([]interface{}, len(t.elems))
+		for i, elem := range t.elems {
+			values[i] = reflect.ValueOf(elem).Elem().Interface()
+		}
+		src = reflect.ValueOf(values)
+	}
+	return convert(dbscanAPI, t.dst.Elem(), src)
+}
+
+// needsConversion reports whether the value of a column must be converted into the destination,
+// since the column type contains user-defined types or tuples, that must be mapped to struct fields like columns.
+func needsConversion(dst interface{}, typ gocql.TypeInfo) bool {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Type().Elem().Kind() == reflect.Interface {
+		return false
+	}
+	if dstValue.Type().Implements(unmarshalerType) || dstValue.Type().Implements(udtUnmarshalerType) {
+		return false
+	}
+	return hasStructType(typ)
+}
+
+func hasStructType(typ gocql.TypeInfo) bool {
+	switch t := typ.(type) {
+	case gocql.UDTTypeInfo, gocql.TupleTypeInfo:
+		return true
+	case gocql.CollectionType:
+		return (t.Key != nil && hasStructType(t.Key)) || (t.Elem != nil && hasStructType(t.Elem))
+	default:
+		return false
+	}
+}
+
+// convert sets dst to the src value: lists and sets are converted into slices, maps into maps,
+// user-defined types, that gocql scans into map[string]interface{}, and tuples, that it scans into []interface{},
+// into structs, and numbers and strings into other types of the same kind.
+func convert(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	for src.Kind() == reflect.Interface || src.Kind() == reflect.Ptr {
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		src = src.Elem()
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	if (src.Kind() == reflect.Map || src.Kind() == reflect.Slice) && src.IsNil() {
+		// gocql scans null values of collections and user-defined types into nil maps and slices.
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := convert(dbscanAPI, elem.Elem(), src); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			break
+		}
+		slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := convert(dbscanAPI, slice.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			break
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := convert(dbscanAPI, key, iter.Key()); err != nil {
+				return fmt.Errorf("key %v: %w", iter.Key(), err)
+			}
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := convert(dbscanAPI, value, iter.Value()); err != nil {
+				return fmt.Errorf("value of key %v: %w", iter.Key(), err)
+			}
+			m.SetMapIndex(key, value)
+		}
+		dst.Set(m)
+		return nil
+	case reflect.Struct:
+		switch src.Kind() {
+		case reflect.Slice:
+			return convertStructByPosition(dbscanAPI, dst, src)
+		case reflect.Map:
+			if src.Type().Key().Kind() == reflect.String {
+				return convertStructByName(dbscanAPI, dst, src)
+			}
+		}
+	default:
+		if isConvertibleKind(src.Kind(), dst.Kind()) {
+			dst.Set(src.Convert(dst.Type()))
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s into %s", errNotConvertible, src.Type(), dst.Type())
+}
+
+// convertStructByPosition sets elements of a tuple to exported struct fields in the order they are declared,
+// except fields ignored via `db:"-"`.
+func convertStructByPosition(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	dst.Set(reflect.Zero(dst.Type()))
+	elemIndex := 0
+	for i := 0; i < dst.NumField() && elemIndex < src.Len(); i++ {
+		field := dst.Type().Field(i)
+		if field.PkgPath != "" || field.Tag.Get("db") == "-" {
+			continue
+		}
+		if err := convert(dbscanAPI, dst.Field(i), src.Index(elemIndex)); err != nil {
+			return fmt.Errorf("tuple element %d: %w", elemIndex, err)
+		}
+		elemIndex++
+	}
+	return nil
+}
+
+// convertStructByName sets fields of a user-defined type to struct fields the same way dbscan maps columns to them.
+// Fields without corresponding struct fields are skipped.
+func convertStructByName(dbscanAPI *dbscan.API, dst, src reflect.Value) error {
+	dst.Set(reflect.Zero(dst.Type()))
+	iter := src.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		index, ok := dbscanAPI.FieldIndex(dst.Type(), name)
+		if !ok {
+			continue
+		}
+		if err := convert(dbscanAPI, dbscan.FieldByIndex(dst, index), iter.Value()); err != nil {
+			return fmt.Errorf("field '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+func isConvertibleKind(src, dst reflect.Kind) bool {
+	if src == reflect.String || dst == reflect.String {
+		return src == dst
+	}
+	return isNumberKind(src) && isNumberKind(dst)
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+// Package gocqlscan allows scanning data into Go structs and other composite types,
+// when working with Cassandra or ScyllaDB via gocql library.
+/*
+Essentially, gocqlscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+gocqlscan connects github.com/gocql/gocql with dbscan functionality.
+It contains adapters that are meant to work with *gocql.Iter and proxy all calls to dbscan.
+gocqlscan provides all capabilities available in dbscan.
+It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+Querying rows
+
+gocqlscan can query rows and work with *gocql.Session directly.
+To support this it has two high-level functions Select and Get,
+they accept anything that implements Querier interface and query rows from it.
+To scan rows of a query built by hand, e.g. with a page size or a consistency level, pass its iterator to ScanAll:
+
+	iter := session.Query(`SELECT * FROM users WHERE team = ?`, team).Consistency(gocql.One).PageSize(100).Iter()
+	err := gocqlscan.ScanAll(&users, iter)
+
+Collections and user-defined types
+
+Columns of list, set and map types are scanned into slices and maps by gocql itself.
+gocql maps fields of user-defined types to struct fields by `cql` tags,
+gocqlscan maps them the same way dbscan maps columns, so `db` tags and the naming of columns apply to them too:
+
+	type Address struct {
+		Street  string
+		ZipCode string `db:"zip"`
+	}
+
+	type User struct {
+		ID        gocql.UUID
+		Address   *Address            // frozen<address>
+		Addresses map[string]Address  // map<text, frozen<address>>
+		Phones    []string            // set<text>
+	}
+
+User-defined types are converted into structs wherever they appear, including elements of collections,
+numbers and strings are converted between types of the same kind, e.g. int into int64.
+Tuples are converted into structs by position, or into []interface{}.
+Destinations that implement gocql.Unmarshaler or gocql.UDTUnmarshaler are scanned by gocql as is.
+
+gocqlscan is a separate module, so the gocql dependency doesn't affect users of the other scany packages.
+*/
+package gocqlscan
@@ -0,0 +1,285 @@
+package gocqlscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gocql/gocql"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Querier is something that gocqlscan can query and get the *gocql.Query from.
+// For example, it can be: *gocql.Session.
+type Querier interface {
+	Query(stmt string, values ...interface{}) *gocql.Query
+}
+
+// Iter is an iterator over query results that gocqlscan scans rows from.
+// For example, it can be: *gocql.Iter.
+type Iter interface {
+	Columns() []gocql.ColumnInfo
+	Scanner() gocql.Scanner
+	Close() error
+}
+
+var (
+	_ Querier = &gocql.Session{}
+	_ Iter    = &gocql.Iter{}
+)
+
+var (
+	_ dbscan.Rows            = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows = &RowsAdapter{}
+)
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(dst interface{}, iter Iter) error {
+	return DefaultAPI.ScanAll(dst, iter)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(dst interface{}, iter Iter) error {
+	return DefaultAPI.ScanOne(dst, iter)
+}
+
+// RowScanner is a wrapper around the dbscan.RowScanner type.
+// See dbscan.RowScanner for details.
+type RowScanner struct {
+	*dbscan.RowScanner
+}
+
+// NewRowScanner is a package-level helper function that uses the DefaultAPI object.
+// See API.NewRowScanner for details.
+func NewRowScanner(rows *RowsAdapter) *RowScanner {
+	return DefaultAPI.NewRowScanner(rows)
+}
+
+// ScanRow is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanRow for details.
+func ScanRow(dst interface{}, rows *RowsAdapter) error {
+	return DefaultAPI.ScanRow(dst, rows)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for gocqlscan.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI *dbscan.API
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// Select is a high-level function that queries rows from Querier and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	iter := db.Query(query, args...).WithContext(ctx).Iter()
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, api.NewRowsAdapter(iter)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// Get is a high-level function that queries rows from Querier and calls the ScanOne function.
+// See ScanOne for details.
+func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	iter := db.Query(query, args...).WithContext(ctx).Iter()
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, api.NewRowsAdapter(iter)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(dst interface{}, iter Iter) error {
+	return api.dbscanAPI.ScanAll(dst, api.NewRowsAdapter(iter))
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(dst interface{}, iter Iter) error {
+	return api.dbscanAPI.ScanOne(dst, api.NewRowsAdapter(iter))
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// NewRowScanner returns a new RowScanner instance.
+// Unlike other scany packages, it accepts RowsAdapter, since the iterator can't be scanned without it,
+// and the adapter must be used in place of the iterator to iterate over rows.
+func (api *API) NewRowScanner(rows *RowsAdapter) *RowScanner {
+	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(rows)}
+}
+
+// ScanRow is a wrapper around the dbscan.ScanRow function.
+// See dbscan.ScanRow for details.
+func (api *API) ScanRow(dst interface{}, rows *RowsAdapter) error {
+	return api.dbscanAPI.ScanRow(dst, rows)
+}
+
+// RowsAdapter makes Iter compliant with the dbscan.Rows interface, it iterates over rows with the gocql.Scanner
+// of the iterator, and converts values of user-defined types and tuples into the types of destinations,
+// see the package docs. See dbscan.Rows for details.
+type RowsAdapter struct {
+	api     *API
+	iter    Iter
+	scanner gocql.Scanner
+	columns []gocql.ColumnInfo
+	err     error
+	closed  bool
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+// The iterator must not be used after that, use the adapter instead.
+func NewRowsAdapter(iter Iter) *RowsAdapter {
+	return DefaultAPI.NewRowsAdapter(iter)
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance that converts values as configured in the API.
+// The iterator must not be used after that, use the adapter instead.
+func (api *API) NewRowsAdapter(iter Iter) *RowsAdapter {
+	return &RowsAdapter{api: api, iter: iter, scanner: iter.Scanner(), columns: iter.Columns()}
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *RowsAdapter) Next() bool {
+	if ra.closed {
+		return false
+	}
+	return ra.scanner.Next()
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	columns := make([]string, len(ra.columns))
+	for i, c := range ra.columns {
+		columns[i] = c.Name
+	}
+	return columns, nil
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+func (ra *RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	dbTypes := make([]string, len(ra.columns))
+	for i, c := range ra.columns {
+		dbTypes[i] = fmt.Sprint(c.TypeInfo)
+	}
+	return dbTypes, nil
+}
+
+// Err implements the dbscan.Rows.Err method. It releases the iterator, like gocql.Scanner.Err does,
+// so the first call returns the error of the query, if any, and next calls return the same error.
+func (ra *RowsAdapter) Err() error {
+	if !ra.closed {
+		ra.closed = true
+		ra.err = ra.scanner.Err()
+	}
+	return ra.err
+}
+
+// Close implements the dbscan.Rows.Close method, it's the same as Err.
+func (ra *RowsAdapter) Close() error {
+	return ra.Err()
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+// Scan implements the dbscan.Rows.Scan method. Values of columns that contain user-defined types or tuples
+// are scanned into the types that gocql uses for them and then converted into the destinations,
+// other values are scanned by gocql as is.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if len(dest) != len(ra.columns) {
+		return ra.scanner.Scan(dest...)
+	}
+	scanDest := make([]interface{}, 0, len(dest))
+	var targets []convertTarget
+	for i, d := range dest {
+		column := ra.columns[i]
+		tuple, isTuple := column.TypeInfo.(gocql.TupleTypeInfo)
+		if !isTuple && !needsConversion(d, column.TypeInfo) {
+			scanDest = append(scanDest, d)
+			continue
+		}
+		target, err := newConvertTarget(i, d, column.TypeInfo)
+		if err != nil {
+			return fmt.Errorf("scany: column '%s' of type %s: %w", column.Name, column.TypeInfo, err)
+		}
+		if isTuple {
+			// gocql scans tuple columns into a destination per tuple element, like separate columns.
+			target.elems = make([]interface{}, len(tuple.Elems))
+			for j, elemType := range tuple.Elems {
+				if target.elems[j], err = elemType.NewWithError(); err != nil {
+					return fmt.Errorf("scany: column '%s' of type %s: %w", column.Name, column.TypeInfo, err)
+				}
+			}
+			scanDest = append(scanDest, target.elems...)
+		} else {
+			scanDest = append(scanDest, target.src.Interface())
+		}
+		targets = append(targets, target)
+	}
+	if err := ra.scanner.Scan(scanDest...); err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if err := target.convert(ra.api.dbscanAPI); err != nil {
+			column := ra.columns[target.index]
+			return fmt.Errorf("scany: convert column '%s' of type %s: %w", column.Name, column.TypeInfo, err)
+		}
+	}
+	return nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
+	api, err := NewDBScanAPI(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
@@ -0,0 +1,193 @@
+package gocqlscan_test
+
+import (
+	"testing"
+
+	"github.com/gocql/gocql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/gocqlscan"
+)
+
+const protoVersion = 4
+
+// fakeIter returns rows of values, its scanner marshals and unmarshals them with gocql,
+// so destinations get the same values as with a real database.
+type fakeIter struct {
+	columns []gocql.ColumnInfo
+	rows    [][]interface{}
+	err     error
+}
+
+func (it *fakeIter) Columns() []gocql.ColumnInfo { return it.columns }
+func (it *fakeIter) Scanner() gocql.Scanner      { return &fakeScanner{iter: it} }
+func (it *fakeIter) Close() error                { return it.err }
+
+type fakeScanner struct {
+	iter *fakeIter
+	pos  int
+}
+
+func (s *fakeScanner) Next() bool {
+	if s.pos >= len(s.iter.rows) || s.iter.err != nil {
+		return false
+	}
+	s.pos++
+	return true
+}
+
+func (s *fakeScanner) Scan(dest ...interface{}) error {
+	i := 0
+	for j, column := range s.iter.columns {
+		var data []byte
+		var err error
+		if value := s.iter.rows[s.pos-1][j]; value != nil {
+			// nil values are null, like in the database.
+			if data, err = gocql.Marshal(column.TypeInfo, value); err != nil {
+				return err
+			}
+		}
+		if tuple, ok := column.TypeInfo.(gocql.TupleTypeInfo); ok {
+			err = gocql.Unmarshal(tuple, data, dest[i:i+len(tuple.Elems)])
+			i += len(tuple.Elems)
+		} else {
+			err = gocql.Unmarshal(column.TypeInfo, data, dest[i])
+			i++
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeScanner) Err() error { return s.iter.Close() }
+
+func nativeType(typ gocql.Type) gocql.NativeType {
+	return gocql.NewNativeType(protoVersion, typ, "")
+}
+
+func collectionType(typ gocql.Type, key, elem gocql.TypeInfo) gocql.TypeInfo {
+	return gocql.CollectionType{NativeType: nativeType(typ), Key: key, Elem: elem}
+}
+
+var addressType = gocql.UDTTypeInfo{
+	NativeType: nativeType(gocql.TypeUDT),
+	Name:       "address",
+	Elements: []gocql.UDTField{
+		{Name: "street", Type: nativeType(gocql.TypeText)},
+		{Name: "zip", Type: nativeType(gocql.TypeText)},
+	},
+}
+
+type address struct {
+	Street  string
+	ZipCode string `db:"zip"`
+}
+
+func TestScanOne_collectionsAndUDTs(t *testing.T) {
+	t.Parallel()
+	iter := &fakeIter{
+		columns: []gocql.ColumnInfo{
+			{Name: "name", TypeInfo: nativeType(gocql.TypeText)},
+			{Name: "home", TypeInfo: addressType},
+			{Name: "work", TypeInfo: addressType},
+			{Name: "addresses", TypeInfo: collectionType(gocql.TypeMap, nativeType(gocql.TypeText), addressType)},
+			{Name: "history", TypeInfo: collectionType(gocql.TypeList, nil, addressType)},
+			{Name: "phones", TypeInfo: collectionType(gocql.TypeSet, nil, nativeType(gocql.TypeText))},
+			{Name: "scores", TypeInfo: collectionType(gocql.TypeMap, nativeType(gocql.TypeText), nativeType(gocql.TypeInt))},
+		},
+		rows: [][]interface{}{{
+			"bob",
+			map[string]interface{}{"street": "Main St", "zip": "10001"},
+			nil,
+			map[string]map[string]interface{}{"office": {"street": "2nd Ave", "zip": "10002"}},
+			[]map[string]interface{}{{"street": "Old St", "zip": "10003"}},
+			[]string{"123", "456"},
+			map[string]int{"math": 5},
+		}},
+	}
+	type user struct {
+		Name      string
+		Home      address
+		Work      *address
+		Addresses map[string]*address
+		History   []address
+		Phones    []string
+		Scores    map[string]int64
+	}
+	expected := user{
+		Name:      "bob",
+		Home:      address{Street: "Main St", ZipCode: "10001"},
+		Addresses: map[string]*address{"office": {Street: "2nd Ave", ZipCode: "10002"}},
+		History:   []address{{Street: "Old St", ZipCode: "10003"}},
+		Phones:    []string{"123", "456"},
+		Scores:    map[string]int64{"math": 5},
+	}
+
+	var got user
+	err := gocqlscan.ScanOne(&got, iter)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAll_tupleColumn(t *testing.T) {
+	t.Parallel()
+	pointType := gocql.TupleTypeInfo{
+		NativeType: nativeType(gocql.TypeTuple),
+		Elems:      []gocql.TypeInfo{nativeType(gocql.TypeDouble), nativeType(gocql.TypeDouble)},
+	}
+	iter := &fakeIter{
+		columns: []gocql.ColumnInfo{
+			{Name: "id", TypeInfo: nativeType(gocql.TypeInt)},
+			{Name: "point", TypeInfo: pointType},
+		},
+		rows: [][]interface{}{
+			{1, []interface{}{1.5, 2.5}},
+			{2, []interface{}{3.5, 4.5}},
+		},
+	}
+	type point struct {
+		X, Y float64
+	}
+	type row struct {
+		ID    int
+		Point point
+	}
+	expected := []row{{ID: 1, Point: point{X: 1.5, Y: 2.5}}, {ID: 2, Point: point{X: 3.5, Y: 4.5}}}
+
+	var got []row
+	err := gocqlscan.ScanAll(&got, iter)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanAll_iterErr_returnsErr(t *testing.T) {
+	t.Parallel()
+	iter := &fakeIter{
+		columns: []gocql.ColumnInfo{{Name: "id", TypeInfo: nativeType(gocql.TypeInt)}},
+		err:     gocql.ErrNotFound,
+	}
+
+	var got []struct{ ID int }
+	err := gocqlscan.ScanAll(&got, iter)
+
+	assert.ErrorIs(t, err, gocql.ErrNotFound)
+}
+
+func TestScanOne_notConvertible_returnsErr(t *testing.T) {
+	t.Parallel()
+	iter := &fakeIter{
+		columns: []gocql.ColumnInfo{{Name: "home", TypeInfo: addressType}},
+		rows:    [][]interface{}{{map[string]interface{}{"street": "Main St", "zip": "10001"}}},
+	}
+
+	var got struct{ Home struct{ Zip int } }
+	err := gocqlscan.ScanOne(&got, iter)
+
+	assert.ErrorContains(t, err, "scany: convert column 'home' of type ")
+	assert.ErrorContains(t, err, "field 'zip': value isn't convertible: string into int")
+}
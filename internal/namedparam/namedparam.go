@@ -0,0 +1,151 @@
+// Package namedparam parses named parameters of queries, e.g. `WHERE id = :id` or `WHERE id = @id`,
+// for the BindNamed functions of sqlscan and pgxscan.
+package namedparam
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Lookup returns a function that looks up values of parameters in arg by name.
+// arg is either a map with string keys or a struct, in which case parameters are named after columns,
+// with the same values that dbscan.API.NamedArgs returns, e.g. `post.id` for a nested struct.
+func Lookup(
+	ctx context.Context, dbscanAPI *dbscan.API, arg interface{},
+) (func(name string) (interface{}, bool), error) {
+	argValue := reflect.ValueOf(arg)
+	for argValue.Kind() == reflect.Ptr && !argValue.IsNil() {
+		argValue = argValue.Elem()
+	}
+	switch {
+	case argValue.Kind() == reflect.Map && argValue.Type().Key().Kind() == reflect.String:
+		return func(name string) (interface{}, bool) {
+			value := argValue.MapIndex(reflect.ValueOf(name).Convert(argValue.Type().Key()))
+			if !value.IsValid() {
+				return nil, false
+			}
+			return value.Interface(), true
+		}, nil
+	case argValue.Kind() == reflect.Struct:
+		namedArgs, err := dbscanAPI.NamedArgsContext(ctx, arg)
+		if err != nil {
+			return nil, fmt.Errorf("scany: get named args: %w", err)
+		}
+		return func(name string) (interface{}, bool) {
+			value, ok := namedArgs[name]
+			return value, ok
+		}, nil
+	default:
+		return nil, fmt.Errorf("scany: named args must be a struct or a map with string keys, got: %T", arg)
+	}
+}
+
+// Rewrite replaces parameters that start with prefix in the query with placeholders that placeholder returns
+// for their names, and stops at the first error that it returns.
+// Parameters inside string literals, quoted identifiers and comments are left as is, see SkipQuoted,
+// so are doubled prefixes, e.g. PostgreSQL casts like `::TEXT` or SQL Server system functions like `@@ROWCOUNT`.
+func Rewrite(query string, prefix byte, placeholder func(name string) (string, error)) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(query); {
+		if end := SkipQuoted(query, i); end > i {
+			result.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		if query[i] != prefix {
+			result.WriteByte(query[i])
+			i++
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == prefix {
+			result.WriteString(query[i : i+2])
+			i += 2
+			continue
+		}
+		end := i + 1
+		for end < len(query) && IsNameChar(query[end], end == i+1) {
+			end++
+		}
+		name := strings.TrimRight(query[i+1:end], ".")
+		if name == "" {
+			result.WriteByte(prefix)
+			i++
+			continue
+		}
+		p, err := placeholder(name)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(p)
+		i += 1 + len(name)
+	}
+	return result.String(), nil
+}
+
+// IsNameChar reports whether c can be a part of a parameter name, first is true for the first character.
+func IsNameChar(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		return true
+	case c >= '0' && c <= '9', c == '.':
+		return !first
+	default:
+		return false
+	}
+}
+
+// SkipQuoted returns the end of a string literal, including PostgreSQL escape and dollar-quoted strings,
+// a quoted identifier or a comment that starts at i, or i itself if there is none.
+func SkipQuoted(query string, i int) int {
+	switch {
+	case query[i] == '\'' || query[i] == '"' || query[i] == '`':
+		end := strings.IndexByte(query[i+1:], query[i])
+		if end < 0 {
+			return len(query)
+		}
+		return i + 1 + end + 1
+	case (query[i] == 'E' || query[i] == 'e') && strings.HasPrefix(query[i+1:], "'") &&
+		(i == 0 || !IsNameChar(query[i-1], false)):
+		for j := i + 2; j < len(query); j++ {
+			switch query[j] {
+			case '\\':
+				j++
+			case '\'':
+				return j + 1
+			}
+		}
+		return len(query)
+	case query[i] == '$' && (i == 0 || !IsNameChar(query[i-1], false)):
+		tagEnd := i + 1
+		for tagEnd < len(query) && query[tagEnd] != '.' && IsNameChar(query[tagEnd], tagEnd == i+1) {
+			tagEnd++
+		}
+		if tagEnd >= len(query) || query[tagEnd] != '$' {
+			return i
+		}
+		tag := query[i : tagEnd+1]
+		end := strings.Index(query[tagEnd+1:], tag)
+		if end < 0 {
+			return len(query)
+		}
+		return tagEnd + 1 + end + len(tag)
+	case strings.HasPrefix(query[i:], "--"):
+		end := strings.IndexByte(query[i:], '\n')
+		if end < 0 {
+			return len(query)
+		}
+		return i + end
+	case strings.HasPrefix(query[i:], "/*"):
+		end := strings.Index(query[i+2:], "*/")
+		if end < 0 {
+			return len(query)
+		}
+		return i + 2 + end + 2
+	default:
+		return i
+	}
+}
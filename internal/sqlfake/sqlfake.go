@@ -0,0 +1,186 @@
+// Package sqlfake provides a fake database/sql driver for unit tests of the sqlscan adapters,
+// that returns the same result sets for any query and records queries and their arguments.
+package sqlfake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+var (
+	_ driver.Connector                      = &Connector{}
+	_ driver.RowsNextResultSet              = &rows{}
+	_ driver.RowsColumnTypeDatabaseTypeName = &rows{}
+	_ driver.RowsColumnTypePrecisionScale   = &rows{}
+)
+
+// Column is a column of a result set and the type that the driver reports for it.
+type Column struct {
+	Name string
+	// Type is the database type name of the column, e.g. "NVARCHAR", empty if the driver reports none.
+	Type string
+	// Precision and Scale are the decimal size of the column, the driver reports it if either is set.
+	Precision, Scale int64
+}
+
+// Columns returns columns with the names and without types.
+func Columns(names ...string) []Column {
+	columns := make([]Column, len(names))
+	for i, name := range names {
+		columns[i] = Column{Name: name}
+	}
+	return columns
+}
+
+// ResultSet is a result set that the driver returns for queries.
+type ResultSet struct {
+	Columns []Column
+	Rows    [][]driver.Value
+}
+
+// Connector is a database/sql connector that returns Sets for any query and LastInsertID for any statement.
+// The first Failures queries and statements fail with Err, e.g. to test retries. Transactions do nothing.
+// Connector records queries and statements and their arguments, it's safe for concurrent use.
+type Connector struct {
+	Sets         []ResultSet
+	LastInsertID int64
+	Failures     int
+	Err          error
+
+	mu      sync.Mutex
+	queries []string
+	args    [][]interface{}
+}
+
+// NewDB opens *sql.DB with the connector and closes it when the test finishes.
+func NewDB(t testing.TB, c *Connector) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(c)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// Queries returns queries and statements that the connector received, in order.
+func (c *Connector) Queries() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.queries...)
+}
+
+// Args returns arguments of queries and statements that the connector received, in order.
+func (c *Connector) Args() [][]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([][]interface{}(nil), c.args...)
+}
+
+// Connect implements the driver.Connector.Connect method.
+func (c *Connector) Connect(context.Context) (driver.Conn, error) { return &conn{c: c}, nil }
+
+// Driver implements the driver.Connector.Driver method.
+func (c *Connector) Driver() driver.Driver { return fakeDriver{} }
+
+// record records the query and returns Err if the query is one of the first Failures ones.
+func (c *Connector) record(query string, args []driver.NamedValue) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	c.queries = append(c.queries, query)
+	c.args = append(c.args, values)
+	if len(c.queries) <= c.Failures {
+		return c.Err
+	}
+	return nil
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("not supported") }
+
+type conn struct {
+	c *Connector
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *conn) Close() error                        { return nil }
+func (c *conn) Begin() (driver.Tx, error)           { return tx{}, nil }
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.c.record(query, args); err != nil {
+		return nil, err
+	}
+	sets := make([]ResultSet, len(c.c.Sets))
+	for i, set := range c.c.Sets {
+		sets[i] = ResultSet{Columns: set.Columns, Rows: append([][]driver.Value(nil), set.Rows...)}
+	}
+	if len(sets) == 0 {
+		sets = []ResultSet{{}}
+	}
+	return &rows{sets: sets}, nil
+}
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.c.record(query, args); err != nil {
+		return nil, err
+	}
+	return result{lastInsertID: c.c.LastInsertID}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error   { return nil }
+func (tx) Rollback() error { return nil }
+
+type rows struct {
+	sets []ResultSet
+}
+
+func (r *rows) Columns() []string {
+	columns := make([]string, len(r.sets[0].Columns))
+	for i, c := range r.sets[0].Columns {
+		columns[i] = c.Name
+	}
+	return columns
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(i int) string { return r.sets[0].Columns[i].Type }
+
+func (r *rows) ColumnTypePrecisionScale(i int) (int64, int64, bool) {
+	c := r.sets[0].Columns[i]
+	return c.Precision, c.Scale, c.Precision != 0 || c.Scale != 0
+}
+
+func (r *rows) Close() error           { return nil }
+func (r *rows) HasNextResultSet() bool { return len(r.sets) > 1 }
+
+func (r *rows) NextResultSet() error {
+	if len(r.sets) <= 1 {
+		return io.EOF
+	}
+	r.sets = r.sets[1:]
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if len(r.sets[0].Rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.sets[0].Rows[0])
+	r.sets[0].Rows = r.sets[0].Rows[1:]
+	return nil
+}
+
+type result struct {
+	lastInsertID int64
+}
+
+func (r result) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r result) RowsAffected() (int64, error) { return 1, nil }
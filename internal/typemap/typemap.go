@@ -0,0 +1,120 @@
+// Package typemap maps columns to fields of struct types of go/types by the default dbscan rules,
+// for tools that work with source code rather than reflection, like scany-gen and scanyvet.
+package typemap
+
+import (
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+const (
+	tagKey           = "db"
+	columnSeparator  = "."
+	rowHashTagOption = "rowhash"
+)
+
+// Column is a column and the path to its struct field, from the outermost struct to the field itself.
+type Column struct {
+	Name string
+	Path []*types.Var
+}
+
+// Columns returns columns of the struct type in the order dbscan maps them, following the default dbscan rules:
+// the "db" struct tag key, SnakeCaseMapper, the "." column separator and unlimited struct depth.
+// It mirrors the breadth-first traversal of dbscan: the first field mapped to the column wins.
+func Columns(structType types.Type) []Column {
+	type traversal struct {
+		typ          *types.Struct
+		path         []*types.Var
+		columnPrefix string
+		ancestors    []types.Type
+	}
+	var result []Column
+	seen := map[string]bool{}
+	queue := []traversal{{typ: structType.Underlying().(*types.Struct), ancestors: []types.Type{structType}}}
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		for i := 0; i < t.typ.NumFields(); i++ {
+			field := t.typ.Field(i)
+			if !field.Exported() && !field.Embedded() {
+				continue
+			}
+			ptr, isPtr := field.Type().Underlying().(*types.Pointer)
+			if !field.Exported() && field.Embedded() && isPtr {
+				// dbscan can't initialize a nil pointer to an unexported embedded struct.
+				continue
+			}
+			tag, tagPresent := reflect.StructTag(t.typ.Tag(i)).Lookup(tagKey)
+			var tagOptions []string
+			if tagPresent {
+				parts := strings.Split(tag, ",")
+				tag, tagOptions = parts[0], parts[1:]
+			}
+			if tag == "-" || hasOption(tagOptions, rowHashTagOption) {
+				continue
+			}
+			path := append(append([]*types.Var(nil), t.path...), field)
+			columnPart := tag
+			if !tagPresent {
+				columnPart = dbscan.SnakeCaseMapper(field.Name())
+			}
+			if !field.Embedded() {
+				column := buildColumn(t.columnPrefix, columnPart)
+				if !seen[column] {
+					seen[column] = true
+					result = append(result, Column{Name: column, Path: path})
+				}
+			}
+			childType := field.Type()
+			if isPtr {
+				childType = ptr.Elem()
+			}
+			childStruct, ok := childType.Underlying().(*types.Struct)
+			if !ok || containsType(t.ancestors, childType) {
+				continue
+			}
+			if field.Embedded() {
+				columnPart = tag
+			}
+			queue = append(queue, traversal{
+				typ:          childStruct,
+				path:         path,
+				columnPrefix: buildColumn(t.columnPrefix, columnPart),
+				ancestors:    append(append([]types.Type(nil), t.ancestors...), childType),
+			})
+		}
+	}
+	return result
+}
+
+func buildColumn(prefix, part string) string {
+	if prefix == "" {
+		return part
+	}
+	if part == "" {
+		return prefix
+	}
+	return prefix + columnSeparator + part
+}
+
+func hasOption(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func containsType(list []types.Type, t types.Type) bool {
+	for _, other := range list {
+		if types.Identical(other, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+package libsqlscan
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Statement is an SQL statement with positional arguments, e.g. `SELECT * FROM users WHERE id = ?`.
+type Statement struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Result is the result of a statement in the Hrana format of libSQL.
+type Result struct {
+	Cols            []Col     `json:"cols"`
+	Rows            [][]Value `json:"rows"`
+	AffectedRows    int64     `json:"affected_row_count"`
+	LastInsertRowID *string   `json:"last_insert_rowid"`
+}
+
+// Col is a column of Result.
+type Col struct {
+	Name string `json:"name"`
+	// DeclType is the declared type of the column, e.g. "INTEGER", it's empty for expressions.
+	DeclType string `json:"decltype"`
+}
+
+// Value is a value of Result in the Hrana format, e.g. `{"type": "integer", "value": "42"}`.
+type Value struct {
+	Type   string          `json:"type"`
+	Value  json.RawMessage `json:"value,omitempty"`
+	Base64 string          `json:"base64,omitempty"`
+}
+
+// Client runs statements on a libSQL server, e.g. Turso, with the Hrana over HTTP protocol.
+type Client struct {
+	// URL is the URL of the database, e.g. "https://foo-bar.turso.io", libsql:// URLs are sent with HTTPS.
+	URL string
+	// AuthToken is sent as a bearer token if it isn't empty.
+	AuthToken string
+	// HTTPClient sends requests, http.DefaultClient is used if it's nil.
+	HTTPClient *http.Client
+}
+
+var (
+	_ Querier = &Client{}
+	_ Batcher = &Client{}
+)
+
+// NewClient returns a new Client instance for the database URL and the auth token.
+func NewClient(url, authToken string) *Client {
+	return &Client{URL: url, AuthToken: authToken}
+}
+
+// Execute implements the Querier.Execute method, it runs the statement in a pipeline of its own.
+func (c *Client) Execute(ctx context.Context, query string, args ...interface{}) (*Result, error) {
+	stmt, err := encodeStatement(Statement{SQL: query, Args: args})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.pipeline(ctx, pipelineRequest{Type: "execute", Stmt: stmt})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+// Batch implements the Batcher.Batch method, it runs the statements in a batch of a single pipeline,
+// so they all run on the same connection. A statement runs only if the previous one succeeded.
+func (c *Client) Batch(ctx context.Context, stmts ...Statement) ([]*Result, error) {
+	steps := make([]batchStep, len(stmts))
+	for i, s := range stmts {
+		stmt, err := encodeStatement(s)
+		if err != nil {
+			return nil, fmt.Errorf("scany: statement %d: %w", i, err)
+		}
+		steps[i].Stmt = stmt
+		if i > 0 {
+			steps[i].Condition = &batchCondition{Type: "ok", Step: i - 1}
+		}
+	}
+	resp, err := c.pipeline(ctx, pipelineRequest{Type: "batch", Batch: &batch{Steps: steps}})
+	if err != nil {
+		return nil, err
+	}
+	var batchResult struct {
+		StepResults []*Result      `json:"step_results"`
+		StepErrors  []*streamError `json:"step_errors"`
+	}
+	if err := json.Unmarshal(resp.RawResult, &batchResult); err != nil {
+		return nil, fmt.Errorf("scany: decode batch result: %w", err)
+	}
+	for i, stepErr := range batchResult.StepErrors {
+		if stepErr != nil {
+			return nil, fmt.Errorf("scany: statement %d: %w", i, stepErr)
+		}
+	}
+	if len(batchResult.StepResults) != len(stmts) {
+		return nil, fmt.Errorf("scany: expected %d statement results, got: %d", len(stmts), len(batchResult.StepResults))
+	}
+	for i, result := range batchResult.StepResults {
+		if result == nil {
+			return nil, fmt.Errorf("scany: statement %d didn't run", i)
+		}
+	}
+	return batchResult.StepResults, nil
+}
+
+type pipelineRequest struct {
+	Type  string `json:"type"`
+	Stmt  *stmt  `json:"stmt,omitempty"`
+	Batch *batch `json:"batch,omitempty"`
+}
+
+type stmt struct {
+	SQL  string  `json:"sql"`
+	Args []Value `json:"args"`
+}
+
+type batch struct {
+	Steps []batchStep `json:"steps"`
+}
+
+type batchStep struct {
+	Stmt      *stmt           `json:"stmt"`
+	Condition *batchCondition `json:"condition,omitempty"`
+}
+
+type batchCondition struct {
+	Type string `json:"type"`
+	Step int    `json:"step"`
+}
+
+type streamResult struct {
+	Type     string `json:"type"`
+	Response struct {
+		Type      string          `json:"type"`
+		RawResult json.RawMessage `json:"result"`
+	} `json:"response"`
+	Error *streamError `json:"error"`
+}
+
+// streamError is an error of a Hrana request, e.g. a syntax error of the statement.
+type streamError struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (e *streamError) Error() string {
+	if e.Code == "" {
+		return "libsql: " + e.Message
+	}
+	return "libsql: " + e.Message + " (" + e.Code + ")"
+}
+
+// pipeline sends the request followed by a close request to the /v2/pipeline endpoint,
+// and returns the response of the request.
+func (c *Client) pipeline(ctx context.Context, request pipelineRequest) (*pipelineResponse, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"requests": []interface{}{request, pipelineRequest{Type: "close"}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scany: encode request: %w", err)
+	}
+	baseURL := strings.TrimRight(c.URL, "/")
+	if strings.HasPrefix(baseURL, "libsql://") {
+		baseURL = "https://" + strings.TrimPrefix(baseURL, "libsql://")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/v2/pipeline", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("scany: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AuthToken)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scany: send request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("scany: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scany: libsql: unexpected status %s: %s", resp.Status, bytes.TrimSpace(respBody))
+	}
+	var pipelineResp struct {
+		Results []streamResult `json:"results"`
+	}
+	if err := json.Unmarshal(respBody, &pipelineResp); err != nil {
+		return nil, fmt.Errorf("scany: decode response: %w", err)
+	}
+	if len(pipelineResp.Results) == 0 {
+		return nil, errors.New("scany: libsql: empty pipeline response")
+	}
+	result := pipelineResp.Results[0]
+	if result.Type == "error" && result.Error != nil {
+		return nil, fmt.Errorf("scany: %w", result.Error)
+	}
+	if result.Type != "ok" {
+		return nil, fmt.Errorf("scany: libsql: unexpected result type %q", result.Type)
+	}
+	out := &pipelineResponse{RawResult: result.Response.RawResult}
+	if request.Type == "execute" {
+		out.Result = &Result{}
+		if err := json.Unmarshal(result.Response.RawResult, out.Result); err != nil {
+			return nil, fmt.Errorf("scany: decode result: %w", err)
+		}
+	}
+	return out, nil
+}
+
+type pipelineResponse struct {
+	RawResult json.RawMessage
+	Result    *Result
+}
+
+func encodeStatement(s Statement) (*stmt, error) {
+	args := make([]Value, len(s.Args))
+	for i, arg := range s.Args {
+		value, err := encodeValue(arg)
+		if err != nil {
+			return nil, fmt.Errorf("scany: argument %d: %w", i+1, err)
+		}
+		args[i] = value
+	}
+	return &stmt{SQL: s.SQL, Args: args}, nil
+}
+
+// encodeValue converts the argument into a Hrana value, driver.Valuer arguments are converted first,
+// and time.Time arguments are sent as RFC 3339 text, the way SQLite drivers store them.
+func encodeValue(arg interface{}) (Value, error) {
+	if valuer, ok := arg.(driver.Valuer); ok {
+		var err error
+		if arg, err = valuer.Value(); err != nil {
+			return Value{}, fmt.Errorf("get argument value: %w", err)
+		}
+	}
+	switch v := arg.(type) {
+	case nil:
+		return Value{Type: "null"}, nil
+	case bool:
+		if v {
+			return integerValue(1), nil
+		}
+		return integerValue(0), nil
+	case int:
+		return integerValue(int64(v)), nil
+	case int8:
+		return integerValue(int64(v)), nil
+	case int16:
+		return integerValue(int64(v)), nil
+	case int32:
+		return integerValue(int64(v)), nil
+	case int64:
+		return integerValue(v), nil
+	case uint8:
+		return integerValue(int64(v)), nil
+	case uint16:
+		return integerValue(int64(v)), nil
+	case uint32:
+		return integerValue(int64(v)), nil
+	case float32:
+		return floatValue(float64(v)), nil
+	case float64:
+		return floatValue(v), nil
+	case string:
+		return textValue(v), nil
+	case []byte:
+		return Value{Type: "blob", Base64: base64.StdEncoding.EncodeToString(v)}, nil
+	case time.Time:
+		return textValue(v.Format(time.RFC3339Nano)), nil
+	default:
+		return Value{}, fmt.Errorf("unsupported argument type %T", arg)
+	}
+}
+
+func integerValue(v int64) Value {
+	return Value{Type: "integer", Value: json.RawMessage(strconv.Quote(strconv.FormatInt(v, 10)))}
+}
+
+func floatValue(v float64) Value {
+	return Value{Type: "float", Value: json.RawMessage(strconv.FormatFloat(v, 'g', -1, 64))}
+}
+
+func textValue(v string) Value {
+	text, _ := json.Marshal(v)
+	return Value{Type: "text", Value: text}
+}
@@ -0,0 +1,38 @@
+// Package libsqlscan allows scanning data into Go structs and other composite types,
+// when working with libSQL servers, e.g. Turso, via the Hrana over HTTP protocol.
+/*
+Essentially, libsqlscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+It executes statements with the /v2/pipeline endpoint of the libSQL server, that returns results
+in the Hrana format, and scans them with dbscan.
+It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+libsqlscan needs no driver, for libSQL database/sql drivers, e.g. github.com/tursodatabase/libsql-client-go,
+use sqlitescan instead.
+
+Executing statements
+
+Client executes statements, Select and Get scan their results,
+arguments are positional, e.g. `WHERE id = ?`:
+
+	type User struct {
+		ID        int64
+		Name      string
+		CreatedAt time.Time
+	}
+
+	db := libsqlscan.NewClient("libsql://foo-bar.turso.io", authToken)
+	var users []*User
+	err := libsqlscan.Select(ctx, db, &users, `SELECT * FROM users WHERE team_id = ?`, teamID)
+
+SelectBatch executes statements in a batch, each one only if the previous one succeeded,
+and scans their results into destinations of the same index, nil ones skip results.
+Results that are already fetched are scanned with ScanAll and ScanOne.
+
+Values
+
+Values are converted into the types of destinations the way database/sql does it for SQLite drivers.
+Text values are scanned into time.Time fields with TimeLayouts, and integer values as seconds since the Unix epoch.
+interface{} destinations, e.g. values of map[string]interface{}, get int64, float64, string, []byte or nil values.
+*/
+package libsqlscan
@@ -0,0 +1,435 @@
+package libsqlscan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Querier is something that libsqlscan can execute statements with and get results from.
+// For example, it can be: *Client.
+type Querier interface {
+	Execute(ctx context.Context, query string, args ...interface{}) (*Result, error)
+}
+
+// Batcher is something that libsqlscan can execute batches of statements with.
+// For example, it can be: *Client.
+type Batcher interface {
+	Batch(ctx context.Context, stmts ...Statement) ([]*Result, error)
+}
+
+var (
+	_ dbscan.Rows            = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows = &RowsAdapter{}
+)
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectBatch is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectBatch for details.
+func SelectBatch(ctx context.Context, db Batcher, dsts []interface{}, stmts ...Statement) error {
+	return DefaultAPI.SelectBatch(ctx, db, dsts, stmts...)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(dst interface{}, result *Result) error {
+	return DefaultAPI.ScanAll(dst, result)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(dst interface{}, result *Result) error {
+	return DefaultAPI.ScanOne(dst, result)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for libsqlscan.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI *dbscan.API
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// Select is a high-level function that executes the statement with Querier and calls the ScanAll function.
+// Arguments are positional, e.g. `WHERE id = ?`.
+// See ScanAll for details.
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	result, err := db.Execute(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("scany: execute statement: %w", err)
+	}
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, NewRowsAdapter(result)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// Get is a high-level function that executes the statement with Querier and calls the ScanOne function.
+// See ScanOne for details.
+func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	result, err := db.Execute(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("scany: execute statement: %w", err)
+	}
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, NewRowsAdapter(result)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// SelectBatch executes the statements in a batch with Batcher and scans the result of every statement
+// into the destination at the same index with the ScanAll function, so dsts must have a destination
+// per statement. A nil destination skips the result, e.g. of an INSERT statement:
+//
+//	var users []*User
+//	err := libsqlscan.SelectBatch(ctx, db, []interface{}{nil, &users},
+//		libsqlscan.Statement{SQL: `INSERT INTO users (name) VALUES (?)`, Args: []interface{}{name}},
+//		libsqlscan.Statement{SQL: `SELECT * FROM users`},
+//	)
+func (api *API) SelectBatch(ctx context.Context, db Batcher, dsts []interface{}, stmts ...Statement) error {
+	if len(dsts) != len(stmts) {
+		return fmt.Errorf("scany: expected %d destinations, got: %d", len(stmts), len(dsts))
+	}
+	results, err := db.Batch(ctx, stmts...)
+	if err != nil {
+		return fmt.Errorf("scany: execute batch: %w", err)
+	}
+	for i, dst := range dsts {
+		if dst == nil {
+			continue
+		}
+		if err := api.dbscanAPI.ScanAllContext(ctx, dst, NewRowsAdapter(results[i])); err != nil {
+			return fmt.Errorf("scanning all of statement %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(dst interface{}, result *Result) error {
+	return api.dbscanAPI.ScanAll(dst, NewRowsAdapter(result))
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(dst interface{}, result *Result) error {
+	return api.dbscanAPI.ScanOne(dst, NewRowsAdapter(result))
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// RowsAdapter makes Result compliant with the dbscan.Rows interface.
+// Hrana values are converted into int64, float64, string, []byte or nil, and assigned to destinations
+// the way database/sql does it for SQLite drivers: numbers are converted into other number types and strings,
+// text values into time.Time with TimeLayouts, and sql.Scanner destinations get the converted value.
+// See dbscan.Rows for details.
+type RowsAdapter struct {
+	result  *Result
+	current []Value
+	next    int
+	closed  bool
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(result *Result) *RowsAdapter {
+	return &RowsAdapter{result: result}
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *RowsAdapter) Next() bool {
+	if ra.closed || ra.next >= len(ra.result.Rows) {
+		ra.current = nil
+		return false
+	}
+	ra.current = ra.result.Rows[ra.next]
+	ra.next++
+	return true
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	columns := make([]string, len(ra.result.Cols))
+	for i, c := range ra.result.Cols {
+		columns[i] = c.Name
+	}
+	return columns, nil
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+func (ra *RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	dbTypes := make([]string, len(ra.result.Cols))
+	for i, c := range ra.result.Cols {
+		dbTypes[i] = c.DeclType
+	}
+	return dbTypes, nil
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if ra.current == nil {
+		return errors.New("scany: scan called without calling Next")
+	}
+	if len(dest) != len(ra.result.Cols) || len(ra.current) != len(ra.result.Cols) {
+		return fmt.Errorf("scany: expected %d destination arguments in Scan, not %d", len(ra.result.Cols), len(dest))
+	}
+	for i, d := range dest {
+		value, err := decodeValue(ra.current[i])
+		if err != nil {
+			return fmt.Errorf("scany: column '%s': %w", ra.result.Cols[i].Name, err)
+		}
+		if err := assign(d, value); err != nil {
+			return fmt.Errorf("scany: column '%s': %w", ra.result.Cols[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// Err implements the dbscan.Rows.Err method, the result is in memory, so it always returns nil.
+func (ra *RowsAdapter) Err() error {
+	return nil
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (ra *RowsAdapter) Close() error {
+	ra.closed = true
+	return nil
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+// TimeLayouts are layouts of text values that are scanned into time destinations, see time.Parse.
+// They are the formats that SQLite drivers write and SQLite date functions return.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// decodeValue converts the Hrana value into int64, float64, string, []byte or nil.
+func decodeValue(v Value) (interface{}, error) {
+	switch v.Type {
+	case "null":
+		return nil, nil
+	case "integer":
+		var s string
+		if err := json.Unmarshal(v.Value, &s); err != nil {
+			return nil, fmt.Errorf("decode integer value: %w", err)
+		}
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("decode integer value: %w", err)
+		}
+		return i, nil
+	case "float":
+		var f float64
+		if err := json.Unmarshal(v.Value, &f); err != nil {
+			return nil, fmt.Errorf("decode float value: %w", err)
+		}
+		return f, nil
+	case "text":
+		var s string
+		if err := json.Unmarshal(v.Value, &s); err != nil {
+			return nil, fmt.Errorf("decode text value: %w", err)
+		}
+		return s, nil
+	case "blob":
+		b, err := base64.StdEncoding.DecodeString(v.Base64)
+		if err != nil {
+			return nil, fmt.Errorf("decode blob value: %w", err)
+		}
+		return b, nil
+	default:
+		return nil, fmt.Errorf("unknown value type %q", v.Type)
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// assign assigns the decoded value to the destination.
+func assign(dst, value interface{}) error {
+	switch d := dst.(type) {
+	case *interface{}:
+		*d = value
+		return nil
+	case *sql.NullTime:
+		if value == nil {
+			*d = sql.NullTime{}
+			return nil
+		}
+		t, err := toTime(value)
+		if err != nil {
+			return err
+		}
+		*d = sql.NullTime{Time: t, Valid: true}
+		return nil
+	case sql.Scanner:
+		return d.Scan(value)
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got: %T", dst)
+	}
+	return assignValue(dstValue.Elem(), value)
+}
+
+func assignValue(dst reflect.Value, value interface{}) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+	if dst.Type() == timeType {
+		t, err := toTime(value)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch v := value.(type) {
+	case int64:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(v) {
+				return fmt.Errorf("value %d overflows %s", v, dst.Type())
+			}
+			dst.SetInt(v)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v < 0 || dst.OverflowUint(uint64(v)) {
+				return fmt.Errorf("value %d overflows %s", v, dst.Type())
+			}
+			dst.SetUint(uint64(v))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(v))
+			return nil
+		case reflect.Bool:
+			dst.SetBool(v != 0)
+			return nil
+		case reflect.String:
+			dst.SetString(strconv.FormatInt(v, 10))
+			return nil
+		}
+	case float64:
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(v)
+			return nil
+		case reflect.String:
+			dst.SetString(strconv.FormatFloat(v, 'g', -1, 64))
+			return nil
+		}
+	case string:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(v)
+			return nil
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes([]byte(v))
+			return nil
+		}
+	case []byte:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(string(v))
+			return nil
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("can't scan %T into %s", value, dst.Type())
+}
+
+// toTime converts text values with TimeLayouts and integer values as seconds since the Unix epoch.
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case int64:
+		return time.Unix(v, 0).UTC(), nil
+	case string:
+		var firstErr error
+		for _, layout := range TimeLayouts {
+			t, err := time.Parse(layout, v)
+			if err == nil {
+				return t, nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return time.Time{}, fmt.Errorf("parse time value: %w", firstErr)
+	default:
+		return time.Time{}, fmt.Errorf("can't scan %T into a time value", value)
+	}
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
@@ -0,0 +1,185 @@
+package libsqlscan_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/libsqlscan"
+)
+
+var ctx = context.Background()
+
+// fakeServer responds to /v2/pipeline requests with the result of the first request and records request bodies.
+type fakeServer struct {
+	result string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func newFakeClient(t *testing.T, fs *fakeServer) *libsqlscan.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/pipeline" || r.Header.Get("Authorization") != "Bearer foo-token" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fs.mu.Lock()
+		fs.requests = append(fs.requests, string(body))
+		fs.mu.Unlock()
+		_, _ = w.Write([]byte(`{"baton": null, "base_url": null, "results": [` + fs.result +
+			`, {"type": "ok", "response": {"type": "close"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+	return libsqlscan.NewClient(server.URL, "foo-token")
+}
+
+const usersResult = `{"type": "ok", "response": {"type": "execute", "result": {
+	"cols": [
+		{"name": "id", "decltype": "INTEGER"},
+		{"name": "name", "decltype": "TEXT"},
+		{"name": "score", "decltype": "REAL"},
+		{"name": "avatar", "decltype": "BLOB"},
+		{"name": "created_at", "decltype": "DATETIME"},
+		{"name": "deleted_at", "decltype": "DATETIME"},
+		{"name": "active", "decltype": "BOOLEAN"}
+	],
+	"rows": [[
+		{"type": "integer", "value": "1"},
+		{"type": "text", "value": "foo"},
+		{"type": "float", "value": 1.5},
+		{"type": "blob", "base64": "AQI="},
+		{"type": "text", "value": "2024-01-02 03:04:05"},
+		{"type": "null"},
+		{"type": "integer", "value": "1"}
+	]],
+	"affected_row_count": 0,
+	"last_insert_rowid": null
+}}}`
+
+type user struct {
+	ID        int64
+	Name      string
+	Score     float64
+	Avatar    []byte
+	CreatedAt time.Time
+	DeletedAt *time.Time
+	Active    bool
+}
+
+func TestSelect_positionalArgs(t *testing.T) {
+	t.Parallel()
+	fs := &fakeServer{result: usersResult}
+	db := newFakeClient(t, fs)
+	expected := []user{{
+		ID:        1,
+		Name:      "foo",
+		Score:     1.5,
+		Avatar:    []byte{1, 2},
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Active:    true,
+	}}
+
+	var got []user
+	err := libsqlscan.Select(ctx, db, &got, `SELECT * FROM users WHERE id = ? AND name = ?`, 1, "foo")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	require.Len(t, fs.requests, 1)
+	assert.JSONEq(t, `{"requests": [
+		{"type": "execute", "stmt": {"sql": "SELECT * FROM users WHERE id = ? AND name = ?", "args": [
+			{"type": "integer", "value": "1"},
+			{"type": "text", "value": "foo"}
+		]}},
+		{"type": "close"}
+	]}`, fs.requests[0])
+}
+
+func TestGet_mapDestination(t *testing.T) {
+	t.Parallel()
+	db := newFakeClient(t, &fakeServer{result: usersResult})
+	expected := map[string]interface{}{
+		"id":         int64(1),
+		"name":       "foo",
+		"score":      1.5,
+		"avatar":     []byte{1, 2},
+		"created_at": "2024-01-02 03:04:05",
+		"deleted_at": nil,
+		"active":     int64(1),
+	}
+
+	var got map[string]interface{}
+	err := libsqlscan.Get(ctx, db, &got, `SELECT * FROM users WHERE id = ?`, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectBatch(t *testing.T) {
+	t.Parallel()
+	fs := &fakeServer{result: `{"type": "ok", "response": {"type": "batch", "result": {
+		"step_results": [
+			{"cols": [], "rows": [], "affected_row_count": 1, "last_insert_rowid": "2"},
+			{"cols": [{"name": "name", "decltype": "TEXT"}], "rows": [[{"type": "text", "value": "foo"}]]}
+		],
+		"step_errors": [null, null]
+	}}}`}
+	db := newFakeClient(t, fs)
+
+	var names []string
+	err := libsqlscan.SelectBatch(ctx, db, []interface{}{nil, &names},
+		libsqlscan.Statement{SQL: `INSERT INTO users (name) VALUES (?)`, Args: []interface{}{"foo"}},
+		libsqlscan.Statement{SQL: `SELECT name FROM users`},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"foo"}, names)
+	var request struct {
+		Requests []struct {
+			Batch struct {
+				Steps []json.RawMessage `json:"steps"`
+			} `json:"batch"`
+		} `json:"requests"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(fs.requests[0]), &request))
+	assert.JSONEq(t, `{"stmt": {"sql": "SELECT name FROM users", "args": []}, "condition": {"type": "ok", "step": 0}}`,
+		string(request.Requests[0].Batch.Steps[1]))
+}
+
+func TestSelectBatch_stepError_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := newFakeClient(t, &fakeServer{result: `{"type": "ok", "response": {"type": "batch", "result": {
+		"step_results": [null, null],
+		"step_errors": [{"message": "no such table: users", "code": "SQLITE_ERROR"}, null]
+	}}}`})
+
+	var names []string
+	err := libsqlscan.SelectBatch(ctx, db, []interface{}{nil, &names},
+		libsqlscan.Statement{SQL: `INSERT INTO users (name) VALUES (?)`, Args: []interface{}{"foo"}},
+		libsqlscan.Statement{SQL: `SELECT name FROM users`},
+	)
+
+	assert.ErrorContains(t, err, "scany: statement 0: libsql: no such table: users (SQLITE_ERROR)")
+}
+
+func TestSelect_streamError_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := newFakeClient(t, &fakeServer{
+		result: `{"type": "error", "error": {"message": "near \"SELEC\": syntax error", "code": "SQLITE_ERROR"}}`,
+	})
+
+	var got []user
+	err := libsqlscan.Select(ctx, db, &got, `SELEC * FROM users`)
+
+	assert.ErrorContains(t, err, `libsql: near "SELEC": syntax error (SQLITE_ERROR)`)
+}
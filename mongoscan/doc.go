@@ -0,0 +1,38 @@
+// Package mongoscan allows scanning MongoDB documents into Go structs and other composite types,
+// when working with go.mongodb.org/mongo-driver/v2.
+/*
+Essentially, mongoscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+It treats documents of a cursor as rows with a column per top-level field,
+so the same structs with "db" tags can be used with relational databases and MongoDB.
+It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+mongoscan is a separate module, so applications that don't use MongoDB don't depend on the driver.
+
+Querying documents
+
+Select and Get find documents in a collection, SelectAggregate runs an aggregation pipeline,
+and they all scan the documents of the cursor:
+
+	type User struct {
+		ID        string `db:"user_id"`
+		Name      string
+		CreatedAt time.Time
+		Address   *Address // embedded document, decoded with bson tags of Address
+	}
+
+	var users []*User
+	err := mongoscan.Select(ctx, db.Collection("users"), &users, bson.D{{Key: "team_id", Value: teamID}})
+
+A *mongo.Cursor that is already open is scanned with ScanAll and ScanOne,
+that accept the context to fetch next batches of the cursor.
+
+Documents and columns
+
+Columns are the top-level fields of the first document, so documents of one query should have the same fields,
+e.g. filter them with a projection. Fields that a document doesn't have and null values set struct fields
+to zero values. Unknown columns are allowed by default, see NewDBScanAPI, since documents often have fields
+that aren't mapped, e.g. _id. Values are decoded into struct fields with the bson package,
+so embedded documents and arrays are decoded into nested structs, maps and slices.
+*/
+package mongoscan
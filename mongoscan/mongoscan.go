@@ -0,0 +1,315 @@
+package mongoscan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Finder is something that mongoscan can find documents in.
+// For example, it can be: *mongo.Collection.
+type Finder interface {
+	Find(ctx context.Context, filter interface{}, opts ...options.Lister[options.FindOptions]) (*mongo.Cursor, error)
+}
+
+// Aggregator is something that mongoscan can run aggregation pipelines on.
+// For example, it can be: *mongo.Collection.
+type Aggregator interface {
+	Aggregate(
+		ctx context.Context, pipeline interface{}, opts ...options.Lister[options.AggregateOptions],
+	) (*mongo.Cursor, error)
+}
+
+// Cursor is a cursor over documents that mongoscan scans rows from.
+// For example, it can be: *mongo.Cursor.
+type Cursor interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+	Close(ctx context.Context) error
+}
+
+var (
+	_ Finder     = &mongo.Collection{}
+	_ Aggregator = &mongo.Collection{}
+	_ Cursor     = &mongo.Cursor{}
+)
+
+var _ dbscan.Rows = &RowsAdapter{}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(
+	ctx context.Context, coll Finder, dst interface{}, filter interface{}, opts ...options.Lister[options.FindOptions],
+) error {
+	return DefaultAPI.Select(ctx, coll, dst, filter, opts...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(
+	ctx context.Context, coll Finder, dst interface{}, filter interface{}, opts ...options.Lister[options.FindOptions],
+) error {
+	return DefaultAPI.Get(ctx, coll, dst, filter, opts...)
+}
+
+// SelectAggregate is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectAggregate for details.
+func SelectAggregate(
+	ctx context.Context, coll Aggregator, dst interface{}, pipeline interface{},
+	opts ...options.Lister[options.AggregateOptions],
+) error {
+	return DefaultAPI.SelectAggregate(ctx, coll, dst, pipeline, opts...)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(ctx context.Context, dst interface{}, cursor Cursor) error {
+	return DefaultAPI.ScanAll(ctx, dst, cursor)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(ctx context.Context, dst interface{}, cursor Cursor) error {
+	return DefaultAPI.ScanOne(ctx, dst, cursor)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for mongoscan.
+// Unlike other scany packages, unknown columns are allowed by default, since documents often have
+// fields that aren't mapped, e.g. _id.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	opts = append([]dbscan.APIOption{dbscan.WithAllowUnknownColumns(true)}, opts...)
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI *dbscan.API
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// Create dbscanAPI with NewDBScanAPI, so document fields without struct fields are ignored.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// Select is a high-level function that finds documents in the collection and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) Select(
+	ctx context.Context, coll Finder, dst interface{}, filter interface{}, opts ...options.Lister[options.FindOptions],
+) error {
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return fmt.Errorf("scany: find documents: %w", err)
+	}
+	return api.ScanAll(ctx, dst, cursor)
+}
+
+// Get is a high-level function that finds documents in the collection and calls the ScanOne function.
+// See ScanOne for details.
+func (api *API) Get(
+	ctx context.Context, coll Finder, dst interface{}, filter interface{}, opts ...options.Lister[options.FindOptions],
+) error {
+	cursor, err := coll.Find(ctx, filter, opts...)
+	if err != nil {
+		return fmt.Errorf("scany: find documents: %w", err)
+	}
+	return api.ScanOne(ctx, dst, cursor)
+}
+
+// SelectAggregate is a high-level function that runs the aggregation pipeline on the collection
+// and calls the ScanAll function.
+// See ScanAll for details.
+func (api *API) SelectAggregate(
+	ctx context.Context, coll Aggregator, dst interface{}, pipeline interface{},
+	opts ...options.Lister[options.AggregateOptions],
+) error {
+	cursor, err := coll.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return fmt.Errorf("scany: aggregate documents: %w", err)
+	}
+	return api.ScanAll(ctx, dst, cursor)
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// Unlike other scany packages, it accepts the context, since the cursor needs it to fetch batches of documents.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(ctx context.Context, dst interface{}, cursor Cursor) error {
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, api.NewRowsAdapter(ctx, cursor)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// Unlike other scany packages, it accepts the context, since the cursor needs it to fetch batches of documents.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(ctx context.Context, dst interface{}, cursor Cursor) error {
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, api.NewRowsAdapter(ctx, cursor)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// RowsAdapter makes Cursor compliant with the dbscan.Rows interface.
+// Columns are the top-level fields of the first document, in the order of the document,
+// so filter with a projection to get the same fields in all documents.
+// Fields that a next document doesn't have are scanned as NULL values, that set destinations to zero values,
+// and fields that the first document doesn't have are skipped.
+// Field values are decoded into destinations with the bson package, so nested documents follow bson struct tags.
+// See dbscan.Rows for details.
+type RowsAdapter struct {
+	ctx     context.Context
+	cursor  Cursor
+	current bson.Raw
+	columns []string
+	err     error
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(ctx context.Context, cursor Cursor) *RowsAdapter {
+	return DefaultAPI.NewRowsAdapter(ctx, cursor)
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance, the context is used to iterate over the cursor.
+func (api *API) NewRowsAdapter(ctx context.Context, cursor Cursor) *RowsAdapter {
+	return &RowsAdapter{ctx: ctx, cursor: cursor}
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *RowsAdapter) Next() bool {
+	ra.current = nil
+	if ra.err != nil || !ra.cursor.Next(ra.ctx) {
+		return false
+	}
+	var doc bson.Raw
+	if err := ra.cursor.Decode(&doc); err != nil {
+		ra.err = fmt.Errorf("scany: decode document: %w", err)
+		return false
+	}
+	ra.current = doc
+	if ra.columns == nil {
+		elements, err := doc.Elements()
+		if err != nil {
+			ra.err = fmt.Errorf("scany: read document fields: %w", err)
+			return false
+		}
+		ra.columns = make([]string, len(elements))
+		for i, element := range elements {
+			ra.columns[i] = element.Key()
+		}
+	}
+	return true
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+// Columns are known once the first document is read, dbscan calls it after Next.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	return ra.columns, nil
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if ra.current == nil {
+		return errors.New("scany: scan called without calling Next")
+	}
+	if len(dest) != len(ra.columns) {
+		return fmt.Errorf("scany: expected %d destination arguments in Scan, not %d", len(ra.columns), len(dest))
+	}
+	for i, d := range dest {
+		// Lookup returns the zero value if the document doesn't have the field.
+		value := ra.current.Lookup(ra.columns[i])
+		if value.Type == 0 || value.Type == bson.TypeNull {
+			if err := setZero(d); err != nil {
+				return fmt.Errorf("scany: field '%s': %w", ra.columns[i], err)
+			}
+			continue
+		}
+		if err := decode(value, d); err != nil {
+			return fmt.Errorf("scany: decode field '%s': %w", ra.columns[i], err)
+		}
+	}
+	return nil
+}
+
+// Err implements the dbscan.Rows.Err method.
+func (ra *RowsAdapter) Err() error {
+	if ra.err != nil {
+		return ra.err
+	}
+	return ra.cursor.Err()
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (ra *RowsAdapter) Close() error {
+	return ra.cursor.Close(ra.ctx)
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+// decode decodes the field value into dst, sql.Scanner destinations, e.g. the ones dbscan uses
+// to skip unknown columns, scan the value that bson produces for interface{}, e.g. a string or an int32.
+func decode(value bson.RawValue, dst interface{}) error {
+	scanner, ok := dst.(sql.Scanner)
+	if !ok {
+		return value.Unmarshal(dst)
+	}
+	var src interface{}
+	if err := value.Unmarshal(&src); err != nil {
+		return err
+	}
+	return scanner.Scan(src)
+}
+
+func setZero(dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got: %T", dst)
+	}
+	dstValue.Elem().Set(reflect.Zero(dstValue.Elem().Type()))
+	return nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
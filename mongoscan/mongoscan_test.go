@@ -0,0 +1,118 @@
+package mongoscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github.com/georgysavva/scany/v2/mongoscan"
+)
+
+var ctx = context.Background()
+
+// fakeCollection returns a cursor over the documents for any filter or pipeline.
+type fakeCollection struct {
+	docs []interface{}
+	err  error
+}
+
+func (c fakeCollection) Find(
+	context.Context, interface{}, ...options.Lister[options.FindOptions],
+) (*mongo.Cursor, error) {
+	return mongo.NewCursorFromDocuments(c.docs, c.err, nil)
+}
+
+func (c fakeCollection) Aggregate(
+	context.Context, interface{}, ...options.Lister[options.AggregateOptions],
+) (*mongo.Cursor, error) {
+	return mongo.NewCursorFromDocuments(c.docs, c.err, nil)
+}
+
+type address struct {
+	City string `bson:"city"`
+}
+
+type user struct {
+	ID      string `db:"user_id"`
+	Name    string
+	Age     int
+	Tags    []string
+	Address *address
+}
+
+func TestSelect_documents(t *testing.T) {
+	t.Parallel()
+	coll := fakeCollection{docs: []interface{}{
+		bson.D{
+			{Key: "_id", Value: bson.NewObjectID()},
+			{Key: "user_id", Value: "1"},
+			{Key: "name", Value: "foo"},
+			{Key: "age", Value: int32(30)},
+			{Key: "tags", Value: bson.A{"a", "b"}},
+			{Key: "address", Value: bson.D{{Key: "city", Value: "Paris"}}},
+		},
+		bson.D{{Key: "user_id", Value: "2"}, {Key: "name", Value: "bar"}, {Key: "age", Value: nil}},
+	}}
+	expected := []*user{
+		{ID: "1", Name: "foo", Age: 30, Tags: []string{"a", "b"}, Address: &address{City: "Paris"}},
+		{ID: "2", Name: "bar"},
+	}
+
+	var got []*user
+	err := mongoscan.Select(ctx, coll, &got, bson.D{})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectAggregate_mapDestination(t *testing.T) {
+	t.Parallel()
+	coll := fakeCollection{docs: []interface{}{
+		bson.D{{Key: "team", Value: "foo"}, {Key: "total", Value: int64(3)}},
+	}}
+	expected := []map[string]interface{}{{"team": "foo", "total": int64(3)}}
+
+	var got []map[string]interface{}
+	err := mongoscan.SelectAggregate(ctx, coll, &got, mongo.Pipeline{})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGet_noDocuments_returnsNotFound(t *testing.T) {
+	t.Parallel()
+	coll := fakeCollection{}
+
+	var got user
+	err := mongoscan.Get(ctx, coll, &got, bson.D{{Key: "user_id", Value: "1"}})
+
+	assert.True(t, mongoscan.NotFound(err))
+}
+
+func TestSelect_cursorErr_returnsErr(t *testing.T) {
+	t.Parallel()
+	cursorErr := errors.New("cursor failed")
+	coll := fakeCollection{docs: []interface{}{bson.D{{Key: "name", Value: "foo"}}}, err: cursorErr}
+
+	var got []*user
+	err := mongoscan.Select(ctx, coll, &got, bson.D{})
+
+	assert.ErrorIs(t, err, cursorErr)
+}
+
+func TestScanAll_notDecodable_returnsErr(t *testing.T) {
+	t.Parallel()
+	cursor, err := mongo.NewCursorFromDocuments([]interface{}{bson.D{{Key: "age", Value: "thirty"}}}, nil, nil)
+	require.NoError(t, err)
+
+	var got []user
+	err = mongoscan.ScanAll(ctx, &got, cursor)
+
+	assert.ErrorContains(t, err, "scany: decode field 'age'")
+}
@@ -0,0 +1,35 @@
+// Package mssqlscan allows scanning data into Go structs and other composite types,
+// when working with SQL Server via database/sql library.
+/*
+Essentially, mssqlscan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for SQL Server drivers, e.g. github.com/microsoft/go-mssqldb.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+SQL Server specifics
+
+mssqlscan uses the Dialect dialect, so high-level functions behave the way SQL Server expects:
+
+  - Named parameters start with "@", e.g. `WHERE id = @id`, like T-SQL variables,
+    and are rewritten into "@p1" placeholders that go-mssqldb binds positional arguments to.
+    System functions, e.g. `@@ROWCOUNT`, are left as is.
+  - Identifiers are quoted with brackets, e.g. [order], and SelectPage limits rows with OFFSET FETCH.
+  - InsertAll splits rows into statements of at most 1000 rows and 2098 arguments.
+  - UNIQUEIDENTIFIER values, that the driver returns as bytes in mixed-endian order, are reordered,
+    so uuid.UUID and string destinations get the same UUID that SQL Server displays.
+
+For example:
+
+	var users []*User
+	err := mssqlscan.SelectNamed(ctx, db, &users, `SELECT * FROM users WHERE team_id = @team_id`, filter)
+
+SQL Server has no RETURNING clause, statements return rows with the OUTPUT clause instead,
+pass them to ExecReturning:
+
+	var user User
+	err := mssqlscan.ExecReturning(ctx, db, &user,
+		`INSERT INTO users (name) OUTPUT INSERTED.* VALUES (@p1)`, name)
+
+Stored procedures and batches of statements return multiple result sets, scan them with SelectSets.
+*/
+package mssqlscan
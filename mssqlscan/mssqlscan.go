@@ -0,0 +1,109 @@
+package mssqlscan
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Dialect is the dialect that mssqlscan uses, it's sqlscan.DialectMSSQL with named parameters that start with "@",
+// see sqlscan.Dialect.NamedPrefix, and a value converter that reorders bytes of UNIQUEIDENTIFIER values,
+// see sqlscan.Dialect.ValueConverters.
+var Dialect = newDialect()
+
+func newDialect() sqlscan.Dialect {
+	dialect := sqlscan.DialectMSSQL
+	dialect.NamedPrefix = '@'
+	dialect.ValueConverters = []sqlscan.ValueConverter{convertUUID}
+	return dialect
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// SelectSets is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectSets for details.
+func SelectSets(ctx context.Context, db sqlscan.Querier, dsts []interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.SelectSets(ctx, db, dsts, query, args...)
+}
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// API is a wrapper around the sqlscan.API type that uses the SQL Server dialect.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect, options can change it or its parts, e.g. with sqlscan.WithPlaceholderStyle.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{sqlscan.WithDialect(Dialect)}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
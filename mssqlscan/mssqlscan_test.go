@@ -0,0 +1,138 @@
+package mssqlscan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/mssqlscan"
+)
+
+var ctx = context.Background()
+
+// uuid is a UUID type like github.com/google/uuid.UUID, it scans strings and 16 bytes as is.
+type uuid [16]byte
+
+func (u *uuid) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		_, err := hex.Decode(u[:], []byte(strings.ReplaceAll(v, "-", "")))
+		return err
+	case []byte:
+		copy(u[:], v)
+		return nil
+	default:
+		return fmt.Errorf("can't scan %T into uuid", src)
+	}
+}
+
+// mixedEndianID is 6f9619ff-8b86-d011-b42d-00c04fc964ff the way SQL Server sends it.
+var mixedEndianID = []byte{
+	0xff, 0x19, 0x96, 0x6f, 0x86, 0x8b, 0x11, 0xd0, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff,
+}
+
+var id = [16]byte{0x6f, 0x96, 0x19, 0xff, 0x8b, 0x86, 0xd0, 0x11, 0xb4, 0x2d, 0x00, 0xc0, 0x4f, 0xc9, 0x64, 0xff}
+
+func TestSelect_uniqueIdentifierColumns(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{
+			{Name: "id", Type: "UNIQUEIDENTIFIER"},
+			{Name: "id_string", Type: "UNIQUEIDENTIFIER"},
+			{Name: "id_array", Type: "UNIQUEIDENTIFIER"},
+			{Name: "parent_id", Type: "UNIQUEIDENTIFIER"},
+			{Name: "raw_id", Type: "UNIQUEIDENTIFIER"},
+			{Name: "name", Type: "NVARCHAR"},
+		},
+		Rows: [][]driver.Value{
+			{mixedEndianID, mixedEndianID, mixedEndianID, nil, mixedEndianID, "foo val"},
+		},
+	}}})
+	type row struct {
+		ID       uuid
+		IDString string
+		IDArray  [16]byte
+		ParentID *string
+		RawID    []byte
+		Name     string
+	}
+	expected := []row{{
+		ID:       id,
+		IDString: "6f9619ff-8b86-d011-b42d-00c04fc964ff",
+		IDArray:  id,
+		RawID:    mixedEndianID,
+		Name:     "foo val",
+	}}
+
+	var got []row
+	err := mssqlscan.Select(ctx, db, &got, `SELECT * FROM items`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed_rewritesAtParameters(t *testing.T) {
+	t.Parallel()
+	fc := &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{{Name: "name", Type: "NVARCHAR"}},
+		Rows:    [][]driver.Value{{"foo val"}},
+	}}}
+	db := sqlfake.NewDB(t, fc)
+
+	var got string
+	err := mssqlscan.GetNamed(ctx, db, &got,
+		`SELECT name FROM users WHERE name = @name AND id > @id AND @@ROWCOUNT > 0 AND note <> '@id'`,
+		map[string]interface{}{"name": "foo val", "id": 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo val", got)
+	assert.Equal(t, []string{`SELECT name FROM users WHERE name = @p1 AND id > @p2 AND @@ROWCOUNT > 0 AND note <> '@id'`},
+		fc.Queries())
+	assert.Equal(t, [][]interface{}{{"foo val", int64(0)}}, fc.Args())
+}
+
+func TestSelectSets_uniqueIdentifierColumnsPerResultSet(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{
+		{Columns: []sqlfake.Column{{Name: "id", Type: "UNIQUEIDENTIFIER"}}, Rows: [][]driver.Value{{mixedEndianID}}},
+		{Columns: []sqlfake.Column{{Name: "id", Type: "VARBINARY"}}, Rows: [][]driver.Value{{mixedEndianID}}},
+	}})
+
+	var ids []uuid
+	var raw []uuid
+	err := mssqlscan.SelectSets(ctx, db, []interface{}{&ids, &raw}, `EXEC get_ids`)
+	require.NoError(t, err)
+
+	var rawID uuid
+	copy(rawID[:], mixedEndianID)
+	assert.Equal(t, []uuid{id}, ids)
+	assert.Equal(t, []uuid{rawID}, raw)
+}
+
+func TestExecReturning_outputClause(t *testing.T) {
+	t.Parallel()
+	fc := &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{{Name: "id", Type: "UNIQUEIDENTIFIER"}, {Name: "name", Type: "NVARCHAR"}},
+		Rows:    [][]driver.Value{{mixedEndianID, "foo val"}},
+	}}}
+	db := sqlfake.NewDB(t, fc)
+	query := `INSERT INTO users (name) OUTPUT INSERTED.id, INSERTED.name VALUES (@p1)`
+
+	var got struct {
+		ID   uuid
+		Name string
+	}
+	err := mssqlscan.ExecReturning(ctx, db, &got, query, "foo val")
+	require.NoError(t, err)
+
+	assert.Equal(t, uuid(id), got.ID)
+	assert.Equal(t, "foo val", got.Name)
+	assert.Equal(t, []string{query}, fc.Queries())
+}
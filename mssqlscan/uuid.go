@@ -0,0 +1,82 @@
+package mssqlscan
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// convertUUID is the sqlscan.ValueConverter of UNIQUEIDENTIFIER values, see uuidScanner.
+func convertUUID(columnType *sql.ColumnType, dst interface{}) sql.Scanner {
+	if !strings.EqualFold(columnType.DatabaseTypeName(), "UNIQUEIDENTIFIER") {
+		return nil
+	}
+	return newUUIDScanner(dst)
+}
+
+// newUUIDScanner returns uuidScanner for dst, or nil if dst gets UNIQUEIDENTIFIER values as is.
+func newUUIDScanner(dst interface{}) sql.Scanner {
+	switch dst.(type) {
+	case *[]byte, *sql.RawBytes:
+		return nil
+	default:
+		return &uuidScanner{dst: dst}
+	}
+}
+
+// uuidScanner scans UNIQUEIDENTIFIER values that SQL Server drivers return as 16 bytes in mixed-endian order
+// into arrays of 16 bytes, e.g. uuid.UUID, in the standard order, and into strings and sql.Scanner destinations
+// as the standard string representation, e.g. "6f9619ff-8b86-d011-b42d-00c04fc964ff".
+type uuidScanner struct {
+	dst interface{}
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (us *uuidScanner) Scan(src interface{}) error {
+	var u [16]byte
+	b, ok := src.([]byte)
+	if ok && len(b) == len(u) {
+		copy(u[:], b)
+		// The first three groups are little-endian: 4, 2 and 2 bytes long.
+		u[0], u[1], u[2], u[3] = u[3], u[2], u[1], u[0]
+		u[4], u[5] = u[5], u[4]
+		u[6], u[7] = u[7], u[6]
+		src = fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+	}
+	if scanner, ok := us.dst.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	return assignUUID(reflect.ValueOf(us.dst).Elem(), src, u)
+}
+
+func assignUUID(dst reflect.Value, src interface{}, u [16]byte) error {
+	if src == nil {
+		if dst.Kind() == reflect.Ptr || dst.Kind() == reflect.Interface {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("scany: can't scan NULL into %s", dst.Type())
+	}
+	s, isString := src.(string)
+	switch {
+	case dst.Kind() == reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if err := assignUUID(elem.Elem(), src, u); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case dst.Kind() == reflect.Interface && dst.NumMethod() == 0:
+		dst.Set(reflect.ValueOf(src))
+		return nil
+	case dst.Kind() == reflect.String && isString:
+		dst.SetString(s)
+		return nil
+	case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(u) && isString:
+		reflect.Copy(dst, reflect.ValueOf(u[:]))
+		return nil
+	default:
+		return fmt.Errorf("scany: can't scan UNIQUEIDENTIFIER value of type %T into %s", src, dst.Type())
+	}
+}
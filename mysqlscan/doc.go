@@ -0,0 +1,26 @@
+// Package mysqlscan allows scanning data into Go structs and other composite types,
+// when working with MySQL or MariaDB via database/sql library.
+/*
+Essentially, mysqlscan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for MySQL drivers, e.g. github.com/go-sql-driver/mysql.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+MySQL specifics
+
+mysqlscan uses the Dialect dialect, so high-level functions behave the way MySQL expects:
+
+  - Named parameters, e.g. `WHERE id = :id`, are rewritten into "?" placeholders.
+  - DATETIME and DATE values that the driver returns as strings without parseTime=true
+    are parsed into time.Time destinations.
+  - Text values that the driver returns as []byte become strings in interface{} destinations,
+    e.g. when scanning into map[string]interface{}.
+
+MySQL has no RETURNING clause, so to insert a row and fetch it back use InsertAndGet,
+it scans the row by the AUTO_INCREMENT id of the inserted row, see LastInsertID:
+
+	var user User
+	err := mysqlscan.InsertAndGet(ctx, db, &user, `SELECT * FROM users WHERE id = ?`,
+		`INSERT INTO users (name, email) VALUES (?, ?)`, name, email)
+*/
+package mysqlscan
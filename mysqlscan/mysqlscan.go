@@ -0,0 +1,150 @@
+package mysqlscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Dialect is the dialect that mysqlscan uses, it's sqlscan.DialectMySQL
+// that also converts []byte values into strings, see sqlscan.Dialect.BytesAsString.
+var Dialect = newDialect()
+
+func newDialect() sqlscan.Dialect {
+	dialect := sqlscan.DialectMySQL
+	dialect.BytesAsString = true
+	return dialect
+}
+
+// ExecQuerier is something that mysqlscan can both execute statements with and query rows from.
+// For example, it can be: *sql.DB, *sql.Conn or *sql.Tx.
+type ExecQuerier interface {
+	sqlscan.Execer
+	sqlscan.Querier
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// LastInsertID is a package-level helper function that uses the DefaultAPI object.
+// See API.LastInsertID for details.
+func LastInsertID(ctx context.Context, db sqlscan.Execer, query string, args ...interface{}) (int64, error) {
+	return DefaultAPI.LastInsertID(ctx, db, query, args...)
+}
+
+// InsertAndGet is a package-level helper function that uses the DefaultAPI object.
+// See API.InsertAndGet for details.
+func InsertAndGet(
+	ctx context.Context, db ExecQuerier, dst interface{}, getQuery, query string, args ...interface{},
+) error {
+	return DefaultAPI.InsertAndGet(ctx, db, dst, getQuery, query, args...)
+}
+
+// API is a wrapper around the sqlscan.API type that uses the MySQL dialect.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect, options can change it or its parts, e.g. with sqlscan.WithPlaceholderStyle.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{sqlscan.WithDialect(Dialect)}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+// LastInsertID executes the INSERT statement and returns the value that LAST_INSERT_ID() returns after it:
+// the AUTO_INCREMENT id of the inserted row, or of the first row if the statement inserts multiple rows.
+func (api *API) LastInsertID(ctx context.Context, db sqlscan.Execer, query string, args ...interface{}) (int64, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("scany: exec statement: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("scany: get last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// InsertAndGet executes the INSERT statement and scans the inserted row into dst with getQuery,
+// that receives the id of the row, see LastInsertID, as its only argument:
+//
+//	err := mysqlscan.InsertAndGet(ctx, db, &user, `SELECT * FROM users WHERE id = ?`,
+//		`INSERT INTO users (name, email) VALUES (?, ?)`, name, email)
+//
+// It's the counterpart of sqlscan.API.ExecReturning for MySQL that has no RETURNING clause.
+// The statements run one by one, pass *sql.Tx to get the row, e.g. with defaults set by triggers,
+// in the same transaction, or *sql.Conn to read it from the same connection.
+func (api *API) InsertAndGet(
+	ctx context.Context, db ExecQuerier, dst interface{}, getQuery, query string, args ...interface{},
+) error {
+	id, err := api.LastInsertID(ctx, db, query, args...)
+	if err != nil {
+		return err
+	}
+	return api.Get(ctx, db, dst, getQuery, id)
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
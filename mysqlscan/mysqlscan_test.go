@@ -0,0 +1,102 @@
+package mysqlscan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/mysqlscan"
+)
+
+var ctx = context.Background()
+
+// user is a row of the users table. Tests return rows the way MySQL drivers without parseTime=true do:
+// text and DATETIME values are []byte.
+type user struct {
+	ID        int64
+	Name      string
+	CreatedAt time.Time
+}
+
+func TestSelect_parsesTimeBytes(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("id", "name", "created_at"),
+		Rows: [][]driver.Value{
+			{int64(1), []byte("foo val"), []byte("2021-03-04 05:06:07")},
+			{int64(2), []byte("bar val"), []byte("2021-03-05 00:00:00.5")},
+		},
+	}}})
+	expected := []*user{
+		{ID: 1, Name: "foo val", CreatedAt: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)},
+		{ID: 2, Name: "bar val", CreatedAt: time.Date(2021, 3, 5, 0, 0, 0, 500000000, time.UTC)},
+	}
+
+	var got []*user
+	err := mysqlscan.Select(ctx, db, &got, `SELECT id, name, created_at FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_mapDestination_convertsBytesToStrings(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("id", "name"),
+		Rows:    [][]driver.Value{{int64(1), []byte("foo val")}},
+	}}})
+	expected := []map[string]interface{}{{"id": int64(1), "name": "foo val"}}
+
+	var got []map[string]interface{}
+	err := mysqlscan.Select(ctx, db, &got, `SELECT id, name FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed_usesQuestionPlaceholders(t *testing.T) {
+	t.Parallel()
+	fc := &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("id", "name"),
+		Rows:    [][]driver.Value{{int64(1), []byte("foo val")}},
+	}}}
+	db := sqlfake.NewDB(t, fc)
+
+	var got struct {
+		ID   int64
+		Name string
+	}
+	err := mysqlscan.GetNamed(ctx, db, &got, `SELECT id, name FROM users WHERE name = :name AND id > :id`,
+		map[string]interface{}{"name": "foo val", "id": 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`SELECT id, name FROM users WHERE name = ? AND id > ?`}, fc.Queries())
+	assert.Equal(t, [][]interface{}{{"foo val", int64(0)}}, fc.Args())
+}
+
+func TestInsertAndGet(t *testing.T) {
+	t.Parallel()
+	fc := &sqlfake.Connector{
+		Sets: []sqlfake.ResultSet{{
+			Columns: sqlfake.Columns("id", "name", "created_at"),
+			Rows:    [][]driver.Value{{int64(42), []byte("foo val"), []byte("2021-03-04 05:06:07")}},
+		}},
+		LastInsertID: 42,
+	}
+	db := sqlfake.NewDB(t, fc)
+	expected := user{ID: 42, Name: "foo val", CreatedAt: time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}
+
+	var got user
+	err := mysqlscan.InsertAndGet(ctx, db, &got, `SELECT * FROM users WHERE id = ?`,
+		`INSERT INTO users (name) VALUES (?)`, "foo val")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []string{`INSERT INTO users (name) VALUES (?)`, `SELECT * FROM users WHERE id = ?`}, fc.Queries())
+	assert.Equal(t, [][]interface{}{{"foo val"}, {int64(42)}}, fc.Args())
+}
@@ -0,0 +1,36 @@
+// Package odbcscan allows scanning data into Go structs and other composite types,
+// when working with ODBC data sources via database/sql library.
+/*
+Essentially, odbcscan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for ODBC drivers, e.g. github.com/alexbrainman/odbc,
+with workarounds for legacy databases like DB2 and Informix.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+ODBC specifics
+
+odbcscan uses the Dialect dialect, FixColumnNames and dbscan.LowerCaseNormalizer,
+so high-level functions work around what ODBC drivers of legacy databases report:
+
+  - Column names that are encoded as UTF-16 or padded with NUL characters and spaces are fixed,
+    and converted into lower case, so CREATED_AT matches the CreatedAt field.
+  - Columns without names, e.g. expressions of drivers without column metadata,
+    are named by their position, e.g. "column_2".
+  - Values that the driver returns as bytes are scanned into int, float and string fields by database/sql,
+    into time.Time fields with TimeLayouts, e.g. DB2 timestamps like "2024-01-02-03.04.05.000006",
+    and into interface{} destinations, e.g. values of map[string]interface{}, as strings.
+  - Named parameters, e.g. `WHERE id = :id`, are rewritten into "?" placeholders.
+
+For example:
+
+	type Order struct {
+		ID        int64
+		Total     float64
+		CreatedAt time.Time
+		Items     int `db:"column_4"` // COUNT(*)
+	}
+
+	var orders []*Order
+	err := odbcscan.Select(ctx, db, &orders, `SELECT o.id, o.total, o.created_at, COUNT(*) FROM orders o ...`)
+*/
+package odbcscan
@@ -0,0 +1,175 @@
+package odbcscan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Dialect is the dialect that odbcscan uses. It parses time values and converts []byte values
+// into strings for interface{} destinations, since ODBC drivers of legacy databases often return all values
+// as bytes, see sqlscan.Dialect.ParseTime and sqlscan.Dialect.BytesAsString.
+var Dialect = sqlscan.Dialect{
+	Name:        "odbc",
+	Placeholder: sqlscan.PlaceholderQuestion,
+	OpenQuote:   `"`,
+	CloseQuote:  `"`,
+	Limit:       sqlscan.FetchNextClause,
+	// ODBC numbers parameters with 16-bit integers.
+	MaxParams:     32767,
+	ParseTime:     true,
+	TimeLayouts:   TimeLayouts,
+	BytesAsString: true,
+}
+
+// TimeLayouts are layouts of time values that Dialect parses: DB2 timestamps, e.g. "2006-01-02-15.04.05.000000",
+// ISO timestamps of DB2 and Informix DATETIME values, and dates.
+var TimeLayouts = []string{
+	"2006-01-02-15.04.05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for odbcscan:
+// the ones of sqlscan, FixColumnNames and dbscan.LowerCaseNormalizer for upper-case column names of DB2 and Informix.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	opts = append([]dbscan.APIOption{
+		dbscan.WithRowsMiddleware(FixColumnNames),
+		dbscan.WithColumnNormalizers(dbscan.LowerCaseNormalizer),
+	}, opts...)
+	return sqlscan.NewDBScanAPI(opts...)
+}
+
+// FixColumnNames is a dbscan.RowsMiddleware that fixes column names that ODBC drivers report
+// for legacy databases:
+//
+//   - Wide-char names, that some drivers return as UTF-16 bytes, e.g. "I\x00D\x00", are decoded.
+//   - NUL characters and spaces that pad names to the length of the column metadata are trimmed.
+//   - Empty names, that drivers without column metadata return for expressions, become "column_1",
+//     "column_2" and so on by the position of the column, so they can be matched with `db:"column_2"` tags.
+func FixColumnNames(rows dbscan.Rows) dbscan.Rows {
+	return &columnNamesRows{Rows: rows}
+}
+
+// columnNamesRows returns column names of the rows they wrap fixed, see FixColumnNames.
+type columnNamesRows struct {
+	dbscan.Rows
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (r *columnNamesRows) Columns() ([]string, error) {
+	columns, err := r.Rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	fixed := make([]string, len(columns))
+	for i, column := range columns {
+		column = strings.TrimRight(decodeWideChars(column), "\x00 ")
+		if column == "" {
+			column = fmt.Sprintf("column_%d", i+1)
+		}
+		fixed[i] = column
+	}
+	return fixed, nil
+}
+
+// UnwrapRows implements the dbscan.RowsUnwrapper.UnwrapRows method.
+func (r *columnNamesRows) UnwrapRows() dbscan.Rows {
+	return r.Rows
+}
+
+// decodeWideChars decodes the name from UTF-16 in little-endian order if it's encoded that way,
+// i.e. every second byte of ASCII characters is NUL, or returns the name as is.
+func decodeWideChars(name string) string {
+	if len(name) < 2 || len(name)%2 != 0 || name[1] != 0 {
+		return name
+	}
+	units := make([]uint16, len(name)/2)
+	for i := range units {
+		units[i] = uint16(name[2*i]) | uint16(name[2*i+1])<<8
+	}
+	return string(utf16.Decode(units))
+}
+
+// API is a wrapper around the sqlscan.API type that uses the ODBC dialect.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect, options can change it or its parts, e.g. with sqlscan.WithPlaceholderStyle.
+// Create dbscanAPI with NewDBScanAPI, so column names are fixed and match struct fields.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{sqlscan.WithDialect(Dialect)}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
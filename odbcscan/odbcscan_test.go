@@ -0,0 +1,62 @@
+package odbcscan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/odbcscan"
+)
+
+var ctx = context.Background()
+
+func TestSelect_legacyColumns(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("I\x00D\x00", "NAME\x00\x00", "CREATED_AT  ", "BIRTH_DATE", ""),
+		Rows: [][]driver.Value{
+			{[]byte("1"), []byte("foo"), []byte("2024-01-02-03.04.05.000006"), []byte("1990-05-06"), []byte("42")},
+		},
+	}}})
+	type user struct {
+		ID        int
+		Name      string
+		CreatedAt time.Time
+		BirthDate *time.Time
+		Total     int64 `db:"column_5"`
+	}
+	birthDate := time.Date(1990, 5, 6, 0, 0, 0, 0, time.UTC)
+	expected := []user{{
+		ID:        1,
+		Name:      "foo",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+		BirthDate: &birthDate,
+		Total:     42,
+	}}
+
+	var got []user
+	err := odbcscan.Select(ctx, db, &got, `SELECT id, name, created_at, birth_date, COUNT(*) FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_mapDestination_bytesAsStrings(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("ID", "NAME"),
+		Rows:    [][]driver.Value{{[]byte("1"), []byte("foo")}},
+	}}})
+	expected := []map[string]interface{}{{"id": "1", "name": "foo"}}
+
+	var got []map[string]interface{}
+	err := odbcscan.Select(ctx, db, &got, `SELECT id, name FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,38 @@
+// Package oraclescan allows scanning data into Go structs and other composite types,
+// when working with Oracle Database via database/sql library.
+/*
+Essentially, oraclescan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for Oracle drivers, e.g. github.com/godror/godror.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+Oracle specifics
+
+oraclescan uses the Dialect dialect and dbscan.LowerCaseNormalizer, so high-level functions behave
+the way Oracle expects:
+
+  - Column names, that Oracle returns in upper case unless they are quoted, are converted into lower case,
+    so CREATED_AT matches the CreatedAt field and the "created_at" key of map destinations.
+  - Named parameters, e.g. `WHERE id = :id`, are rewritten into ":1" placeholders.
+  - NUMBER values, that godror returns as godror.Number strings, are scanned into int and float fields
+    by database/sql, and into decimal types and other sql.Scanner fields as strings.
+    interface{} destinations, e.g. values of map[string]interface{}, get int64 values of integer columns,
+    float64 values of columns with up to 15 digits, and strings of larger ones, so no digits are lost.
+  - CLOB, NCLOB and BLOB values, that godror returns as readers with the LobAsReader option,
+    are read into string and []byte fields while the row is scanned.
+  - SelectPage limits rows with FETCH NEXT, and InsertAll inserts rows one by one,
+    since Oracle before 23ai has no VALUES lists of multiple rows.
+
+For example:
+
+	type User struct {
+		ID        int64
+		Balance   decimal.Decimal // NUMBER(12, 2)
+		Bio       string          // CLOB
+		CreatedAt time.Time
+	}
+
+	var users []*User
+	err := oraclescan.SelectNamed(ctx, db, &users, `SELECT * FROM users WHERE team_id = :team_id`, filter)
+*/
+package oraclescan
@@ -0,0 +1,86 @@
+package oraclescan
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// convertLOB is the sqlscan.ValueConverter of CLOB, NCLOB and BLOB values, see lobScanner.
+func convertLOB(columnType *sql.ColumnType, dst interface{}) sql.Scanner {
+	switch dbType := strings.ToUpper(columnType.DatabaseTypeName()); dbType {
+	case "CLOB", "NCLOB", "BLOB":
+		return newLOBScanner(dst, dbType == "BLOB")
+	default:
+		return nil
+	}
+}
+
+// newLOBScanner returns lobScanner for dst, or nil if dst can't get LOB values, binary is true for BLOB values.
+func newLOBScanner(dst interface{}, binary bool) sql.Scanner {
+	switch dst.(type) {
+	case *string, **string, *[]byte, *interface{}, sql.Scanner:
+		return &lobScanner{dst: dst, binary: binary}
+	default:
+		return nil
+	}
+}
+
+// lobScanner reads CLOB, NCLOB and BLOB values that Oracle drivers return as io.Reader,
+// and scans them into string, []byte, interface{} and sql.Scanner destinations,
+// interface{} destinations get strings for CLOB and NCLOB values, and []byte for BLOB values.
+// The value is read while the row is scanned, since the reader is valid only until the next row.
+type lobScanner struct {
+	dst    interface{}
+	binary bool
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (ls *lobScanner) Scan(src interface{}) error {
+	if r, ok := src.(io.Reader); ok {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("scany: read LOB value: %w", err)
+		}
+		src = data
+		if !ls.binary {
+			src = string(data)
+		}
+	}
+	switch d := ls.dst.(type) {
+	case sql.Scanner:
+		return d.Scan(src)
+	case *interface{}:
+		*d = src
+		return nil
+	}
+	var s string
+	switch v := src.(type) {
+	case nil:
+		switch d := ls.dst.(type) {
+		case **string:
+			*d = nil
+			return nil
+		case *[]byte:
+			*d = nil
+			return nil
+		}
+		return fmt.Errorf("scany: can't scan NULL into %T", ls.dst)
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("scany: can't scan LOB value of type %T into %T", src, ls.dst)
+	}
+	switch d := ls.dst.(type) {
+	case *string:
+		*d = s
+	case **string:
+		*d = &s
+	case *[]byte:
+		*d = []byte(s)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package oraclescan
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Precision limits of NUMBER columns, which values fit into int64 and float64 without losing digits.
+const (
+	maxInt64Precision   = 18
+	maxFloat64Precision = 15
+	// unconstrainedScale is the scale that Oracle drivers report for NUMBER columns without precision
+	// and for FLOAT columns.
+	unconstrainedScale = -127
+)
+
+// convertNumber is the sqlscan.ValueConverter of NUMBER values, see numberScanner.
+func convertNumber(columnType *sql.ColumnType, dst interface{}) sql.Scanner {
+	if !strings.EqualFold(columnType.DatabaseTypeName(), "NUMBER") {
+		return nil
+	}
+	return newNumberScanner(dst, columnType)
+}
+
+// newNumberScanner returns numberScanner for dst, or nil if database/sql converts NUMBER values into dst by itself,
+// e.g. into int and float64 destinations.
+func newNumberScanner(dst interface{}, columnType *sql.ColumnType) sql.Scanner {
+	switch dst.(type) {
+	case *interface{}, sql.Scanner:
+		return &numberScanner{dst: dst, columnType: columnType}
+	default:
+		return nil
+	}
+}
+
+// numberScanner scans NUMBER values that Oracle drivers return as types based on string, e.g. godror.Number,
+// into *interface{} as int64 or float64 values, and into sql.Scanner destinations as plain strings,
+// since scanners usually accept only the string type itself.
+type numberScanner struct {
+	dst        interface{}
+	columnType *sql.ColumnType
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (ns *numberScanner) Scan(src interface{}) error {
+	if v := reflect.ValueOf(src); v.Kind() == reflect.String {
+		src = v.String()
+	}
+	if scanner, ok := ns.dst.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	dst := ns.dst.(*interface{})
+	s, ok := src.(string)
+	if !ok {
+		*dst = src
+		return nil
+	}
+	*dst = numberValue(s, ns.columnType)
+	return nil
+}
+
+// numberValue converts s into int64 if the column has no fractional digits, or into float64,
+// if the value fits into these types without losing digits, otherwise it returns s as is.
+// Values of columns without precision, e.g. results of COUNT(*), are converted based on the value itself.
+func numberValue(s string, columnType *sql.ColumnType) interface{} {
+	precision, scale, ok := columnType.DecimalSize()
+	unconstrained := !ok || precision == 0 || scale == unconstrainedScale
+	if unconstrained || (scale == 0 && precision <= maxInt64Precision) {
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	}
+	if unconstrained || precision <= maxFloat64Precision {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package oraclescan
+
+import (
+	"context"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Dialect is the dialect that oraclescan uses, it's sqlscan.DialectOracle with value converters
+// of NUMBER and LOB values that godror returns, see sqlscan.Dialect.ValueConverters.
+var Dialect = newDialect()
+
+func newDialect() sqlscan.Dialect {
+	dialect := sqlscan.DialectOracle
+	dialect.ValueConverters = []sqlscan.ValueConverter{convertNumber, convertLOB}
+	return dialect
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for oraclescan:
+// the ones of sqlscan and dbscan.LowerCaseNormalizer for upper-case column names of Oracle.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	opts = append([]dbscan.APIOption{dbscan.WithColumnNormalizers(dbscan.LowerCaseNormalizer)}, opts...)
+	return sqlscan.NewDBScanAPI(opts...)
+}
+
+// API is a wrapper around the sqlscan.API type that uses the Oracle dialect.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect, options can change it or its parts, e.g. with sqlscan.WithPlaceholderStyle.
+// Create dbscanAPI with NewDBScanAPI, so upper-case column names match struct fields.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{sqlscan.WithDialect(Dialect)}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
@@ -0,0 +1,115 @@
+package oraclescan_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/oraclescan"
+)
+
+var ctx = context.Background()
+
+// number is a NUMBER value type like godror.Number.
+type number string
+
+// decimal is a decimal type like github.com/shopspring/decimal.Decimal, it scans only strings.
+type decimal struct {
+	value string
+}
+
+func (d *decimal) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("can't scan %T into decimal", src)
+	}
+	d.value = s
+	return nil
+}
+
+func TestSelect_oracleTypes(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{
+			{Name: "ID", Type: "NUMBER", Precision: 10},
+			{Name: "BALANCE", Type: "NUMBER", Precision: 12, Scale: 2},
+			{Name: "BIO", Type: "CLOB"},
+			{Name: "AVATAR", Type: "BLOB"},
+			{Name: "NOTE", Type: "NCLOB"},
+		},
+		Rows: [][]driver.Value{
+			{number("1"), number("10.50"), strings.NewReader("foo bio"), bytes.NewReader([]byte{1, 2}), nil},
+		},
+	}}})
+	type user struct {
+		ID      int64
+		Balance decimal
+		Bio     string
+		Avatar  []byte
+		Note    *string
+	}
+	expected := []user{{ID: 1, Balance: decimal{value: "10.50"}, Bio: "foo bio", Avatar: []byte{1, 2}}}
+
+	var got []user
+	err := oraclescan.Select(ctx, db, &got, `SELECT * FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_mapDestination_convertsNumbers(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{
+			{Name: "ID", Type: "NUMBER", Precision: 10},
+			{Name: "RATE", Type: "NUMBER", Precision: 5, Scale: 2},
+			{Name: "BIG", Type: "NUMBER", Precision: 38, Scale: 10},
+			{Name: "COUNT", Type: "NUMBER", Scale: -127},
+			{Name: "BIO", Type: "CLOB"},
+			{Name: "NAME", Type: "VARCHAR2"},
+		},
+		Rows: [][]driver.Value{{
+			number("1"), number("1.25"), number("12345678901234567890.0123456789"), number("42"),
+			strings.NewReader("foo bio"), "foo val",
+		}},
+	}}})
+	expected := []map[string]interface{}{{
+		"id":    int64(1),
+		"rate":  1.25,
+		"big":   "12345678901234567890.0123456789",
+		"count": int64(42),
+		"bio":   "foo bio",
+		"name":  "foo val",
+	}}
+
+	var got []map[string]interface{}
+	err := oraclescan.Select(ctx, db, &got, `SELECT * FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed_usesColonPlaceholders(t *testing.T) {
+	t.Parallel()
+	fc := &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{{Name: "NAME", Type: "VARCHAR2"}},
+		Rows:    [][]driver.Value{{"foo val"}},
+	}}}
+	db := sqlfake.NewDB(t, fc)
+
+	var got struct{ Name string }
+	err := oraclescan.GetNamed(ctx, db, &got, `SELECT name FROM users WHERE name = :name AND id > :id`,
+		map[string]interface{}{"name": "foo val", "id": 0})
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo val", got.Name)
+	assert.Equal(t, []string{`SELECT name FROM users WHERE name = :1 AND id > :2`}, fc.Queries())
+	assert.Equal(t, [][]interface{}{{"foo val", int64(0)}}, fc.Args())
+}
@@ -0,0 +1,79 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ScanBatch is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanBatch for details.
+func ScanBatch(ctx context.Context, br pgx.BatchResults, dsts ...interface{}) error {
+	return DefaultAPI.ScanBatch(ctx, br, dsts...)
+}
+
+// ScanBatch scans results of the queries queued in pgx.Batch into the destinations in the same order,
+// the i-th destination receives the result of the i-th query:
+//
+//	batch := &pgx.Batch{}
+//	batch.Queue(`SELECT * FROM users WHERE id = $1`, id)
+//	batch.Queue(`SELECT * FROM posts WHERE user_id = $1`, id)
+//	batch.Queue(`UPDATE users SET seen_at = now() WHERE id = $1`, id)
+//	var user User
+//	var posts []*Post
+//	err := pgxscan.ScanBatch(ctx, conn.SendBatch(ctx, batch), &user, &posts, nil)
+//
+// If the destination is a pointer to a slice, rows are scanned as in ScanAll,
+// otherwise exactly one row is scanned as in ScanOne, and a not found error is returned if there are no rows.
+// A nil destination executes the query and discards its result, e.g. for statements that don't return rows.
+// ScanBatch always closes br, so there must be a destination for every queued query.
+func (api *API) ScanBatch(ctx context.Context, br pgx.BatchResults, dsts ...interface{}) error {
+	for i, dst := range dsts {
+		if err := api.scanBatchResult(ctx, br, dst); err != nil {
+			_ = br.Close()
+			return classifyError(fmt.Errorf("scany: batch query %d: %w", i, err))
+		}
+	}
+	if err := br.Close(); err != nil {
+		return classifyError(fmt.Errorf("scany: close batch results: %w", err))
+	}
+	return nil
+}
+
+func (api *API) scanBatchResult(ctx context.Context, br pgx.BatchResults, dst interface{}) error {
+	if dst == nil {
+		if _, err := br.Exec(); err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+		return nil
+	}
+	rows, err := br.Query()
+	if err != nil {
+		if rows != nil {
+			rows.Close()
+		}
+		return fmt.Errorf("query: %w", err)
+	}
+	if isSliceDestination(dst) {
+		if err := api.ScanAllContext(ctx, dst, rows); err != nil {
+			return fmt.Errorf("scanning all: %w", err)
+		}
+		return nil
+	}
+	if err := api.ScanOneContext(ctx, dst, rows); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// isSliceDestination reports whether dst is a pointer to a slice of rows,
+// []byte is a single value, e.g. of a bytea column.
+func isSliceDestination(dst interface{}) bool {
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr || dstType.Elem().Kind() != reflect.Slice {
+		return false
+	}
+	return dstType.Elem().Elem().Kind() != reflect.Uint8
+}
@@ -0,0 +1,43 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestScanBatch(t *testing.T) {
+	t.Parallel()
+	batch := &pgx.Batch{}
+	batch.Queue(singleRowsQuery)
+	batch.Queue(multipleRowsQuery)
+	batch.Queue(`SELECT 1`)
+	batch.Queue(`SELECT 'foo val'::bytea`)
+
+	var one testModel
+	var all []*testModel
+	var bytes []byte
+	err := testAPI.ScanBatch(ctx, testDB.SendBatch(ctx, batch), &one, &all, nil, &bytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, one)
+	assert.Len(t, all, 3)
+	assert.Equal(t, []byte("foo val"), bytes)
+}
+
+func TestScanBatch_noRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	batch := &pgx.Batch{}
+	batch.Queue(singleRowsQuery)
+	batch.Queue(noRowsQuery)
+
+	var one, missing testModel
+	err := testAPI.ScanBatch(ctx, testDB.SendBatch(ctx, batch), &one, &missing)
+
+	assert.True(t, pgxscan.NotFound(err))
+	assert.Contains(t, err.Error(), "scany: batch query 1")
+}
@@ -0,0 +1,276 @@
+package pgxscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	_ pgtype.CompositeIndexScanner = &compositeScanner{}
+	_ pgtype.ArraySetter           = &compositeArrayScanner{}
+)
+
+// RegisterTypes loads the types by name, e.g. composite types and arrays of them, and registers them
+// in the connection type map, so columns of these types are decoded into structs, see the Composite types section
+// of the package docs. Row types of tables, e.g. for array_agg(t.*), are named after the table,
+// and names of array types start with an underscore, so the element type must go first:
+//
+//	err := pgxscan.RegisterTypes(ctx, conn, "child", "_child")
+//
+// With *pgxpool.Pool, register types in the AfterConnect hook of the pool config.
+func RegisterTypes(ctx context.Context, conn *pgx.Conn, typeNames ...string) error {
+	for _, typeName := range typeNames {
+		typ, err := conn.LoadType(ctx, typeName)
+		if err != nil {
+			return fmt.Errorf("scany: load type %s: %w", typeName, err)
+		}
+		conn.TypeMap().RegisterType(typ)
+	}
+	return nil
+}
+
+var (
+	compositeIndexScannerType = reflect.TypeOf((*pgtype.CompositeIndexScanner)(nil)).Elem()
+	arraySetterType           = reflect.TypeOf((*pgtype.ArraySetter)(nil)).Elem()
+	sqlScannerType            = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType                  = reflect.TypeOf(time.Time{})
+)
+
+// structDst wraps the destination, so pgx scans a composite or a record column, or an array of them, into it,
+// as well as a JSON column with an object or an array of objects, e.g. built with jsonb_agg.
+// If the API has a JSONDecoder, other destinations of JSON columns are decoded with it.
+// It returns dst as is if the column isn't of such type, or dst can't hold it.
+// Composite fields are mapped to struct fields by name, like columns, records have no field names,
+// so their fields are mapped by position.
+func structDst(api *API, typ *pgtype.Type, oid uint32, dst interface{}) interface{} {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return dst
+	}
+	if typ != nil && isJSONCodec(typ.Codec) {
+		switch {
+		case isCompositeTarget(dstValue.Type().Elem()) && isJSONRowsTarget(dstValue.Type().Elem()):
+			return &jsonRowsScanner{dbscanAPI: api.dbscanAPI, decoder: api.jsonDecoder, ptr: dstValue}
+		case api.jsonDecoder != nil && isJSONDecoderTarget(dstValue.Type().Elem()):
+			return &jsonDecoderScanner{decoder: api.jsonDecoder, ptr: dstValue}
+		}
+		return dst
+	}
+	if !isCompositeTarget(dstValue.Type().Elem()) {
+		return dst
+	}
+	if s := newCompositeTarget(api.dbscanAPI, typ, oid, dstValue); s != nil {
+		return s
+	}
+	return dst
+}
+
+func newCompositeTarget(dbscanAPI *dbscan.API, typ *pgtype.Type, oid uint32, ptr reflect.Value) interface{} {
+	var codec pgtype.Codec
+	if typ != nil {
+		codec, oid = typ.Codec, typ.OID
+	}
+	baseType := ptr.Type().Elem()
+	if baseType.Kind() == reflect.Ptr {
+		baseType = baseType.Elem()
+	}
+	if baseType.Kind() == reflect.Slice {
+		var elemType *pgtype.Type
+		switch c := codec.(type) {
+		case *pgtype.ArrayCodec:
+			elemType = c.ElementType
+		case nil:
+			if oid != pgtype.RecordArrayOID {
+				return nil
+			}
+		default:
+			return nil
+		}
+		if elemType != nil && !isCompositeCodec(elemType.Codec) {
+			return nil
+		}
+		return &compositeArrayScanner{dbscanAPI: dbscanAPI, elemType: elemType, ptr: ptr}
+	}
+	switch c := codec.(type) {
+	case *pgtype.CompositeCodec:
+		return &compositeScanner{dbscanAPI: dbscanAPI, fields: c.Fields, ptr: ptr}
+	case pgtype.RecordCodec:
+		return &compositeScanner{dbscanAPI: dbscanAPI, ptr: ptr}
+	case nil:
+		if oid == pgtype.RecordOID {
+			return &compositeScanner{dbscanAPI: dbscanAPI, ptr: ptr}
+		}
+	}
+	return nil
+}
+
+func isCompositeCodec(codec pgtype.Codec) bool {
+	switch codec.(type) {
+	case *pgtype.CompositeCodec, pgtype.RecordCodec:
+		return true
+	default:
+		return false
+	}
+}
+
+// isCompositeTarget reports whether the type is a struct, a pointer to a struct, or a slice of them,
+// that pgx can't scan into by itself. time.Time is excluded, since fields of records have no known types
+// and a time.Time field must be scanned as is.
+func isCompositeTarget(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	for _, iface := range []reflect.Type{compositeIndexScannerType, arraySetterType, sqlScannerType} {
+		if t.Implements(iface) || reflect.PtrTo(t).Implements(iface) {
+			return false
+		}
+	}
+	return true
+}
+
+// compositeScanner scans a composite or a record value into the struct that ptr points to,
+// ptr is a pointer to a struct or a pointer to a pointer to a struct.
+type compositeScanner struct {
+	dbscanAPI *dbscan.API
+	// fields are fields of the composite type, they are nil for records.
+	fields []pgtype.CompositeCodecField
+	ptr    reflect.Value
+}
+
+func (s *compositeScanner) ScanNull() error {
+	s.ptr.Elem().Set(reflect.Zero(s.ptr.Elem().Type()))
+	return nil
+}
+
+func (s *compositeScanner) ScanIndex(i int) any {
+	structValue := allocate(s.ptr.Elem())
+	var fieldValue reflect.Value
+	var fieldType *pgtype.Type
+	if s.fields != nil {
+		if i >= len(s.fields) {
+			return nil
+		}
+		fieldIndex, ok := s.dbscanAPI.FieldIndex(structValue.Type(), s.fields[i].Name)
+		if !ok {
+			// The composite field has no corresponding struct field, skip it.
+			return nil
+		}
+		fieldValue = dbscan.FieldByIndex(structValue, fieldIndex)
+		fieldType = s.fields[i].Type
+	} else {
+		fields := positionalFields(structValue.Type())
+		if i >= len(fields) {
+			return nil
+		}
+		fieldValue = structValue.Field(fields[i])
+	}
+	fieldPtr := fieldValue.Addr()
+	if isCompositeTarget(fieldValue.Type()) {
+		oid := uint32(pgtype.RecordOID)
+		if fieldType == nil && fieldValue.Type().Kind() == reflect.Slice {
+			oid = pgtype.RecordArrayOID
+		}
+		if target := newCompositeTarget(s.dbscanAPI, fieldType, oid, fieldPtr); target != nil {
+			return target
+		}
+	}
+	return fieldPtr.Interface()
+}
+
+// compositeArrayScanner scans an array of composite or record values into the slice that ptr points to.
+type compositeArrayScanner struct {
+	dbscanAPI *dbscan.API
+	// elemType is the type of array elements, it's nil for arrays of records if the type map is unknown.
+	elemType *pgtype.Type
+	ptr      reflect.Value
+}
+
+func (s *compositeArrayScanner) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	if dimensions == nil {
+		s.ptr.Elem().Set(reflect.Zero(s.ptr.Elem().Type()))
+		return nil
+	}
+	sliceValue := allocate(s.ptr.Elem())
+	n := cardinality(dimensions)
+	sliceValue.Set(reflect.MakeSlice(sliceValue.Type(), n, n))
+	return nil
+}
+
+func (s *compositeArrayScanner) ScanIndex(i int) any {
+	sliceValue := s.ptr.Elem()
+	if sliceValue.Kind() == reflect.Ptr {
+		sliceValue = sliceValue.Elem()
+	}
+	return s.elemScanner(sliceValue.Index(i).Addr())
+}
+
+func (s *compositeArrayScanner) ScanIndexType() any {
+	sliceType := s.ptr.Type().Elem()
+	if sliceType.Kind() == reflect.Ptr {
+		sliceType = sliceType.Elem()
+	}
+	return s.elemScanner(reflect.New(sliceType.Elem()))
+}
+
+func (s *compositeArrayScanner) elemScanner(elemPtr reflect.Value) *compositeScanner {
+	scanner := &compositeScanner{dbscanAPI: s.dbscanAPI, ptr: elemPtr}
+	if s.elemType != nil {
+		if c, ok := s.elemType.Codec.(*pgtype.CompositeCodec); ok {
+			scanner.fields = c.Fields
+		}
+	}
+	return scanner
+}
+
+func cardinality(dimensions []pgtype.ArrayDimension) int {
+	if len(dimensions) == 0 {
+		return 0
+	}
+	n := 1
+	for _, d := range dimensions {
+		n *= int(d.Length)
+	}
+	return n
+}
+
+// allocate returns the value that v points to, allocating it if v is a nil pointer, or v itself if it isn't a pointer.
+func allocate(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Ptr {
+		return v
+	}
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	return v.Elem()
+}
+
+// positionalFields returns indexes of the struct fields that record values are scanned into by position:
+// exported fields in the order they are declared, except fields ignored via `db:"-"`.
+func positionalFields(structType reflect.Type) []int {
+	fields := make([]int, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("db") == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields
+}
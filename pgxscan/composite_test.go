@@ -0,0 +1,71 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet_recordColumn_decodesIntoStruct(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Model    testModel
+		ModelPtr *testModel
+		Nullable *testModel
+	}
+	query := `
+		SELECT ROW('foo val', 'bar val') AS model, ROW('foo val 2', 'bar val 2') AS model_ptr, NULL::RECORD AS nullable
+	`
+
+	var got dst
+	err := testAPI.Get(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, dst{
+		Model:    testModel{Foo: "foo val", Bar: "bar val"},
+		ModelPtr: &testModel{Foo: "foo val 2", Bar: "bar val 2"},
+	}, got)
+}
+
+func TestGet_recordArrayColumn_decodesIntoSliceOfStructs(t *testing.T) {
+	t.Parallel()
+	type dst struct {
+		Models []*testModel
+	}
+	query := `SELECT ARRAY[ROW('foo val', 'bar val'), ROW('foo val 2', 'bar val 2')] AS models`
+
+	var got dst
+	err := testAPI.Get(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}, got.Models)
+}
+
+func TestGet_jsonAggColumn_decodesIntoSliceOfStructs(t *testing.T) {
+	t.Parallel()
+	type child struct {
+		ChildID int
+		Name    string
+	}
+	type dst struct {
+		ID       int
+		Children []child
+	}
+	query := `
+		SELECT 1 AS id, jsonb_agg(jsonb_build_object('child_id', t.child_id, 'name', t.name)) AS children
+		FROM (VALUES (1, 'foo val'), (2, 'bar val')) AS t (child_id, name)
+	`
+
+	var got dst
+	err := testAPI.Get(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, dst{
+		ID:       1,
+		Children: []child{{ChildID: 1, Name: "foo val"}, {ChildID: 2, Name: "bar val"}},
+	}, got)
+}
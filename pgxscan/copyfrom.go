@@ -0,0 +1,101 @@
+package pgxscan
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var _ pgx.CopyFromSource = &CopyFromSource{}
+
+// NewCopyFromSource is a package-level helper function that uses the DefaultAPI object.
+// See API.NewCopyFromSource for details.
+func NewCopyFromSource[T any](rows []T) (*CopyFromSource, error) {
+	return DefaultAPI.NewCopyFromSource(rows)
+}
+
+// CopyFromSource implements pgx.CopyFromSource for a slice of structs, see API.NewCopyFromSource.
+type CopyFromSource struct {
+	api     *API
+	rows    reflect.Value
+	columns []string
+	index   int
+	err     error
+}
+
+// NewCopyFromSource returns a pgx.CopyFromSource that copies structs from the rows slice, it's the inverse of ScanAll.
+// Columns are derived from the struct the same way dbscan maps them for scanning, see dbscan.API.Columns,
+// and values are extracted via dbscan.API.Values, so bulk loads follow the same struct tags as scanning:
+//
+//	src, err := pgxscan.NewCopyFromSource(users)
+//	n, err := conn.CopyFrom(ctx, pgx.Identifier{"users"}, src.Columns(), src)
+//
+// Source columns of composed fields are omitted, since a composer can't be reversed.
+func (api *API) NewCopyFromSource(rows interface{}) (*CopyFromSource, error) {
+	rowsValue := reflect.ValueOf(rows)
+	if rowsValue.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("scany: rows to copy must be a slice, got: %T", rows)
+	}
+	structType := rowsValue.Type().Elem()
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	columns, err := api.copyColumns(reflect.New(structType).Interface())
+	if err != nil {
+		return nil, err
+	}
+	return &CopyFromSource{api: api, rows: rowsValue, columns: columns, index: -1}, nil
+}
+
+// copyColumns returns columns of the row struct that values can be extracted for.
+func (api *API) copyColumns(row interface{}) ([]string, error) {
+	allColumns, err := api.dbscanAPI.Columns(row)
+	if err != nil {
+		return nil, err
+	}
+	// Source columns of composed fields can't be extracted, NamedArgs omits them.
+	namedArgs, err := api.dbscanAPI.NamedArgs(row)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(namedArgs))
+	for _, column := range allColumns {
+		if _, ok := namedArgs[column]; ok {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("scany: %T has no columns to copy", row)
+	}
+	return columns, nil
+}
+
+// Columns returns the columns to pass to CopyFrom, in the order of the values.
+func (s *CopyFromSource) Columns() []string {
+	return s.columns
+}
+
+// Next implements the pgx.CopyFromSource.Next method.
+func (s *CopyFromSource) Next() bool {
+	if s.err != nil || s.index+1 >= s.rows.Len() {
+		return false
+	}
+	s.index++
+	return true
+}
+
+// Values implements the pgx.CopyFromSource.Values method.
+func (s *CopyFromSource) Values() ([]interface{}, error) {
+	values, err := s.api.dbscanAPI.Values(s.rows.Index(s.index).Interface(), s.columns...)
+	if err != nil {
+		s.err = fmt.Errorf("scany: row %d: %w", s.index, err)
+		return nil, s.err
+	}
+	return values, nil
+}
+
+// Err implements the pgx.CopyFromSource.Err method.
+func (s *CopyFromSource) Err() error {
+	return s.err
+}
@@ -0,0 +1,42 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestCopyFromSource(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE copy_from_source_test (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	defer testDB.Exec(ctx, `DROP TABLE copy_from_source_test`) //nolint: errcheck
+	rows := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	src, err := testAPI.NewCopyFromSource(rows)
+	require.NoError(t, err)
+	n, err := testDB.CopyFrom(ctx, pgx.Identifier{"copy_from_source_test"}, src.Columns(), src)
+	require.NoError(t, err)
+
+	var got []*testModel
+	err = testAPI.Select(ctx, testDB, &got, `SELECT foo, bar FROM copy_from_source_test ORDER BY foo`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, []string{"foo", "bar"}, src.Columns())
+	assert.Equal(t, rows, got)
+}
+
+func TestNewCopyFromSource_notStruct_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := pgxscan.NewCopyFromSource([]string{"foo"})
+
+	assert.EqualError(t, err, "scany: columns can only be listed for a struct, got: *string")
+}
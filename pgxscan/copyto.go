@@ -0,0 +1,288 @@
+package pgxscan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CopyToFormat is the format of the COPY TO output that SelectCopy and EachCopy request from the server.
+type CopyToFormat int
+
+const (
+	// CopyToCSV requests the CSV format, values are decoded from their text representation. It's the default.
+	CopyToCSV CopyToFormat = iota
+	// CopyToBinary requests the binary format, values are decoded the same way as pgx decodes binary results,
+	// so all column types must be known to the connection type map.
+	CopyToBinary
+)
+
+// CopyToOption is a function type that changes how SelectCopy and EachCopy run COPY TO.
+type CopyToOption func(o *copyToOptions)
+
+type copyToOptions struct {
+	format CopyToFormat
+}
+
+// CopyFormat sets the format of the COPY TO output, the default is CopyToCSV.
+func CopyFormat(format CopyToFormat) CopyToOption {
+	return func(o *copyToOptions) {
+		o.format = format
+	}
+}
+
+// SelectCopy is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectCopy for details.
+func SelectCopy(ctx context.Context, conn *pgx.Conn, dst interface{}, query string, opts ...CopyToOption) error {
+	return DefaultAPI.SelectCopy(ctx, conn, dst, query, opts...)
+}
+
+// EachCopy is a package-level helper function that uses the DefaultAPI object.
+// See API.EachCopy for details.
+func EachCopy(
+	ctx context.Context, conn *pgx.Conn, dst interface{}, fn func() error, query string, opts ...CopyToOption,
+) error {
+	return DefaultAPI.EachCopy(ctx, conn, dst, fn, query, opts...)
+}
+
+// SelectCopy is like Select, but it runs the query via `COPY (query) TO STDOUT` and scans its output,
+// which is much faster than the regular protocol for bulk exports:
+//
+//	var events []*Event
+//	err := pgxscan.SelectCopy(ctx, conn, &events, `SELECT * FROM events WHERE day = '2022-01-01'`)
+//
+// COPY doesn't accept query arguments, so the query must not have placeholders.
+// Columns are described before the COPY, so values are decoded according to their types
+// and mapped to struct fields the same way as Select does, see CopyFormat for the output formats.
+// With *pgxpool.Pool, acquire a connection and pass its Conn().
+func (api *API) SelectCopy(
+	ctx context.Context, conn *pgx.Conn, dst interface{}, query string, opts ...CopyToOption,
+) error {
+	return api.copyTo(ctx, conn, query, opts, func(rows *rawRows) error {
+		if err := api.dbscanAPI.ScanAllContext(ctx, dst, rows); err != nil {
+			return fmt.Errorf("scanning all: %w", err)
+		}
+		return nil
+	})
+}
+
+// EachCopy is the streaming counterpart of SelectCopy, it scans rows of the COPY TO output into dst one by one
+// as they arrive and calls fn for every row, the same way as Each does.
+// If fn returns an error, iteration stops and EachCopy returns it. COPY TO can't be stopped in the middle,
+// so the connection is closed in that case.
+func (api *API) EachCopy(
+	ctx context.Context, conn *pgx.Conn, dst interface{}, fn func() error, query string, opts ...CopyToOption,
+) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("scany: destination must be a non-nil pointer, got: %T", dst)
+	}
+	return api.copyTo(ctx, conn, query, opts, func(rows *rawRows) error {
+		rs := api.dbscanAPI.NewRowScanner(rows)
+		zero := reflect.Zero(dstValue.Elem().Type())
+		for rows.Next() {
+			dstValue.Elem().Set(zero)
+			if err := rs.ScanContext(ctx, dst); err != nil {
+				return fmt.Errorf("scanning row: %w", err)
+			}
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("scany: rows final error: %w", err)
+		}
+		return nil
+	})
+}
+
+// copyTo describes the query, runs COPY TO for it and calls fn with rows that decode the output as it arrives.
+func (api *API) copyTo(
+	ctx context.Context, conn *pgx.Conn, query string, opts []CopyToOption, fn func(rows *rawRows) error,
+) error {
+	o := copyToOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	sd, err := conn.PgConn().Prepare(ctx, "", query, nil)
+	if err != nil {
+		return classifyError(fmt.Errorf("scany: describe query: %w", err))
+	}
+	rows := &rawRows{
+		fields:  make([]pgconn.FieldDescription, len(sd.Fields)),
+		typeMap: conn.TypeMap(),
+		api:     api,
+	}
+	copy(rows.fields, sd.Fields)
+	copySQL := "COPY (" + query + ") TO STDOUT WITH (FORMAT "
+	formatCode := int16(pgtype.TextFormatCode)
+	if o.format == CopyToBinary {
+		copySQL += "binary)"
+		formatCode = pgtype.BinaryFormatCode
+	} else {
+		copySQL += "csv)"
+	}
+	for i := range rows.fields {
+		rows.fields[i].Format = formatCode
+	}
+	pr, pw := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := conn.PgConn().CopyTo(ctx, pw, copySQL)
+		// A nil error makes the reader get io.EOF.
+		_ = pw.CloseWithError(err)
+	}()
+	r := bufio.NewReader(pr)
+	if o.format == CopyToBinary {
+		rows.reader = &binaryCopyReader{r: r}
+	} else {
+		rows.reader = &csvCopyReader{r: r}
+	}
+	err = fn(rows)
+	// Makes the COPY fail if the output isn't read to the end, e.g. fn has stopped early.
+	_ = pr.Close()
+	<-done
+	return classifyError(err)
+}
+
+// csvCopyReader reads the CSV format of COPY TO, NULL values are unquoted empty strings.
+type csvCopyReader struct {
+	r *bufio.Reader
+}
+
+func (c *csvCopyReader) next() ([][]byte, error) {
+	var record [][]byte
+	var field []byte
+	var quoted, inQuotes, started bool
+	appendField := func() {
+		var value []byte
+		if quoted || len(field) > 0 {
+			value = append([]byte{}, field...)
+		}
+		record = append(record, value)
+		field, quoted = field[:0], false
+	}
+	for {
+		b, err := c.r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			switch {
+			case inQuotes:
+				return nil, io.ErrUnexpectedEOF
+			case !started && len(record) == 0:
+				return nil, io.EOF
+			}
+			appendField()
+			return record, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		started = true
+		if inQuotes {
+			if b != '"' {
+				field = append(field, b)
+				continue
+			}
+			if next, err := c.r.Peek(1); err == nil && next[0] == '"' {
+				_, _ = c.r.ReadByte()
+				field = append(field, '"')
+				continue
+			}
+			inQuotes = false
+			continue
+		}
+		switch b {
+		case '"':
+			inQuotes, quoted = true, true
+		case ',':
+			appendField()
+		case '\n':
+			appendField()
+			return record, nil
+		case '\r':
+		default:
+			field = append(field, b)
+		}
+	}
+}
+
+// binaryCopySignature starts the header of the binary format of COPY TO.
+var binaryCopySignature = []byte("PGCOPY\n\xff\r\n\x00")
+
+// binaryCopyReader reads the binary format of COPY TO.
+type binaryCopyReader struct {
+	r           *bufio.Reader
+	headerRead  bool
+	trailerRead bool
+}
+
+func (c *binaryCopyReader) next() ([][]byte, error) {
+	if c.trailerRead {
+		return nil, io.EOF
+	}
+	if !c.headerRead {
+		if err := c.readHeader(); err != nil {
+			return nil, err
+		}
+		c.headerRead = true
+	}
+	var buf [4]byte
+	if _, err := io.ReadFull(c.r, buf[:2]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	count := int16(binary.BigEndian.Uint16(buf[:2]))
+	if count == -1 {
+		c.trailerRead = true
+		return nil, io.EOF
+	}
+	record := make([][]byte, count)
+	for i := range record {
+		if _, err := io.ReadFull(c.r, buf[:4]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		size := int32(binary.BigEndian.Uint32(buf[:4]))
+		if size < 0 {
+			continue
+		}
+		record[i] = make([]byte, size)
+		if _, err := io.ReadFull(c.r, record[i]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+	}
+	return record, nil
+}
+
+func (c *binaryCopyReader) readHeader() error {
+	// The header is the signature, the flags field and the length of the header extension area, followed by it.
+	header := make([]byte, len(binaryCopySignature)+8)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		return unexpectedEOF(err)
+	}
+	if !bytes.Equal(header[:len(binaryCopySignature)], binaryCopySignature) {
+		return fmt.Errorf("scany: invalid binary copy signature")
+	}
+	extensionSize := binary.BigEndian.Uint32(header[len(binaryCopySignature)+4:])
+	if _, err := c.r.Discard(int(extensionSize)); err != nil {
+		return unexpectedEOF(err)
+	}
+	return nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
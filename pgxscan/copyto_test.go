@@ -0,0 +1,90 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestSelectCopy(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err = testAPI.SelectCopy(ctx, conn.Conn(), &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectCopy_nullsAndQuotes(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	type dst struct {
+		ID   int
+		Name *string
+		Note string
+	}
+	query := `SELECT * FROM (VALUES (1, NULL, ''), (2, 'a, "b"', e'multi\nline')) AS t (id, name, note)`
+	name := `a, "b"`
+	expected := []dst{{ID: 1, Note: ""}, {ID: 2, Name: &name, Note: "multi\nline"}}
+
+	var got []dst
+	err = testAPI.SelectCopy(ctx, conn.Conn(), &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestEachCopy(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	expected := []testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []testModel
+	var row testModel
+	err = testAPI.EachCopy(ctx, conn.Conn(), &row, func() error {
+		got = append(got, row)
+		return nil
+	}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestEachCopy_fnErr_stops(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	stopErr := errors.New("stop")
+	var calls int
+
+	var row testModel
+	err = pgxscan.EachCopy(ctx, conn.Conn(), &row, func() error {
+		calls++
+		return stopErr
+	}, multipleRowsQuery)
+
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, 1, calls)
+}
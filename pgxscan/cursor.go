@@ -0,0 +1,127 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TxBeginner is something that pgxscan can start a transaction with, see SelectCursor and WithTxRetry.
+// For example, it can be: *pgxpool.Pool, *pgx.Conn or pgx.Tx, the latter starts a nested transaction.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var (
+	_ TxBeginner = &pgxpool.Pool{}
+	_ TxBeginner = &pgx.Conn{}
+	_ TxBeginner = pgx.Tx(nil)
+)
+
+const defaultFetchSize = 1000
+
+// CursorOption is a function type that changes how SelectCursor fetches rows.
+type CursorOption func(o *cursorOptions)
+
+type cursorOptions struct {
+	fetchSize int
+}
+
+// FetchSize sets how many rows SelectCursor fetches from the cursor at once, the default is 1000.
+func FetchSize(n int) CursorOption {
+	return func(o *cursorOptions) {
+		if n > 0 {
+			o.fetchSize = n
+		}
+	}
+}
+
+// SelectCursor is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectCursor for details.
+func SelectCursor(
+	ctx context.Context, db TxBeginner, dst interface{}, query string, args []interface{}, opts ...CursorOption,
+) error {
+	return DefaultAPI.SelectCursor(ctx, db, dst, query, args, opts...)
+}
+
+// SelectCursor is like Select, but it declares a server-side cursor for the query and fetches rows in chunks,
+// so neither the server nor the connection has to buffer a huge result set at once:
+//
+//	var events []*Event
+//	err := pgxscan.SelectCursor(ctx, pool, &events, `SELECT * FROM events WHERE day = $1`, []interface{}{day},
+//		pgxscan.FetchSize(500))
+//
+// Cursors only exist within a transaction, so SelectCursor starts one and commits it after the last chunk.
+// If db is a pgx.Tx, a nested transaction is used, so the outer transaction stays open.
+// dst must be a pointer to a slice, it's set to rows of all chunks, scanned the same way as ScanAll does.
+func (api *API) SelectCursor(
+	ctx context.Context, db TxBeginner, dst interface{}, query string, args []interface{}, opts ...CursorOption,
+) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("scany: destination must be a non-nil pointer to a slice, got: %T", dst)
+	}
+	o := cursorOptions{fetchSize: defaultFetchSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	if err := api.fetchCursor(ctx, tx, dstValue.Elem(), query, args, o.fetchSize); err != nil {
+		if rbErr := tx.Rollback(ctx); rbErr != nil {
+			return classifyError(fmt.Errorf("%w (rollback error: %v)", err, rbErr))
+		}
+		return classifyError(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return classifyError(fmt.Errorf("scany: commit transaction: %w", err))
+	}
+	return nil
+}
+
+// cursorSeq numbers cursors of SelectCursor, see newCursorName.
+var cursorSeq uint64
+
+// newCursorName returns a cursor name that no other SelectCursor call uses,
+// so cursors don't clash if an outer transaction has several of them open.
+func newCursorName() string {
+	return "scany_cursor_" + strconv.FormatUint(atomic.AddUint64(&cursorSeq, 1), 10)
+}
+
+func (api *API) fetchCursor(
+	ctx context.Context, tx pgx.Tx, sliceValue reflect.Value, query string, args []interface{}, fetchSize int,
+) error {
+	cursorName := newCursorName()
+	if _, err := tx.Exec(ctx, "DECLARE "+cursorName+" NO SCROLL CURSOR FOR "+query, args...); err != nil {
+		return fmt.Errorf("scany: declare cursor: %w", err)
+	}
+	fetchQuery := "FETCH " + strconv.Itoa(fetchSize) + " FROM " + cursorName
+	chunk := reflect.New(sliceValue.Type())
+	result := reflect.MakeSlice(sliceValue.Type(), 0, 0)
+	for {
+		chunk.Elem().SetLen(0)
+		rows, err := tx.Query(ctx, fetchQuery)
+		if err != nil {
+			return fmt.Errorf("scany: fetch rows: %w", err)
+		}
+		if err := api.ScanAllContext(ctx, chunk.Interface(), rows); err != nil {
+			return fmt.Errorf("scanning all: %w", err)
+		}
+		result = reflect.AppendSlice(result, chunk.Elem())
+		if chunk.Elem().Len() < fetchSize {
+			break
+		}
+	}
+	if _, err := tx.Exec(ctx, "CLOSE "+cursorName); err != nil {
+		return fmt.Errorf("scany: close cursor: %w", err)
+	}
+	sliceValue.Set(result)
+	return nil
+}
@@ -0,0 +1,16 @@
+package pgxscan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCursorName_unique(t *testing.T) {
+	t.Parallel()
+
+	first, second := newCursorName(), newCursorName()
+
+	assert.NotEqual(t, first, second)
+	assert.Regexp(t, `^scany_cursor_\d+$`, first)
+}
@@ -0,0 +1,59 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestSelectCursor(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name      string
+		fetchSize int
+	}{
+		{name: "fetch size less than rows", fetchSize: 2},
+		{name: "fetch size equal to rows", fetchSize: 3},
+		{name: "fetch size greater than rows", fetchSize: 10},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			expected := []*testModel{
+				{Foo: "foo val", Bar: "bar val"},
+				{Foo: "foo val 2", Bar: "bar val 2"},
+				{Foo: "foo val 3", Bar: "bar val 3"},
+			}
+
+			var got []*testModel
+			err := testAPI.SelectCursor(ctx, testDB, &got, multipleRowsQuery, nil, pgxscan.FetchSize(tc.fetchSize))
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, got)
+		})
+	}
+}
+
+func TestSelectCursor_withArgs(t *testing.T) {
+	t.Parallel()
+	query := `SELECT * FROM (VALUES ('foo val'), ('foo val 2')) AS t (foo) WHERE foo = $1`
+
+	var got []*testModel
+	err := testAPI.SelectCursor(ctx, testDB, &got, query, []interface{}{"foo val 2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*testModel{{Foo: "foo val 2"}}, got)
+}
+
+func TestSelectCursor_notSlice_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	var got testModel
+	err := testAPI.SelectCursor(ctx, testDB, &got, multipleRowsQuery, nil)
+
+	assert.EqualError(t, err, "scany: destination must be a non-nil pointer to a slice, got: *pgxscan_test.testModel")
+}
@@ -14,6 +14,63 @@ pgxscan can query rows and work with *pgxpool.Pool, *pgx.Conn or pgx.Tx directly
 To support this it has two high-level functions Select and Get,
 they accept anything that implements Querier interface and query rows from it.
 This means that they can be used with *pgxpool.Pool, *pgx.Conn or pgx.Tx.
+For queries sent with pgx.Batch, ScanBatch scans the result of every queued query into its destination.
+For latency-sensitive fan-out reads over one connection, ScanPipeline sends queries in pipeline mode.
+For bulk loads, NewCopyFromSource turns a slice of structs into a pgx.CopyFromSource with columns from the struct.
+For huge result sets, SelectCursor fetches rows from a server-side cursor in chunks, see FetchSize.
+For bulk exports, SelectCopy and EachCopy scan the CSV or binary output of COPY TO, see CopyFormat.
+Listen and WaitForNotification consume LISTEN/NOTIFY events, decoding JSON payloads into structs.
+Each is the streaming counterpart of Select, it calls a function for every scanned row,
+QueryEach does the same with the signature of pgx QueryFunc.
+Pool wraps *pgxpool.Pool with Get, Select and Each that add an acquire timeout, slow query logging
+and pool statistics hooks, see NewPool.
+SelectNamed and GetNamed bind named arguments, e.g. `WHERE id = @id`, from a struct or a map,
+NamedArgs returns a pgx.QueryRewriter with the same binding for any pgx query method.
+WithTxRetry runs a function in a transaction and retries it with exponential backoff on serialization failures,
+the function receives Tx that exposes Select, Get and ExecReturning.
+To execute a statement and scan the rows it returns, e.g. `INSERT ... RETURNING id`, use ExecReturning.
+PostgreSQL errors are returned as Error, that exposes the SQLSTATE code, the constraint and the table,
+and matches ErrConflict, ErrUniqueViolation, ErrForeignKeyViolation and ErrSerializationFailure via errors.Is.
+To unit test code that queries with pgxscan without a database, the pgxscantest package has a mock Querier
+that records queries and returns canned rows built from structs.
+RowToStruct and RowToAddrOfStruct are pgx.RowToFunc functions, so pgx.CollectRows follows scany mapping rules.
+
+Composite types
+
+Columns of composite types and records, e.g. ROW(...) values, are decoded directly into struct fields,
+and arrays of them into slices of structs:
+
+	type User struct {
+		ID      string
+		Address Address   // address column of a composite type.
+		Orders  []*Order  // ARRAY(SELECT ROW(o.id, o.total) FROM orders o ...) AS orders
+	}
+
+Fields of composite types are mapped to struct fields by name, the same way as columns are.
+Records have no field names, so their fields are mapped to exported struct fields by position.
+Composite types must be registered in the pgx connection type map, see RegisterTypes.
+For example, to fetch parents with their children in a single query via array_agg(c.*),
+register the row type of the children table and its array type: RegisterTypes(ctx, conn, "children", "_children").
+
+JSON objects, e.g. built with to_jsonb(t.*) or jsonb_agg(t.*), are decoded into structs with the same mapping rules,
+so keys are matched to struct fields the same way as columns are. This applies to structs without json tags,
+structs that have json tags or implement json.Unmarshaler are decoded by encoding/json as usual.
+To decode JSON and JSONB columns with a faster library than encoding/json, see WithJSONDecoder.
+Go types registered in the pgx type map are decoded by their codecs, WithTypeHook lets pgx decode other columns as is.
+
+Large objects
+
+Fields of []byte or io.Reader type marked with the "lo" tag option are populated from the large object
+whose OID is in the column, the large object API requires Get and Select to run within a transaction:
+
+	type File struct {
+		Name string
+		Data []byte    `db:"data,lo"`
+		Body io.Reader `db:"body,lo"`
+	}
+
+A []byte field receives the whole content, an io.Reader field streams the content
+and remains valid until the transaction ends.
 
 Note about pgx custom types
 
@@ -34,5 +91,9 @@ pgx can't handle **pgtype.Text, since only *pgtype.Text implements pgx custom ty
 Supported pgx version
 
 pgxscan v2 only works with pgx v5. So the import path of your pgx must be: "github.com/jackc/pgx/v5".
+For pgx v4 use pgxscan v1 from the "github.com/georgysavva/scany" module.
+Query arguments are passed to pgx as is, so pgx v5 features like pgx.NamedArgs (pgx v5.1.0 and later)
+and query exec modes work with Select and Get. Errors from pgx are wrapped with %w,
+so *pgconn.PgError can be inspected via errors.As, and pgx.ErrNoRows matches via errors.Is.
 */
 package pgxscan
@@ -0,0 +1,73 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See API.Each for details.
+func Each[T any](ctx context.Context, db Querier, fn func(row T) error, query string, args ...interface{}) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// Each queries rows from Querier and calls fn for every row, it's the streaming counterpart of Select
+// for result sets that shouldn't be loaded into memory at once:
+//
+//	err := pgxscan.Each(ctx, db, func(user User) error {
+//		return enc.Encode(user)
+//	}, `SELECT * FROM users`)
+//
+// dst must be a non-nil pointer, it's reset to the zero value and the row is scanned into it before fn is called,
+// the package-level Each passes the scanned value to the typed callback instead.
+// If fn returns an error, iteration stops and Each returns it. Rows are closed in any case.
+func (api *API) Each(
+	ctx context.Context, db Querier, dst interface{}, fn func() error, query string, args ...interface{},
+) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("scany: destination must be a non-nil pointer, got: %T", dst)
+	}
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return classifyError(fmt.Errorf("scany: query rows: %w", err))
+	}
+	defer rows.Close()
+	rs := api.NewRowScanner(rows)
+	zero := reflect.Zero(dstValue.Elem().Type())
+	for rows.Next() {
+		dstValue.Elem().Set(zero)
+		if err := rs.ScanContext(ctx, dst); err != nil {
+			return classifyError(fmt.Errorf("scanning row: %w", err))
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return classifyError(fmt.Errorf("scany: rows final error: %w", err))
+	}
+	return nil
+}
+
+// QueryEach is like the package-level Each, but it mirrors pgx QueryFunc: arguments go as a slice before the callback,
+// and the callback receives a pointer to the row, that is scanned with the DefaultAPI:
+//
+//	err := pgxscan.QueryEach(ctx, conn, `SELECT * FROM users WHERE team_id = $1`, []interface{}{teamID},
+//		func(user *User) error {
+//			return enc.Encode(user)
+//		})
+//
+// Every row is scanned into a new value, so the callback may keep the pointer. Rows are closed in any case.
+func QueryEach[T any](ctx context.Context, db Querier, query string, args []interface{}, fn func(dst *T) error) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		// Each resets row before scanning the next one, so the copy doesn't share anything with it.
+		dst := row
+		return fn(&dst)
+	}, query, args...)
+}
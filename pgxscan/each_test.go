@@ -0,0 +1,62 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err := pgxscan.Each(ctx, testDB, func(row *testModel) error {
+		got = append(got, row)
+		return nil
+	}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestEach_fnErr_stops(t *testing.T) {
+	t.Parallel()
+	stopErr := errors.New("stop")
+	var calls int
+
+	err := pgxscan.Each(ctx, testDB, func(row testModel) error {
+		calls++
+		return stopErr
+	}, multipleRowsQuery)
+
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestQueryEach(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2'), ('foo val 3', 'bar val 3'))
+		AS t (foo, bar) WHERE foo <> $1`
+
+	var got []*testModel
+	err := pgxscan.QueryEach(ctx, testDB, query, []interface{}{"foo val 3"}, func(dst *testModel) error {
+		got = append(got, dst)
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
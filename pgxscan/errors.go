@@ -0,0 +1,108 @@
+package pgxscan
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Sentinel errors that pgxscan wraps PostgreSQL errors into, so application code can handle them
+// without inspecting SQLSTATE codes. They are matched via errors.Is, the original *pgconn.PgError
+// remains in the chain and the error message stays the same. See Error for details of the database error.
+var (
+	// ErrConflict is returned if the statement violates a constraint, e.g. a unique or a foreign key one,
+	// it matches the whole integrity constraint violation class of SQLSTATE codes.
+	ErrConflict = errors.New("scany: constraint violation")
+	// ErrUniqueViolation is returned if the statement violates a unique constraint or an exclusion constraint.
+	ErrUniqueViolation = errors.New("scany: unique violation")
+	// ErrForeignKeyViolation is returned if the statement violates a foreign key constraint.
+	ErrForeignKeyViolation = errors.New("scany: foreign key violation")
+	// ErrSerializationFailure is returned if the transaction can't be serialized with concurrent transactions,
+	// or it's aborted due to a deadlock. The transaction should be retried.
+	ErrSerializationFailure = errors.New("scany: serialization failure")
+)
+
+// SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	integrityConstraintViolationClass = "23"
+	uniqueViolationCode               = "23505"
+	exclusionViolationCode            = "23P01"
+	foreignKeyViolationCode           = "23503"
+	serializationFailureCode          = "40001"
+	deadlockDetectedCode              = "40P01"
+)
+
+// Error is a PostgreSQL error that Select, Get and other high-level functions return,
+// it exposes the details of *pgconn.PgError that services usually handle:
+//
+//	err := pgxscan.Get(ctx, db, &id, `INSERT INTO users (email) VALUES ($1) RETURNING id`, email)
+//	var dbErr *pgxscan.Error
+//	if errors.As(err, &dbErr) && errors.Is(err, pgxscan.ErrUniqueViolation) {
+//		log.Printf("user already exists, violates %s on %s", dbErr.ConstraintName, dbErr.TableName)
+//	}
+//
+// Error matches the sentinel errors of its SQLSTATE code via errors.Is, e.g. ErrConflict and ErrUniqueViolation.
+type Error struct {
+	// Code is the SQLSTATE code of the error, e.g. "23505".
+	Code string
+	// SchemaName, TableName, ColumnName and ConstraintName are the objects that the error is associated with,
+	// they are empty if the error isn't associated with such an object.
+	SchemaName     string
+	TableName      string
+	ColumnName     string
+	ConstraintName string
+
+	err error
+}
+
+// Error returns the message of the original error.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the original error, so *pgconn.PgError can be extracted via errors.As.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error corresponds to the target sentinel error.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrConflict:
+		return strings.HasPrefix(e.Code, integrityConstraintViolationClass)
+	case ErrUniqueViolation:
+		return e.Code == uniqueViolationCode || e.Code == exclusionViolationCode
+	case ErrForeignKeyViolation:
+		return e.Code == foreignKeyViolationCode
+	case ErrSerializationFailure:
+		return e.Code == serializationFailureCode || e.Code == deadlockDetectedCode
+	default:
+		return false
+	}
+}
+
+// SQLState returns the SQLSTATE code of the error.
+func (e *Error) SQLState() string {
+	return e.Code
+}
+
+// classifyError wraps err into Error if there is *pgconn.PgError in the chain.
+func classifyError(err error) error {
+	var pgErr *pgconn.PgError
+	if err == nil || !errors.As(err, &pgErr) {
+		return err
+	}
+	var dbErr *Error
+	if errors.As(err, &dbErr) {
+		return err
+	}
+	return &Error{
+		Code:           pgErr.Code,
+		SchemaName:     pgErr.SchemaName,
+		TableName:      pgErr.TableName,
+		ColumnName:     pgErr.ColumnName,
+		ConstraintName: pgErr.ConstraintName,
+		err:            err,
+	}
+}
@@ -0,0 +1,66 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestGet_uniqueViolation_returnsErr(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE conflicts (id INT CONSTRAINT conflicts_pkey PRIMARY KEY)`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `INSERT INTO conflicts (id) VALUES (1)`)
+	require.NoError(t, err)
+
+	var id int
+	err = testAPI.Get(ctx, tx, &id, `INSERT INTO conflicts (id) VALUES (1) RETURNING id`)
+
+	assert.ErrorIs(t, err, pgxscan.ErrConflict)
+	assert.ErrorIs(t, err, pgxscan.ErrUniqueViolation)
+	assert.False(t, errors.Is(err, pgxscan.ErrForeignKeyViolation))
+	var dbErr *pgxscan.Error
+	require.True(t, errors.As(err, &dbErr))
+	assert.Equal(t, "23505", dbErr.Code)
+	assert.Equal(t, "conflicts_pkey", dbErr.ConstraintName)
+	var pgErr *pgconn.PgError
+	assert.True(t, errors.As(err, &pgErr))
+}
+
+func TestSelect_foreignKeyViolation_returnsErr(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE parents (id INT PRIMARY KEY);
+		CREATE TEMP TABLE children (id INT PRIMARY KEY, parent_id INT REFERENCES parents (id))
+	`)
+	require.NoError(t, err)
+
+	var ids []int
+	err = testAPI.Select(ctx, tx, &ids, `INSERT INTO children (id, parent_id) VALUES (1, 1) RETURNING id`)
+
+	assert.ErrorIs(t, err, pgxscan.ErrConflict)
+	assert.ErrorIs(t, err, pgxscan.ErrForeignKeyViolation)
+	assert.False(t, errors.Is(err, pgxscan.ErrUniqueViolation))
+}
+
+func TestGet_notPgErr_notWrapped(t *testing.T) {
+	t.Parallel()
+
+	var got testModel
+	err := testAPI.Get(ctx, testDB, &got, noRowsQuery)
+
+	var dbErr *pgxscan.Error
+	assert.False(t, errors.As(err, &dbErr))
+	assert.True(t, pgxscan.NotFound(err))
+}
@@ -0,0 +1,197 @@
+package pgxscan
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	_ pgtype.BytesScanner = &jsonRowsScanner{}
+	_ pgtype.BytesScanner = &jsonDecoderScanner{}
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	bytesScannerType    = reflect.TypeOf((*pgtype.BytesScanner)(nil)).Elem()
+)
+
+// JSONDecoder decodes JSON documents, its Unmarshal method has the same contract as json.Unmarshal.
+// It allows plugging a faster JSON library than encoding/json, see WithJSONDecoder.
+type JSONDecoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONDecoderFunc is an adapter to allow the use of ordinary functions as JSONDecoder,
+// e.g. pgxscan.JSONDecoderFunc(sonic.Unmarshal).
+type JSONDecoderFunc func(data []byte, v interface{}) error
+
+// Unmarshal implements the JSONDecoder.Unmarshal method.
+func (f JSONDecoderFunc) Unmarshal(data []byte, v interface{}) error {
+	return f(data, v)
+}
+
+// WithJSONDecoder makes the API decode JSON and JSONB columns with the decoder instead of encoding/json:
+//
+//	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithJSONDecoder(pgxscan.JSONDecoderFunc(sonic.Unmarshal)))
+//
+// JSON documents are passed to the decoder as they are received, for JSONB in the binary format
+// only the version byte is stripped, so there is no conversion to text in between.
+// This applies to struct, map, slice and interface{} destinations, as well as to JSON objects
+// decoded with the same mapping rules as rows, see the Composite types section of the package docs.
+// Destinations that are strings, byte slices or implement sql.Scanner or pgtype.BytesScanner are scanned by pgx as is.
+func WithJSONDecoder(decoder JSONDecoder) APIOption {
+	return func(api *API) {
+		api.jsonDecoder = decoder
+	}
+}
+
+// unmarshalJSON decodes data into v with the decoder, or with encoding/json if the decoder is nil.
+func unmarshalJSON(decoder JSONDecoder, data []byte, v interface{}) error {
+	if decoder == nil {
+		return json.Unmarshal(data, v)
+	}
+	return decoder.Unmarshal(data, v)
+}
+
+func isJSONCodec(codec pgtype.Codec) bool {
+	switch codec.(type) {
+	case pgtype.JSONCodec, pgtype.JSONBCodec, *pgtype.JSONCodec, *pgtype.JSONBCodec:
+		return true
+	default:
+		return false
+	}
+}
+
+// isJSONRowsTarget reports whether JSON objects are decoded into the type with the same mapping rules as rows,
+// it's a struct, a pointer to a struct, or a slice of them, whose fields don't have json tags.
+// Types with json tags or a custom json.Unmarshaler are decoded by encoding/json as usual.
+func isJSONRowsTarget(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	if t.Implements(jsonUnmarshalerType) || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("json"); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonRowsScanner decodes a JSON object, or an array of objects, into the struct or the slice that ptr points to.
+// Keys of objects are mapped to struct fields the same way as columns are,
+// so an object built from a row, e.g. with to_jsonb(t.*) or jsonb_agg(t.*), is decoded like the row itself.
+type jsonRowsScanner struct {
+	dbscanAPI *dbscan.API
+	// decoder is the JSONDecoder of the API, nil means encoding/json.
+	decoder JSONDecoder
+	ptr     reflect.Value
+}
+
+func (s *jsonRowsScanner) ScanBytes(src []byte) error {
+	if src == nil {
+		s.ptr.Elem().Set(reflect.Zero(s.ptr.Elem().Type()))
+		return nil
+	}
+	if err := s.decode(s.ptr.Elem(), src); err != nil {
+		return fmt.Errorf("scany: decode JSON into %v: %w", s.ptr.Elem().Type(), err)
+	}
+	return nil
+}
+
+func (s *jsonRowsScanner) decode(v reflect.Value, data json.RawMessage) error {
+	if !isJSONRowsTarget(v.Type()) {
+		return unmarshalJSON(s.decoder, data, v.Addr().Interface())
+	}
+	if string(data) == "null" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		return s.decode(allocate(v), data)
+	case reflect.Slice:
+		var elems []json.RawMessage
+		if err := unmarshalJSON(s.decoder, data, &elems); err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		for i, elem := range elems {
+			if err := s.decode(v.Index(i), elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		var object map[string]json.RawMessage
+		if err := unmarshalJSON(s.decoder, data, &object); err != nil {
+			return err
+		}
+		for key, value := range object {
+			fieldIndex, ok := s.dbscanAPI.FieldIndex(v.Type(), key)
+			if !ok {
+				// Like encoding/json, ignore keys that have no corresponding field.
+				continue
+			}
+			if err := s.decode(dbscan.FieldByIndex(v, fieldIndex), value); err != nil {
+				return fmt.Errorf("key %q: %w", key, err)
+			}
+		}
+		return nil
+	}
+}
+
+// isJSONDecoderTarget reports whether the JSONDecoder decodes JSON columns into the type,
+// it's a struct, a map, a slice other than a byte slice, an interface or a pointer to them,
+// that doesn't implement sql.Scanner or pgtype.BytesScanner, pgx scans such types by itself.
+func isJSONDecoderTarget(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for _, iface := range []reflect.Type{sqlScannerType, bytesScannerType} {
+		if t.Implements(iface) || reflect.PtrTo(t).Implements(iface) {
+			return false
+		}
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// jsonDecoderScanner decodes a JSON document into the value that ptr points to with the JSONDecoder.
+type jsonDecoderScanner struct {
+	decoder JSONDecoder
+	ptr     reflect.Value
+}
+
+func (s *jsonDecoderScanner) ScanBytes(src []byte) error {
+	if src == nil {
+		s.ptr.Elem().Set(reflect.Zero(s.ptr.Elem().Type()))
+		return nil
+	}
+	if err := s.decoder.Unmarshal(src, s.ptr.Interface()); err != nil {
+		return fmt.Errorf("scany: decode JSON into %v: %w", s.ptr.Elem().Type(), err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package pgxscan_test
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestGet_withJSONDecoder_decodesJSONColumns(t *testing.T) {
+	t.Parallel()
+	type tagged struct {
+		ChildID int `json:"child_id"`
+	}
+	type dst struct {
+		Tagged   tagged
+		Map      map[string]interface{}
+		Untagged struct{ ChildID int }
+		Text     string
+	}
+	var calls int32
+	decoder := pgxscan.JSONDecoderFunc(func(data []byte, v interface{}) error {
+		atomic.AddInt32(&calls, 1)
+		return json.Unmarshal(data, v)
+	})
+	dbscanAPI, err := pgxscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithJSONDecoder(decoder))
+	require.NoError(t, err)
+	query := `
+		SELECT '{"child_id": 1}'::JSONB AS tagged, '{"child_id": 2}'::JSONB AS map,
+			'{"child_id": 3}'::JSONB AS untagged, '{"child_id": 4}'::JSONB AS text
+	`
+
+	var got dst
+	err = api.Get(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, tagged{ChildID: 1}, got.Tagged)
+	assert.Equal(t, map[string]interface{}{"child_id": float64(2)}, got.Map)
+	assert.Equal(t, 3, got.Untagged.ChildID)
+	assert.JSONEq(t, `{"child_id": 4}`, got.Text)
+	assert.Positive(t, atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,208 @@
+package pgxscan
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+const largeObjectTagOption = "lo"
+
+var (
+	bytesType  = reflect.TypeOf([]byte(nil))
+	readerType = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// largeObjectColumns returns columns of the destination struct that are marked with the "lo" tag option,
+// or nil if there are none. dst is a pointer to a struct or to a slice of structs.
+func (api *API) largeObjectColumns(dst interface{}) ([]string, error) {
+	structType, ok := destinationStruct(dst)
+	if !ok {
+		return nil, nil
+	}
+	columns, err := api.dbscanAPI.Columns(reflect.New(structType).Interface(), dbscan.ColumnsTagged(largeObjectTagOption))
+	if err != nil || len(columns) == 0 {
+		return nil, err
+	}
+	for _, column := range columns {
+		fieldType, _ := api.dbscanAPI.FieldType(structType, column)
+		if fieldType != bytesType && fieldType != readerType {
+			return nil, fmt.Errorf(
+				"scany: column %s: large object field must be of []byte or io.Reader type, got: %v", column, fieldType,
+			)
+		}
+	}
+	return columns, nil
+}
+
+// prepareLargeObjects returns large object columns of the destination, and the transaction to read them in.
+func (api *API) prepareLargeObjects(db Querier, dst interface{}) ([]string, pgx.Tx, error) {
+	columns, err := api.largeObjectColumns(dst)
+	if err != nil || columns == nil {
+		return nil, nil, err
+	}
+	tx, ok := db.(pgx.Tx)
+	if !ok {
+		return nil, nil, fmt.Errorf("scany: large object fields can only be read within a transaction, got: %T", db)
+	}
+	return columns, tx, nil
+}
+
+func (ra RowsAdapter) isLargeObjectColumn(column string) bool {
+	for _, c := range ra.largeObjectColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// destinationStruct returns the struct type of the destination, that is a pointer to a struct
+// or to a slice of structs, the slice elements may be pointers as well.
+func destinationStruct(dst interface{}) (reflect.Type, bool) {
+	t := reflect.TypeOf(dst)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	t = t.Elem()
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t, t.Kind() == reflect.Struct
+}
+
+// largeObjectDst scans the OID of a large object into the field as a placeholder,
+// the large object is read into the field once all rows are scanned, see readLargeObjects.
+// A []byte field holds the OID in 4 bytes, an io.Reader field holds a largeObjectRef.
+type largeObjectDst struct {
+	dst interface{}
+}
+
+var _ pgtype.Uint32Scanner = &largeObjectDst{}
+
+func (d *largeObjectDst) ScanUint32(v pgtype.Uint32) error {
+	switch dst := d.dst.(type) {
+	case *[]byte:
+		if !v.Valid {
+			*dst = nil
+			return nil
+		}
+		*dst = make([]byte, 4)
+		binary.BigEndian.PutUint32(*dst, v.Uint32)
+	case *io.Reader:
+		if !v.Valid {
+			*dst = nil
+			return nil
+		}
+		*dst = &largeObjectRef{oid: v.Uint32}
+	default:
+		return fmt.Errorf("scany: large object field must be of []byte or io.Reader type, got: %T", d.dst)
+	}
+	return nil
+}
+
+// largeObjectRef is a placeholder of an io.Reader field until the large object is opened.
+type largeObjectRef struct {
+	oid uint32
+}
+
+func (r *largeObjectRef) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("scany: large object %d isn't opened", r.oid)
+}
+
+// scannedFrom returns the index of the first element that ScanAll scans into the slice that dst points to,
+// it's the current length of the slice with dbscan.WithAppendToSlice, and 0 otherwise.
+func (api *API) scannedFrom(dst interface{}) int {
+	if !api.dbscanAPI.AppendsToSlice() {
+		return 0
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Slice {
+		return 0
+	}
+	return dstValue.Elem().Len()
+}
+
+// readLargeObjects replaces OID placeholders in the large object fields of dst with the large objects:
+// []byte fields receive the whole content, io.Reader fields receive the large object opened for reading,
+// that streams the content and remains valid until the transaction ends.
+// If dst is a pointer to a slice, only elements starting from the from index are scanned ones, see scannedFrom,
+// the ones before them already hold their large objects.
+func (api *API) readLargeObjects(ctx context.Context, tx pgx.Tx, dst interface{}, columns []string, from int) error {
+	largeObjects := tx.LargeObjects()
+	dstValue := reflect.ValueOf(dst).Elem()
+	structs := []reflect.Value{dstValue}
+	if dstValue.Kind() == reflect.Slice {
+		structs = make([]reflect.Value, 0, dstValue.Len()-from)
+		for i := from; i < dstValue.Len(); i++ {
+			structs = append(structs, dstValue.Index(i))
+		}
+	}
+	for _, structValue := range structs {
+		if structValue.Kind() == reflect.Ptr {
+			if structValue.IsNil() {
+				continue
+			}
+			structValue = structValue.Elem()
+		}
+		for _, column := range columns {
+			fieldIndex, _ := api.dbscanAPI.FieldIndex(structValue.Type(), column)
+			field, err := structValue.FieldByIndexErr(fieldIndex)
+			if err != nil {
+				// A nested struct on the path is nil, so there is no large object to read.
+				continue
+			}
+			if err := readLargeObject(ctx, &largeObjects, field); err != nil {
+				return fmt.Errorf("scany: column %s: %w", column, err)
+			}
+		}
+	}
+	return nil
+}
+
+func readLargeObject(ctx context.Context, largeObjects *pgx.LargeObjects, field reflect.Value) error {
+	var oid uint32
+	switch v := field.Interface().(type) {
+	case []byte:
+		if v == nil {
+			return nil
+		}
+		if len(v) != 4 {
+			return fmt.Errorf("large object OID must be 4 bytes long, got: %d bytes", len(v))
+		}
+		oid = binary.BigEndian.Uint32(v)
+	case *largeObjectRef:
+		oid = v.oid
+	default:
+		// The field is nil or hasn't been scanned, e.g. the column isn't in the query.
+		return nil
+	}
+	lo, err := largeObjects.Open(ctx, oid, pgx.LargeObjectModeRead)
+	if err != nil {
+		return fmt.Errorf("open large object %d: %w", oid, err)
+	}
+	if field.Type() == readerType {
+		field.Set(reflect.ValueOf(lo))
+		return nil
+	}
+	data, err := io.ReadAll(lo)
+	if err != nil {
+		_ = lo.Close()
+		return fmt.Errorf("read large object %d: %w", oid, err)
+	}
+	if err := lo.Close(); err != nil {
+		return fmt.Errorf("close large object %d: %w", oid, err)
+	}
+	field.SetBytes(data)
+	return nil
+}
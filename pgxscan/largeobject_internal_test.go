@@ -0,0 +1,18 @@
+package pgxscan
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLargeObject_shortOID_returnsErr(t *testing.T) {
+	t.Parallel()
+	data := []byte{1, 2}
+
+	err := readLargeObject(context.Background(), nil, reflect.ValueOf(&data).Elem())
+
+	assert.EqualError(t, err, "large object OID must be 4 bytes long, got: 2 bytes")
+}
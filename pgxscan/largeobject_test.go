@@ -0,0 +1,82 @@
+package pgxscan_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestGet_largeObjectField_notInTx_returnsErr(t *testing.T) {
+	t.Parallel()
+	type file struct {
+		Name string
+		Data []byte `db:"data,lo"`
+	}
+
+	var got file
+	err := testAPI.Get(ctx, testDB, &got, `SELECT 'foo' AS name, 1::OID AS data`)
+
+	assert.EqualError(t, err, "scany: large object fields can only be read within a transaction, got: *pgxpool.Pool")
+}
+
+func TestSelect_largeObjectField_invalidType_returnsErr(t *testing.T) {
+	t.Parallel()
+	type file struct {
+		Name string
+		Data string `db:"data,lo"`
+	}
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+
+	var got []*file
+	err = testAPI.Select(ctx, tx, &got, `SELECT 'foo' AS name, 1::OID AS data`)
+
+	assert.EqualError(t, err, "scany: column data: large object field must be of []byte or io.Reader type, got: string")
+}
+
+func TestSelect_largeObjectField_null_leavesFieldEmpty(t *testing.T) {
+	t.Parallel()
+	type file struct {
+		Name string
+		Data []byte    `db:"data,lo"`
+		Body io.Reader `db:"body,lo"`
+	}
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+
+	var got []*file
+	err = testAPI.Select(ctx, tx, &got, `SELECT 'foo' AS name, NULL::OID AS data, NULL::OID AS body`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*file{{Name: "foo"}}, got)
+}
+
+func TestSelect_largeObjectFieldWithAppendToSlice_keepsExistingElements(t *testing.T) {
+	t.Parallel()
+	type file struct {
+		Name string
+		Data []byte `db:"data,lo"`
+	}
+	dbscanAPI, err := pgxscan.NewDBScanAPI(dbscan.WithAppendToSlice(true))
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	// The content of the existing element isn't an OID, it must not be read as a large object.
+	got := []*file{{Name: "existing", Data: []byte("existing content")}}
+	expected := []*file{{Name: "existing", Data: []byte("existing content")}, {Name: "foo"}}
+
+	err = api.Select(ctx, tx, &got, `SELECT 'foo' AS name, NULL::OID AS data`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
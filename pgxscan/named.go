@@ -0,0 +1,125 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/georgysavva/scany/v2/internal/namedparam"
+)
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.GetNamed for details.
+func GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// BindNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.BindNamed for details.
+func BindNamed(ctx context.Context, query string, arg interface{}) (string, []interface{}, error) {
+	return DefaultAPI.BindNamed(ctx, query, arg)
+}
+
+// NamedArgs is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedArgs for details.
+func NamedArgs(ctx context.Context, arg interface{}) (pgx.QueryRewriter, error) {
+	return DefaultAPI.NamedArgs(ctx, arg)
+}
+
+// SelectNamed is the same as Select, but it takes named arguments, e.g. `WHERE id = @id`,
+// and binds them from a struct or a map. See BindNamed for details.
+func (api *API) SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	query, args, err := api.BindNamed(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	return api.Select(ctx, db, dst, query, args...)
+}
+
+// GetNamed is the same as Get, but it takes named arguments, e.g. `WHERE id = @id`,
+// and binds them from a struct or a map. See BindNamed for details.
+func (api *API) GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	query, args, err := api.BindNamed(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	return api.Get(ctx, db, dst, query, args...)
+}
+
+// BindNamed rewrites named arguments in the query, e.g. `WHERE id = @id`, into positional placeholders,
+// the same syntax as pgx.NamedArgs has, and returns the values of arguments in the order of placeholders.
+// arg is either a map with string keys, e.g. pgx.NamedArgs, or a struct, in which case arguments are named
+// after columns, with the same values that dbscan.API.NamedArgs returns, e.g. `@post.id` for a nested struct.
+// Arguments inside string literals, quoted identifiers and comments are left as is.
+// Unlike pgx.NamedArgs, an argument that is missing in arg is an error.
+func (api *API) BindNamed(ctx context.Context, query string, arg interface{}) (string, []interface{}, error) {
+	lookup, err := namedparam.Lookup(ctx, api.dbscanAPI, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	var missing string
+	query, args := rewriteNamed(query, func(name string) interface{} {
+		value, ok := lookup(name)
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", nil, fmt.Errorf("scany: named argument '%s' is missing in args", missing)
+	}
+	return query, args, nil
+}
+
+// NamedArgs returns a pgx.QueryRewriter that binds named arguments from a struct or a map the same way
+// as BindNamed does, so it can be passed as the first argument to any pgx query method, e.g. Exec or Batch.Queue:
+//
+//	args, err := pgxscan.NamedArgs(ctx, &user)
+//	_, err = conn.Exec(ctx, `UPDATE users SET email = @email WHERE id = @id`, args)
+//
+// Same as with pgx.NamedArgs, arguments that are missing in arg are bound to NULL.
+func (api *API) NamedArgs(ctx context.Context, arg interface{}) (pgx.QueryRewriter, error) {
+	lookup, err := namedparam.Lookup(ctx, api.dbscanAPI, arg)
+	if err != nil {
+		return nil, err
+	}
+	return namedArgsRewriter(lookup), nil
+}
+
+type namedArgsRewriter func(name string) (interface{}, bool)
+
+// RewriteQuery implements the pgx.QueryRewriter.RewriteQuery method.
+func (r namedArgsRewriter) RewriteQuery(
+	ctx context.Context, conn *pgx.Conn, sql string, args []interface{},
+) (string, []interface{}) {
+	return rewriteNamed(sql, func(name string) interface{} {
+		value, _ := r(name)
+		return value
+	})
+}
+
+// rewriteNamed replaces named arguments in the query with positional placeholders,
+// an argument used more than once gets the same placeholder.
+func rewriteNamed(query string, value func(name string) interface{}) (string, []interface{}) {
+	var args []interface{}
+	placeholders := make(map[string]int)
+	// The placeholder function never fails, so neither does Rewrite.
+	query, _ = namedparam.Rewrite(query, '@', func(name string) (string, error) {
+		n, ok := placeholders[name]
+		if !ok {
+			args = append(args, value(name))
+			n = len(args)
+			placeholders[name] = n
+		}
+		return "$" + strconv.Itoa(n), nil
+	})
+	return query, args
+}
@@ -0,0 +1,108 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestBindNamed(t *testing.T) {
+	t.Parallel()
+	type post struct {
+		ID string
+	}
+	type args struct {
+		Foo  string
+		Post post
+	}
+	cases := []struct {
+		name         string
+		query        string
+		arg          interface{}
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "struct",
+			query:        `SELECT * FROM t WHERE foo = @foo AND post_id = @post.id`,
+			arg:          &args{Foo: "foo val", Post: post{ID: "1"}},
+			expectedSQL:  `SELECT * FROM t WHERE foo = $1 AND post_id = $2`,
+			expectedArgs: []interface{}{"foo val", "1"},
+		},
+		{
+			name:         "map",
+			query:        `SELECT * FROM t WHERE foo = @foo OR bar = @foo`,
+			arg:          pgx.NamedArgs{"foo": "foo val"},
+			expectedSQL:  `SELECT * FROM t WHERE foo = $1 OR bar = $1`,
+			expectedArgs: []interface{}{"foo val"},
+		},
+		{
+			name:         "quoted and operators",
+			query:        `SELECT '@foo', "@foo", $$@foo$$, a @> b -- @foo` + "\n" + `WHERE foo = @foo`,
+			arg:          map[string]interface{}{"foo": "foo val"},
+			expectedSQL:  `SELECT '@foo', "@foo", $$@foo$$, a @> b -- @foo` + "\n" + `WHERE foo = $1`,
+			expectedArgs: []interface{}{"foo val"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			sql, args, err := pgxscan.BindNamed(ctx, tc.query, tc.arg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedSQL, sql)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestBindNamed_missingArg_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := pgxscan.BindNamed(ctx, `SELECT @foo, @bar`, map[string]interface{}{"foo": "foo val"})
+
+	assert.EqualError(t, err, "scany: named argument 'bar' is missing in args")
+}
+
+func TestSelectNamed(t *testing.T) {
+	t.Parallel()
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2')) AS t (foo, bar) WHERE foo = @foo`
+	expected := []*testModel{{Foo: "foo val 2", Bar: "bar val 2"}}
+
+	var got []*testModel
+	err := testAPI.SelectNamed(ctx, testDB, &got, query, &testModel{Foo: "foo val 2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed(t *testing.T) {
+	t.Parallel()
+	query := `SELECT @foo::TEXT AS foo, @bar::TEXT AS bar`
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.GetNamed(ctx, testDB, &got, query, expected)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestNamedArgs_queryRewriter(t *testing.T) {
+	t.Parallel()
+	query := `SELECT @foo::TEXT AS foo, @bar::TEXT AS bar`
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+	args, err := testAPI.NamedArgs(ctx, expected)
+	require.NoError(t, err)
+
+	var got testModel
+	err = testAPI.Get(ctx, testDB, &got, query, args)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
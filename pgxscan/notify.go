@@ -0,0 +1,72 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Listen starts listening for notifications on the channel, see WaitForNotification.
+// The channel name is quoted, so it's case-sensitive.
+func Listen(ctx context.Context, conn *pgx.Conn, channel string) error {
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("scany: listen %s: %w", channel, err)
+	}
+	return nil
+}
+
+// WaitForNotification is a package-level helper function that uses the DefaultAPI object.
+// See API.WaitForNotification for details.
+func WaitForNotification(ctx context.Context, conn *pgx.Conn, dst interface{}) (*pgconn.Notification, error) {
+	return DefaultAPI.WaitForNotification(ctx, conn, dst)
+}
+
+// ScanNotification is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanNotification for details.
+func ScanNotification(n *pgconn.Notification, dst interface{}) error {
+	return DefaultAPI.ScanNotification(n, dst)
+}
+
+// WaitForNotification waits for a notification on any of the channels the connection listens on,
+// and decodes its JSON payload into dst, see ScanNotification:
+//
+//	err := pgxscan.Listen(ctx, conn, "orders")
+//	for {
+//		var order Order
+//		n, err := pgxscan.WaitForNotification(ctx, conn, &order)
+//		...
+//	}
+//
+// The notification is returned as well, so the caller can tell channels apart.
+// If the payload can't be decoded, both the notification and the error are returned.
+func (api *API) WaitForNotification(
+	ctx context.Context, conn *pgx.Conn, dst interface{},
+) (*pgconn.Notification, error) {
+	n, err := conn.WaitForNotification(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scany: wait for notification: %w", err)
+	}
+	if err := api.ScanNotification(n, dst); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// ScanNotification decodes the JSON payload of the notification into dst, e.g. sent with
+// pg_notify('orders', row_to_json(NEW)::text) from a trigger. Keys of JSON objects are mapped to struct fields
+// the same way as columns are, structs that have json tags or implement json.Unmarshaler are decoded
+// by encoding/json as usual, see the Composite types section of the package docs.
+func (api *API) ScanNotification(n *pgconn.Notification, dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("scany: destination must be a non-nil pointer, got: %T", dst)
+	}
+	s := &jsonRowsScanner{dbscanAPI: api.dbscanAPI, decoder: api.jsonDecoder, ptr: dstValue}
+	if err := s.ScanBytes([]byte(n.Payload)); err != nil {
+		return fmt.Errorf("scany: notification on channel %s: %w", n.Channel, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestScanNotification(t *testing.T) {
+	t.Parallel()
+	type event struct {
+		OrderID int
+		Model   testModel
+	}
+	n := &pgconn.Notification{
+		Channel: "orders",
+		Payload: `{"order_id": 1, "model": {"foo": "foo val", "bar": "bar val"}, "unknown": true}`,
+	}
+
+	var got event
+	err := testAPI.ScanNotification(n, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, event{OrderID: 1, Model: testModel{Foo: "foo val", Bar: "bar val"}}, got)
+}
+
+func TestScanNotification_invalidPayload_returnsErr(t *testing.T) {
+	t.Parallel()
+	n := &pgconn.Notification{Channel: "orders", Payload: `{"foo": 1}`}
+
+	var got testModel
+	err := pgxscan.ScanNotification(n, &got)
+
+	assert.ErrorContains(t, err, "scany: notification on channel orders: ")
+}
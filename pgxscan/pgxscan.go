@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/georgysavva/scany/v2/dbscan"
@@ -18,6 +20,12 @@ type Querier interface {
 	Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error)
 }
 
+var (
+	_ dbscan.Rows                = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows     = &RowsAdapter{}
+	_ dbscan.ScanErrorColumnRows = &RowsAdapter{}
+)
+
 var (
 	_ Querier = &pgxpool.Pool{}
 	_ Querier = &pgx.Conn{}
@@ -48,6 +56,36 @@ func ScanOne(dst interface{}, rows pgx.Rows) error {
 	return DefaultAPI.ScanOne(dst, rows)
 }
 
+// ScanAllContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllContext for details.
+func ScanAllContext(ctx context.Context, dst interface{}, rows pgx.Rows) error {
+	return DefaultAPI.ScanAllContext(ctx, dst, rows)
+}
+
+// ScanOneContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOneContext for details.
+func ScanOneContext(ctx context.Context, dst interface{}, rows pgx.Rows) error {
+	return DefaultAPI.ScanOneContext(ctx, dst, rows)
+}
+
+// ScanPivot is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanPivot for details.
+func ScanPivot(rows pgx.Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return DefaultAPI.ScanPivot(rows, keyColumn)
+}
+
+// ScanToJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToJSON for details.
+func ScanToJSON(w io.Writer, rows pgx.Rows) error {
+	return DefaultAPI.ScanToJSON(w, rows)
+}
+
+// ScanToCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToCSV for details.
+func ScanToCSV(w io.Writer, rows pgx.Rows) error {
+	return DefaultAPI.ScanToCSV(w, rows)
+}
+
 // RowScanner is a wrapper around the dbscan.RowScanner type.
 // See dbscan.RowScanner for details.
 type RowScanner struct {
@@ -81,24 +119,45 @@ func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
 // API is a wrapper around the dbscan.API type.
 // See dbscan.API for details.
 type API struct {
-	dbscanAPI *dbscan.API
+	dbscanAPI   *dbscan.API
+	jsonDecoder JSONDecoder
+	typeHook    TypeHook
 }
 
-// NewAPI creates new API instance from dbscan.API instance.
-func NewAPI(dbscanAPI *dbscan.API) (*API, error) {
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
 	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
 	return api, nil
 }
 
 // Select is a high-level function that queries rows from Querier and calls the ScanAll function.
 // See ScanAll for details.
 func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	loColumns, loTx, err := api.prepareLargeObjects(db, dst)
+	if err != nil {
+		return err
+	}
+	var loFrom int
+	if loTx != nil {
+		loFrom = api.scannedFrom(dst)
+	}
 	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("scany: query multiple result rows: %w", err)
+		return classifyError(fmt.Errorf("scany: query multiple result rows: %w", err))
 	}
-	if err := api.ScanAll(dst, rows); err != nil {
-		return fmt.Errorf("scanning all: %w", err)
+	ra := api.newRowsAdapter(rows)
+	ra.largeObjectColumns = loColumns
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, ra); err != nil {
+		return classifyError(fmt.Errorf("scanning all: %w", err))
+	}
+	if loTx != nil {
+		return api.readLargeObjects(ctx, loTx, dst, loColumns, loFrom)
 	}
 	return nil
 }
@@ -106,12 +165,21 @@ func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query s
 // Get is a high-level function that queries rows from Querier and calls the ScanOne function.
 // See ScanOne for details.
 func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	loColumns, loTx, err := api.prepareLargeObjects(db, dst)
+	if err != nil {
+		return err
+	}
 	rows, err := db.Query(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("scany: query one result row: %w", err)
+		return classifyError(fmt.Errorf("scany: query one result row: %w", err))
 	}
-	if err := api.ScanOne(dst, rows); err != nil {
-		return fmt.Errorf("scanning one: %w", err)
+	ra := api.newRowsAdapter(rows)
+	ra.largeObjectColumns = loColumns
+	if err := api.scanOne(ctx, dst, ra); err != nil {
+		return classifyError(fmt.Errorf("scanning one: %w", err))
+	}
+	if loTx != nil {
+		return api.readLargeObjects(ctx, loTx, dst, loColumns, 0)
 	}
 	return nil
 }
@@ -119,16 +187,33 @@ func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query stri
 // ScanAll is a wrapper around the dbscan.ScanAll function.
 // See dbscan.ScanAll for details.
 func (api *API) ScanAll(dst interface{}, rows pgx.Rows) error {
-	return api.dbscanAPI.ScanAll(dst, NewRowsAdapter(rows))
+	return api.dbscanAPI.ScanAll(dst, api.newRowsAdapter(rows))
+}
+
+// ScanAllContext is a wrapper around the dbscan.ScanAllContext function.
+// See dbscan.ScanAllContext for details.
+func (api *API) ScanAllContext(ctx context.Context, dst interface{}, rows pgx.Rows) error {
+	return api.dbscanAPI.ScanAllContext(ctx, dst, api.newRowsAdapter(rows))
 }
 
 // ScanOne is a wrapper around the dbscan.ScanOne function.
 // See dbscan.ScanOne for details. If no rows are found it
-// returns a pgx.ErrNoRows error.
+// returns a pgx.ErrNoRows error, that matches dbscan.ErrNotFound via errors.Is as well.
 func (api *API) ScanOne(dst interface{}, rows pgx.Rows) error {
-	switch err := api.dbscanAPI.ScanOne(dst, NewRowsAdapter(rows)); {
-	case dbscan.NotFound(err):
-		return fmt.Errorf("%w", pgx.ErrNoRows)
+	return api.ScanOneContext(context.Background(), dst, rows)
+}
+
+// ScanOneContext is a wrapper around the dbscan.ScanOneContext function.
+// See dbscan.ScanOneContext for details. If no rows are found it
+// returns a pgx.ErrNoRows error, that matches dbscan.ErrNotFound via errors.Is as well.
+func (api *API) ScanOneContext(ctx context.Context, dst interface{}, rows pgx.Rows) error {
+	return api.scanOne(ctx, dst, api.newRowsAdapter(rows))
+}
+
+func (api *API) scanOne(ctx context.Context, dst interface{}, rows dbscan.Rows) error {
+	switch err := api.dbscanAPI.ScanOneContext(ctx, dst, rows); {
+	case dbscan.NotFound(err), errors.Is(err, pgx.ErrNoRows):
+		return dbscan.WrapNotFound(pgx.ErrNoRows)
 	case err != nil:
 		return fmt.Errorf("%w", err)
 	default:
@@ -136,28 +221,61 @@ func (api *API) ScanOne(dst interface{}, rows pgx.Rows) error {
 	}
 }
 
+// ScanPivot is a wrapper around the dbscan.ScanPivot function.
+// See dbscan.ScanPivot for details.
+func (api *API) ScanPivot(rows pgx.Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return api.dbscanAPI.ScanPivot(api.newRowsAdapter(rows), keyColumn)
+}
+
+// ScanToJSON is a wrapper around the dbscan.ScanToJSON function.
+// See dbscan.ScanToJSON for details.
+func (api *API) ScanToJSON(w io.Writer, rows pgx.Rows) error {
+	return api.dbscanAPI.ScanToJSON(w, api.newRowsAdapter(rows))
+}
+
+// ScanToJSONContext is a wrapper around the dbscan.ScanToJSONContext function.
+// See dbscan.ScanToJSONContext for details.
+func (api *API) ScanToJSONContext(ctx context.Context, w io.Writer, rows pgx.Rows) error {
+	return api.dbscanAPI.ScanToJSONContext(ctx, w, api.newRowsAdapter(rows))
+}
+
+// ScanToCSV is a wrapper around the dbscan.ScanToCSV function.
+// See dbscan.ScanToCSV for details.
+func (api *API) ScanToCSV(w io.Writer, rows pgx.Rows) error {
+	return api.dbscanAPI.ScanToCSV(w, api.newRowsAdapter(rows))
+}
+
+// ScanToCSVContext is a wrapper around the dbscan.ScanToCSVContext function.
+// See dbscan.ScanToCSVContext for details.
+func (api *API) ScanToCSVContext(ctx context.Context, w io.Writer, rows pgx.Rows) error {
+	return api.dbscanAPI.ScanToCSVContext(ctx, w, api.newRowsAdapter(rows))
+}
+
 // NotFound is a helper function to check if an error
-// is `pgx.ErrNoRows`.
+// is `pgx.ErrNoRows` or `dbscan.ErrNotFound`.
 func NotFound(err error) bool {
-	return errors.Is(err, pgx.ErrNoRows)
+	return errors.Is(err, pgx.ErrNoRows) || dbscan.NotFound(err)
 }
 
 // NewRowScanner returns a new RowScanner instance.
 func (api *API) NewRowScanner(rows pgx.Rows) *RowScanner {
-	ra := NewRowsAdapter(rows)
+	ra := api.newRowsAdapter(rows)
 	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(ra)}
 }
 
 // ScanRow is a wrapper around the dbscan.ScanRow function.
 // See dbscan.ScanRow for details.
 func (api *API) ScanRow(dst interface{}, rows pgx.Rows) error {
-	return api.dbscanAPI.ScanRow(dst, NewRowsAdapter(rows))
+	return api.dbscanAPI.ScanRow(dst, api.newRowsAdapter(rows))
 }
 
 // RowsAdapter makes pgx.Rows compliant with the dbscan.Rows interface.
 // See dbscan.Rows for details.
 type RowsAdapter struct {
 	pgx.Rows
+	api *API
+	// largeObjectColumns are columns with OIDs of large objects that Get and Select read into the fields.
+	largeObjectColumns []string
 }
 
 // NewRowsAdapter returns a new RowsAdapter instance.
@@ -165,6 +283,10 @@ func NewRowsAdapter(rows pgx.Rows) *RowsAdapter {
 	return &RowsAdapter{Rows: rows}
 }
 
+func (api *API) newRowsAdapter(rows pgx.Rows) *RowsAdapter {
+	return &RowsAdapter{Rows: rows, api: api}
+}
+
 // Columns implements the dbscan.Rows.Columns method.
 func (ra RowsAdapter) Columns() ([]string, error) {
 	columns := make([]string, len(ra.Rows.FieldDescriptions()))
@@ -174,12 +296,85 @@ func (ra RowsAdapter) Columns() ([]string, error) {
 	return columns, nil
 }
 
+// Scan implements the dbscan.Rows.Scan method.
+// Columns of composite types and records, including ROW(...) values, and arrays of them,
+// are decoded into struct destinations directly, composite fields are mapped to struct fields
+// the same way as columns are, and record fields are mapped to exported struct fields by position.
+// Columns of large object fields, see the Large objects section of the package docs, receive OID placeholders.
+// Go types registered in the connection type map and columns taken by the type hook of the API,
+// see WithTypeHook, are left to pgx.
+func (ra RowsAdapter) Scan(dest ...interface{}) error {
+	fieldDescriptions := ra.Rows.FieldDescriptions()
+	var typeMap *pgtype.Map
+	if conn := ra.Rows.Conn(); conn != nil {
+		typeMap = conn.TypeMap()
+	}
+	api := ra.api
+	if api == nil {
+		api = DefaultAPI
+	}
+	var wrapped []interface{}
+	for i, dst := range dest {
+		if i >= len(fieldDescriptions) {
+			break
+		}
+		var target interface{}
+		if ra.isLargeObjectColumn(fieldDescriptions[i].Name) {
+			target = &largeObjectDst{dst: dst}
+		} else {
+			target = api.scanTarget(typeMap, fieldDescriptions[i], dst)
+		}
+		if target == dst {
+			continue
+		}
+		if wrapped == nil {
+			wrapped = make([]interface{}, len(dest))
+			copy(wrapped, dest)
+		}
+		wrapped[i] = target
+	}
+	if wrapped != nil {
+		dest = wrapped
+	}
+	return ra.Rows.Scan(dest...)
+}
+
 // Close implements the dbscan.Rows.Close method.
 func (ra RowsAdapter) Close() error {
 	ra.Rows.Close()
 	return nil
 }
 
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+// If the type of a column isn't registered in the connection type map, it's reported by its OID.
+func (ra RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	fieldDescriptions := ra.Rows.FieldDescriptions()
+	var typeMap *pgtype.Map
+	if conn := ra.Rows.Conn(); conn != nil {
+		typeMap = conn.TypeMap()
+	}
+	dbTypes := make([]string, len(fieldDescriptions))
+	for i, fd := range fieldDescriptions {
+		if typeMap != nil {
+			if t, ok := typeMap.TypeForOID(fd.DataTypeOID); ok {
+				dbTypes[i] = t.Name
+				continue
+			}
+		}
+		dbTypes[i] = fmt.Sprintf("OID %d", fd.DataTypeOID)
+	}
+	return dbTypes, nil
+}
+
+// ScanErrorColumn implements the dbscan.ScanErrorColumnRows.ScanErrorColumn method.
+func (ra RowsAdapter) ScanErrorColumn(err error) (int, bool) {
+	var scanArgErr pgx.ScanArgError
+	if errors.As(err, &scanArgErr) {
+		return scanArgErr.ColumnIndex, true
+	}
+	return 0, false
+}
+
 // NextResultSet is currently always returning false.
 func (ra RowsAdapter) NextResultSet() bool {
 	// TODO: when pgx issue #308 and #1512 and  is fixed mabye we can do something here.
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/georgysavva/scany/v2"
 	"github.com/georgysavva/scany/v2/pgxscan"
 )
 
@@ -139,6 +140,7 @@ func TestScanOne_noRows_returnsNotFoundErr(t *testing.T) {
 
 	assert.True(t, pgxscan.NotFound(err))
 	assert.True(t, errors.Is(err, pgx.ErrNoRows))
+	assert.True(t, errors.Is(err, scany.ErrNotFound))
 }
 
 func TestRowScanner_Scan(t *testing.T) {
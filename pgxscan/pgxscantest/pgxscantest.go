@@ -0,0 +1,172 @@
+// Package pgxscantest provides a mock of pgxscan.Querier for unit tests of code that queries databases
+// with pgxscan, e.g. repositories, without a database.
+/*
+Querier records queries and returns canned rows, that can be built from structs:
+
+	db := pgxscantest.NewQuerier()
+	db.On("FROM users").Return([]User{{ID: 1, Name: "foo"}})
+
+	users, err := repo.ListUsers(ctx, db)
+	// db.Queries() has the query and its arguments.
+
+Rows of Querier are in-memory pgx.Rows, values are assigned to destinations with adapter.Assign,
+database types of columns are unknown.
+*/
+package pgxscantest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+var _ pgxscan.Querier = &Querier{}
+
+// Query is a query that Querier received.
+type Query struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Response is the canned response to queries that contain the text, see Querier.On.
+type Response struct {
+	text       string
+	columns    []string
+	values     [][]interface{}
+	err        error
+	commandTag string
+}
+
+// Return makes queries return rows with data of the struct or the slice of structs, see dbscantest.StructRows.
+// It panics if src isn't a struct or a slice of structs.
+func (r *Response) Return(src interface{}) *Response {
+	columns, values, err := dbscantest.StructRows(src)
+	if err != nil {
+		panic(err)
+	}
+	return r.ReturnRows(columns, values)
+}
+
+// ReturnRows makes queries return rows with the columns and values of every row in the order of columns.
+func (r *Response) ReturnRows(columns []string, values [][]interface{}) *Response {
+	r.columns = columns
+	r.values = values
+	return r
+}
+
+// ReturnError makes queries and statements fail with the error.
+func (r *Response) ReturnError(err error) *Response {
+	r.err = err
+	return r
+}
+
+// ReturnCommandTag makes queries and statements return the command tag, e.g. "UPDATE 1".
+func (r *Response) ReturnCommandTag(commandTag string) *Response {
+	r.commandTag = commandTag
+	return r
+}
+
+// Querier is a mock of pgxscan.Querier, that also has the Exec method of pgx connections.
+// Querier is safe for concurrent use.
+type Querier struct {
+	mu        sync.Mutex
+	responses []*Response
+	queries   []Query
+}
+
+// NewQuerier returns a new mock querier without responses.
+func NewQuerier() *Querier {
+	return &Querier{}
+}
+
+// On adds a response to queries and statements that contain the text, e.g. "FROM users",
+// an empty text matches any query. Responses are matched in the order they were added.
+// Queries that match no response fail.
+func (q *Querier) On(text string) *Response {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	r := &Response{text: text}
+	q.responses = append(q.responses, r)
+	return r
+}
+
+// Queries returns queries and statements that the querier received, in order.
+func (q *Querier) Queries() []Query {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Query(nil), q.queries...)
+}
+
+// Query implements the pgxscan.Querier.Query method.
+func (q *Querier) Query(_ context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	r, err := q.respond(query, args)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]pgconn.FieldDescription, len(r.columns))
+	for i, column := range r.columns {
+		fields[i] = pgconn.FieldDescription{Name: column}
+	}
+	return &rows{
+		Rows:       dbscantest.NewRows(r.columns, r.values),
+		fields:     fields,
+		commandTag: pgconn.NewCommandTag(r.commandTag),
+	}, nil
+}
+
+// Exec executes the statement, like pgx.Conn.Exec does.
+func (q *Querier) Exec(_ context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	r, err := q.respond(query, args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag(r.commandTag), nil
+}
+
+// respond records the query and returns its response.
+func (q *Querier) respond(query string, args []interface{}) (*Response, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queries = append(q.queries, Query{SQL: query, Args: args})
+	for _, r := range q.responses {
+		if strings.Contains(query, r.text) {
+			if r.err != nil {
+				return nil, r.err
+			}
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("scany: unexpected query: %s", query)
+}
+
+// rows implements pgx.Rows on top of in-memory rows.
+type rows struct {
+	*dbscantest.Rows
+	fields     []pgconn.FieldDescription
+	commandTag pgconn.CommandTag
+}
+
+func (r *rows) Close()                                       { r.Rows.Close() } //nolint: errcheck
+func (r *rows) CommandTag() pgconn.CommandTag                { return r.commandTag }
+func (r *rows) FieldDescriptions() []pgconn.FieldDescription { return r.fields }
+func (r *rows) RawValues() [][]byte                          { return nil }
+func (r *rows) Conn() *pgx.Conn                              { return nil }
+
+func (r *rows) Values() ([]interface{}, error) {
+	values := make([]interface{}, len(r.fields))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := r.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,103 @@
+package pgxscantest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+var ctx = context.Background()
+
+type user struct {
+	ID        int64
+	Name      string
+	Email     pgtype.Text
+	CreatedAt time.Time
+}
+
+func TestQuerier_Return_selectsStructs(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+	expected := []*user{
+		{ID: 1, Name: "foo", Email: pgtype.Text{String: "foo@example.com", Valid: true}, CreatedAt: time.Unix(1, 0).UTC()},
+		{ID: 2, Name: "bar", CreatedAt: time.Unix(2, 0).UTC()},
+	}
+	q.On("FROM users").Return(expected)
+
+	var got []*user
+	err := pgxscan.Select(ctx, q, &got, `SELECT * FROM users WHERE name <> $1`, "baz")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []pgxscantest.Query{
+		{SQL: `SELECT * FROM users WHERE name <> $1`, Args: []interface{}{"baz"}},
+	}, q.Queries())
+}
+
+func TestQuerier_Return_emptySlice_notFound(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+	q.On("FROM users").Return([]user{})
+
+	var got user
+	err := pgxscan.Get(ctx, q, &got, `SELECT * FROM users WHERE id = $1`, 1)
+
+	assert.True(t, pgxscan.NotFound(err))
+}
+
+func TestQuerier_ReturnRows(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+	q.On("").ReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "foo"}})
+
+	rows, err := q.Query(ctx, `SELECT id, name FROM users`)
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	values, err := rows.Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, []interface{}{int64(1), "foo"}, values)
+	assert.Equal(t, "name", rows.FieldDescriptions()[1].Name)
+}
+
+func TestQuerier_ReturnError(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+	errQuery := errors.New("query failed")
+	q.On("FROM users").ReturnError(errQuery)
+
+	var got []user
+	err := pgxscan.Select(ctx, q, &got, `SELECT * FROM users`)
+
+	assert.ErrorIs(t, err, errQuery)
+}
+
+func TestQuerier_unexpectedQuery(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+
+	_, err := q.Exec(ctx, `DELETE FROM users`)
+
+	assert.EqualError(t, err, "scany: unexpected query: DELETE FROM users")
+}
+
+func TestQuerier_Exec(t *testing.T) {
+	t.Parallel()
+	q := pgxscantest.NewQuerier()
+	q.On("UPDATE users").ReturnCommandTag("UPDATE 2")
+
+	tag, err := q.Exec(ctx, `UPDATE users SET name = $1`, "foo")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), tag.RowsAffected())
+	assert.Equal(t, []pgxscantest.Query{{SQL: `UPDATE users SET name = $1`, Args: []interface{}{"foo"}}}, q.Queries())
+}
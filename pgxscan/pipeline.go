@@ -0,0 +1,219 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PipelineQuery is a query that ScanPipeline sends over the pipeline, along with the destination of its result.
+type PipelineQuery struct {
+	// SQL is the query, with positional arguments, e.g. $1.
+	SQL  string
+	Args []interface{}
+	// Dst is the destination of the result, see ScanBatch for how it's scanned. A nil Dst discards the result.
+	Dst interface{}
+}
+
+// ScanPipeline is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanPipeline for details.
+func ScanPipeline(ctx context.Context, conn *pgx.Conn, queries ...PipelineQuery) error {
+	return DefaultAPI.ScanPipeline(ctx, conn, queries...)
+}
+
+// ScanPipeline sends the queries over the connection in pipeline mode and scans the result of every query
+// into its destination, so fan-out reads take two round trips regardless of the number of queries,
+// one to describe the queries and one to execute them:
+//
+//	var user User
+//	var posts []*Post
+//	err := pgxscan.ScanPipeline(ctx, conn,
+//		pgxscan.PipelineQuery{SQL: `SELECT * FROM users WHERE id = $1`, Args: []interface{}{id}, Dst: &user},
+//		pgxscan.PipelineQuery{SQL: `SELECT * FROM posts WHERE user_id = $1`, Args: []interface{}{id}, Dst: &posts},
+//	)
+//
+// Unlike pgx.Batch, queries don't run in a single implicit transaction, so a failed query doesn't abort the others.
+// All results are scanned before ScanPipeline returns, and it returns the error of the first query that has failed.
+// With *pgxpool.Pool, acquire a connection and pass its Conn().
+func (api *API) ScanPipeline(ctx context.Context, conn *pgx.Conn, queries ...PipelineQuery) error {
+	if len(queries) == 0 {
+		return nil
+	}
+	pipeline := conn.PgConn().StartPipeline(ctx)
+	errs := api.runPipeline(ctx, conn, pipeline, queries)
+	closeErr := pipeline.Close()
+	for i, err := range errs {
+		if err != nil {
+			return classifyError(fmt.Errorf("scany: pipeline query %d: %w", i, err))
+		}
+	}
+	if closeErr != nil {
+		return classifyError(fmt.Errorf("scany: close pipeline: %w", closeErr))
+	}
+	return nil
+}
+
+// runPipeline returns errors of the queries by their index. If the pipeline itself fails,
+// e.g. the connection is broken, all queries get its error.
+func (api *API) runPipeline(
+	ctx context.Context, conn *pgx.Conn, pipeline *pgconn.Pipeline, queries []PipelineQuery,
+) []error {
+	errs := make([]error, len(queries))
+
+	// Queries are described first, so their arguments are encoded the same way as pgx.Conn.Query does.
+	for _, q := range queries {
+		pipeline.SendPrepare("", q.SQL, nil)
+		if err := pipeline.Sync(); err != nil {
+			return pipelineErrs(len(queries), err)
+		}
+	}
+	descriptions := make([]*pgconn.StatementDescription, len(queries))
+	for i := range queries {
+		results, err := pipeline.GetResults()
+		sd, ok := results.(*pgconn.StatementDescription)
+		switch {
+		case err != nil:
+			if !isPgError(err) {
+				return pipelineErrs(len(queries), err)
+			}
+			errs[i] = fmt.Errorf("describe: %w", err)
+		case ok:
+			descriptions[i] = sd
+		default:
+			return pipelineErrs(len(queries), fmt.Errorf("describe: unexpected pipeline results: %T", results))
+		}
+		if err := getPipelineSync(pipeline); err != nil {
+			return pipelineErrs(len(queries), err)
+		}
+	}
+
+	// Every query is synchronized on its own, so an error doesn't abort the queries after it.
+	sent := make([]int, 0, len(queries))
+	for i, q := range queries {
+		if errs[i] != nil {
+			continue
+		}
+		var eqb pgx.ExtendedQueryBuilder
+		if err := eqb.Build(conn.TypeMap(), descriptions[i], q.Args); err != nil {
+			errs[i] = fmt.Errorf("encode arguments: %w", err)
+			continue
+		}
+		sd := descriptions[i]
+		pipeline.SendQueryParams(q.SQL, eqb.ParamValues, sd.ParamOIDs, eqb.ParamFormats, eqb.ResultFormats)
+		if err := pipeline.Sync(); err != nil {
+			return pipelineErrs(len(queries), err)
+		}
+		sent = append(sent, i)
+	}
+	for _, i := range sent {
+		results, err := pipeline.GetResults()
+		rr, ok := results.(*pgconn.ResultReader)
+		switch {
+		case err != nil:
+			if !isPgError(err) {
+				return pipelineErrs(len(queries), err)
+			}
+			errs[i] = err
+		case ok:
+			errs[i] = api.scanPipelineResult(ctx, conn, rr, queries[i].Dst)
+		default:
+			return pipelineErrs(len(queries), fmt.Errorf("unexpected pipeline results: %T", results))
+		}
+		if err := getPipelineSync(pipeline); err != nil {
+			return pipelineErrs(len(queries), err)
+		}
+	}
+	return errs
+}
+
+func (api *API) scanPipelineResult(
+	ctx context.Context, conn *pgx.Conn, rr *pgconn.ResultReader, dst interface{},
+) error {
+	rows := &rawRows{
+		fields:  rr.FieldDescriptions(),
+		typeMap: conn.TypeMap(),
+		api:     api,
+		reader:  resultReader{rr},
+		closeFn: func() error {
+			_, err := rr.Close()
+			return err
+		},
+	}
+	switch {
+	case dst == nil:
+		if err := rows.Close(); err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+		return nil
+	case isSliceDestination(dst):
+		if err := api.dbscanAPI.ScanAllContext(ctx, dst, rows); err != nil {
+			return fmt.Errorf("scanning all: %w", err)
+		}
+		return nil
+	default:
+		if err := api.scanOne(ctx, dst, rows); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scanning one: %w", err)
+		}
+		return nil
+	}
+}
+
+// getPipelineSync reads the results of the pipeline up to the synchronization point.
+func getPipelineSync(pipeline *pgconn.Pipeline) error {
+	for {
+		results, err := pipeline.GetResults()
+		if err != nil && !isPgError(err) {
+			return err
+		}
+		switch results.(type) {
+		case *pgconn.PipelineSync:
+			return nil
+		case nil:
+			if err == nil {
+				return fmt.Errorf("pipeline has no synchronization point")
+			}
+		}
+	}
+}
+
+func pipelineErrs(n int, err error) []error {
+	errs := make([]error, n)
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+func isPgError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr)
+}
+
+// resultReader reads rows of the result in the pipeline.
+type resultReader struct {
+	rr *pgconn.ResultReader
+}
+
+func (r resultReader) next() ([][]byte, error) {
+	if !r.rr.NextRow() {
+		// Errors of the query, e.g. that happen in the middle of the result, are only reported on Close.
+		if _, err := r.rr.Close(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	// Values are only valid until the next row, and may be kept by the destination, e.g. *[]byte.
+	values := r.rr.Values()
+	copied := make([][]byte, len(values))
+	for i, v := range values {
+		if v != nil {
+			copied[i] = append([]byte{}, v...)
+		}
+	}
+	return copied, nil
+}
@@ -0,0 +1,65 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestScanPipeline(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	var one testModel
+	var all []*testModel
+	var bar string
+	err = testAPI.ScanPipeline(ctx, conn.Conn(),
+		pgxscan.PipelineQuery{SQL: singleRowsQuery, Dst: &one},
+		pgxscan.PipelineQuery{SQL: multipleRowsQuery, Dst: &all},
+		pgxscan.PipelineQuery{SQL: `SELECT $1::text`, Args: []interface{}{"bar val"}, Dst: &bar},
+		pgxscan.PipelineQuery{SQL: `SELECT 1`},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, one)
+	assert.Len(t, all, 3)
+	assert.Equal(t, "bar val", bar)
+}
+
+func TestScanPipeline_failedQuery_scansOthers(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	var one testModel
+	var missing int
+	var all []*testModel
+	err = testAPI.ScanPipeline(ctx, conn.Conn(),
+		pgxscan.PipelineQuery{SQL: singleRowsQuery, Dst: &one},
+		pgxscan.PipelineQuery{SQL: `SELECT * FROM missing_table`, Dst: &missing},
+		pgxscan.PipelineQuery{SQL: multipleRowsQuery, Dst: &all},
+	)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "scany: pipeline query 1")
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, one)
+	assert.Len(t, all, 3)
+}
+
+func TestScanPipeline_noRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	var one testModel
+	err = testAPI.ScanPipeline(ctx, conn.Conn(), pgxscan.PipelineQuery{SQL: noRowsQuery, Dst: &one})
+
+	assert.True(t, pgxscan.NotFound(err))
+}
@@ -0,0 +1,156 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrAcquireTimeout is returned by Pool if a connection isn't acquired within the timeout, see WithAcquireTimeout.
+var ErrAcquireTimeout = errors.New("scany: timed out acquiring a connection from the pool")
+
+// QueryEvent describes a query that Pool has run, see WithSlowQueryLog.
+type QueryEvent struct {
+	// Query and Args are the query and its arguments.
+	Query string
+	Args  []interface{}
+	// AcquireDuration is how long it took to acquire a connection from the pool.
+	AcquireDuration time.Duration
+	// Duration is how long it took to run the query and scan rows, not including AcquireDuration.
+	Duration time.Duration
+	// Err is the error that the query has failed with, or nil.
+	Err error
+}
+
+// PoolOption is a function type that changes Pool configuration.
+type PoolOption func(p *Pool)
+
+// WithAcquireTimeout limits how long Pool waits for a free connection, if the pool is exhausted.
+// The query itself isn't limited. If the timeout expires, an error that matches ErrAcquireTimeout is returned.
+func WithAcquireTimeout(timeout time.Duration) PoolOption {
+	return func(p *Pool) {
+		p.acquireTimeout = timeout
+	}
+}
+
+// WithSlowQueryLog makes Pool call log for every query that takes at least threshold,
+// including the time it took to acquire a connection.
+func WithSlowQueryLog(threshold time.Duration, log func(ctx context.Context, event *QueryEvent)) PoolOption {
+	return func(p *Pool) {
+		p.slowQueryThreshold = threshold
+		p.slowQueryLog = log
+	}
+}
+
+// WithPoolStatsHook makes Pool call hook with the pool statistics after every query,
+// e.g. to export the number of idle and acquired connections as metrics.
+func WithPoolStatsHook(hook func(ctx context.Context, stat *pgxpool.Stat)) PoolOption {
+	return func(p *Pool) {
+		p.statsHook = hook
+	}
+}
+
+// WithPoolAPI sets the API object that Pool runs queries with, the default one is DefaultAPI.
+func WithPoolAPI(api *API) PoolOption {
+	return func(p *Pool) {
+		p.api = api
+	}
+}
+
+// Pool wraps *pgxpool.Pool with Get, Select and Each, that handle the acquire timeout,
+// log slow queries and report pool statistics, so applications don't need to write their own wrapper:
+//
+//	db := pgxscan.NewPool(pool,
+//		pgxscan.WithAcquireTimeout(time.Second),
+//		pgxscan.WithSlowQueryLog(200*time.Millisecond, func(ctx context.Context, e *pgxscan.QueryEvent) {
+//			log.Printf("slow query: %s took %v", e.Query, e.AcquireDuration+e.Duration)
+//		}),
+//	)
+//	err := db.Get(ctx, &user, `SELECT * FROM users WHERE id = $1`, id)
+type Pool struct {
+	pool               *pgxpool.Pool
+	api                *API
+	acquireTimeout     time.Duration
+	slowQueryThreshold time.Duration
+	slowQueryLog       func(ctx context.Context, event *QueryEvent)
+	statsHook          func(ctx context.Context, stat *pgxpool.Stat)
+}
+
+// NewPool creates a new Pool instance that wraps the pgx pool with provided list of options.
+func NewPool(pool *pgxpool.Pool, opts ...PoolOption) *Pool {
+	p := &Pool{pool: pool, api: DefaultAPI}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+// Pool returns the wrapped pgx pool.
+func (p *Pool) Pool() *pgxpool.Pool {
+	return p.pool
+}
+
+// Select is the same as API.Select executed on a connection acquired from the pool.
+func (p *Pool) Select(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return p.run(ctx, query, args, func(conn *pgxpool.Conn) error {
+		return p.api.Select(ctx, conn, dst, query, args...)
+	})
+}
+
+// Get is the same as API.Get executed on a connection acquired from the pool.
+func (p *Pool) Get(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return p.run(ctx, query, args, func(conn *pgxpool.Conn) error {
+		return p.api.Get(ctx, conn, dst, query, args...)
+	})
+}
+
+// Each is the same as API.Each executed on a connection acquired from the pool.
+func (p *Pool) Each(ctx context.Context, dst interface{}, fn func() error, query string, args ...interface{}) error {
+	return p.run(ctx, query, args, func(conn *pgxpool.Conn) error {
+		return p.api.Each(ctx, conn, dst, fn, query, args...)
+	})
+}
+
+func (p *Pool) run(ctx context.Context, query string, args []interface{}, fn func(conn *pgxpool.Conn) error) error {
+	event := &QueryEvent{Query: query, Args: args}
+	start := time.Now()
+	conn, err := p.acquire(ctx)
+	event.AcquireDuration = time.Since(start)
+	if err == nil {
+		queryStart := time.Now()
+		err = fn(conn)
+		event.Duration = time.Since(queryStart)
+		conn.Release()
+	}
+	event.Err = err
+	if p.slowQueryLog != nil && event.AcquireDuration+event.Duration >= p.slowQueryThreshold {
+		p.slowQueryLog(ctx, event)
+	}
+	if p.statsHook != nil {
+		p.statsHook(ctx, p.pool.Stat())
+	}
+	return err
+}
+
+func (p *Pool) acquire(ctx context.Context) (*pgxpool.Conn, error) {
+	if p.acquireTimeout <= 0 {
+		conn, err := p.pool.Acquire(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("scany: acquire connection: %w", err)
+		}
+		return conn, nil
+	}
+	acquireCtx, cancel := context.WithTimeout(ctx, p.acquireTimeout)
+	defer cancel()
+	conn, err := p.pool.Acquire(acquireCtx)
+	if err != nil {
+		if ctx.Err() == nil && errors.Is(acquireCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w after %v: %v", ErrAcquireTimeout, p.acquireTimeout, err)
+		}
+		return nil, fmt.Errorf("scany: acquire connection: %w", err)
+	}
+	return conn, nil
+}
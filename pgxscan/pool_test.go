@@ -0,0 +1,112 @@
+package pgxscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestPool_Select(t *testing.T) {
+	t.Parallel()
+	var events []*pgxscan.QueryEvent
+	var stats []*pgxpool.Stat
+	pool := pgxscan.NewPool(testDB,
+		pgxscan.WithPoolAPI(testAPI),
+		pgxscan.WithAcquireTimeout(time.Minute),
+		pgxscan.WithSlowQueryLog(0, func(ctx context.Context, event *pgxscan.QueryEvent) {
+			events = append(events, event)
+		}),
+		pgxscan.WithPoolStatsHook(func(ctx context.Context, stat *pgxpool.Stat) {
+			stats = append(stats, stat)
+		}),
+	)
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err := pool.Select(ctx, &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	require.Len(t, events, 1)
+	assert.Equal(t, multipleRowsQuery, events[0].Query)
+	assert.NoError(t, events[0].Err)
+	assert.Len(t, stats, 1)
+}
+
+func TestPool_Get(t *testing.T) {
+	t.Parallel()
+	pool := pgxscan.NewPool(testDB, pgxscan.WithPoolAPI(testAPI))
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := pool.Get(ctx, &got, singleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestPool_Each(t *testing.T) {
+	t.Parallel()
+	pool := pgxscan.NewPool(testDB, pgxscan.WithPoolAPI(testAPI))
+	expected := []testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []testModel
+	var row testModel
+	err := pool.Each(ctx, &row, func() error {
+		got = append(got, row)
+		return nil
+	}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestPool_slowQueryLog_fastQuery_notLogged(t *testing.T) {
+	t.Parallel()
+	var logged bool
+	pool := pgxscan.NewPool(testDB,
+		pgxscan.WithPoolAPI(testAPI),
+		pgxscan.WithSlowQueryLog(time.Hour, func(ctx context.Context, event *pgxscan.QueryEvent) {
+			logged = true
+		}),
+	)
+
+	var got testModel
+	err := pool.Get(ctx, &got, singleRowsQuery)
+	require.NoError(t, err)
+
+	assert.False(t, logged)
+}
+
+func TestPool_acquireTimeout_returnsErr(t *testing.T) {
+	t.Parallel()
+	config := testDB.Config()
+	config.MaxConns = 1
+	dbPool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	defer dbPool.Close()
+	conn, err := dbPool.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+	pool := pgxscan.NewPool(dbPool, pgxscan.WithPoolAPI(testAPI), pgxscan.WithAcquireTimeout(10*time.Millisecond))
+
+	var got testModel
+	err = pool.Get(ctx, &got, singleRowsQuery)
+
+	assert.True(t, errors.Is(err, pgxscan.ErrAcquireTimeout))
+}
@@ -0,0 +1,96 @@
+package pgxscan
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// rawReader reads raw values of the next row in the format of their field descriptions, NULL values are nil.
+// It returns io.EOF after the last row.
+type rawReader interface {
+	next() ([][]byte, error)
+}
+
+// rawRows implements the dbscan.Rows interface on top of raw values, e.g. of the COPY TO output,
+// and decodes them with the type map the same way as RowsAdapter does.
+type rawRows struct {
+	fields  []pgconn.FieldDescription
+	typeMap *pgtype.Map
+	api     *API
+	reader  rawReader
+	// closeFn is called once on Close, e.g. to read the rest of the result, it may be nil.
+	closeFn func() error
+	values  [][]byte
+	err     error
+	closed  bool
+}
+
+var _ dbscan.Rows = &rawRows{}
+
+func (r *rawRows) Columns() ([]string, error) {
+	columns := make([]string, len(r.fields))
+	for i, fd := range r.fields {
+		columns[i] = fd.Name
+	}
+	return columns, nil
+}
+
+func (r *rawRows) Next() bool {
+	if r.closed || r.err != nil {
+		return false
+	}
+	values, err := r.reader.next()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			r.err = err
+		}
+		r.values = nil
+		return false
+	}
+	if len(values) != len(r.fields) {
+		r.err = fmt.Errorf("scany: row has %d values, expected %d", len(values), len(r.fields))
+		return false
+	}
+	r.values = values
+	return true
+}
+
+func (r *rawRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.values) {
+		return fmt.Errorf("scany: number of destinations %d doesn't match the number of columns %d",
+			len(dest), len(r.values))
+	}
+	for i, dst := range dest {
+		target := r.api.scanTarget(r.typeMap, r.fields[i], dst)
+		if err := r.typeMap.Scan(r.fields[i].DataTypeOID, r.fields[i].Format, r.values[i], target); err != nil {
+			return pgx.ScanArgError{ColumnIndex: i, Err: err}
+		}
+	}
+	return nil
+}
+
+func (r *rawRows) Err() error {
+	return r.err
+}
+
+func (r *rawRows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if r.closeFn != nil {
+		return r.closeFn()
+	}
+	return nil
+}
+
+func (r *rawRows) NextResultSet() bool {
+	return false
+}
@@ -0,0 +1,42 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// ExecReturning executes a statement that returns rows, e.g. `INSERT ... RETURNING *` or `UPDATE ... RETURNING *`,
+// and scans the returned rows into dst, it's meant to be used within a transaction as well as without one:
+//
+//	var ids []int64
+//	err := pgxscan.ExecReturning(ctx, tx, &ids, `UPDATE users SET active = false WHERE seen < $1 RETURNING id`, t)
+//
+// If dst is a pointer to a slice, it scans all rows like Select does, otherwise it expects exactly one row like Get
+// does, and returns a not found error that matches pgx.ErrNoRows and dbscan.ErrNotFound if the statement returns
+// no rows, e.g. an UPDATE that matched nothing. For a slice destination no rows isn't an error,
+// since the statement itself succeeded.
+func (api *API) ExecReturning(
+	ctx context.Context, db Querier, dst interface{}, query string, args ...interface{},
+) error {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return classifyError(fmt.Errorf("scany: exec returning: %w", err))
+	}
+	ra := api.newRowsAdapter(rows)
+	if isSliceDestination(dst) {
+		if err := api.dbscanAPI.ScanAllContext(ctx, dst, ra); err != nil {
+			return classifyError(fmt.Errorf("scanning all: %w", err))
+		}
+		return nil
+	}
+	if err := api.scanOne(ctx, dst, ra); err != nil {
+		return classifyError(fmt.Errorf("scanning one: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestExecReturning_oneRow(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE returning_one (id SERIAL PRIMARY KEY, foo TEXT)`)
+	require.NoError(t, err)
+
+	var got struct {
+		ID  int64
+		Foo string
+	}
+	err = testAPI.ExecReturning(ctx, tx, &got, `INSERT INTO returning_one (foo) VALUES ($1) RETURNING *`, "foo val")
+	require.NoError(t, err)
+
+	assert.NotZero(t, got.ID)
+	assert.Equal(t, "foo val", got.Foo)
+}
+
+func TestExecReturning_multipleRows(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE returning_many (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	query := `INSERT INTO returning_many (foo, bar) VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2')
+		RETURNING foo, bar`
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	var got []*testModel
+	err = testAPI.ExecReturning(ctx, tx, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestExecReturning_noRows(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.Begin(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback(ctx) //nolint: errcheck
+	_, err = tx.Exec(ctx, `CREATE TEMP TABLE returning_none (foo TEXT)`)
+	require.NoError(t, err)
+	query := `UPDATE returning_none SET foo = 'foo val' RETURNING foo`
+
+	var one string
+	err = testAPI.ExecReturning(ctx, tx, &one, query)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.ErrorIs(t, err, scany.ErrNotFound)
+
+	var many []string
+	err = testAPI.ExecReturning(ctx, tx, &many, query)
+	require.NoError(t, err)
+	assert.Empty(t, many)
+}
+
+func TestTx_ExecReturning(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		if _, err := tx.Exec(ctx, `CREATE TEMP TABLE returning_tx (foo TEXT)`); err != nil {
+			return err
+		}
+		return tx.ExecReturning(ctx, &got, `INSERT INTO returning_tx (foo) VALUES ('foo val') RETURNING foo`)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"foo val"}, got)
+}
@@ -0,0 +1,65 @@
+package pgxscan
+
+import (
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// RowToStruct is a pgx.RowToFunc that scans the row into a struct of type T with the DefaultAPI,
+// so pgx.CollectRows and pgx.CollectOneRow follow scany mapping rules, e.g. the "db" tag,
+// prefixes of embedded structs and nested structs, and JSON and composite columns decoded into structs:
+//
+//	users, err := pgx.CollectRows(rows, pgxscan.RowToStruct[User])
+//
+// T may also be a map or a primitive type, the same destinations as ScanRow accepts.
+func RowToStruct[T any](row pgx.CollectableRow) (T, error) {
+	return RowToStructFunc[T](DefaultAPI)(row)
+}
+
+// RowToAddrOfStruct is the same as RowToStruct, but it returns a pointer to T.
+func RowToAddrOfStruct[T any](row pgx.CollectableRow) (*T, error) {
+	return RowToAddrOfStructFunc[T](DefaultAPI)(row)
+}
+
+// RowToStructFunc returns a pgx.RowToFunc that scans rows into T with the API object,
+// see RowToStruct for details.
+func RowToStructFunc[T any](api *API) pgx.RowToFunc[T] {
+	return func(row pgx.CollectableRow) (T, error) {
+		var value T
+		err := api.ScanRow(&value, collectableRows(row))
+		return value, err
+	}
+}
+
+// RowToAddrOfStructFunc returns a pgx.RowToFunc that scans rows into *T with the API object,
+// see RowToStruct for details.
+func RowToAddrOfStructFunc[T any](api *API) pgx.RowToFunc[*T] {
+	return func(row pgx.CollectableRow) (*T, error) {
+		value := new(T)
+		if err := api.ScanRow(value, collectableRows(row)); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+}
+
+// collectableRows returns the row as pgx.Rows, pgx.CollectRows passes the rows themselves,
+// other callers may pass a row that only implements pgx.CollectableRow.
+func collectableRows(row pgx.CollectableRow) pgx.Rows {
+	if rows, ok := row.(pgx.Rows); ok {
+		return rows
+	}
+	return collectableRowAdapter{CollectableRow: row}
+}
+
+// collectableRowAdapter implements pgx.Rows on top of a single pgx.CollectableRow,
+// it has no connection, so composite columns are decoded only if they are records.
+type collectableRowAdapter struct {
+	pgx.CollectableRow
+}
+
+func (r collectableRowAdapter) Close()                        {}
+func (r collectableRowAdapter) Err() error                    { return nil }
+func (r collectableRowAdapter) CommandTag() pgconn.CommandTag { return pgconn.CommandTag{} }
+func (r collectableRowAdapter) Next() bool                    { return false }
+func (r collectableRowAdapter) Conn() *pgx.Conn               { return nil }
@@ -0,0 +1,59 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestRowToStruct(t *testing.T) {
+	t.Parallel()
+	expected := []testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	rows, err := testDB.Query(ctx, multipleRowsQuery)
+	require.NoError(t, err)
+
+	got, err := pgx.CollectRows(rows, pgxscan.RowToStruct[testModel])
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRowToAddrOfStruct(t *testing.T) {
+	t.Parallel()
+	expected := &testModel{Foo: "foo val", Bar: "bar val"}
+	rows, err := testDB.Query(ctx, singleRowsQuery)
+	require.NoError(t, err)
+
+	got, err := pgx.CollectOneRow(rows, pgxscan.RowToAddrOfStruct[testModel])
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRowToStructFunc_nestedStruct(t *testing.T) {
+	t.Parallel()
+	type inner struct {
+		Bar string
+	}
+	type dst struct {
+		Foo   string
+		Inner inner `db:"inner"`
+	}
+	query := `SELECT 'foo val' AS foo, 'bar val' AS "inner.bar"`
+	expected := []dst{{Foo: "foo val", Inner: inner{Bar: "bar val"}}}
+	rows, err := testDB.Query(ctx, query)
+	require.NoError(t, err)
+
+	got, err := pgx.CollectRows(rows, pgxscan.RowToStructFunc[dst](testAPI))
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,198 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultTxMaxAttempts = 3
+	defaultTxBackoffBase = 10 * time.Millisecond
+	defaultTxBackoffMax  = time.Second
+)
+
+// ErrTxManaged is returned by Tx.Commit and Tx.Rollback, since WithTxRetry ends the transaction itself.
+var ErrTxManaged = errors.New("scany: the transaction is committed or rolled back by WithTxRetry")
+
+// Tx is a wrapper around pgx.Tx that exposes pgxscan high-level functions bound to the transaction.
+// All pgx.Tx methods are available as well, but Commit and Rollback return ErrTxManaged,
+// fn returns nil to commit the transaction and an error to roll it back, see WithTxRetry.
+type Tx struct {
+	pgx.Tx
+	api *API
+}
+
+// Commit returns ErrTxManaged without committing the transaction.
+func (tx *Tx) Commit(context.Context) error {
+	return ErrTxManaged
+}
+
+// Rollback returns ErrTxManaged without rolling the transaction back.
+func (tx *Tx) Rollback(context.Context) error {
+	return ErrTxManaged
+}
+
+// Select is the same as API.Select executed within the transaction.
+func (tx *Tx) Select(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.Select(ctx, tx.Tx, dst, query, args...)
+}
+
+// Get is the same as API.Get executed within the transaction.
+func (tx *Tx) Get(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.Get(ctx, tx.Tx, dst, query, args...)
+}
+
+// ExecReturning is the same as API.ExecReturning executed within the transaction.
+func (tx *Tx) ExecReturning(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.ExecReturning(ctx, tx.Tx, dst, query, args...)
+}
+
+// TxRetryOption is a function type that changes how WithTxRetry runs the transaction.
+type TxRetryOption func(o *txRetryOptions)
+
+type txRetryOptions struct {
+	maxAttempts int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	txOptions   *pgx.TxOptions
+}
+
+// TxMaxAttempts sets how many times WithTxRetry runs the transaction, the default is 3 attempts, 1 disables retries.
+func TxMaxAttempts(n int) TxRetryOption {
+	return func(o *txRetryOptions) {
+		if n > 0 {
+			o.maxAttempts = n
+		}
+	}
+}
+
+// TxBackoff sets the delay before the first retry, that doubles with every next retry up to maxDelay.
+// The defaults are 10ms and 1s. The actual delay is randomized, so concurrent transactions don't collide again.
+func TxBackoff(initial, maxDelay time.Duration) TxRetryOption {
+	return func(o *txRetryOptions) {
+		o.backoffBase = initial
+		o.backoffMax = maxDelay
+	}
+}
+
+// TxOptions sets options that WithTxRetry begins the transaction with, e.g. the pgx.Serializable isolation level.
+// The database must have the BeginTx method then, like *pgxpool.Pool and *pgx.Conn have.
+func TxOptions(txOptions pgx.TxOptions) TxRetryOption {
+	return func(o *txRetryOptions) {
+		o.txOptions = &txOptions
+	}
+}
+
+// WithTxRetry is a package-level helper function that uses the DefaultAPI object.
+// See API.WithTxRetry for details.
+func WithTxRetry(ctx context.Context, db TxBeginner, fn func(tx *Tx) error, opts ...TxRetryOption) error {
+	return DefaultAPI.WithTxRetry(ctx, db, fn, opts...)
+}
+
+// WithTxRetry begins a transaction, calls fn with it and commits the transaction if fn returns nil,
+// otherwise it rolls the transaction back and returns the error of fn. It rolls back on panic as well.
+//
+//	err := pgxscan.WithTxRetry(ctx, pool, func(tx *pgxscan.Tx) error {
+//		var user User
+//		if err := tx.Get(ctx, &user, `SELECT * FROM users WHERE id = $1 FOR UPDATE`, id); err != nil {
+//			return err
+//		}
+//		_, err := tx.Exec(ctx, `UPDATE users SET balance = $1 WHERE id = $2`, user.Balance+amount, id)
+//		return err
+//	}, pgxscan.TxOptions(pgx.TxOptions{IsoLevel: pgx.Serializable}))
+//
+// If fn or the commit fails with a serialization failure or a deadlock, SQLSTATE 40001 or 40P01,
+// that is an error matching ErrSerializationFailure, the whole transaction is retried with exponential backoff,
+// so fn must be safe to call multiple times, see TxMaxAttempts and TxBackoff.
+func (api *API) WithTxRetry(ctx context.Context, db TxBeginner, fn func(tx *Tx) error, opts ...TxRetryOption) error {
+	o := txRetryOptions{
+		maxAttempts: defaultTxMaxAttempts,
+		backoffBase: defaultTxBackoffBase,
+		backoffMax:  defaultTxBackoffMax,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var err error
+	for attempt := 1; attempt <= o.maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(o.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("scany: retry aborted: %w (last error: %v)", ctx.Err(), err)
+			case <-timer.C:
+			}
+		}
+		err = classifyError(api.runTx(ctx, db, o.txOptions, fn))
+		if err == nil || !errors.Is(err, ErrSerializationFailure) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("scany: transaction failed after %d attempts: %w", o.maxAttempts, err)
+}
+
+func (api *API) runTx(ctx context.Context, db TxBeginner, txOptions *pgx.TxOptions, fn func(tx *Tx) error) error {
+	pgxTx, err := beginTx(ctx, db, txOptions)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = pgxTx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+	if err := fn(&Tx{Tx: pgxTx, api: api}); err != nil {
+		if rollbackErr := pgxTx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			return fmt.Errorf("%w (rollback error: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	if err := pgxTx.Commit(ctx); err != nil {
+		return fmt.Errorf("scany: commit transaction: %w", err)
+	}
+	return nil
+}
+
+func beginTx(ctx context.Context, db TxBeginner, txOptions *pgx.TxOptions) (pgx.Tx, error) {
+	if txOptions == nil {
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("scany: begin transaction: %w", err)
+		}
+		return tx, nil
+	}
+	optionsBeginner, ok := db.(interface {
+		BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("scany: transaction options require a database with the BeginTx method, got: %T", db)
+	}
+	tx, err := optionsBeginner.BeginTx(ctx, *txOptions)
+	if err != nil {
+		return nil, fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// backoff returns the delay before the retry with the given number, starting from 1.
+func (o *txRetryOptions) backoff(retry int) time.Duration {
+	delay := o.backoffBase
+	for i := 1; i < retry && delay < o.backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > o.backoffMax {
+		delay = o.backoffMax
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// Half of the delay is fixed and half is random.
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1)) //nolint: gosec
+}
@@ -0,0 +1,106 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestWithTxRetry_commits(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		return tx.Get(ctx, &got, singleRowsQuery)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestWithTxRetry_fnError_rollsBackAndReturnsErr(t *testing.T) {
+	t.Parallel()
+	fnErr := errors.New("fn error")
+	var attempts int
+
+	err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		attempts++
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithTxRetry_commitInFn_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		return tx.Commit(ctx)
+	})
+
+	assert.ErrorIs(t, err, pgxscan.ErrTxManaged)
+}
+
+func TestWithTxRetry_serializationFailure_retries(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name string
+		code string
+	}{
+		{name: "serialization failure", code: "40001"},
+		{name: "deadlock", code: "40P01"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var attempts int
+
+			err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+				attempts++
+				if attempts < 2 {
+					return &pgconn.PgError{Code: tc.code}
+				}
+				return nil
+			}, pgxscan.TxBackoff(time.Millisecond, time.Millisecond))
+			require.NoError(t, err)
+
+			assert.Equal(t, 2, attempts)
+		})
+	}
+}
+
+func TestWithTxRetry_txMaxAttempts_returnsErr(t *testing.T) {
+	t.Parallel()
+	var attempts int
+
+	err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		attempts++
+		return &pgconn.PgError{Severity: "ERROR", Code: "40001", Message: "restart transaction"}
+	}, pgxscan.TxMaxAttempts(2), pgxscan.TxBackoff(time.Millisecond, time.Millisecond))
+
+	assert.EqualError(t, err, "scany: transaction failed after 2 attempts: ERROR: restart transaction (SQLSTATE 40001)")
+	assert.ErrorIs(t, err, pgxscan.ErrSerializationFailure)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithTxRetry_txOptions(t *testing.T) {
+	t.Parallel()
+	var isoLevel string
+
+	err := pgxscan.WithTxRetry(ctx, testDB, func(tx *pgxscan.Tx) error {
+		return tx.Get(ctx, &isoLevel, `SHOW transaction_isolation`)
+	}, pgxscan.TxOptions(pgx.TxOptions{IsoLevel: pgx.Serializable}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "serializable", isoLevel)
+}
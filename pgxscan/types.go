@@ -0,0 +1,68 @@
+package pgxscan
+
+import (
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TypeHook decides how a column is scanned into the destination before pgxscan applies its own decoding
+// of composite, record and JSON columns, see the Composite types section of the package docs.
+// It returns the target that pgx scans the column into, that is dst itself to let the codec registered
+// in the pgx type map decode it, or a wrapper around dst, e.g. a pgtype.BytesScanner.
+// It returns false to leave the column to the default handling. typeMap is nil if the rows have no connection.
+type TypeHook func(typeMap *pgtype.Map, fd pgconn.FieldDescription, dst interface{}) (target interface{}, ok bool)
+
+// WithTypeHook makes the API consult the hook for every column before decoding it, e.g. to let a custom codec
+// registered for an enum, a domain or a composite type decode it into a struct, instead of pgxscan:
+//
+//	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithTypeHook(
+//		func(typeMap *pgtype.Map, fd pgconn.FieldDescription, dst interface{}) (interface{}, bool) {
+//			return dst, fd.DataTypeOID == moneyOID
+//		},
+//	))
+//
+// If the hook doesn't take the column, Go types registered in the type map via pgtype.Map.RegisterDefaultPgType
+// are passed to pgx as is, unless the column is JSON.
+func WithTypeHook(hook TypeHook) APIOption {
+	return func(api *API) {
+		api.typeHook = hook
+	}
+}
+
+// scanTarget returns the value that pgx scans the column into: the one that the type hook returns,
+// dst itself if its type is registered in the type map for a column that isn't JSON, or the result of structDst.
+func (api *API) scanTarget(typeMap *pgtype.Map, fd pgconn.FieldDescription, dst interface{}) interface{} {
+	if api.typeHook != nil {
+		if target, ok := api.typeHook(typeMap, fd, dst); ok {
+			return target
+		}
+	}
+	var typ *pgtype.Type
+	if typeMap != nil {
+		typ, _ = typeMap.TypeForOID(fd.DataTypeOID)
+		// JSON codecs decode any Go type, so registered types only matter for other columns.
+		if (typ == nil || !isJSONCodec(typ.Codec)) && isRegisteredType(typeMap, dst) {
+			return dst
+		}
+	}
+	return structDst(api, typ, fd.DataTypeOID, dst)
+}
+
+// isRegisteredType reports whether the type that dst points to, or a pointer to it points to,
+// is registered in the type map as the Go type of a PostgreSQL type.
+func isRegisteredType(typeMap *pgtype.Map, dst interface{}) bool {
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr {
+		return false
+	}
+	for t := dstType.Elem(); ; t = t.Elem() {
+		if _, ok := typeMap.TypeForValue(reflect.Zero(t).Interface()); ok {
+			return true
+		}
+		if t.Kind() != reflect.Ptr {
+			return false
+		}
+	}
+}
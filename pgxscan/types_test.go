@@ -0,0 +1,39 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestGet_withTypeHook_leavesColumnToPgx(t *testing.T) {
+	t.Parallel()
+	type child struct {
+		ChildID int
+	}
+	type dst struct {
+		Mapped child
+		Hooked child
+	}
+	dbscanAPI, err := pgxscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithTypeHook(
+		func(typeMap *pgtype.Map, fd pgconn.FieldDescription, dst interface{}) (interface{}, bool) {
+			return dst, fd.Name == "hooked"
+		},
+	))
+	require.NoError(t, err)
+	query := `SELECT '{"child_id": 1}'::JSONB AS mapped, '{"ChildID": 2}'::JSONB AS hooked`
+
+	var got dst
+	err = api.Get(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	// The hooked column is decoded by encoding/json, that matches keys to field names.
+	assert.Equal(t, dst{Mapped: child{ChildID: 1}, Hooked: child{ChildID: 2}}, got)
+}
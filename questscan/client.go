@@ -0,0 +1,85 @@
+package questscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Result is a query result in the format of the QuestDB REST API /exec endpoint.
+type Result struct {
+	Columns []Column            `json:"columns"`
+	Dataset [][]json.RawMessage `json:"dataset"`
+	Count   int64               `json:"count"`
+}
+
+// Column is a column of Result.
+type Column struct {
+	Name string `json:"name"`
+	// Type is the QuestDB type of the column, e.g. "TIMESTAMP" or "SYMBOL".
+	Type string `json:"type"`
+}
+
+// Client queries QuestDB via the REST API /exec endpoint.
+type Client struct {
+	// URL is the base URL of the QuestDB HTTP server, e.g. "http://localhost:9000".
+	URL string
+	// HTTPClient sends requests, http.DefaultClient is used if it's nil.
+	HTTPClient *http.Client
+	// Username and Password are sent with HTTP basic authentication if Username isn't empty.
+	Username, Password string
+}
+
+var _ Querier = &Client{}
+
+// NewClient returns a new Client instance for the base URL of the QuestDB HTTP server.
+func NewClient(baseURL string) *Client {
+	return &Client{URL: baseURL}
+}
+
+// queryError is the body of the /exec response for failed queries.
+type queryError struct {
+	Error    string `json:"error"`
+	Position int    `json:"position"`
+}
+
+// Query implements the Querier.Query method, it runs the query with the /exec endpoint.
+func (c *Client) Query(ctx context.Context, query string) (*Result, error) {
+	endpoint := strings.TrimRight(c.URL, "/") + "/exec?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("scany: create request: %w", err)
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scany: send request: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("scany: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var qe queryError
+		if json.Unmarshal(body, &qe) == nil && qe.Error != "" {
+			return nil, fmt.Errorf("scany: questdb: %s at position %d", qe.Error, qe.Position)
+		}
+		return nil, fmt.Errorf("scany: questdb: unexpected status %s", resp.Status)
+	}
+	result := &Result{}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, fmt.Errorf("scany: decode response: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,36 @@
+// Package questscan allows scanning data into Go structs and other composite types,
+// when working with QuestDB via its REST API.
+/*
+Essentially, questscan is a wrapper around github.com/georgysavva/scany/v2/dbscan package.
+It queries results with the /exec endpoint of the QuestDB HTTP server, that returns them as JSON,
+and scans them with dbscan. It's encouraged to read dbscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan
+
+For inserts, use InfluxDB Line Protocol clients, e.g. github.com/questdb/go-questdb-client,
+and for the PostgreSQL wire protocol of QuestDB, use sqlscan or pgxscan.
+
+Querying results
+
+Client runs queries with the /exec endpoint, Select and Get scan the results:
+
+	type Trade struct {
+		Symbol string
+		Price  float64
+		Ts     time.Time // TIMESTAMP
+	}
+
+	db := questscan.NewClient("http://localhost:9000")
+	var trades []*Trade
+	err := questscan.Select(ctx, db, &trades, `SELECT symbol, price, ts FROM trades WHERE ts IN '2024-01-02'`)
+
+The REST API has no bind parameters, so values must be a part of the query.
+Results that are already fetched are scanned with ScanAll and ScanOne.
+
+Timestamps
+
+TIMESTAMP values are scanned into time.Time fields from ISO strings, that the REST API returns by default,
+or from numbers of microseconds since the Unix epoch, the precision of QuestDB timestamps.
+Numbers of other columns, e.g. `cast(ts AS LONG)`, are microseconds too, and DATE numbers are milliseconds.
+Time values are in UTC.
+*/
+package questscan
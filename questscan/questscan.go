@@ -0,0 +1,335 @@
+package questscan
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Querier is something that questscan can query results from.
+// For example, it can be: *Client.
+type Querier interface {
+	Query(ctx context.Context, query string) (*Result, error)
+}
+
+var (
+	_ dbscan.Rows            = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows = &RowsAdapter{}
+)
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See API.Select for details.
+func Select(ctx context.Context, db Querier, dst interface{}, query string) error {
+	return DefaultAPI.Select(ctx, db, dst, query)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See API.Get for details.
+func Get(ctx context.Context, db Querier, dst interface{}, query string) error {
+	return DefaultAPI.Get(ctx, db, dst, query)
+}
+
+// ScanAll is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAll for details.
+func ScanAll(dst interface{}, result *Result) error {
+	return DefaultAPI.ScanAll(dst, result)
+}
+
+// ScanOne is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOne for details.
+func ScanOne(dst interface{}, result *Result) error {
+	return DefaultAPI.ScanOne(dst, result)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for questscan.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	return dbscan.NewAPI(opts...)
+}
+
+// API is a wrapper around the dbscan.API type.
+// See dbscan.API for details.
+type API struct {
+	dbscanAPI *dbscan.API
+}
+
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	return api, nil
+}
+
+// Select is a high-level function that queries the result from Querier and calls the ScanAll function.
+// The REST API has no bind parameters, so values must be a part of the query.
+// See ScanAll for details.
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string) error {
+	result, err := db.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("scany: query result: %w", err)
+	}
+	if err := api.dbscanAPI.ScanAllContext(ctx, dst, NewRowsAdapter(result)); err != nil {
+		return fmt.Errorf("scanning all: %w", err)
+	}
+	return nil
+}
+
+// Get is a high-level function that queries the result from Querier and calls the ScanOne function.
+// See ScanOne for details.
+func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string) error {
+	result, err := db.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("scany: query result: %w", err)
+	}
+	if err := api.dbscanAPI.ScanOneContext(ctx, dst, NewRowsAdapter(result)); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
+
+// ScanAll is a wrapper around the dbscan.ScanAll function.
+// See dbscan.ScanAll for details.
+func (api *API) ScanAll(dst interface{}, result *Result) error {
+	return api.dbscanAPI.ScanAll(dst, NewRowsAdapter(result))
+}
+
+// ScanOne is a wrapper around the dbscan.ScanOne function.
+// See dbscan.ScanOne for details.
+func (api *API) ScanOne(dst interface{}, result *Result) error {
+	return api.dbscanAPI.ScanOne(dst, NewRowsAdapter(result))
+}
+
+// NotFound is a helper function to check if an error
+// is `dbscan.ErrNotFound`.
+func NotFound(err error) bool {
+	return dbscan.NotFound(err)
+}
+
+// RowsAdapter makes Result compliant with the dbscan.Rows interface.
+// Values are decoded from JSON into destinations, with these conversions of time values:
+//
+//   - TIMESTAMP values are scanned into time.Time, *time.Time and sql.NullTime destinations
+//     from ISO strings, e.g. "2024-01-02T03:04:05.000006Z", or from microseconds since the Unix epoch.
+//   - DATE values are scanned the same way, from ISO strings or milliseconds since the Unix epoch.
+//   - Numbers of other columns, e.g. LONG values of `cast(ts AS LONG)`, are microseconds since the Unix epoch
+//     for time destinations.
+//   - interface{} destinations get time.Time values of TIMESTAMP and DATE columns,
+//     int64 values of integer columns and float64 values of DOUBLE and FLOAT columns.
+//
+// See dbscan.Rows for details.
+type RowsAdapter struct {
+	result  *Result
+	current []json.RawMessage
+	next    int
+	closed  bool
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(result *Result) *RowsAdapter {
+	return &RowsAdapter{result: result}
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *RowsAdapter) Next() bool {
+	if ra.closed || ra.next >= len(ra.result.Dataset) {
+		ra.current = nil
+		return false
+	}
+	ra.current = ra.result.Dataset[ra.next]
+	ra.next++
+	return true
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *RowsAdapter) Columns() ([]string, error) {
+	columns := make([]string, len(ra.result.Columns))
+	for i, c := range ra.result.Columns {
+		columns[i] = c.Name
+	}
+	return columns, nil
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+func (ra *RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	dbTypes := make([]string, len(ra.result.Columns))
+	for i, c := range ra.result.Columns {
+		dbTypes[i] = c.Type
+	}
+	return dbTypes, nil
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if ra.current == nil {
+		return errors.New("scany: scan called without calling Next")
+	}
+	if len(dest) != len(ra.result.Columns) || len(ra.current) != len(ra.result.Columns) {
+		return fmt.Errorf("scany: expected %d destination arguments in Scan, not %d", len(ra.result.Columns), len(dest))
+	}
+	for i, d := range dest {
+		column := ra.result.Columns[i]
+		if err := scanValue(ra.current[i], strings.ToUpper(column.Type), d); err != nil {
+			return fmt.Errorf("scany: column '%s' of type %s: %w", column.Name, column.Type, err)
+		}
+	}
+	return nil
+}
+
+// Err implements the dbscan.Rows.Err method, the result is in memory, so it always returns nil.
+func (ra *RowsAdapter) Err() error {
+	return nil
+}
+
+// Close implements the dbscan.Rows.Close method.
+func (ra *RowsAdapter) Close() error {
+	ra.closed = true
+	return nil
+}
+
+// NextResultSet is currently always return false.
+func (ra *RowsAdapter) NextResultSet() bool {
+	return false
+}
+
+var nullValue = []byte("null")
+
+func scanValue(raw json.RawMessage, colType string, dst interface{}) error {
+	if bytes.Equal(raw, nullValue) {
+		if scanner, ok := dst.(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+		return setZero(dst)
+	}
+	switch d := dst.(type) {
+	case *time.Time:
+		t, err := parseTime(raw, colType)
+		if err != nil {
+			return err
+		}
+		*d = t
+		return nil
+	case **time.Time:
+		t, err := parseTime(raw, colType)
+		if err != nil {
+			return err
+		}
+		*d = &t
+		return nil
+	case *sql.NullTime:
+		t, err := parseTime(raw, colType)
+		if err != nil {
+			return err
+		}
+		*d = sql.NullTime{Time: t, Valid: true}
+		return nil
+	case *interface{}:
+		value, err := decodeValue(raw, colType)
+		if err != nil {
+			return err
+		}
+		*d = value
+		return nil
+	case sql.Scanner:
+		value, err := decodeValue(raw, colType)
+		if err != nil {
+			return err
+		}
+		return d.Scan(value)
+	}
+	if err := json.Unmarshal(raw, dst); err != nil {
+		return fmt.Errorf("decode value: %w", err)
+	}
+	return nil
+}
+
+// parseTime parses an ISO string or a number of microseconds since the Unix epoch, milliseconds for DATE values.
+func parseTime(raw json.RawMessage, colType string) (time.Time, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse time value: %w", err)
+		}
+		return t, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return time.Time{}, fmt.Errorf("can't scan %s into a time value", raw)
+	}
+	epoch, err := n.Int64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse epoch time value: %w", err)
+	}
+	if colType == "DATE" {
+		return time.UnixMilli(epoch).UTC(), nil
+	}
+	return time.UnixMicro(epoch).UTC(), nil
+}
+
+// decodeValue decodes the value for interface{} and sql.Scanner destinations.
+func decodeValue(raw json.RawMessage, colType string) (interface{}, error) {
+	switch colType {
+	case "TIMESTAMP", "DATE":
+		return parseTime(raw, colType)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("decode value: %w", err)
+	}
+	n, ok := value.(json.Number)
+	if !ok {
+		return value, nil
+	}
+	if colType != "DOUBLE" && colType != "FLOAT" {
+		if i, err := n.Int64(); err == nil {
+			return i, nil
+		}
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("decode number: %w", err)
+	}
+	return f, nil
+}
+
+func setZero(dst interface{}) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got: %T", dst)
+	}
+	dstValue.Elem().Set(reflect.Zero(dstValue.Elem().Type()))
+	return nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
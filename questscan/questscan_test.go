@@ -0,0 +1,112 @@
+package questscan_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/questscan"
+)
+
+var ctx = context.Background()
+
+// newFakeServer returns a server that responds to /exec requests with the body and records queries.
+func newFakeServer(t *testing.T, status int, body string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/exec" {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.Query().Get("query"))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, &queries
+}
+
+type trade struct {
+	Symbol    string
+	Price     float64
+	Amount    int64
+	Ts        time.Time
+	Day       *time.Time
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func TestSelect_timeSeries(t *testing.T) {
+	t.Parallel()
+	server, queries := newFakeServer(t, http.StatusOK, `{
+		"query": "trades",
+		"columns": [
+			{"name": "symbol", "type": "SYMBOL"},
+			{"name": "price", "type": "DOUBLE"},
+			{"name": "amount", "type": "LONG"},
+			{"name": "ts", "type": "TIMESTAMP"},
+			{"name": "day", "type": "DATE"},
+			{"name": "created_at", "type": "LONG"}
+		],
+		"dataset": [
+			["BTC-USD", 42000.5, 3, "2024-01-02T03:04:05.000006Z", "2024-01-02T00:00:00.000Z", 1704164645000006],
+			["ETH-USD", 2200.25, 1, 1704164645000006, null, 1704164645000006]
+		],
+		"count": 2
+	}`)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	expected := []trade{
+		{Symbol: "BTC-USD", Price: 42000.5, Amount: 3, Ts: ts, Day: &day, CreatedAt: ts},
+		{Symbol: "ETH-USD", Price: 2200.25, Amount: 1, Ts: ts, CreatedAt: ts},
+	}
+
+	var got []trade
+	err := questscan.Select(ctx, questscan.NewClient(server.URL), &got, `trades`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []string{`trades`}, *queries)
+}
+
+func TestGet_mapDestination(t *testing.T) {
+	t.Parallel()
+	server, _ := newFakeServer(t, http.StatusOK, `{
+		"columns": [
+			{"name": "symbol", "type": "SYMBOL"},
+			{"name": "count", "type": "LONG"},
+			{"name": "avg", "type": "DOUBLE"},
+			{"name": "ts", "type": "TIMESTAMP"}
+		],
+		"dataset": [["BTC-USD", 10, 2, "2024-01-02T03:04:05.000006Z"]],
+		"count": 1
+	}`)
+	expected := map[string]interface{}{
+		"symbol": "BTC-USD",
+		"count":  int64(10),
+		"avg":    float64(2),
+		"ts":     time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC),
+	}
+
+	var got map[string]interface{}
+	err := questscan.Get(ctx, questscan.NewClient(server.URL), &got, `SELECT symbol, count(), avg(price), ts FROM trades`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_queryError_returnsErr(t *testing.T) {
+	t.Parallel()
+	server, _ := newFakeServer(t, http.StatusBadRequest,
+		`{"query": "SELECT foo FROM trades", "error": "Invalid column: foo", "position": 7}`)
+
+	var got []trade
+	err := questscan.Select(ctx, questscan.NewClient(server.URL), &got, `SELECT foo FROM trades`)
+
+	assert.ErrorContains(t, err, "scany: questdb: Invalid column: foo at position 7")
+}
@@ -0,0 +1,13 @@
+// Command scanyvet checks that columns of queries passed to sqlscan and pgxscan match destination structs,
+// see the scanyvet package for details.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/georgysavva/scany/v2/scanyvet"
+)
+
+func main() {
+	singlechecker.Main(scanyvet.Analyzer)
+}
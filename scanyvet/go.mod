@@ -0,0 +1,19 @@
+module github.com/georgysavva/scany/v2/scanyvet
+
+go 1.22.0
+
+require (
+	github.com/georgysavva/scany/v2 v2.1.3
+	github.com/stretchr/testify v1.8.4
+	golang.org/x/tools v0.26.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/georgysavva/scany/v2 => ../
@@ -0,0 +1,140 @@
+// Package scanyvet provides a go/analysis analyzer that validates SQL queries passed to sqlscan and pgxscan
+// against destination structs, so column drift is caught at CI time rather than at runtime.
+/*
+For calls of Select, Get, SelectNamed and GetNamed of sqlscan and pgxscan with a constant query,
+the analyzer extracts the select list of the query and reports columns that have no corresponding field
+in the destination struct, that dbscan would fail to scan:
+
+	var users []User
+	err := sqlscan.Select(ctx, db, &users, `SELECT id, full_name FROM users`)
+	// column "full_name" has no corresponding field in User
+
+Columns are mapped to fields by the default dbscan rules, the same way the package-level functions do.
+Queries with a star in the select list or that don't start with SELECT aren't checked,
+as well as items of the select list without a known name, e.g. `count(*)` without an alias.
+Unquoted identifiers are folded to lower case, as PostgreSQL does.
+
+Run it with the scanyvet command, standalone or via go vet:
+
+	go vet -vettool=$(which scanyvet) ./...
+
+scanyvet is a separate module, so golang.org/x/tools isn't a dependency of scany itself.
+*/
+package scanyvet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/georgysavva/scany/v2/internal/typemap"
+)
+
+// Analyzer reports columns of queries passed to sqlscan and pgxscan that don't match the destination struct.
+var Analyzer = &analysis.Analyzer{
+	Name:     "scanyvet",
+	Doc:      "check that columns of queries passed to sqlscan and pgxscan match destination structs",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// checkedPackages are packages whose functions are checked.
+var checkedPackages = map[string]bool{
+	"github.com/georgysavva/scany/v2/sqlscan": true,
+	"github.com/georgysavva/scany/v2/pgxscan": true,
+}
+
+// checkedFuncs are checked functions, with true for the ones that scan multiple rows into a slice.
+var checkedFuncs = map[string]bool{
+	"Select":      true,
+	"SelectNamed": true,
+	"Get":         false,
+	"GetNamed":    false,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || !checkedPackages[fn.Pkg().Path()] {
+			return
+		}
+		multipleRows, ok := checkedFuncs[fn.Name()]
+		sig := fn.Type().(*types.Signature)
+		if !ok || sig.Recv() != nil {
+			return
+		}
+		dstArg, queryArg := argByName(call, sig, "dst"), argByName(call, sig, "query")
+		if dstArg == nil || queryArg == nil {
+			return
+		}
+		query := pass.TypesInfo.Types[queryArg].Value
+		if query == nil || query.Kind() != constant.String {
+			return
+		}
+		structType, ok := destinationStruct(pass.TypesInfo.TypeOf(dstArg), multipleRows)
+		if !ok {
+			return
+		}
+		columns, ok := selectColumns(constant.StringVal(query))
+		if !ok {
+			return
+		}
+		fields := make(map[string]bool)
+		for _, c := range typemap.Columns(structType) {
+			fields[c.Name] = true
+		}
+		typeName := types.TypeString(structType, types.RelativeTo(pass.Pkg))
+		for _, column := range columns {
+			if column != "" && !fields[column] {
+				pass.Reportf(queryArg.Pos(), "column %q has no corresponding field in %s", column, typeName)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// argByName returns the argument of the call for the parameter with the name, nil if there is no such parameter.
+func argByName(call *ast.CallExpr, sig *types.Signature, name string) ast.Expr {
+	for i := 0; i < sig.Params().Len(); i++ {
+		if sig.Params().At(i).Name() == name && i < len(call.Args) {
+			return call.Args[i]
+		}
+	}
+	return nil
+}
+
+// destinationStruct returns the struct type that rows are scanned into, ok is false if the destination
+// isn't a struct, e.g. a map, or it's scanned as a whole, like sql.Scanner implementations.
+func destinationStruct(dstType types.Type, multipleRows bool) (types.Type, bool) {
+	ptr, ok := dstType.Underlying().(*types.Pointer)
+	if !ok {
+		return nil, false
+	}
+	elem := ptr.Elem()
+	if multipleRows {
+		slice, ok := elem.Underlying().(*types.Slice)
+		if !ok {
+			return nil, false
+		}
+		elem = slice.Elem()
+		if ptr, ok := elem.Underlying().(*types.Pointer); ok {
+			elem = ptr.Elem()
+		}
+	}
+	if _, ok := elem.Underlying().(*types.Struct); !ok {
+		return nil, false
+	}
+	if scan, _, _ := types.LookupFieldOrMethod(types.NewPointer(elem), true, nil, "Scan"); scan != nil {
+		if _, isFunc := scan.(*types.Func); isFunc {
+			return nil, false
+		}
+	}
+	return elem, true
+}
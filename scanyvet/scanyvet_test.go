@@ -0,0 +1,14 @@
+package scanyvet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/georgysavva/scany/v2/scanyvet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Parallel()
+	analysistest.Run(t, analysistest.TestData(), scanyvet.Analyzer, "a")
+}
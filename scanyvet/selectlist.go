@@ -0,0 +1,204 @@
+package scanyvet
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	quotedToken
+	stringToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// depth is the depth of parentheses that the token is in.
+	depth int
+}
+
+// stopWords end the select list.
+var stopWords = map[string]bool{
+	"FROM": true, "INTO": true, "WHERE": true, "GROUP": true, "HAVING": true, "ORDER": true, "LIMIT": true,
+	"OFFSET": true, "FETCH": true, "UNION": true, "EXCEPT": true, "INTERSECT": true, "WINDOW": true, "FOR": true,
+}
+
+// keywords can't be implicit aliases or precede them in a select list item, e.g. END of CASE ... END.
+var keywords = map[string]bool{
+	"END": true, "NULL": true, "TRUE": true, "FALSE": true, "AND": true, "OR": true, "NOT": true, "IS": true,
+	"CASE": true, "WHEN": true, "THEN": true, "ELSE": true, "IN": true, "LIKE": true, "BETWEEN": true,
+}
+
+// selectColumns returns names of columns that the query returns, in order. Names are empty for items of
+// the select list without a known name, e.g. `count(*)` without an alias. ok is false if the columns
+// can't be determined, e.g. for `SELECT *` or queries that don't start with SELECT.
+// Unquoted identifiers are folded to lower case, as PostgreSQL does.
+func selectColumns(query string) (columns []string, ok bool) {
+	tokens := tokenize(query)
+	if len(tokens) == 0 || !isWord(tokens[0], "SELECT") {
+		return nil, false
+	}
+	tokens = tokens[1:]
+	if len(tokens) > 0 && (isWord(tokens[0], "ALL") || isWord(tokens[0], "DISTINCT")) {
+		tokens = tokens[1:]
+		if len(tokens) > 0 && isWord(tokens[0], "ON") {
+			tokens = tokens[1:]
+			for len(tokens) > 0 && (tokens[0].depth > 0 || tokens[0].text == "(") {
+				tokens = tokens[1:]
+			}
+			if len(tokens) > 0 && tokens[0].text == ")" {
+				tokens = tokens[1:]
+			}
+		}
+	}
+	var item []token
+	for i := 0; i <= len(tokens); i++ {
+		end := i == len(tokens)
+		if !end {
+			t := tokens[i]
+			end = t.depth == 0 && ((t.kind == wordToken && stopWords[strings.ToUpper(t.text)]) || t.text == ";")
+		}
+		if end || (tokens[i].depth == 0 && tokens[i].text == ",") {
+			name, ok := itemName(item)
+			if !ok {
+				return nil, false
+			}
+			columns = append(columns, name)
+			item = nil
+			if end {
+				break
+			}
+			continue
+		}
+		item = append(item, tokens[i])
+	}
+	return columns, true
+}
+
+// itemName returns the name of the column of the select list item, ok is false if it's a star.
+func itemName(item []token) (string, bool) {
+	n := len(item)
+	if n == 0 {
+		return "", true
+	}
+	last := item[n-1]
+	if last.text == "*" && (n == 1 || item[n-2].text == ".") {
+		return "", false
+	}
+	if last.depth > 0 || !isIdent(last) {
+		return "", true
+	}
+	if n == 1 {
+		return identName(last), true
+	}
+	prev := item[n-2]
+	switch {
+	case isWord(prev, "AS"):
+		return identName(last), true
+	case prev.text == ".":
+		// A qualified column, e.g. u.name, is named after the column if the whole item is a qualified name.
+		for i, t := range item {
+			if t.depth > 0 || (i%2 == 0) != isIdent(t) || (i%2 == 1 && t.text != ".") {
+				return "", true
+			}
+		}
+		return identName(last), true
+	case prev.text == ")" || prev.kind != symbolToken:
+		// An implicit alias, e.g. count(*) total.
+		if isKeyword(last) || isKeyword(prev) {
+			return "", true
+		}
+		return identName(last), true
+	}
+	return "", true
+}
+
+func isWord(t token, word string) bool {
+	return t.kind == wordToken && strings.EqualFold(t.text, word)
+}
+
+func isKeyword(t token) bool {
+	return t.kind == wordToken && keywords[strings.ToUpper(t.text)]
+}
+
+// isIdent reports whether the token is an identifier, rather than a number or a placeholder like $1.
+func isIdent(t token) bool {
+	if t.kind == quotedToken {
+		return true
+	}
+	return t.kind == wordToken && !unicode.IsDigit(rune(t.text[0])) && t.text[0] != '$'
+}
+
+func identName(t token) string {
+	if t.kind == quotedToken {
+		quote := t.text[:1]
+		return strings.ReplaceAll(t.text[1:len(t.text)-1], quote+quote, quote)
+	}
+	return strings.ToLower(t.text)
+}
+
+// tokenize splits the query into tokens, skipping whitespace and comments.
+func tokenize(query string) []token {
+	var tokens []token
+	depth := 0
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(query[i:], "--"):
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`':
+			j := i + 1
+			for j < len(query) {
+				if query[j] == c {
+					if j+1 < len(query) && query[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j >= len(query) {
+				return tokens
+			}
+			kind := quotedToken
+			if c == '\'' {
+				kind = stringToken
+			}
+			tokens = append(tokens, token{kind: kind, text: query[i : j+1], depth: depth})
+			i = j + 1
+		case c == '_' || c == '$' || c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i
+			for j < len(query) && (query[j] == '_' || query[j] == '$' || query[j] >= 0x80 ||
+				unicode.IsLetter(rune(query[j])) || unicode.IsDigit(rune(query[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: query[i:j], depth: depth})
+			i = j
+		default:
+			if c == ')' && depth > 0 {
+				depth--
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: string(c), depth: depth})
+			if c == '(' {
+				depth++
+			}
+			i++
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,63 @@
+package scanyvet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectColumns(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		query    string
+		expected []string
+		ok       bool
+	}{
+		{name: "plain columns", query: "SELECT id, name FROM users", expected: []string{"id", "name"}, ok: true},
+		{name: "lower case folding", query: "select ID, Name from users", expected: []string{"id", "name"}, ok: true},
+		{
+			name:     "qualified columns",
+			query:    "SELECT u.id, public.users.name FROM users u",
+			expected: []string{"id", "name"},
+			ok:       true,
+		},
+		{
+			name:     "aliases",
+			query:    `SELECT id AS "User.ID", count(*) total, 'x' label FROM t`,
+			expected: []string{"User.ID", "total", "label"},
+			ok:       true,
+		},
+		{name: "escaped quote", query: `SELECT 1 AS "a""b"`, expected: []string{`a"b`}, ok: true},
+		{name: "backticks", query: "SELECT `userName` FROM users", expected: []string{"userName"}, ok: true},
+		{
+			name:     "unknown names",
+			query:    `SELECT count(*), id::text, $1, CASE WHEN a THEN 1 END, a AND b FROM t`,
+			expected: []string{"", "", "", "", ""},
+			ok:       true,
+		},
+		{
+			name:     "subquery",
+			query:    "SELECT (SELECT max(id) FROM posts) AS max_id, name FROM users",
+			expected: []string{"max_id", "name"},
+			ok:       true,
+		},
+		{name: "comments", query: "-- users\nSELECT /* id */ name FROM users", expected: []string{"name"}, ok: true},
+		{name: "distinct on", query: "SELECT DISTINCT ON (a, b) a, c FROM t", expected: []string{"a", "c"}, ok: true},
+		{name: "without from", query: "SELECT 1 AS one;", expected: []string{"one"}, ok: true},
+		{name: "union", query: "SELECT a FROM t UNION SELECT b FROM u", expected: []string{"a"}, ok: true},
+		{name: "star", query: "SELECT * FROM users", ok: false},
+		{name: "qualified star", query: "SELECT id, u.* FROM users u", ok: false},
+		{name: "not select", query: "WITH x AS (SELECT 1) SELECT * FROM x", ok: false},
+		{name: "empty", query: "", ok: false},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := selectColumns(tc.query)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package a
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type Post struct {
+	ID    int64
+	Title string
+}
+
+type User struct {
+	ID        int64
+	Name      string `db:"user_name"`
+	CreatedAt time.Time
+	Post      *Post
+	Secret    string `db:"-"`
+}
+
+const usersQuery = `SELECT id, user_name FROM users`
+
+func queries(ctx context.Context, db *sql.DB, pdb pgxscan.Querier, api *sqlscan.API, query string) {
+	var users []*User
+	var user User
+	_ = sqlscan.Select(ctx, db, &users, `SELECT id, user_name, created_at FROM users`)
+	_ = sqlscan.Select(ctx, db, &users, `SELECT id, name FROM users`) // want `column "name" has no corresponding field in User`
+	_ = sqlscan.Get(ctx, db, &user, `SELECT u.id, u.name AS user_name, p.title AS "post.title" FROM users u JOIN posts p`)
+	_ = sqlscan.Get(ctx, db, &user, "SELECT id, secret FROM users WHERE id = $1", 1)     // want `column "secret" has no corresponding field in User`
+	_ = sqlscan.Get(ctx, db, &user, `SELECT count(*), max(id) AS id, "Post" FROM users`) // want `column "Post" has no corresponding field in User`
+	_ = sqlscan.SelectNamed(ctx, db, &users, usersQuery+` WHERE id = :id`, nil)
+	_ = sqlscan.GetNamed(ctx, db, &user, `SELECT id, email FROM users WHERE id = :id`, nil)  // want `column "email" has no corresponding field in User`
+	_ = pgxscan.Select(ctx, pdb, &users, `SELECT ID, Deleted_At FROM users`)                 // want `column "deleted_at" has no corresponding field in User`
+	_ = pgxscan.Get(ctx, pdb, &user, `SELECT DISTINCT ON (id) id, created_at at FROM users`) // want `column "at" has no corresponding field in User`
+
+	// Queries that can't be checked.
+	_ = sqlscan.Select(ctx, db, &users, `SELECT * FROM users`)
+	_ = sqlscan.Select(ctx, db, &users, `SELECT u.*, p.title FROM users u`)
+	_ = sqlscan.Select(ctx, db, &users, `INSERT INTO users (name) VALUES ('foo') RETURNING name`)
+	_ = sqlscan.Select(ctx, db, &users, query)
+	_ = api.Select(ctx, db, &users, `SELECT name FROM users`)
+	var names []string
+	_ = sqlscan.Select(ctx, db, &names, `SELECT name FROM users`)
+	var rows []map[string]interface{}
+	_ = sqlscan.Select(ctx, db, &rows, `SELECT name FROM users`)
+	var nullName sql.NullString
+	_ = sqlscan.Get(ctx, db, &nullName, `SELECT name FROM users`)
+}
@@ -0,0 +1,14 @@
+// Package pgxscan is a stub of the pgxscan package with functions that scanyvet checks.
+package pgxscan
+
+import "context"
+
+type Querier interface{}
+
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return nil
+}
@@ -0,0 +1,33 @@
+// Package sqlscan is a stub of the sqlscan package with functions that scanyvet checks.
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return nil
+}
+
+func GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return nil
+}
+
+type API struct{}
+
+func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return nil
+}
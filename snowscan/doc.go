@@ -0,0 +1,48 @@
+// Package snowscan allows scanning data into Go structs and other composite types,
+// when working with Snowflake via database/sql library and the gosnowflake driver.
+/*
+Essentially, snowscan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for github.com/snowflakedb/gosnowflake driver.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+snowscan is a separate module, so applications that don't use Snowflake don't depend on gosnowflake.
+
+Snowflake specifics
+
+snowscan uses the Dialect dialect and dbscan.LowerCaseNormalizer, so high-level functions behave
+the way Snowflake expects:
+
+  - Column names, that Snowflake returns in upper case unless they are quoted, are converted into lower case,
+    so CREATED_AT matches the CreatedAt field and the "created_at" key of map destinations.
+  - Named parameters, e.g. `WHERE id = :id`, are rewritten into "?" placeholders.
+  - VARIANT, OBJECT and ARRAY values, that gosnowflake returns as JSON strings, are decoded
+    into struct, map and slice fields with encoding/json, so json struct tags apply.
+    string, []byte and sql.Scanner fields get the JSON text as is.
+
+For example:
+
+	type Event struct {
+		ID      int64
+		Payload struct {
+			Kind string `json:"kind"`
+		} // VARIANT
+		Tags []string // ARRAY
+	}
+
+	var events []*Event
+	err := snowscan.Select(ctx, db, &events, `SELECT id, payload, tags FROM events`)
+
+Asynchronous queries
+
+Submit starts a query in gosnowflake async mode and returns its query ID right away,
+SelectResult and GetResult wait for the query to complete and scan its results later:
+
+	queryID, err := snowscan.Submit(ctx, db, `SELECT * FROM events WHERE day = ?`, day)
+	// ...
+	err = snowscan.SelectResult(ctx, db, &events, queryID)
+
+Rows of a query run with gosnowflake.WithAsyncMode context can be passed to ScanAll and ScanOne directly,
+they block until results are ready.
+*/
+package snowscan
@@ -0,0 +1,168 @@
+package snowscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sf "github.com/snowflakedb/gosnowflake"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Dialect is the dialect that snowscan uses.
+var Dialect = sqlscan.Dialect{
+	Name:        "snowflake",
+	Placeholder: sqlscan.PlaceholderQuestion,
+	OpenQuote:   `"`,
+	CloseQuote:  `"`,
+	Limit:       sqlscan.LimitClause,
+	// Snowflake accepts up to 16384 expressions in a list, e.g. of IN and VALUES.
+	MaxParams:       16384,
+	ValueConverters: []sqlscan.ValueConverter{sqlscan.JSONConverter("VARIANT", "OBJECT", "ARRAY")},
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// Submit is a package-level helper function that uses the DefaultAPI object.
+// See API.Submit for details.
+func Submit(ctx context.Context, db sqlscan.Querier, query string, args ...interface{}) (string, error) {
+	return DefaultAPI.Submit(ctx, db, query, args...)
+}
+
+// SelectResult is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectResult for details.
+func SelectResult(ctx context.Context, db sqlscan.Querier, dst interface{}, queryID string) error {
+	return DefaultAPI.SelectResult(ctx, db, dst, queryID)
+}
+
+// GetResult is a package-level helper function that uses the DefaultAPI object.
+// See API.GetResult for details.
+func GetResult(ctx context.Context, db sqlscan.Querier, dst interface{}, queryID string) error {
+	return DefaultAPI.GetResult(ctx, db, dst, queryID)
+}
+
+// NewDBScanAPI creates a new dbscan API object with default configuration settings for snowscan:
+// the ones of sqlscan and dbscan.LowerCaseNormalizer for upper-case column names of Snowflake.
+func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
+	opts = append([]dbscan.APIOption{dbscan.WithColumnNormalizers(dbscan.LowerCaseNormalizer)}, opts...)
+	return sqlscan.NewDBScanAPI(opts...)
+}
+
+// API is a wrapper around the sqlscan.API type that uses the Snowflake dialect.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect, options can change it or its parts, e.g. with sqlscan.WithPlaceholderStyle.
+// Create dbscanAPI with NewDBScanAPI, so upper-case column names match struct fields.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{sqlscan.WithDialect(Dialect)}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+// Submit starts the query asynchronously and returns its query ID without waiting for results.
+// Pass the ID to SelectResult or GetResult to scan the results later, possibly from another connection.
+// db must be backed by the gosnowflake driver.
+func (api *API) Submit(ctx context.Context, db sqlscan.Querier, query string, args ...interface{}) (string, error) {
+	queryIDs := make(chan string, 1)
+	ctx = sf.WithQueryIDChan(sf.WithAsyncMode(ctx), queryIDs)
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("scany: submit query: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return "", fmt.Errorf("scany: close rows: %w", err)
+	}
+	select {
+	case queryID, ok := <-queryIDs:
+		if ok && queryID != "" {
+			return queryID, nil
+		}
+	default:
+	}
+	return "", errors.New("scany: driver didn't report the query ID, db must be backed by gosnowflake")
+}
+
+// SelectResult is the same as Select, but it scans the results of a query submitted before, see Submit.
+// It waits until the query completes.
+func (api *API) SelectResult(ctx context.Context, db sqlscan.Querier, dst interface{}, queryID string) error {
+	return api.Select(resultContext(ctx, queryID), db, dst, "")
+}
+
+// GetResult is the same as Get, but it scans the results of a query submitted before, see Submit.
+// It waits until the query completes.
+func (api *API) GetResult(ctx context.Context, db sqlscan.Querier, dst interface{}, queryID string) error {
+	return api.Get(resultContext(ctx, queryID), db, dst, "")
+}
+
+// resultContext makes gosnowflake fetch results by the query ID, the query text is empty,
+// so results aren't cached, see sqlscan.WithCache, since they all share the same key.
+func resultContext(ctx context.Context, queryID string) context.Context {
+	return sf.WithFetchResultByID(sqlscan.CacheTTL(ctx, 0), queryID)
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
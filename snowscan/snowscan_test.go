@@ -0,0 +1,82 @@
+package snowscan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/snowscan"
+)
+
+var ctx = context.Background()
+
+type payload struct {
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+func TestSelect_semiStructuredColumns(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{
+			{Name: "ID", Type: "FIXED"},
+			{Name: "PAYLOAD", Type: "VARIANT"},
+			{Name: "ATTRS", Type: "OBJECT"},
+			{Name: "TAGS", Type: "ARRAY"},
+			{Name: "RAW", Type: "VARIANT"},
+			{Name: "EXTRA", Type: "OBJECT"},
+		},
+		Rows: [][]driver.Value{
+			{"1", `{"kind": "click", "count": 2}`, `{"a": 1}`, `["x", "y"]`, `{"b": true}`, nil},
+		},
+	}}})
+	type event struct {
+		ID      int64
+		Payload payload
+		Attrs   map[string]int
+		Tags    []string
+		Raw     string
+		Extra   *payload
+	}
+	expected := []event{{
+		ID:      1,
+		Payload: payload{Kind: "click", Count: 2},
+		Attrs:   map[string]int{"a": 1},
+		Tags:    []string{"x", "y"},
+		Raw:     `{"b": true}`,
+	}}
+
+	var got []event
+	err := snowscan.Select(ctx, db, &got, `SELECT * FROM events`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGet_invalidJSON_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{{Name: "TAGS", Type: "ARRAY"}},
+		Rows:    [][]driver.Value{{`["x", `}},
+	}}})
+
+	var got struct{ Tags []string }
+	err := snowscan.Get(ctx, db, &got, `SELECT tags FROM events`)
+
+	assert.ErrorContains(t, err, "scany: decode JSON value into *[]string")
+}
+
+func TestSubmit_notGoSnowflake_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: []sqlfake.Column{{Name: "ID", Type: "FIXED"}},
+	}}})
+
+	_, err := snowscan.Submit(ctx, db, `SELECT id FROM events`)
+
+	assert.EqualError(t, err, "scany: driver didn't report the query ID, db must be backed by gosnowflake")
+}
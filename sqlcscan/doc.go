@@ -0,0 +1,29 @@
+// Package sqlcscan allows scanning results of sqlc generated queries into Go structs and other composite types
+// with scany, so codebases can mix sqlc and scany or move from one to the other query by query.
+/*
+sqlc generates a Queries object with a method per query, that sends the query to a DBTX interface
+and scans the rows into generated row structs field by field. sqlcscan keeps the query
+and its arguments from the generated code and replaces the scanning with scany's,
+so rows can be scanned into any destination that dbscan supports:
+structs with nested and embedded structs, column prefixes, JSON fields, maps or a custom row struct.
+
+Call the generated method with Recorder in place of the database in a function,
+sqlcscan records the query the method sends and runs it with sqlscan or pgxscan:
+
+	var authors []*AuthorWithBooks
+	err := sqlcscan.Select(ctx, db, &authors, func(r *sqlcscan.Recorder) error {
+		_, err := sqlcdb.New(r).ListAuthors(ctx, arg)
+		return err
+	})
+
+Recorder implements both DBTX interfaces that sqlc generates, of database/sql and of pgx/v5,
+so the same function works for code generated with either sql_package.
+Select and Get use the sqlscan.DefaultAPI, SelectPgx and GetPgx use the pgxscan.DefaultAPI.
+With custom API objects, call Capture and pass the Query it returns to the API:
+
+	query, err := sqlcscan.Capture(call)
+	err = api.Select(ctx, db, &authors, query.SQL, query.Args...)
+
+Recorder doesn't support prepared queries, generate the code without emit_prepared_queries.
+*/
+package sqlcscan
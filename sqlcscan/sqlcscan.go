@@ -0,0 +1,210 @@
+package sqlcscan
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// Query is the query that a sqlc generated method sends to the database.
+type Query struct {
+	// Name and Command are the name and the command of the query from the sqlc annotation,
+	// e.g. "ListAuthors" and ":many" for "-- name: ListAuthors :many". They are empty if there is no annotation.
+	Name    string
+	Command string
+	SQL     string
+	Args    []interface{}
+}
+
+// Call calls a sqlc generated method with the Recorder as the database, see Capture.
+type Call func(r *Recorder) error
+
+// ErrNoQuery is returned by Capture if the call doesn't send a query to the Recorder.
+var ErrNoQuery = errors.New("scany: sqlc call didn't send a query")
+
+var errPrepared = errors.New("scany: prepared sqlc queries aren't supported")
+
+// Capture calls the function and returns the query that it sends to the Recorder.
+// Only the first query is captured, the Recorder makes it fail, so the generated method returns.
+func Capture(call Call) (Query, error) {
+	err := call(&Recorder{})
+	if err == nil {
+		return Query{}, ErrNoQuery
+	}
+	var captured *capturedQuery
+	if !errors.As(err, &captured) {
+		return Query{}, fmt.Errorf("scany: sqlc call: %w", err)
+	}
+	return captured.query, nil
+}
+
+// Select captures the query of the sqlc call and selects its rows into dst with sqlscan, see sqlscan.Select.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, call Call) error {
+	query, err := Capture(call)
+	if err != nil {
+		return err
+	}
+	return sqlscan.Select(ctx, db, dst, query.SQL, query.Args...)
+}
+
+// Get captures the query of the sqlc call and gets a single row into dst with sqlscan, see sqlscan.Get.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, call Call) error {
+	query, err := Capture(call)
+	if err != nil {
+		return err
+	}
+	return sqlscan.Get(ctx, db, dst, query.SQL, query.Args...)
+}
+
+// SelectPgx captures the query of the sqlc call and selects its rows into dst with pgxscan, see pgxscan.Select.
+func SelectPgx(ctx context.Context, db pgxscan.Querier, dst interface{}, call Call) error {
+	query, err := Capture(call)
+	if err != nil {
+		return err
+	}
+	return pgxscan.Select(ctx, db, dst, query.SQL, query.Args...)
+}
+
+// GetPgx captures the query of the sqlc call and gets a single row into dst with pgxscan, see pgxscan.Get.
+func GetPgx(ctx context.Context, db pgxscan.Querier, dst interface{}, call Call) error {
+	query, err := Capture(call)
+	if err != nil {
+		return err
+	}
+	return pgxscan.Get(ctx, db, dst, query.SQL, query.Args...)
+}
+
+// Recorder is a fake database that records queries instead of sending them, see Capture.
+// It implements DBTX interfaces that sqlc generates for database/sql and for pgx/v5.
+type Recorder struct{}
+
+// ExecContext records the query.
+func (r *Recorder) ExecContext(_ context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, newCapturedQuery(query, args)
+}
+
+// PrepareContext fails, since prepared queries aren't supported.
+func (r *Recorder) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errPrepared
+}
+
+// QueryContext records the query.
+func (r *Recorder) QueryContext(_ context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, newCapturedQuery(query, args)
+}
+
+// QueryRowContext records the query. The returned row fails to scan with the recorded query.
+func (r *Recorder) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	// *sql.Row can't be created outside of database/sql, so the query goes through a fake driver
+	// that fails it, and the row keeps the error.
+	return recorderDB().QueryRowContext(ctx, query, args...)
+}
+
+// Exec records the query.
+func (r *Recorder) Exec(_ context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, newCapturedQuery(query, args)
+}
+
+// Query records the query.
+func (r *Recorder) Query(_ context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	return nil, newCapturedQuery(query, args)
+}
+
+// QueryRow records the query. The returned row fails to scan with the recorded query.
+func (r *Recorder) QueryRow(_ context.Context, query string, args ...interface{}) pgx.Row {
+	return errRow{err: newCapturedQuery(query, args)}
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...interface{}) error { return r.err }
+
+// capturedQuery is the error that fails recorded queries, so generated methods return it to Capture.
+type capturedQuery struct {
+	query Query
+}
+
+func newCapturedQuery(text string, args []interface{}) *capturedQuery {
+	name, command := parseAnnotation(text)
+	return &capturedQuery{query: Query{Name: name, Command: command, SQL: text, Args: args}}
+}
+
+func (e *capturedQuery) Error() string {
+	return fmt.Sprintf("scany: sqlc query %q is captured", e.query.Name)
+}
+
+// parseAnnotation returns the name and the command of the "-- name: ListAuthors :many" comment,
+// that sqlc puts on the first line of queries.
+func parseAnnotation(query string) (name, command string) {
+	line := query
+	if i := strings.IndexByte(query, '\n'); i >= 0 {
+		line = query[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[0] != "--" || fields[1] != "name:" {
+		return "", ""
+	}
+	name = fields[2]
+	if len(fields) > 3 {
+		command = fields[3]
+	}
+	return name, command
+}
+
+var (
+	recorderDBOnce  sync.Once
+	recorderDBValue *sql.DB
+)
+
+func recorderDB() *sql.DB {
+	recorderDBOnce.Do(func() {
+		recorderDBValue = sql.OpenDB(recorderConnector{})
+	})
+	return recorderDBValue
+}
+
+// recorderConnector connects to the fake driver that fails queries with capturedQuery errors.
+type recorderConnector struct{}
+
+func (recorderConnector) Connect(context.Context) (driver.Conn, error) { return recorderConn{}, nil }
+func (recorderConnector) Driver() driver.Driver                        { return recorderDriver{} }
+
+type recorderDriver struct{}
+
+func (recorderDriver) Open(string) (driver.Conn, error) { return recorderConn{}, nil }
+
+type recorderConn struct{}
+
+func (recorderConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errPrepared
+}
+func (recorderConn) Close() error { return nil }
+func (recorderConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("scany: transactions aren't supported")
+}
+
+// CheckNamedValue accepts any argument, so the recorded arguments are the ones that the call passed.
+func (recorderConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (recorderConn) QueryContext(_ context.Context, query string, namedArgs []driver.NamedValue) (driver.Rows, error) {
+	args := make([]interface{}, len(namedArgs))
+	for i, arg := range namedArgs {
+		args[i] = arg.Value
+		if arg.Name != "" {
+			args[i] = sql.Named(arg.Name, arg.Value)
+		}
+	}
+	return nil, newCapturedQuery(query, args)
+}
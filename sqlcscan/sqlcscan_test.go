@@ -0,0 +1,246 @@
+package sqlcscan_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+	"github.com/georgysavva/scany/v2/sqlcscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+var ctx = context.Background()
+
+// The code below mirrors what sqlc generates for database/sql.
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Author struct {
+	ID   int64
+	Name string
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name FROM authors WHERE name LIKE $1 ORDER BY name
+`
+
+func (q *Queries) ListAuthors(ctx context.Context, name string) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+// The code below mirrors what sqlc generates for pgx/v5.
+
+type PgxDBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+type PgxQueries struct {
+	db PgxDBTX
+}
+
+func NewPgx(db PgxDBTX) *PgxQueries {
+	return &PgxQueries{db: db}
+}
+
+func (q *PgxQueries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRow(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+func (q *PgxQueries) ListAuthors(ctx context.Context, name string) ([]Author, error) {
+	rows, err := q.db.Query(ctx, listAuthors, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	return items, rows.Err()
+}
+
+// AuthorWithBooks is a destination that sqlc can't scan into.
+type AuthorWithBooks struct {
+	Author
+	Books []string `db:"books"`
+}
+
+func TestCapture_queryRow(t *testing.T) {
+	t.Parallel()
+	query, err := sqlcscan.Capture(func(r *sqlcscan.Recorder) error {
+		_, err := New(r).GetAuthor(ctx, 42)
+		return err
+	})
+	require.NoError(t, err)
+
+	expected := sqlcscan.Query{Name: "GetAuthor", Command: ":one", SQL: getAuthor, Args: []interface{}{int64(42)}}
+	assert.Equal(t, expected, query)
+}
+
+func TestCapture_pgxQuery(t *testing.T) {
+	t.Parallel()
+	query, err := sqlcscan.Capture(func(r *sqlcscan.Recorder) error {
+		_, err := NewPgx(r).ListAuthors(ctx, "foo%")
+		return err
+	})
+	require.NoError(t, err)
+
+	expected := sqlcscan.Query{Name: "ListAuthors", Command: ":many", SQL: listAuthors, Args: []interface{}{"foo%"}}
+	assert.Equal(t, expected, query)
+}
+
+func TestCapture_noAnnotation(t *testing.T) {
+	t.Parallel()
+	query, err := sqlcscan.Capture(func(r *sqlcscan.Recorder) error {
+		_, err := r.ExecContext(ctx, "DELETE FROM authors")
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, sqlcscan.Query{SQL: "DELETE FROM authors"}, query)
+}
+
+func TestCapture_errors(t *testing.T) {
+	t.Parallel()
+	_, err := sqlcscan.Capture(func(*sqlcscan.Recorder) error { return nil })
+	assert.ErrorIs(t, err, sqlcscan.ErrNoQuery)
+
+	callErr := errors.New("invalid argument")
+	_, err = sqlcscan.Capture(func(*sqlcscan.Recorder) error { return callErr })
+	assert.ErrorIs(t, err, callErr)
+
+	_, err = sqlcscan.Capture(func(r *sqlcscan.Recorder) error {
+		_, err := r.PrepareContext(ctx, getAuthor)
+		return err
+	})
+	assert.EqualError(t, err, "scany: sqlc call: scany: prepared sqlc queries aren't supported")
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+	db := sqlscantest.NewQuerier()
+	defer db.Close() //nolint: errcheck
+	db.On("FROM authors").ReturnRows(
+		[]string{"id", "name", "books"},
+		[][]interface{}{{int64(1), "foo", "bar"}, {int64(2), "baz", "qux"}},
+	)
+
+	var authors []struct {
+		Author
+		Book string `db:"books"`
+	}
+	err := sqlcscan.Select(ctx, db, &authors, func(r *sqlcscan.Recorder) error {
+		_, err := New(r).ListAuthors(ctx, "%")
+		return err
+	})
+	require.NoError(t, err)
+
+	require.Len(t, authors, 2)
+	assert.Equal(t, Author{ID: 2, Name: "baz"}, authors[1].Author)
+	assert.Equal(t, "qux", authors[1].Book)
+	assert.Equal(t, []sqlscantest.Query{{SQL: listAuthors, Args: []interface{}{"%"}}}, db.Queries())
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	db := sqlscantest.NewQuerier()
+	defer db.Close() //nolint: errcheck
+	db.On("FROM authors").Return(Author{ID: 42, Name: "foo"})
+
+	var author map[string]interface{}
+	err := sqlcscan.Get(ctx, db, &author, func(r *sqlcscan.Recorder) error {
+		_, err := New(r).GetAuthor(ctx, 42)
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"id": int64(42), "name": "foo"}, author)
+}
+
+func TestSelectPgx(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewQuerier()
+	db.On("FROM authors").ReturnRows(
+		[]string{"id", "name", "books"},
+		[][]interface{}{{int64(1), "foo", []string{"bar", "baz"}}},
+	)
+
+	var authors []*AuthorWithBooks
+	err := sqlcscan.SelectPgx(ctx, db, &authors, func(r *sqlcscan.Recorder) error {
+		_, err := NewPgx(r).ListAuthors(ctx, "foo%")
+		return err
+	})
+	require.NoError(t, err)
+
+	expected := []*AuthorWithBooks{{Author: Author{ID: 1, Name: "foo"}, Books: []string{"bar", "baz"}}}
+	assert.Equal(t, expected, authors)
+}
+
+func TestGetPgx(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewQuerier()
+	db.On("FROM authors").Return(Author{ID: 42, Name: "foo"})
+
+	var author Author
+	err := sqlcscan.GetPgx(ctx, db, &author, func(r *sqlcscan.Recorder) error {
+		_, err := NewPgx(r).GetAuthor(ctx, 42)
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Author{ID: 42, Name: "foo"}, author)
+	assert.Equal(t, []pgxscantest.Query{{SQL: getAuthor, Args: []interface{}{int64(42)}}}, db.Queries())
+}
@@ -0,0 +1,23 @@
+// Package sqlitescan allows scanning data into Go structs and other composite types,
+// when working with SQLite via database/sql library.
+/*
+Essentially, sqlitescan is github.com/georgysavva/scany/v2/sqlscan package
+preconfigured for SQLite drivers: github.com/mattn/go-sqlite3 and modernc.org/sqlite.
+It's encouraged to read sqlscan docs first to get familiar with all concepts and features:
+https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan
+
+SQLite specifics
+
+SQLite has no date and time types, so dates are usually stored as text.
+sqlitescan parses text values into time.Time, *time.Time and sql.NullTime destinations
+in the formats that SQLite date and time functions produce and SQLite drivers write, see TimeLayouts.
+
+SQLite allows one writer at a time, and drivers return SQLITE_BUSY, see sqlscan.ErrBusy,
+if the database stays locked longer than the busy timeout.
+Read queries, ExecReturning and transactions of WithTx are retried on such errors, with BusyRetryPolicy
+unless sqlscan.WithRetryPolicy sets another policy.
+
+The RETURNING clause is supported since SQLite 3.35.0, SupportsReturning checks the version of the database,
+and DetectDialect returns the dialect that reflects it.
+*/
+package sqlitescan
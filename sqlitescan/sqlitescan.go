@@ -0,0 +1,249 @@
+package sqlitescan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// TimeLayouts are layouts of text time values that sqlitescan parses,
+// the same ones that github.com/mattn/go-sqlite3 accepts for DATE, DATETIME and TIMESTAMP columns.
+var TimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// Dialect is the dialect that sqlitescan uses, it's sqlscan.DialectSQLite
+// that also parses text time values, see sqlscan.Dialect.ParseTime and TimeLayouts.
+var Dialect = newDialect()
+
+func newDialect() sqlscan.Dialect {
+	dialect := sqlscan.DialectSQLite
+	dialect.ParseTime = true
+	dialect.TimeLayouts = TimeLayouts
+	return dialect
+}
+
+// BusyRetryPolicy is the policy that sqlitescan retries queries and transactions with
+// if the database is busy, see sqlscan.ErrBusy.
+var BusyRetryPolicy = sqlscan.RetryPolicy{
+	MaxAttempts: 5,
+	Backoff:     sqlscan.ExponentialBackoff(10*time.Millisecond, time.Second),
+	Retryable:   IsBusy,
+}
+
+// IsBusy reports whether the error is caused by the database locked by another connection,
+// SQLITE_BUSY or SQLITE_LOCKED, so the same query can succeed once the lock is released.
+func IsBusy(err error) bool {
+	return errors.Is(err, sqlscan.ErrBusy)
+}
+
+// Select is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Select for details.
+func Select(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Select(ctx, db, dst, query, args...)
+}
+
+// Get is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Get for details.
+func Get(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.Get(ctx, db, dst, query, args...)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.GetNamed for details.
+func GetNamed(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.Each for details.
+func Each[T any](
+	ctx context.Context, db sqlscan.Querier, fn func(row T) error, query string, args ...interface{},
+) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See sqlscan.API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db sqlscan.Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// WithTx is a package-level helper function that uses the DefaultAPI object.
+// See API.WithTx for details.
+func WithTx(ctx context.Context, db sqlscan.TxBeginner, fn func(tx *sqlscan.Tx) error) error {
+	return DefaultAPI.WithTx(ctx, db, fn)
+}
+
+// WithTxOptions is a package-level helper function that uses the DefaultAPI object.
+// See API.WithTxOptions for details.
+func WithTxOptions(
+	ctx context.Context, db sqlscan.TxBeginner, opts *sql.TxOptions, fn func(tx *sqlscan.Tx) error,
+) error {
+	return DefaultAPI.WithTxOptions(ctx, db, opts, fn)
+}
+
+// API is a wrapper around the sqlscan.API type that uses the SQLite dialect and retries busy errors.
+// All sqlscan.API methods are available, see sqlscan.API for details.
+type API struct {
+	*sqlscan.API
+}
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+// The dialect is set to Dialect and read queries are retried with BusyRetryPolicy,
+// options can change both, e.g. with sqlscan.WithDialect and sqlscan.WithRetryPolicy.
+func NewAPI(dbscanAPI *dbscan.API, opts ...sqlscan.APIOption) (*API, error) {
+	opts = append([]sqlscan.APIOption{
+		sqlscan.WithDialect(Dialect),
+		sqlscan.WithRetryPolicy(BusyRetryPolicy),
+	}, opts...)
+	sqlscanAPI, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &API{API: sqlscanAPI}, nil
+}
+
+// ExecReturning is the same as sqlscan.API.ExecReturning, but it reruns the statement if the database is busy,
+// SQLite doesn't apply the statement then. The statement is retried with the policy of the API,
+// BusyRetryPolicy unless NewAPI options set another one.
+func (api *API) ExecReturning(
+	ctx context.Context, db sqlscan.Querier, dst interface{}, query string, args ...interface{},
+) error {
+	return api.retryBusy(ctx, func() error {
+		return api.API.ExecReturning(ctx, db, dst, query, args...)
+	})
+}
+
+// WithTx is the same as sqlscan.API.WithTx, but it also retries the transaction if the database is busy,
+// with the same policy as ExecReturning.
+func (api *API) WithTx(ctx context.Context, db sqlscan.TxBeginner, fn func(tx *sqlscan.Tx) error) error {
+	return api.WithTxOptions(ctx, db, nil, fn)
+}
+
+// WithTxOptions is the same as WithTx, but begins the transaction with the given options.
+func (api *API) WithTxOptions(
+	ctx context.Context, db sqlscan.TxBeginner, opts *sql.TxOptions, fn func(tx *sqlscan.Tx) error,
+) error {
+	return api.retryBusy(ctx, func() error {
+		return api.API.WithTxOptions(ctx, db, opts, fn)
+	})
+}
+
+// retryBusy calls fn until it succeeds, returns an error that isn't retryable, or attempts are exhausted.
+// It uses the retry policy of the API and falls back to BusyRetryPolicy if the API has none.
+// Errors are retryable if the policy reports so, or if they are busy ones when the policy doesn't set Retryable.
+func (api *API) retryBusy(ctx context.Context, fn func() error) error {
+	policy, ok := api.RetryPolicy()
+	if !ok {
+		policy = BusyRetryPolicy
+	}
+	if policy.MaxAttempts <= 1 {
+		return fn()
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsBusy
+	}
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 && policy.Backoff != nil {
+			timer := time.NewTimer(policy.Backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("scany: retry aborted: %w (last error: %v)", ctx.Err(), err)
+			case <-timer.C:
+			}
+		}
+		if err = fn(); err == nil || !retryable(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("scany: query failed after %d attempts: %w", policy.MaxAttempts, err)
+}
+
+// SupportsReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.SupportsReturning for details.
+func SupportsReturning(ctx context.Context, db sqlscan.Querier) (bool, error) {
+	return DefaultAPI.SupportsReturning(ctx, db)
+}
+
+// SupportsReturning reports whether the database supports the RETURNING clause, that is SQLite is 3.35.0 or later.
+func (api *API) SupportsReturning(ctx context.Context, db sqlscan.Querier) (bool, error) {
+	var version string
+	if err := api.Get(ctx, db, &version, `SELECT sqlite_version()`); err != nil {
+		return false, fmt.Errorf("scany: get sqlite version: %w", err)
+	}
+	var major, minor int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &minor); err != nil {
+		return false, fmt.Errorf("scany: parse sqlite version %q: %w", version, err)
+	}
+	return major > 3 || (major == 3 && minor >= 35), nil
+}
+
+// DetectDialect is a package-level helper function that uses the DefaultAPI object.
+// See API.DetectDialect for details.
+func DetectDialect(ctx context.Context, db sqlscan.Querier) (sqlscan.Dialect, error) {
+	return DefaultAPI.DetectDialect(ctx, db)
+}
+
+// DetectDialect returns Dialect with the Returning field set according to the database version,
+// see SupportsReturning:
+//
+//	dialect, err := sqlitescan.DetectDialect(ctx, db)
+//	api, err := sqlitescan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+func (api *API) DetectDialect(ctx context.Context, db sqlscan.Querier) (sqlscan.Dialect, error) {
+	returning, err := api.SupportsReturning(ctx, db)
+	if err != nil {
+		return sqlscan.Dialect{}, err
+	}
+	dialect := Dialect
+	dialect.Returning = returning
+	return dialect, nil
+}
+
+func mustNewAPI(dbscanAPI *dbscan.API) *API {
+	api, err := NewAPI(dbscanAPI)
+	if err != nil {
+		panic(err)
+	}
+	return api
+}
+
+func mustNewDBScanAPI() *dbscan.API {
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	if err != nil {
+		panic(err)
+	}
+	return dbscanAPI
+}
+
+// DefaultAPI is the default instance of API with all configuration settings set to default.
+var DefaultAPI = mustNewAPI(mustNewDBScanAPI())
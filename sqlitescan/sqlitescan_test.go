@@ -0,0 +1,141 @@
+package sqlitescan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/internal/sqlfake"
+	"github.com/georgysavva/scany/v2/sqlitescan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+var ctx = context.Background()
+
+// newBusyConnector returns a connector of the id column rows that fails the first failures queries
+// with a busy error.
+func newBusyConnector(failures int, rows ...[]driver.Value) *sqlfake.Connector {
+	return &sqlfake.Connector{
+		Sets:     []sqlfake.ResultSet{{Columns: sqlfake.Columns("id"), Rows: rows}},
+		Failures: failures,
+		Err:      fmt.Errorf("database is locked: %w", sqlscan.ErrBusy),
+	}
+}
+
+func TestSelect_parsesTextDates(t *testing.T) {
+	t.Parallel()
+	db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+		Columns: sqlfake.Columns("created_at", "deleted_at"),
+		Rows: [][]driver.Value{
+			{"2021-03-04 05:06:07", nil},
+			{"2021-03-04T05:06:07.5+03:00", "2021-03-05"},
+		},
+	}}})
+	deletedAt := time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC)
+
+	var got []struct {
+		CreatedAt time.Time
+		DeletedAt *time.Time
+	}
+	err := sqlitescan.Select(ctx, db, &got, `SELECT created_at, deleted_at FROM events`)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	assert.True(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC).Equal(got[0].CreatedAt))
+	assert.Nil(t, got[0].DeletedAt)
+	assert.True(t, time.Date(2021, 3, 4, 2, 6, 7, 500000000, time.UTC).Equal(got[1].CreatedAt))
+	assert.Equal(t, &deletedAt, got[1].DeletedAt)
+}
+
+func TestGet_busy_retries(t *testing.T) {
+	t.Parallel()
+	fc := newBusyConnector(2, []driver.Value{int64(1)})
+	db := sqlfake.NewDB(t, fc)
+
+	var got int64
+	err := sqlitescan.Get(ctx, db, &got, `SELECT id FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), got)
+	assert.Equal(t, 3, len(fc.Queries()))
+}
+
+func TestWithTx_busy_retries(t *testing.T) {
+	t.Parallel()
+	fc := newBusyConnector(1, []driver.Value{int64(1)})
+	db := sqlfake.NewDB(t, fc)
+	attempts := 0
+
+	var got int64
+	err := sqlitescan.WithTx(ctx, db, func(tx *sqlscan.Tx) error {
+		attempts++
+		return tx.Get(ctx, &got, `SELECT id FROM users`)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), got)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestExecReturning_busyAttemptsExhausted_returnsErr(t *testing.T) {
+	t.Parallel()
+	fc := newBusyConnector(100)
+	db := sqlfake.NewDB(t, fc)
+
+	var got int64
+	err := sqlitescan.ExecReturning(ctx, db, &got, `INSERT INTO users DEFAULT VALUES RETURNING id`)
+
+	assert.ErrorIs(t, err, sqlscan.ErrBusy)
+	assert.Equal(t, sqlitescan.BusyRetryPolicy.MaxAttempts, len(fc.Queries()))
+}
+
+func TestExecReturning_apiRetryPolicy_usesIt(t *testing.T) {
+	t.Parallel()
+	fc := newBusyConnector(100)
+	db := sqlfake.NewDB(t, fc)
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlitescan.NewAPI(dbscanAPI, sqlscan.WithRetryPolicy(sqlscan.RetryPolicy{
+		MaxAttempts: 2,
+		Retryable:   sqlitescan.IsBusy,
+	}))
+	require.NoError(t, err)
+
+	var got int64
+	err = api.ExecReturning(ctx, db, &got, `INSERT INTO users DEFAULT VALUES RETURNING id`)
+
+	assert.ErrorIs(t, err, sqlscan.ErrBusy)
+	assert.Equal(t, 2, len(fc.Queries()))
+}
+
+func TestSupportsReturning(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		version  string
+		expected bool
+	}{
+		{version: "3.34.1", expected: false},
+		{version: "3.35.0", expected: true},
+		{version: "3.45.2", expected: true},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.version, func(t *testing.T) {
+			t.Parallel()
+			db := sqlfake.NewDB(t, &sqlfake.Connector{Sets: []sqlfake.ResultSet{{
+				Columns: sqlfake.Columns("sqlite_version()"),
+				Rows:    [][]driver.Value{{tc.version}},
+			}}})
+
+			got, err := sqlitescan.SupportsReturning(ctx, db)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package sqlscan
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheStore stores results of Get and Select queries, see WithCache.
+// Values are scanned destinations, so the store must keep them in memory as is.
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the value stored by the key, and false if there is no value or it has expired.
+	Get(key string) (interface{}, bool)
+	// Set stores the value by the key for the ttl duration.
+	Set(key string, value interface{}, ttl time.Duration)
+}
+
+// WithCache makes Get, Select, and other high-level functions built on top of them, like GetNamed, SelectPage
+// and Count, cache query results in the store for the ttl duration. It's meant for read-heavy queries that may return
+// slightly outdated data, the cache isn't invalidated when data changes:
+//
+//	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithCache(sqlscan.NewLRUCache(1000), time.Minute))
+//
+// Results are keyed by the query, its arguments and the destination type, regardless of the Querier.
+// The destination is set to the cached result as a whole, so dbscan.WithAppendToSlice doesn't apply.
+// Cached slices are copied, but their elements, e.g. pointers to structs, are shared between callers,
+// so results must not be modified. Errors aren't cached, and query hooks aren't called for cache hits.
+// Queries within transactions bypass the cache. Use CacheTTL to change the ttl of a single query.
+func WithCache(store CacheStore, ttl time.Duration) APIOption {
+	return func(api *API) {
+		api.cache = store
+		api.cacheTTL = ttl
+	}
+}
+
+type cacheTTLKey struct{}
+
+// CacheTTL returns a context that makes the query cache its result for the ttl duration instead of the one
+// passed to WithCache. A non-positive ttl makes the query bypass the cache.
+func CacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	return context.WithValue(ctx, cacheTTLKey{}, ttl)
+}
+
+// withCache runs fn, that scans the query result into the destination it's given,
+// or sets dst to the cached result of the same query.
+func (api *API) withCache(
+	ctx context.Context, db Querier, dst interface{}, query string, args []interface{}, fn func(dst interface{}) error,
+) error {
+	ttl := api.cacheTTL
+	if ctxTTL, ok := ctx.Value(cacheTTLKey{}).(time.Duration); ok {
+		ttl = ctxTTL
+	}
+	dstValue := reflect.ValueOf(dst)
+	if api.cache == nil || ttl <= 0 || isTx(db) || dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fn(dst)
+	}
+	key := cacheKey(query, args, dstValue.Type())
+	if value, ok := api.cache.Get(key); ok && setCachedValue(dstValue.Elem(), value) {
+		return nil
+	}
+	// The result is scanned into a new value, so it doesn't share anything with the caller's destination.
+	result := reflect.New(dstValue.Type().Elem())
+	if err := fn(result.Interface()); err != nil {
+		return err
+	}
+	value := result.Elem().Interface()
+	api.cache.Set(key, value, ttl)
+	setCachedValue(dstValue.Elem(), value)
+	return nil
+}
+
+func isTx(db Querier) bool {
+	_, ok := db.(*sql.Tx)
+	return ok
+}
+
+func cacheKey(query string, args []interface{}, dstType reflect.Type) string {
+	var b strings.Builder
+	b.WriteString(dstType.String())
+	b.WriteString("\x00")
+	b.WriteString(query)
+	for _, arg := range args {
+		// The Go syntax representation distinguishes argument types, e.g. 1 and "1".
+		fmt.Fprintf(&b, "\x00%#v", arg)
+	}
+	return b.String()
+}
+
+// setCachedValue sets dst to the cached value, it returns false if the value is of another type,
+// e.g. a type with the same name from another package.
+func setCachedValue(dst reflect.Value, value interface{}) bool {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return true
+	}
+	if !v.Type().AssignableTo(dst.Type()) {
+		return false
+	}
+	if v.Kind() == reflect.Slice && !v.IsNil() {
+		// Copy the slice, so appending to it doesn't change the cached one.
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		v = c
+	}
+	dst.Set(v)
+	return true
+}
+
+// LRUCache is an in-memory CacheStore that keeps at most size values and evicts the least recently used ones.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+var _ CacheStore = &LRUCache{}
+
+type lruCacheEntry struct {
+	key       string
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewLRUCache creates a new LRUCache instance that keeps at most size values.
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+// Get implements the CacheStore.Get method.
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruCacheEntry)
+	if !time.Now().Before(entry.expiresAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set implements the CacheStore.Set method.
+func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiresAt := time.Now().Add(ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*lruCacheEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&lruCacheEntry{key: key, value: value, expiresAt: expiresAt})
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Remove(c.lru.Back()).(*lruCacheEntry)
+		delete(c.entries, oldest.key)
+	}
+}
+
+// Purge removes all values from the cache, e.g. after data has changed.
+func (c *LRUCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element, c.size)
+	c.lru.Init()
+}
+
+// Len returns the number of values in the cache, including expired ones that haven't been evicted yet.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,125 @@
+package sqlscan_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func getCacheAPI(t *testing.T, queries *int) *sqlscan.API {
+	t.Helper()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI,
+		sqlscan.WithCache(sqlscan.NewLRUCache(10), time.Minute),
+		sqlscan.WithQueryHook(sqlscan.QueryHookFunc(func(ctx context.Context, event *sqlscan.QueryEvent) {
+			*queries++
+		})),
+	)
+	require.NoError(t, err)
+	return api
+}
+
+func TestSelect_withCache_returnsCachedResult(t *testing.T) {
+	t.Parallel()
+	var queries int
+	api := getCacheAPI(t, &queries)
+
+	var first, second []*testModel
+	err := api.Select(ctx, testDB, &first, multipleRowsQuery)
+	require.NoError(t, err)
+	err = api.Select(ctx, testDB, &second, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Len(t, second, 3)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, queries)
+}
+
+func TestGet_withCache_keyedByArgsAndDestinationType(t *testing.T) {
+	t.Parallel()
+	var queries int
+	api := getCacheAPI(t, &queries)
+	query := `SELECT $1::text AS foo`
+
+	var foo1, foo2, foo3 string
+	var model testModel
+	err := api.Get(ctx, testDB, &foo1, query, "foo1")
+	require.NoError(t, err)
+	err = api.Get(ctx, testDB, &foo2, query, "foo2")
+	require.NoError(t, err)
+	err = api.Get(ctx, testDB, &model, query, "foo1")
+	require.NoError(t, err)
+	err = api.Get(ctx, testDB, &foo3, query, "foo1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo1", foo1)
+	assert.Equal(t, "foo2", foo2)
+	assert.Equal(t, testModel{Foo: "foo1"}, model)
+	assert.Equal(t, "foo1", foo3)
+	assert.Equal(t, 3, queries)
+}
+
+func TestGet_withCache_errorNotCached(t *testing.T) {
+	t.Parallel()
+	var queries int
+	api := getCacheAPI(t, &queries)
+
+	for i := 0; i < 2; i++ {
+		var got testModel
+		err := api.Get(ctx, testDB, &got, noRowsQuery)
+		assert.True(t, sqlscan.NotFound(err))
+	}
+
+	assert.Equal(t, 2, queries)
+}
+
+func TestGet_withCache_cacheTTLZeroBypassesCache(t *testing.T) {
+	t.Parallel()
+	var queries int
+	api := getCacheAPI(t, &queries)
+
+	for i := 0; i < 2; i++ {
+		var got testModel
+		err := api.Get(sqlscan.CacheTTL(ctx, 0), testDB, &got, singleRowsQuery)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, queries)
+}
+
+func TestLRUCache_evictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	cache := sqlscan.NewLRUCache(2)
+
+	cache.Set("a", 1, time.Minute)
+	cache.Set("b", 2, time.Minute)
+	_, _ = cache.Get("a")
+	cache.Set("c", 3, time.Minute)
+
+	a, okA := cache.Get("a")
+	_, okB := cache.Get("b")
+	c, okC := cache.Get("c")
+	assert.True(t, okA)
+	assert.Equal(t, 1, a)
+	assert.False(t, okB)
+	assert.True(t, okC)
+	assert.Equal(t, 3, c)
+}
+
+func TestLRUCache_expiredValue_notReturned(t *testing.T) {
+	t.Parallel()
+	cache := sqlscan.NewLRUCache(2)
+
+	cache.Set("a", 1, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,206 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LimitSyntax is the syntax that the database uses to limit the number of rows a query returns.
+type LimitSyntax int
+
+const (
+	// LimitClause is the "LIMIT n" syntax of PostgreSQL, MySQL and SQLite.
+	LimitClause LimitSyntax = iota
+	// FetchNextClause is the "OFFSET 0 ROWS FETCH NEXT n ROWS ONLY" syntax of SQL Server, that requires ORDER BY,
+	// and Oracle 12c and later.
+	FetchNextClause
+)
+
+// Dialect describes SQL syntax differences between databases that sqlscan needs to know about
+// when it rewrites or builds queries: named parameters, slice expansion, InsertAll and SelectPage.
+// Use one of the predefined dialects or describe a custom one, see WithDialect.
+type Dialect struct {
+	// Name is the name of the database, e.g. "postgres".
+	Name string
+	// Placeholder is the style of positional placeholders that the database driver expects.
+	Placeholder PlaceholderStyle
+	// OpenQuote and CloseQuote are the characters that quote identifiers, e.g. `"` or "[" and "]".
+	OpenQuote, CloseQuote string
+	// Returning reports whether the database supports the RETURNING clause, see ExecReturning.
+	Returning bool
+	// Limit is the syntax that limits the number of rows.
+	Limit LimitSyntax
+	// MaxParams is the maximum number of arguments per statement, 0 if it's unlimited.
+	MaxParams int
+	// MaxRows is the maximum number of rows in a VALUES list, 0 if it's unlimited.
+	MaxRows int
+	// ParseTime makes sqlscan parse time values that the driver returns as strings or bytes
+	// into time.Time, *time.Time and sql.NullTime destinations, e.g. DATETIME values of MySQL drivers
+	// without the parseTime=true option, that database/sql can't scan otherwise.
+	ParseTime bool
+	// TimeLocation is the location of parsed time values, the default is UTC like in MySQL drivers.
+	TimeLocation *time.Location
+	// TimeLayouts are layouts of time values that ParseTime tries one by one, see time.Parse.
+	// The default ones are of MySQL DATETIME and DATE values: "2006-01-02 15:04:05.999999999" and "2006-01-02".
+	TimeLayouts []string
+	// BytesAsString makes sqlscan convert []byte values into strings for interface{} destinations,
+	// e.g. values of map[string]interface{}, since MySQL drivers return text columns as []byte.
+	BytesAsString bool
+	// NamedPrefix is the character that named parameters start with, ':' if it's zero,
+	// e.g. '@' for `WHERE id = @id` in SQL Server, see BindNamed.
+	NamedPrefix byte
+	// ValueConverters convert values that the driver returns in a database-specific form,
+	// e.g. JSON values of Vertica ARRAY columns, see ValueConverter and JSONConverter.
+	// Database adapters, e.g. mssqlscan and oraclescan, add converters for quirks of their drivers to their dialects.
+	ValueConverters []ValueConverter
+}
+
+// ValueConverter returns a scanner that converts values of the column on their way into dst,
+// or nil if dst gets values of the column as is.
+// dst is the destination that dbscan scans the column into, e.g. a pointer to a struct field.
+// If the dialect has multiple converters, the first non-nil scanner is used, see Dialect.ValueConverters.
+type ValueConverter func(columnType *sql.ColumnType, dst interface{}) sql.Scanner
+
+// Predefined dialects of popular databases.
+var (
+	// DialectPostgres is the dialect of PostgreSQL and CockroachDB. It's the default one.
+	DialectPostgres = Dialect{
+		Name:        "postgres",
+		Placeholder: PlaceholderDollar,
+		OpenQuote:   `"`,
+		CloseQuote:  `"`,
+		Returning:   true,
+		Limit:       LimitClause,
+		MaxParams:   65535,
+	}
+	// DialectMySQL is the dialect of MySQL and MariaDB.
+	DialectMySQL = Dialect{
+		Name:        "mysql",
+		Placeholder: PlaceholderQuestion,
+		OpenQuote:   "`",
+		CloseQuote:  "`",
+		Limit:       LimitClause,
+		MaxParams:   65535,
+		ParseTime:   true,
+	}
+	// DialectSQLite is the dialect of SQLite 3.35.0 and later, that support the RETURNING clause.
+	DialectSQLite = Dialect{
+		Name:        "sqlite",
+		Placeholder: PlaceholderQuestion,
+		OpenQuote:   `"`,
+		CloseQuote:  `"`,
+		Returning:   true,
+		Limit:       LimitClause,
+		// SQLite allows 32766 parameters by default since 3.32.0.
+		MaxParams: 32766,
+	}
+	// DialectMSSQL is the dialect of SQL Server.
+	DialectMSSQL = Dialect{
+		Name:        "mssql",
+		Placeholder: PlaceholderAtP,
+		OpenQuote:   "[",
+		CloseQuote:  "]",
+		Limit:       FetchNextClause,
+		// SQL Server allows 2100 parameters per request, two of which sp_executesql takes itself,
+		// and 1000 rows in a table value constructor.
+		MaxParams: 2098,
+		MaxRows:   1000,
+	}
+	// DialectOracle is the dialect of Oracle Database 12c and later.
+	// oraclescan.Dialect adds conversions of NUMBER and LOB values that godror returns to it.
+	DialectOracle = Dialect{
+		Name:        "oracle",
+		Placeholder: PlaceholderColon,
+		OpenQuote:   `"`,
+		CloseQuote:  `"`,
+		Limit:       FetchNextClause,
+		MaxParams:   65535,
+		// Oracle supports VALUES lists of multiple rows only since 23ai, so InsertAll inserts rows one by one.
+		MaxRows: 1,
+	}
+	// DialectVertica is the dialect of Vertica, that has no RETURNING clause.
+	// Vertica returns ARRAY, SET and ROW values as JSON, that sqlscan decodes into slice, map and struct fields.
+	DialectVertica = Dialect{
+		Name:            "vertica",
+		Placeholder:     PlaceholderQuestion,
+		OpenQuote:       `"`,
+		CloseQuote:      `"`,
+		Limit:           LimitClause,
+		MaxParams:       65535,
+		ValueConverters: []ValueConverter{JSONConverter("ARRAY", "SET", "ROW")},
+	}
+	// DialectRedshift is the dialect of Amazon Redshift, that speaks the PostgreSQL protocol
+	// but has no RETURNING clause. SUPER values are decoded as JSON into slice, map and struct fields.
+	DialectRedshift = Dialect{
+		Name:            "redshift",
+		Placeholder:     PlaceholderDollar,
+		OpenQuote:       `"`,
+		CloseQuote:      `"`,
+		Limit:           LimitClause,
+		MaxParams:       65535,
+		ValueConverters: []ValueConverter{JSONConverter("SUPER")},
+	}
+)
+
+// predefinedDialects are the dialects that DialectByName finds.
+var predefinedDialects = []*Dialect{
+	&DialectPostgres, &DialectMySQL, &DialectSQLite, &DialectMSSQL, &DialectOracle, &DialectVertica, &DialectRedshift,
+}
+
+// DialectByName returns the predefined dialect with the name, e.g. "redshift", so the dialect can come
+// from configuration. Names are matched case-insensitively, ok is false if there is no such dialect.
+func DialectByName(name string) (dialect Dialect, ok bool) {
+	for _, d := range predefinedDialects {
+		if strings.EqualFold(d.Name, name) {
+			return *d, true
+		}
+	}
+	return Dialect{}, false
+}
+
+// WithDialect sets the dialect of the database that sqlscan uses when it rewrites or builds queries.
+// The default dialect is DialectPostgres.
+func WithDialect(dialect Dialect) APIOption {
+	return func(api *API) {
+		api.dialect = dialect
+	}
+}
+
+// Quote quotes the identifier, e.g. a column name, escaping the closing quote inside it.
+// The identifier is quoted as a whole, so it must not be a qualified name like "users.id".
+func (d Dialect) Quote(ident string) string {
+	return d.OpenQuote + strings.ReplaceAll(ident, d.CloseQuote, d.CloseQuote+d.CloseQuote) + d.CloseQuote
+}
+
+// quoteIfNeeded quotes the identifier only if it isn't a plain one, e.g. the "post.title" column of a nested struct.
+// Plain identifiers stay unquoted, so the database folds their case as usual.
+func (d Dialect) quoteIfNeeded(ident string) string {
+	if ident == "" || d.OpenQuote == "" {
+		return ident
+	}
+	for i, r := range ident {
+		isPlain := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9')
+		if !isPlain {
+			return d.Quote(ident)
+		}
+	}
+	return ident
+}
+
+// namedPrefix returns the character that named parameters start with.
+func (d Dialect) namedPrefix() byte {
+	if d.NamedPrefix == 0 {
+		return ':'
+	}
+	return d.NamedPrefix
+}
+
+// limitClause returns the clause that limits the query to n rows, with a leading space.
+func (d Dialect) limitClause(n int) string {
+	if d.Limit == FetchNextClause {
+		return " OFFSET 0 ROWS FETCH NEXT " + strconv.Itoa(n) + " ROWS ONLY"
+	}
+	return " LIMIT " + strconv.Itoa(n)
+}
@@ -0,0 +1,131 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type recordingExecer struct {
+	queries []string
+}
+
+func (re *recordingExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	re.queries = append(re.queries, query)
+	return nil, nil
+}
+
+func TestDialect_Quote(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		dialect  sqlscan.Dialect
+		expected string
+	}{
+		{name: "postgres", dialect: sqlscan.DialectPostgres, expected: `"post.ti""tle"`},
+		{name: "mysql", dialect: sqlscan.DialectMySQL, expected: "`post.ti\"tle`"},
+		{name: "mssql", dialect: sqlscan.DialectMSSQL, expected: `[post.ti"tle]`},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.dialect.Quote(`post.ti"tle`))
+		})
+	}
+}
+
+func TestInsertAll_withDialect(t *testing.T) {
+	t.Parallel()
+	type Post struct {
+		Title string
+	}
+	type user struct {
+		ID   int
+		Post Post
+	}
+	cases := []struct {
+		name            string
+		dialect         sqlscan.Dialect
+		rows            int
+		expectedQueries int
+		// expectedQuery is the last statement.
+		expectedQuery string
+	}{
+		{
+			name:            "postgres",
+			dialect:         sqlscan.DialectPostgres,
+			rows:            2,
+			expectedQueries: 1,
+			expectedQuery:   `INSERT INTO users (id, "post.title") VALUES ($1, $2), ($3, $4)`,
+		},
+		{
+			name:            "mysql",
+			dialect:         sqlscan.DialectMySQL,
+			rows:            2,
+			expectedQueries: 1,
+			expectedQuery:   "INSERT INTO users (id, `post.title`) VALUES (?, ?), (?, ?)",
+		},
+		{
+			name:            "mssql splits by max rows",
+			dialect:         sqlscan.DialectMSSQL,
+			rows:            1001,
+			expectedQueries: 2,
+			expectedQuery:   `INSERT INTO users (id, [post.title]) VALUES (@p1, @p2)`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dbscanAPI, err := sqlscan.NewDBScanAPI()
+			require.NoError(t, err)
+			api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(tc.dialect))
+			require.NoError(t, err)
+			db := &recordingExecer{}
+
+			err = api.InsertAll(ctx, db, "users", make([]user, tc.rows))
+			require.NoError(t, err)
+
+			require.Len(t, db.queries, tc.expectedQueries)
+			assert.Equal(t, tc.expectedQuery, db.queries[len(db.queries)-1])
+		})
+	}
+}
+
+func TestDialectByName(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		expected sqlscan.Dialect
+	}{
+		{name: "postgres", expected: sqlscan.DialectPostgres},
+		{name: "Redshift", expected: sqlscan.DialectRedshift},
+		{name: "VERTICA", expected: sqlscan.DialectVertica},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := sqlscan.DialectByName(tc.name)
+			require.True(t, ok)
+			// Value converters are functions, they can't be compared.
+			assert.Len(t, got.ValueConverters, len(tc.expected.ValueConverters))
+			got.ValueConverters, tc.expected.ValueConverters = nil, nil
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestDialectByName_unknownName(t *testing.T) {
+	t.Parallel()
+
+	_, ok := sqlscan.DialectByName("foo")
+
+	assert.False(t, ok)
+}
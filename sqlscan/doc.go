@@ -14,5 +14,58 @@ sqlscan can query rows and work with *sql.DB, *sql.Conn or *sql.Tx directly.
 To support this it has two high-level functions Select and Get,
 they accept anything that implements Querier interface and query rows from it.
 This means that they can be used with *sql.DB, *sql.Conn or *sql.Tx.
+Each is the streaming counterpart of Select, it calls a function for every scanned row.
+If scanning stops early, the remaining rows can be drained or the query canceled, see WithAbortDrainLimit.
+InsertAll does the inverse of Select and inserts a slice of structs with multi-row INSERT statements.
+To execute a statement and scan the rows it returns, e.g. `INSERT ... RETURNING id`, use ExecReturning.
+WithTx runs a function in a transaction, commits or rolls it back,
+and retries it on serialization failures. The function receives Tx that exposes Select, Get and ExecReturning.
+To retry queries that fail with transient errors, like broken connections or deadlocks, see WithRetryPolicy.
+Constraint violations and busy database errors match ErrConflict and ErrBusy via errors.Is,
+for SQLite drivers as well as for drivers that report SQLSTATE codes, so application code isn't driver-specific.
+To log queries or collect metrics, WithQueryHook registers hooks that receive the query, its arguments,
+duration, the number of rows and the error of every query.
+The otelsqlscan package, a separate module, uses them to trace queries with OpenTelemetry.
+For queries that return multiple result sets, e.g. stored procedures, use SelectSets.
+SelectPage selects rows page by page with keyset pagination on fields tagged with the "cursor" option,
+and returns an opaque cursor of the next page.
+Prepared statements have their own SelectStmt and GetStmt functions that accept StmtQuerier, e.g. *sql.Stmt.
+Alternatively, WithStmtCache makes high-level functions prepare queries and reuse the statements by query text.
+Router sends read queries to read replicas and statements that change data to the primary database.
+For read-heavy queries, WithCache caches results of Get and Select for a TTL, NewLRUCache is an in-memory store.
+To unit test code that queries with sqlscan without a database, the sqlscantest package has a mock Querier
+that records queries and returns canned rows built from structs.
+With go-sqlmock, the sqlmockscan package, a separate module, builds expected rows from structs.
+
+Named parameters
+
+SelectNamed and GetNamed accept queries with named parameters, e.g. `WHERE id = :id`,
+and bind them from a struct or a map:
+
+	var users []*User
+	err := sqlscan.SelectNamed(ctx, db, &users, `SELECT * FROM users WHERE email = :email`, filter)
+
+Named parameters are rewritten into positional placeholders that the driver expects,
+"$1" by default, use WithPlaceholderStyle to change it. BindNamed does the rewrite alone.
+WithDialect sets the placeholder style along with other syntax differences of the database,
+like quoting and the LIMIT clause, that named parameters, IN expansion, InsertAll and SelectPage follow.
+With Dialect.ParseTime, enabled in DialectMySQL, sqlscan parses time values that the driver returns as strings,
+so MySQL drivers without parseTime=true can scan DATETIME columns into time.Time fields.
+Dialect.BytesAsString makes []byte values strings in interface{} destinations, e.g. map[string]interface{}.
+Dialect.TimeLayouts sets the formats of parsed time values, e.g. of text dates in SQLite.
+The mysqlscan package is sqlscan preconfigured for MySQL, with InsertAndGet in place of ExecReturning.
+The sqlitescan package is sqlscan preconfigured for SQLite, it retries queries if the database is busy.
+Dialect.NamedPrefix changes the prefix of named parameters, e.g. to "@" in the mssqlscan package,
+that is sqlscan preconfigured for SQL Server and converts UNIQUEIDENTIFIER values.
+The oraclescan package is sqlscan preconfigured for Oracle, it converts NUMBER values and reads LOB values.
+Both do it with Dialect.ValueConverters, that convert values the driver returns in a database-specific form.
+JSONConverter makes sqlscan decode JSON values into struct, map and slice fields,
+e.g. VARIANT values in the snowscan package, a separate module for Snowflake.
+DialectVertica and DialectRedshift have no RETURNING clause and decode their ARRAY, SET, ROW and SUPER values.
+DialectByName finds a predefined dialect by its name, e.g. one from configuration.
+The odbcscan package is sqlscan preconfigured for ODBC drivers of legacy databases, e.g. DB2 and Informix.
+
+To pass a slice to an IN clause, expand it into a list of placeholders with In,
+or enable WithInExpansion, so Select, Get and other high-level functions do it for every query.
 */
 package sqlscan
@@ -0,0 +1,93 @@
+package sqlscan
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// WithAbortDrainLimit sets how many remaining rows sqlscan reads when scanning stops before all rows are read,
+// e.g. because of a scanning error, a callback error in Each, or the limit of dbscan.WithMaxRows.
+// Drivers like MySQL can reuse the connection only after all rows of the result are read,
+// and database/sql reads them on close, which can take long or block on a large result.
+// So if rows remain after the limit is reached, or the context is done, sqlscan cancels the query instead,
+// the driver aborts the result and the connection is discarded rather than leaked or blocked.
+// 0 makes sqlscan cancel the query right away.
+// By default sqlscan doesn't cancel queries and leaves the remaining rows to database/sql,
+// so queries don't pay for the cancelable context unless the option is set.
+// If closing the rows fails, the error is returned along with the error that stopped scanning.
+func WithAbortDrainLimit(limit int) APIOption {
+	return func(api *API) {
+		api.abortDrain = true
+		api.abortDrainLimit = limit
+	}
+}
+
+// queryRows wraps rows of a query that sqlscan runs, it counts rows that dbscan reads,
+// tracks when scanning has started and aborts rows that are closed before they are read to the end.
+type queryRows struct {
+	*RowsAdapter
+	count      int
+	scanStart  time.Time
+	cancel     context.CancelFunc
+	drainLimit int
+	exhausted  bool
+	closed     bool
+	closeErr   error
+}
+
+func (r *queryRows) setRows(rows *RowsAdapter) {
+	r.RowsAdapter = rows
+	r.scanStart = time.Now()
+}
+
+func (r *queryRows) Next() bool {
+	if !r.RowsAdapter.Next() {
+		r.exhausted = true
+		return false
+	}
+	r.count++
+	return true
+}
+
+func (r *queryRows) NextResultSet() bool {
+	if !r.RowsAdapter.NextResultSet() {
+		return false
+	}
+	r.exhausted = false
+	return true
+}
+
+// Close drains or cancels rows that aren't read to the end, see WithAbortDrainLimit, and closes them.
+// cancel is nil if the option isn't set, then rows are just closed.
+// It can be called multiple times, e.g. by dbscan and sqlscan, and returns the same error.
+func (r *queryRows) Close() error {
+	if r.closed {
+		return r.closeErr
+	}
+	r.closed = true
+	if r.cancel != nil && !r.exhausted && !r.drain() {
+		r.cancel()
+	}
+	r.closeErr = r.RowsAdapter.Close()
+	return r.closeErr
+}
+
+// drain reads at most drainLimit remaining rows and reports whether there are no more rows.
+func (r *queryRows) drain() bool {
+	for i := 0; i < r.drainLimit; i++ {
+		if !r.RowsAdapter.Next() {
+			// Next also returns false if the context is done, then rows report its error and are closed.
+			return r.RowsAdapter.Err() == nil
+		}
+	}
+	return false
+}
+
+// withCloseErr adds the error of closing rows to err, which dbscan doesn't report if scanning failed.
+func (r *queryRows) withCloseErr(err error) error {
+	if err == nil || r.closeErr == nil {
+		return err
+	}
+	return fmt.Errorf("%w (close rows error: %v)", err, r.closeErr)
+}
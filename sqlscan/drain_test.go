@@ -0,0 +1,44 @@
+package sqlscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestEach_withAbortDrainLimit_connectionIsReusable(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name  string
+		limit int
+	}{
+		{name: "drain remaining rows", limit: 1000},
+		{name: "cancel query", limit: 0},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dbscanAPI, err := sqlscan.NewDBScanAPI()
+			require.NoError(t, err)
+			api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithAbortDrainLimit(tc.limit))
+			require.NoError(t, err)
+			expectedErr := errors.New("stop")
+
+			var n int
+			err = api.Each(ctx, testDB, &n, func() error {
+				return expectedErr
+			}, `SELECT generate_series(1, 100000)`)
+			assert.Same(t, expectedErr, err)
+
+			var got int
+			err = api.Get(ctx, testDB, &got, `SELECT 1`)
+			require.NoError(t, err)
+			assert.Equal(t, 1, got)
+		})
+	}
+}
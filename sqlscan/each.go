@@ -0,0 +1,68 @@
+package sqlscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Each is a package-level helper function that uses the DefaultAPI object.
+// See API.Each for details.
+func Each[T any](ctx context.Context, db Querier, fn func(row T) error, query string, args ...interface{}) error {
+	var row T
+	return DefaultAPI.Each(ctx, db, &row, func() error {
+		return fn(row)
+	}, query, args...)
+}
+
+// Each queries rows from Querier and calls fn for every row, it's the streaming counterpart of Select
+// for result sets that shouldn't be loaded into memory at once:
+//
+//	err := sqlscan.Each(ctx, db, func(user User) error {
+//		return enc.Encode(user)
+//	}, `SELECT * FROM users`)
+//
+// dst must be a non-nil pointer, it's reset to the zero value and the row is scanned into it before fn is called,
+// the package-level Each passes the scanned value to the typed callback instead.
+// If fn returns an error, iteration stops and Each returns it.
+// Rows are closed in any case, and the error of iteration, see sql.Rows.Err, is returned.
+// If iteration stops early, the rest of the rows are drained or the query is canceled, see WithAbortDrainLimit.
+// Unlike Select, the query isn't retried, since fn may have already been called for some rows.
+func (api *API) Each(
+	ctx context.Context, db Querier, dst interface{}, fn func() error, query string, args ...interface{},
+) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() {
+		return fmt.Errorf("scany: destination must be a non-nil pointer, got: %T", dst)
+	}
+	query, args, err := api.prepareQuery(query, args)
+	if err != nil {
+		return err
+	}
+	return api.runQuery(ctx, query, args, func(ctx context.Context, counted *queryRows) error {
+		rows, err := api.queryContext(ctx, db, query, args)
+		if err != nil {
+			return fmt.Errorf("scany: query rows: %w", err)
+		}
+		counted.setRows(api.newRowsAdapter(rows))
+		defer counted.Close() //nolint: errcheck
+		rs := api.dbscanAPI.NewRowScanner(counted)
+		zero := reflect.Zero(dstValue.Elem().Type())
+		for counted.Next() {
+			dstValue.Elem().Set(zero)
+			if err := rs.ScanContext(ctx, dst); err != nil {
+				return fmt.Errorf("scanning row: %w", err)
+			}
+			if err := fn(); err != nil {
+				return err
+			}
+		}
+		if err := counted.Err(); err != nil {
+			return fmt.Errorf("scany: rows final error: %w", err)
+		}
+		if err := counted.Close(); err != nil {
+			return fmt.Errorf("scany: close rows after processing: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package sqlscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+	expected := []testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []testModel
+	err := sqlscan.Each(ctx, testDB, func(row testModel) error {
+		got = append(got, row)
+		return nil
+	}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestEach_fnErr_stopsAndReturnsErr(t *testing.T) {
+	t.Parallel()
+	expectedErr := errors.New("stop")
+
+	var calls int
+	err := sqlscan.Each(ctx, testDB, func(row *testModel) error {
+		calls++
+		return expectedErr
+	}, multipleRowsQuery)
+
+	assert.Same(t, expectedErr, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAPIEach_resetsDestination(t *testing.T) {
+	t.Parallel()
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', NULL)) AS t (foo, bar)`
+	expected := []map[string]interface{}{
+		{"foo": "foo val", "bar": "bar val"},
+		{"foo": "foo val 2", "bar": nil},
+	}
+
+	var got []map[string]interface{}
+	var row map[string]interface{}
+	err := testAPI.Each(ctx, testDB, &row, func() error {
+		got = append(got, row)
+		return nil
+	}, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestAPIEach_nonPointer_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	err := testAPI.Each(ctx, testDB, testModel{}, func() error { return nil }, multipleRowsQuery)
+
+	assert.EqualError(t, err, "scany: destination must be a non-nil pointer, got: sqlscan_test.testModel")
+}
@@ -0,0 +1,111 @@
+package sqlscan
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors that sqlscan wraps database errors into, so application code can handle them
+// without depending on the driver. They are matched via errors.Is, the original driver error
+// remains in the chain and the error message stays the same.
+var (
+	// ErrConflict is returned if the statement violates a constraint, e.g. a unique or a foreign key one.
+	ErrConflict = errors.New("scany: constraint violation")
+	// ErrBusy is returned if the database is locked by another connection, e.g. SQLITE_BUSY.
+	// It's a transient error, see IsTransientError.
+	ErrBusy = errors.New("scany: database is busy")
+)
+
+// SQLite result codes, see https://www.sqlite.org/rescode.html.
+// Extended result codes contain the primary result code in the least significant 8 bits.
+const (
+	sqliteBusy       = 5
+	sqliteLocked     = 6
+	sqliteConstraint = 19
+)
+
+// classifyError wraps err into the sentinel error that the database error corresponds to.
+// It recognizes errors of the mattn/go-sqlite3 and modernc.org/sqlite drivers,
+// and errors that implement the SQLState() string method, e.g. *pgconn.PgError.
+func classifyError(err error) error {
+	if err == nil || errors.Is(err, ErrConflict) || errors.Is(err, ErrBusy) {
+		return err
+	}
+	if sentinel := errorSentinel(err); sentinel != nil {
+		return &classifiedError{err: err, sentinel: sentinel}
+	}
+	return err
+}
+
+func errorSentinel(err error) error {
+	if code, ok := sqliteErrorCode(err); ok {
+		switch code & 0xff {
+		case sqliteConstraint:
+			return ErrConflict
+		case sqliteBusy, sqliteLocked:
+			return ErrBusy
+		}
+		return nil
+	}
+	var sqlStateErr interface{ SQLState() string }
+	if !errors.As(err, &sqlStateErr) {
+		return nil
+	}
+	switch sqlState := sqlStateErr.SQLState(); {
+	case strings.HasPrefix(sqlState, "23"):
+		// Integrity constraint violation.
+		return ErrConflict
+	case sqlState == "55P03":
+		// Lock not available.
+		return ErrBusy
+	default:
+		return nil
+	}
+}
+
+// sqliteErrorCode returns the extended result code of a SQLite driver error in the chain.
+// Driver types are recognized by their package, so sqlscan doesn't depend on the drivers.
+func sqliteErrorCode(err error) (int, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		errType := reflect.TypeOf(err)
+		if errType.Kind() == reflect.Ptr {
+			errType = errType.Elem()
+		}
+		switch errType.PkgPath() {
+		case "modernc.org/sqlite":
+			// *sqlite.Error has the Code() int method that returns the extended result code.
+			if coder, ok := err.(interface{ Code() int }); ok {
+				return coder.Code(), true
+			}
+		case "github.com/mattn/go-sqlite3":
+			// sqlite3.Error has the ExtendedCode field of an integer type.
+			errValue := reflect.Indirect(reflect.ValueOf(err))
+			if errValue.Kind() != reflect.Struct {
+				continue
+			}
+			if code := errValue.FieldByName("ExtendedCode"); code.IsValid() && code.CanInt() {
+				return int(code.Int()), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// classifiedError wraps a database error so it matches the sentinel error via errors.Is.
+type classifiedError struct {
+	err      error
+	sentinel error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.err
+}
+
+func (e *classifiedError) Is(target error) bool {
+	return target == e.sentinel
+}
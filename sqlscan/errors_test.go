@@ -0,0 +1,62 @@
+package sqlscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestGet_dbErr_isClassified(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{
+			name:        "unique violation",
+			err:         &pgconn.PgError{Severity: "ERROR", Message: "duplicate key", Code: "23505"},
+			expectedErr: sqlscan.ErrConflict,
+		},
+		{
+			name:        "lock not available",
+			err:         &pgconn.PgError{Severity: "ERROR", Message: "could not obtain lock", Code: "55P03"},
+			expectedErr: sqlscan.ErrBusy,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db := &flakyQuerier{failures: 1, err: tc.err}
+
+			var got testModel
+			err := testAPI.Get(ctx, db, &got, singleRowsQuery)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.ErrorIs(t, err, tc.err)
+			assert.EqualError(t, err, "scany: query one result row: "+tc.err.Error())
+		})
+	}
+}
+
+func TestExecReturning_uniqueViolation_returnsConflictErr(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE conflicts (id INT PRIMARY KEY)`)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, `INSERT INTO conflicts (id) VALUES (1)`)
+	require.NoError(t, err)
+
+	var id int
+	err = sqlscan.ExecReturning(ctx, tx, &id, `INSERT INTO conflicts (id) VALUES (1) RETURNING id`)
+
+	assert.True(t, errors.Is(err, sqlscan.ErrConflict))
+}
@@ -0,0 +1,100 @@
+package sqlscan
+
+import (
+	"context"
+	"time"
+)
+
+// QueryEvent describes a query that sqlscan has run, it's passed to QueryHook.
+type QueryEvent struct {
+	// Query is the query text as it was sent to the database, after named parameters and slices are expanded.
+	// It's empty for prepared statements of SelectStmt and GetStmt.
+	Query string
+	// Args are the query arguments as they were sent to the database.
+	Args []interface{}
+	// Duration is the time spent on running the query and scanning its rows.
+	Duration time.Duration
+	// ScanDuration is the part of Duration spent on reading and scanning rows after the query has returned.
+	ScanDuration time.Duration
+	// Rows is the number of rows that were read, across all result sets.
+	Rows int
+	// Err is the error the query or scanning failed with, nil if it succeeded.
+	Err error
+}
+
+// QueryHook is called by sqlscan after every query it runs, see WithQueryHook.
+type QueryHook interface {
+	AfterQuery(ctx context.Context, event *QueryEvent)
+}
+
+// BeforeQueryHook can be implemented by a QueryHook that needs to run code before the query, e.g. to start a span.
+// The event has only Query and Args set. The returned context is used to run the query
+// and is passed to AfterQuery of the same hook, so the hook can keep its state in it.
+type BeforeQueryHook interface {
+	BeforeQuery(ctx context.Context, event *QueryEvent) context.Context
+}
+
+// QueryHookFunc is an adapter to allow the use of ordinary functions as QueryHook.
+type QueryHookFunc func(ctx context.Context, event *QueryEvent)
+
+// AfterQuery implements the QueryHook.AfterQuery method.
+func (f QueryHookFunc) AfterQuery(ctx context.Context, event *QueryEvent) {
+	f(ctx, event)
+}
+
+// WithQueryHook adds hooks that are called after every query made by Select, Get, SelectSets
+// and other high-level functions built on top of them, so applications can log queries or collect metrics
+// without wrapping the *sql.DB:
+//
+//	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithQueryHook(sqlscan.QueryHookFunc(
+//		func(ctx context.Context, event *sqlscan.QueryEvent) {
+//			log.Printf("query=%q rows=%d duration=%s err=%v", event.Query, event.Rows, event.Duration, event.Err)
+//		},
+//	)))
+//
+// Hooks are called in the given order, once per attempt if the query is retried, see WithRetryPolicy.
+// The option can be used multiple times, hooks from the next calls are called after the previous ones.
+func WithQueryHook(hooks ...QueryHook) APIOption {
+	return func(api *API) {
+		api.queryHooks = append(api.queryHooks, hooks...)
+	}
+}
+
+// runQuery calls fn that runs the query and scans its rows, and reports it to query hooks.
+// fn must run the query with the context it receives and call setRows with the rows it has got.
+func (api *API) runQuery(
+	ctx context.Context, query string, args []interface{}, fn func(ctx context.Context, rows *queryRows) error,
+) error {
+	event := &QueryEvent{Query: query, Args: args}
+	hookCtxs := make([]context.Context, len(api.queryHooks))
+	for i, hook := range api.queryHooks {
+		hookCtxs[i] = ctx
+		if beforeHook, ok := hook.(BeforeQueryHook); ok {
+			hookCtxs[i] = beforeHook.BeforeQuery(ctx, event)
+			ctx = hookCtxs[i]
+		}
+	}
+	rows := &queryRows{drainLimit: api.abortDrainLimit}
+	if api.abortDrain {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		rows.cancel = cancel
+	}
+	start := time.Now()
+	err := classifyError(rows.withCloseErr(fn(ctx, rows)))
+	if len(api.queryHooks) == 0 {
+		return err
+	}
+	end := time.Now()
+	event.Duration = end.Sub(start)
+	if !rows.scanStart.IsZero() {
+		event.ScanDuration = end.Sub(rows.scanStart)
+	}
+	event.Rows = rows.count
+	event.Err = err
+	for i, hook := range api.queryHooks {
+		hook.AfterQuery(hookCtxs[i], event)
+	}
+	return err
+}
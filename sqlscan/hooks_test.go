@@ -0,0 +1,76 @@
+package sqlscan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func getHookAPI(t *testing.T, events *[]sqlscan.QueryEvent) *sqlscan.API {
+	t.Helper()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithQueryHook(sqlscan.QueryHookFunc(
+		func(ctx context.Context, event *sqlscan.QueryEvent) {
+			*events = append(*events, *event)
+		},
+	)))
+	require.NoError(t, err)
+	return api
+}
+
+func TestSelect_withQueryHook_reportsQuery(t *testing.T) {
+	t.Parallel()
+	var events []sqlscan.QueryEvent
+	api := getHookAPI(t, &events)
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2')) AS t (foo, bar) WHERE foo <> $1`
+
+	var got []*testModel
+	err := api.Select(ctx, testDB, &got, query, "baz")
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, query, events[0].Query)
+	assert.Equal(t, []interface{}{"baz"}, events[0].Args)
+	assert.Equal(t, 2, events[0].Rows)
+	assert.Positive(t, events[0].Duration)
+	assert.NoError(t, events[0].Err)
+}
+
+func TestGet_withQueryHook_reportsErr(t *testing.T) {
+	t.Parallel()
+	var events []sqlscan.QueryEvent
+	api := getHookAPI(t, &events)
+
+	var got testModel
+	err := api.Get(ctx, testDB, &got, noRowsQuery)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, 0, events[0].Rows)
+	assert.Equal(t, err, events[0].Err)
+	assert.True(t, sqlscan.NotFound(events[0].Err))
+}
+
+func TestSelectStmt_withQueryHook_reportsStatement(t *testing.T) {
+	t.Parallel()
+	var events []sqlscan.QueryEvent
+	api := getHookAPI(t, &events)
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val')) AS t (foo, bar) WHERE foo <> $1`
+	stmt, err := testDB.PrepareContext(ctx, query)
+	require.NoError(t, err)
+	defer stmt.Close()
+
+	var got []*testModel
+	err = api.SelectStmt(ctx, stmt, &got, "baz")
+	require.NoError(t, err)
+
+	require.Len(t, events, 1)
+	assert.Empty(t, events[0].Query)
+	assert.Equal(t, []interface{}{"baz"}, events[0].Args)
+	assert.Equal(t, 1, events[0].Rows)
+	assert.NoError(t, events[0].Err)
+}
@@ -0,0 +1,166 @@
+package sqlscan
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/internal/namedparam"
+)
+
+// WithInExpansion makes Select, Get and other high-level functions expand slice arguments
+// into lists of placeholders, see In for details.
+// It's disabled by default, because some drivers, e.g. pgx, accept slices as array arguments.
+func WithInExpansion() APIOption {
+	return func(api *API) {
+		api.inExpansion = true
+	}
+}
+
+// In is a package-level helper function that uses the DefaultAPI object.
+// See API.In for details.
+func In(query string, args ...interface{}) (string, []interface{}, error) {
+	return DefaultAPI.In(query, args...)
+}
+
+// In expands slice arguments into lists of placeholders of the configured style, see WithPlaceholderStyle,
+// so a slice can be passed to an IN clause:
+//
+//	query, args, err := sqlscan.In(`SELECT * FROM users WHERE id IN ($1) AND active = $2`, []int{1, 2, 3}, true)
+//	// SELECT * FROM users WHERE id IN ($1, $2, $3) AND active = $4
+//
+// Placeholders of $1 and @p1 styles are renumbered, and the same placeholder used twice is expanded twice.
+// Byte slices and types that implement driver.Valuer aren't expanded. An empty slice is an error,
+// since `IN ()` isn't valid SQL.
+func (api *API) In(query string, args ...interface{}) (string, []interface{}, error) {
+	expanded := make([][]interface{}, len(args))
+	var hasSlices bool
+	for i, arg := range args {
+		values, ok := sliceArgValues(arg)
+		if !ok {
+			expanded[i] = []interface{}{arg}
+			continue
+		}
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("scany: argument %d is an empty slice, it can't be expanded", i+1)
+		}
+		expanded[i] = values
+		hasSlices = true
+	}
+	if !hasSlices {
+		return query, args, nil
+	}
+	if api.dialect.Placeholder == PlaceholderQuestion {
+		return expandQuestionPlaceholders(query, expanded)
+	}
+	return api.expandNumberedPlaceholders(query, expanded)
+}
+
+func sliceArgValues(arg interface{}) ([]interface{}, bool) {
+	if _, ok := arg.(driver.Valuer); ok {
+		return nil, false
+	}
+	argValue := reflect.ValueOf(arg)
+	if argValue.Kind() != reflect.Slice && argValue.Kind() != reflect.Array {
+		return nil, false
+	}
+	if argValue.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	values := make([]interface{}, argValue.Len())
+	for i := range values {
+		values[i] = argValue.Index(i).Interface()
+	}
+	return values, true
+}
+
+func expandQuestionPlaceholders(query string, expanded [][]interface{}) (string, []interface{}, error) {
+	var result strings.Builder
+	var args []interface{}
+	var argIndex int
+	for i := 0; i < len(query); {
+		if end := namedparam.SkipQuoted(query, i); end > i {
+			result.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		if query[i] != '?' {
+			result.WriteByte(query[i])
+			i++
+			continue
+		}
+		if argIndex >= len(expanded) {
+			return "", nil, fmt.Errorf("scany: query has more placeholders than arguments: %d", len(expanded))
+		}
+		result.WriteString(strings.TrimSuffix(strings.Repeat("?, ", len(expanded[argIndex])), ", "))
+		args = append(args, expanded[argIndex]...)
+		argIndex++
+		i++
+	}
+	if argIndex != len(expanded) {
+		return "", nil, fmt.Errorf("scany: query has %d placeholders, but %d arguments", argIndex, len(expanded))
+	}
+	return result.String(), args, nil
+}
+
+func (api *API) expandNumberedPlaceholders(query string, expanded [][]interface{}) (string, []interface{}, error) {
+	prefix := "$"
+	switch api.dialect.Placeholder {
+	case PlaceholderAtP:
+		prefix = "@p"
+	case PlaceholderColon:
+		prefix = ":"
+	}
+	// offsets contains the new number of the first placeholder of every argument.
+	offsets := make([]int, len(expanded))
+	var args []interface{}
+	for i, values := range expanded {
+		offsets[i] = len(args) + 1
+		args = append(args, values...)
+	}
+	var result strings.Builder
+	for i := 0; i < len(query); {
+		if end := namedparam.SkipQuoted(query, i); end > i {
+			result.WriteString(query[i:end])
+			i = end
+			continue
+		}
+		if !strings.HasPrefix(query[i:], prefix) {
+			result.WriteByte(query[i])
+			i++
+			continue
+		}
+		end := i + len(prefix)
+		for end < len(query) && query[end] >= '0' && query[end] <= '9' {
+			end++
+		}
+		if end == i+len(prefix) {
+			// It isn't a placeholder, e.g. a PostgreSQL dollar-quoted string.
+			result.WriteString(prefix)
+			i = end
+			continue
+		}
+		n, err := strconv.Atoi(query[i+len(prefix) : end])
+		if err != nil || n < 1 || n > len(expanded) {
+			return "", nil, fmt.Errorf("scany: placeholder %s has no corresponding argument", query[i:end])
+		}
+		for k := range expanded[n-1] {
+			if k > 0 {
+				result.WriteString(", ")
+			}
+			result.WriteString(api.dialect.Placeholder.placeholder(offsets[n-1] + k))
+		}
+		i = end
+	}
+	return result.String(), args, nil
+}
+
+// prepareQuery applies query rewrites that are enabled for the API, e.g. WithInExpansion.
+func (api *API) prepareQuery(query string, args []interface{}) (string, []interface{}, error) {
+	if !api.inExpansion {
+		return query, args, nil
+	}
+	return api.In(query, args...)
+}
@@ -0,0 +1,107 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestIn(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name          string
+		style         sqlscan.PlaceholderStyle
+		query         string
+		expectedQuery string
+	}{
+		{
+			name:          "dollar",
+			style:         sqlscan.PlaceholderDollar,
+			query:         `SELECT '$1' WHERE id IN ($1) AND data = $2 OR parent_id IN ($1)`,
+			expectedQuery: `SELECT '$1' WHERE id IN ($1, $2, $3) AND data = $4 OR parent_id IN ($1, $2, $3)`,
+		},
+		{
+			name:          "question",
+			style:         sqlscan.PlaceholderQuestion,
+			query:         `SELECT '?' WHERE id IN (?) AND data = ?`,
+			expectedQuery: `SELECT '?' WHERE id IN (?, ?, ?) AND data = ?`,
+		},
+		{
+			name:          "at p",
+			style:         sqlscan.PlaceholderAtP,
+			query:         `SELECT '@p1' WHERE id IN (@p1) AND data = @p2`,
+			expectedQuery: `SELECT '@p1' WHERE id IN (@p1, @p2, @p3) AND data = @p4`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dbscanAPI, err := sqlscan.NewDBScanAPI()
+			require.NoError(t, err)
+			api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithPlaceholderStyle(tc.style))
+			require.NoError(t, err)
+
+			gotQuery, gotArgs, err := api.In(tc.query, []int{1, 2, 3}, []byte("data val"))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedQuery, gotQuery)
+			assert.Equal(t, []interface{}{1, 2, 3, []byte("data val")}, gotArgs)
+		})
+	}
+}
+
+func TestIn_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		query       string
+		args        []interface{}
+		expectedErr string
+	}{
+		{
+			name:        "empty slice",
+			query:       `SELECT * FROM users WHERE id IN ($1)`,
+			args:        []interface{}{[]int{}},
+			expectedErr: "scany: argument 1 is an empty slice, it can't be expanded",
+		},
+		{
+			name:        "missing argument",
+			query:       `SELECT * FROM users WHERE id IN ($1) AND active = $2`,
+			args:        []interface{}{[]int{1}},
+			expectedErr: "scany: placeholder $2 has no corresponding argument",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := sqlscan.In(tc.query, tc.args...)
+
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestSelect_withInExpansion(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithInExpansion())
+	require.NoError(t, err)
+	query := `SELECT * FROM (` + multipleRowsQuery + `) AS t WHERE foo IN ($1) ORDER BY foo`
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err = api.Select(ctx, testDB, &got, query, []string{"foo val", "foo val 3"})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
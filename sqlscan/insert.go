@@ -0,0 +1,136 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Execer is something that sqlscan can execute statements with.
+// For example, it can be: *sql.DB, *sql.Conn or *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+var (
+	_ Execer = &sql.DB{}
+	_ Execer = &sql.Conn{}
+	_ Execer = &sql.Tx{}
+)
+
+// InsertAll is a package-level helper function that uses the DefaultAPI object.
+// See API.InsertAll for details.
+func InsertAll[T any](ctx context.Context, db Execer, table string, rows []T) error {
+	return DefaultAPI.InsertAll(ctx, db, table, rows)
+}
+
+// InsertAll inserts structs from the rows slice into the table, it's the inverse of ScanAll.
+// Columns are derived from the struct the same way dbscan maps them for scanning, see dbscan.API.Columns,
+// and values are extracted via dbscan.API.Values. Rows are inserted with multi-row INSERT statements,
+// as many rows per statement as the dialect allows, see WithDialect.
+// It's an error if a single row has more columns than the dialect allows arguments per statement:
+//
+//	err := sqlscan.InsertAll(ctx, db, "users", users)
+//	// INSERT INTO users (id, email) VALUES ($1, $2), ($3, $4), ...
+//
+// The table is used in the statement as is, columns are quoted by the dialect if needed, e.g. "post.title".
+// Statements are executed one by one, use a transaction to insert all rows atomically.
+func (api *API) InsertAll(ctx context.Context, db Execer, table string, rows interface{}) error {
+	rowsValue := reflect.ValueOf(rows)
+	if rowsValue.Kind() != reflect.Slice {
+		return fmt.Errorf("scany: rows to insert must be a slice, got: %T", rows)
+	}
+	if rowsValue.Len() == 0 {
+		return nil
+	}
+	columns, err := api.insertColumns(ctx, rowsValue.Index(0).Interface())
+	if err != nil {
+		return err
+	}
+	batchRows := rowsValue.Len()
+	if maxParams := api.dialect.MaxParams; maxParams > 0 {
+		if len(columns) > maxParams {
+			return fmt.Errorf(
+				"scany: %d columns of a row exceed the limit of %d arguments per statement", len(columns), maxParams,
+			)
+		}
+		batchRows = maxParams / len(columns)
+	}
+	if maxRows := api.dialect.MaxRows; maxRows > 0 && batchRows > maxRows {
+		batchRows = maxRows
+	}
+	for start := 0; start < rowsValue.Len(); start += batchRows {
+		end := start + batchRows
+		if end > rowsValue.Len() {
+			end = rowsValue.Len()
+		}
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		for i := start; i < end; i++ {
+			values, err := api.dbscanAPI.ValuesContext(ctx, rowsValue.Index(i).Interface(), columns...)
+			if err != nil {
+				return fmt.Errorf("scany: row %d: %w", i, err)
+			}
+			args = append(args, values...)
+		}
+		query := api.insertQuery(table, columns, end-start)
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return classifyError(fmt.Errorf("scany: insert rows %d to %d: %w", start, end-1, err))
+		}
+	}
+	return nil
+}
+
+// insertColumns returns columns of the row struct that values can be extracted for.
+func (api *API) insertColumns(ctx context.Context, row interface{}) ([]string, error) {
+	allColumns, err := api.dbscanAPI.Columns(row)
+	if err != nil {
+		return nil, err
+	}
+	// Source columns of composed fields can't be extracted, NamedArgs omits them.
+	namedArgs, err := api.dbscanAPI.NamedArgsContext(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, 0, len(namedArgs))
+	for _, column := range allColumns {
+		if _, ok := namedArgs[column]; ok {
+			columns = append(columns, column)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("scany: %T has no columns to insert", row)
+	}
+	return columns, nil
+}
+
+func (api *API) insertQuery(table string, columns []string, rows int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(table)
+	b.WriteString(" (")
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(api.dialect.quoteIfNeeded(column))
+	}
+	b.WriteString(") VALUES ")
+	n := 1
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for k := range columns {
+			if k > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(api.dialect.Placeholder.placeholder(n))
+			n++
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
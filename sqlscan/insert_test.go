@@ -0,0 +1,81 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestInsertAll(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE insert_all (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	rows := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	err = sqlscan.InsertAll(ctx, tx, "insert_all", rows)
+	require.NoError(t, err)
+
+	var got []*testModel
+	err = testAPI.Select(ctx, tx, &got, `SELECT foo, bar FROM insert_all ORDER BY foo`)
+	require.NoError(t, err)
+	assert.Equal(t, rows, got)
+}
+
+func TestInsertAll_notSlice_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	err := testAPI.InsertAll(ctx, testDB, "insert_all", testModel{})
+
+	assert.EqualError(t, err, "scany: rows to insert must be a slice, got: sqlscan_test.testModel")
+}
+
+func TestInsertAll_unlimitedMaxParams(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectPostgres
+	dialect.MaxParams = 0
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE insert_all_unlimited (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	rows := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	err = api.InsertAll(ctx, tx, "insert_all_unlimited", rows)
+	require.NoError(t, err)
+
+	var got []*testModel
+	err = api.Select(ctx, tx, &got, `SELECT foo, bar FROM insert_all_unlimited ORDER BY foo`)
+	require.NoError(t, err)
+	assert.Equal(t, rows, got)
+}
+
+func TestInsertAll_rowExceedsMaxParams_returnsErr(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectPostgres
+	dialect.MaxParams = 1
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+
+	err = api.InsertAll(ctx, testDB, "insert_all", []*testModel{{Foo: "foo val", Bar: "bar val"}})
+
+	assert.EqualError(t, err, "scany: 2 columns of a row exceed the limit of 1 arguments per statement")
+}
@@ -0,0 +1,93 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	sqlScannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// JSONConverter returns a ValueConverter that decodes JSON values of columns of the database types,
+// e.g. "JSON" in MySQL or "VARIANT" in Snowflake, with encoding/json into struct, map and slice destinations,
+// except []byte and sql.Scanner ones. Types are matched case-insensitively and without parameters,
+// e.g. "ARRAY" matches "Array[Int8]".
+func JSONConverter(dbTypes ...string) ValueConverter {
+	jsonTypes := make([]string, len(dbTypes))
+	for i, dbType := range dbTypes {
+		jsonTypes[i] = strings.ToUpper(dbType)
+	}
+	return func(columnType *sql.ColumnType, dst interface{}) sql.Scanner {
+		if !isJSONType(strings.ToUpper(columnType.DatabaseTypeName()), jsonTypes) {
+			return nil
+		}
+		return newJSONScanner(dst)
+	}
+}
+
+// isJSONType reports whether the upper-case database type is one of jsonTypes,
+// type parameters are ignored, e.g. of "ARRAY[INT8]".
+func isJSONType(dbType string, jsonTypes []string) bool {
+	if i := strings.IndexAny(dbType, "[("); i >= 0 {
+		dbType = dbType[:i]
+	}
+	for _, jsonType := range jsonTypes {
+		if dbType == jsonType {
+			return true
+		}
+	}
+	return false
+}
+
+// newJSONScanner returns jsonScanner for dst, or nil if dst isn't a struct, a map or a slice, or a pointer to them,
+// or if it gets JSON values as is, e.g. []byte and sql.Scanner destinations.
+func newJSONScanner(dst interface{}) sql.Scanner {
+	dstType := reflect.TypeOf(dst)
+	if dstType.Kind() != reflect.Ptr || dstType.Implements(sqlScannerType) {
+		return nil
+	}
+	baseType := dstType.Elem()
+	for baseType.Kind() == reflect.Ptr {
+		baseType = baseType.Elem()
+	}
+	switch {
+	case baseType.Kind() == reflect.Struct && baseType != timeType && !reflect.PtrTo(baseType).Implements(sqlScannerType):
+	case baseType.Kind() == reflect.Map:
+	case baseType.Kind() == reflect.Slice && baseType.Elem().Kind() != reflect.Uint8:
+	default:
+		return nil
+	}
+	return &jsonScanner{dst: dst}
+}
+
+// jsonScanner decodes JSON values that the driver returns as strings or bytes into the destination.
+type jsonScanner struct {
+	dst interface{}
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (js *jsonScanner) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		dstValue := reflect.ValueOf(js.dst).Elem()
+		dstValue.Set(reflect.Zero(dstValue.Type()))
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("scany: can't decode JSON value of type %T into %T", src, js.dst)
+	}
+	if err := json.Unmarshal(data, js.dst); err != nil {
+		return fmt.Errorf("scany: decode JSON value into %T: %w", js.dst, err)
+	}
+	return nil
+}
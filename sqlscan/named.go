@@ -0,0 +1,74 @@
+package sqlscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/internal/namedparam"
+)
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.GetNamed for details.
+func GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// BindNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.BindNamed for details.
+func BindNamed(ctx context.Context, query string, arg interface{}) (string, []interface{}, error) {
+	return DefaultAPI.BindNamed(ctx, query, arg)
+}
+
+// SelectNamed is the same as Select, but it takes named parameters, e.g. `WHERE id = :id`,
+// and binds them from a struct or a map. See BindNamed for details.
+func (api *API) SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	query, args, err := api.BindNamed(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	return api.Select(ctx, db, dst, query, args...)
+}
+
+// GetNamed is the same as Get, but it takes named parameters, e.g. `WHERE id = :id`,
+// and binds them from a struct or a map. See BindNamed for details.
+func (api *API) GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	query, args, err := api.BindNamed(ctx, query, arg)
+	if err != nil {
+		return err
+	}
+	return api.Get(ctx, db, dst, query, args...)
+}
+
+// BindNamed rewrites named parameters in the query, e.g. `WHERE id = :id`,
+// into positional placeholders of the configured style, see WithPlaceholderStyle,
+// and returns the values of parameters in the order of placeholders.
+// arg is either a map with string keys or a struct, in which case parameters are named after columns,
+// with the same values that dbscan.API.NamedArgs returns, e.g. `:post.id` for a nested struct.
+// Parameters inside string literals, quoted identifiers and comments are left as is,
+// so are doubled prefixes, e.g. PostgreSQL casts like `::TEXT`. Dialect.NamedPrefix changes the prefix of parameters,
+// e.g. to `@id`, in which case SQL Server system functions like `@@ROWCOUNT` are left as is.
+func (api *API) BindNamed(ctx context.Context, query string, arg interface{}) (string, []interface{}, error) {
+	lookup, err := namedparam.Lookup(ctx, api.dbscanAPI, arg)
+	if err != nil {
+		return "", nil, err
+	}
+	var args []interface{}
+	query, err = namedparam.Rewrite(query, api.dialect.namedPrefix(), func(name string) (string, error) {
+		value, ok := lookup(name)
+		if !ok {
+			return "", fmt.Errorf("scany: named parameter '%s' is missing in args", name)
+		}
+		args = append(args, value)
+		return api.dialect.Placeholder.placeholder(len(args)), nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return query, args, nil
+}
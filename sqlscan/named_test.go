@@ -0,0 +1,140 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestBindNamed(t *testing.T) {
+	t.Parallel()
+	type Post struct {
+		ID int
+	}
+	type user struct {
+		ID    int
+		Email string
+		Post  Post
+	}
+	cases := []struct {
+		name          string
+		style         sqlscan.PlaceholderStyle
+		query         string
+		arg           interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "struct",
+			query:         `SELECT * FROM users WHERE id = :id AND email = :email OR post_id = :post.id`,
+			arg:           &user{ID: 1, Email: "email val", Post: Post{ID: 2}},
+			expectedQuery: `SELECT * FROM users WHERE id = $1 AND email = $2 OR post_id = $3`,
+			expectedArgs:  []interface{}{1, "email val", 2},
+		},
+		{
+			name:          "map",
+			style:         sqlscan.PlaceholderQuestion,
+			query:         `SELECT * FROM users WHERE id = :id OR parent_id = :id`,
+			arg:           map[string]interface{}{"id": 1},
+			expectedQuery: `SELECT * FROM users WHERE id = ? OR parent_id = ?`,
+			expectedArgs:  []interface{}{1, 1},
+		},
+		{
+			name:          "literals, comments and casts",
+			style:         sqlscan.PlaceholderAtP,
+			query:         `SELECT ':foo', "a:b" FROM t /* :foo */ WHERE foo = :foo::TEXT -- :foo`,
+			arg:           map[string]string{"foo": "foo val"},
+			expectedQuery: `SELECT ':foo', "a:b" FROM t /* :foo */ WHERE foo = @p1::TEXT -- :foo`,
+			expectedArgs:  []interface{}{"foo val"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dbscanAPI, err := sqlscan.NewDBScanAPI()
+			require.NoError(t, err)
+			api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithPlaceholderStyle(tc.style))
+			require.NoError(t, err)
+
+			gotQuery, gotArgs, err := api.BindNamed(ctx, tc.query, tc.arg)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedQuery, gotQuery)
+			assert.Equal(t, tc.expectedArgs, gotArgs)
+		})
+	}
+}
+
+func TestBindNamed_withNamedPrefix(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectMSSQL
+	dialect.NamedPrefix = '@'
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	query := `SELECT '@id', :id FROM t WHERE id = @id AND @@ROWCOUNT > 0 OR parent_id = @id`
+
+	gotQuery, gotArgs, err := api.BindNamed(ctx, query, map[string]interface{}{"id": 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, `SELECT '@id', :id FROM t WHERE id = @p1 AND @@ROWCOUNT > 0 OR parent_id = @p2`, gotQuery)
+	assert.Equal(t, []interface{}{1, 1}, gotArgs)
+}
+
+func TestBindNamed_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		arg         interface{}
+		expectedErr string
+	}{
+		{
+			name:        "missing parameter",
+			arg:         map[string]interface{}{"foo": "foo val"},
+			expectedErr: "scany: named parameter 'bar' is missing in args",
+		},
+		{
+			name:        "unsupported args",
+			arg:         []string{"foo val"},
+			expectedErr: "scany: named args must be a struct or a map with string keys, got: []string",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := sqlscan.BindNamed(ctx, `SELECT :foo AS foo, :bar AS bar`, tc.arg)
+
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestGetNamed(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.GetNamed(ctx, testDB, &got, `SELECT :foo::TEXT AS foo, :bar::TEXT AS bar`, expected)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectNamed(t *testing.T) {
+	t.Parallel()
+	query := `SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2')) AS t (foo, bar) WHERE foo = :foo`
+	expected := []*testModel{{Foo: "foo val 2", Bar: "bar val 2"}}
+
+	var got []*testModel
+	err := testAPI.SelectNamed(ctx, testDB, &got, query, map[string]interface{}{"foo": "foo val 2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
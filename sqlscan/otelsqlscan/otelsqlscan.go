@@ -0,0 +1,142 @@
+// Package otelsqlscan traces sqlscan queries with OpenTelemetry.
+/*
+otelsqlscan plugs into sqlscan as a query hook, see sqlscan.WithQueryHook.
+It starts a client span around every query made by Select, Get, SelectSets
+and other high-level functions built on top of them, including scanning of the rows:
+
+	api, err := sqlscan.NewAPI(dbscanAPI, otelsqlscan.WithTracing(otelsqlscan.WithDBSystem("postgresql")))
+
+Spans have the db.statement attribute and, if it's set via WithDBSystem, db.system,
+following OpenTelemetry semantic conventions for database clients.
+They also contain the number of scanned rows and the time spent on scanning,
+split from the time spent on running the query:
+db.sqlscan.rows, db.sqlscan.query_duration and db.sqlscan.scan_duration, durations are in seconds.
+The span context is passed to the driver, so spans of an instrumented driver become children of the sqlscan span.
+
+otelsqlscan is a separate module, so the OpenTelemetry dependency doesn't affect users of the other scany packages.
+*/
+package otelsqlscan
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+const instrumentationName = "github.com/georgysavva/scany/v2/sqlscan/otelsqlscan"
+
+// Attribute keys that otelsqlscan sets on spans.
+const (
+	DBStatementKey   = attribute.Key("db.statement")
+	DBSystemKey      = attribute.Key("db.system")
+	RowsKey          = attribute.Key("db.sqlscan.rows")
+	QueryDurationKey = attribute.Key("db.sqlscan.query_duration")
+	ScanDurationKey  = attribute.Key("db.sqlscan.scan_duration")
+)
+
+// Option is a function type that changes Hook configuration.
+type Option func(h *Hook)
+
+// WithTracerProvider sets the tracer provider to create spans with, the global one is used by default.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(h *Hook) {
+		h.tracerProvider = provider
+	}
+}
+
+// WithDBSystem sets the db.system attribute of spans, e.g. "postgresql" or "mysql".
+func WithDBSystem(system string) Option {
+	return func(h *Hook) {
+		h.attrs = append(h.attrs, DBSystemKey.String(system))
+	}
+}
+
+// WithAttributes adds attributes to every span, e.g. db.name.
+func WithAttributes(attrs ...attribute.KeyValue) Option {
+	return func(h *Hook) {
+		h.attrs = append(h.attrs, attrs...)
+	}
+}
+
+// WithoutStatement omits the db.statement attribute, e.g. if queries may contain sensitive data.
+func WithoutStatement() Option {
+	return func(h *Hook) {
+		h.omitStatement = true
+	}
+}
+
+// Hook is a sqlscan.QueryHook that traces queries, see NewHook.
+type Hook struct {
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer
+	attrs          []attribute.KeyValue
+	omitStatement  bool
+}
+
+var (
+	_ sqlscan.QueryHook       = &Hook{}
+	_ sqlscan.BeforeQueryHook = &Hook{}
+)
+
+// NewHook creates a new Hook instance with provided list of options.
+func NewHook(opts ...Option) *Hook {
+	h := &Hook{}
+	for _, o := range opts {
+		o(h)
+	}
+	if h.tracerProvider == nil {
+		h.tracerProvider = otel.GetTracerProvider()
+	}
+	h.tracer = h.tracerProvider.Tracer(instrumentationName)
+	return h
+}
+
+// WithTracing is a sqlscan.APIOption that adds a Hook created with provided list of options.
+func WithTracing(opts ...Option) sqlscan.APIOption {
+	return sqlscan.WithQueryHook(NewHook(opts...))
+}
+
+// BeforeQuery implements the sqlscan.BeforeQueryHook.BeforeQuery method, it starts the span.
+func (h *Hook) BeforeQuery(ctx context.Context, event *sqlscan.QueryEvent) context.Context {
+	attrs := make([]attribute.KeyValue, 0, len(h.attrs)+1)
+	attrs = append(attrs, h.attrs...)
+	if !h.omitStatement {
+		attrs = append(attrs, DBStatementKey.String(event.Query))
+	}
+	ctx, _ = h.tracer.Start(ctx, spanName(event.Query),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx
+}
+
+// AfterQuery implements the sqlscan.QueryHook.AfterQuery method, it ends the span started by BeforeQuery.
+// Not found errors aren't recorded as span errors, since they are an expected outcome of Get.
+func (h *Hook) AfterQuery(ctx context.Context, event *sqlscan.QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		RowsKey.Int(event.Rows),
+		QueryDurationKey.Float64((event.Duration - event.ScanDuration).Seconds()),
+		ScanDurationKey.Float64(event.ScanDuration.Seconds()),
+	)
+	if event.Err != nil && !sqlscan.NotFound(event.Err) {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End()
+}
+
+// spanName returns the operation of the query, e.g. "SELECT", that the semantic conventions use as the span name.
+func spanName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "sqlscan.query"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,92 @@
+package otelsqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/otelsqlscan"
+)
+
+func runHook(t *testing.T, event *sqlscan.QueryEvent, opts ...otelsqlscan.Option) sdktrace.ReadOnlySpan {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	hook := otelsqlscan.NewHook(append(opts, otelsqlscan.WithTracerProvider(provider))...)
+
+	ctx := hook.BeforeQuery(context.Background(), &sqlscan.QueryEvent{Query: event.Query, Args: event.Args})
+	assert.True(t, trace.SpanFromContext(ctx).IsRecording())
+	hook.AfterQuery(ctx, event)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func TestHook(t *testing.T) {
+	t.Parallel()
+	event := &sqlscan.QueryEvent{
+		Query:        `SELECT * FROM users WHERE id = $1`,
+		Args:         []interface{}{1},
+		Duration:     3 * time.Second,
+		ScanDuration: time.Second,
+		Rows:         5,
+	}
+
+	span := runHook(t, event, otelsqlscan.WithDBSystem("postgresql"))
+
+	assert.Equal(t, "SELECT", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		otelsqlscan.DBSystemKey.String("postgresql"),
+		otelsqlscan.DBStatementKey.String(`SELECT * FROM users WHERE id = $1`),
+		otelsqlscan.RowsKey.Int(5),
+		otelsqlscan.QueryDurationKey.Float64(2),
+		otelsqlscan.ScanDurationKey.Float64(1),
+	}, span.Attributes())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestHook_err_recordsErr(t *testing.T) {
+	t.Parallel()
+	event := &sqlscan.QueryEvent{Query: `SELECT 1`, Err: errors.New("connection refused")}
+
+	span := runHook(t, event)
+
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "connection refused", span.Status().Description)
+	require.Len(t, span.Events(), 1)
+	assert.Equal(t, "exception", span.Events()[0].Name)
+}
+
+func TestHook_notFoundErr_isNotRecorded(t *testing.T) {
+	t.Parallel()
+	event := &sqlscan.QueryEvent{Query: `SELECT 1`, Err: sql.ErrNoRows}
+
+	span := runHook(t, event)
+
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Empty(t, span.Events())
+}
+
+func TestHook_withoutStatement(t *testing.T) {
+	t.Parallel()
+	event := &sqlscan.QueryEvent{Query: `SELECT secret FROM vault`}
+
+	span := runHook(t, event, otelsqlscan.WithoutStatement())
+
+	for _, attr := range span.Attributes() {
+		assert.NotEqual(t, otelsqlscan.DBStatementKey, attr.Key)
+	}
+}
@@ -0,0 +1,181 @@
+package sqlscan
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+const cursorTagOption = "cursor"
+
+// PageRequest describes a page of rows to select with SelectPage.
+type PageRequest struct {
+	// After is the cursor returned by the previous SelectPage call, empty for the first page.
+	After string
+	// Limit is the maximum number of rows in the page.
+	Limit int
+	// Desc makes pages go in the descending order of cursor columns.
+	Desc bool
+}
+
+// SelectPage is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectPage for details.
+func SelectPage(
+	ctx context.Context, db Querier, dst interface{}, query string, req PageRequest, args ...interface{},
+) (string, error) {
+	return DefaultAPI.SelectPage(ctx, db, dst, query, req, args...)
+}
+
+// SelectPage selects a page of rows with keyset pagination and returns the cursor of the next page,
+// or an empty string if it's the last page. dst must be a pointer to a slice of structs,
+// fields that identify a row are marked with the "cursor" tag option, in the order of significance:
+//
+//	type User struct {
+//		CreatedAt time.Time `db:"created_at,cursor"`
+//		ID        string    `db:"id,cursor"`
+//		Email     string
+//	}
+//
+//	var users []*User
+//	next, err := sqlscan.SelectPage(ctx, db, &users, `SELECT * FROM users WHERE active = $1`,
+//		sqlscan.PageRequest{After: cursor, Limit: 50}, true)
+//
+// The query is wrapped as a subquery, so it can be the same query that is used for Select,
+// followed by a predicate that skips rows up to the cursor, ORDER BY cursor columns and the limit.
+// Cursor columns must be unique together, the query syntax and quoting of columns follow the dialect, see WithDialect.
+// The cursor is an opaque string that encodes values of cursor fields of the last row in the page.
+// With dbscan.WithAppendToSlice every page is appended to the rows that dst already has.
+func (api *API) SelectPage(
+	ctx context.Context, db Querier, dst interface{}, query string, req PageRequest, args ...interface{},
+) (string, error) {
+	if req.Limit < 1 {
+		return "", fmt.Errorf("scany: page limit must be positive, got: %d", req.Limit)
+	}
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Slice {
+		return "", fmt.Errorf("scany: page destination must be a non-nil pointer to a slice, got: %T", dst)
+	}
+	elemType := dstValue.Elem().Type().Elem()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	cursorColumns, err := api.dbscanAPI.Columns(reflect.Zero(elemType).Interface(), dbscan.ColumnsTagged(cursorTagOption))
+	if err != nil {
+		return "", fmt.Errorf("scany: get cursor columns: %w", err)
+	}
+	if len(cursorColumns) == 0 {
+		return "", fmt.Errorf("scany: %v has no cursor fields, mark them with the \"cursor\" tag option", elemType)
+	}
+	var after []interface{}
+	if req.After != "" {
+		if after, err = api.decodeCursor(req.After, elemType, cursorColumns); err != nil {
+			return "", err
+		}
+	}
+	query, args = api.pageQuery(query, args, cursorColumns, after, req)
+	// With dbscan.WithAppendToSlice the page is appended after the rows that dst already has.
+	var pageStart int
+	if api.dbscanAPI.AppendsToSlice() {
+		pageStart = dstValue.Elem().Len()
+	}
+	if err := api.Select(ctx, db, dst, query, args...); err != nil {
+		return "", err
+	}
+	rows := dstValue.Elem()
+	pageEnd := pageStart + req.Limit
+	if rows.Len() <= pageEnd {
+		return "", nil
+	}
+	rows.Set(rows.Slice(0, pageEnd))
+	last, err := api.dbscanAPI.Values(rows.Index(pageEnd-1).Interface(), cursorColumns...)
+	if err != nil {
+		return "", fmt.Errorf("scany: get cursor values: %w", err)
+	}
+	return encodeCursor(last)
+}
+
+// pageQuery wraps the query and appends the keyset predicate,
+// e.g. `a > $1 OR (a = $2 AND b > $3)`, ordering and limit to it.
+func (api *API) pageQuery(
+	query string, args []interface{}, cursorColumns []string, after []interface{}, req PageRequest,
+) (string, []interface{}) {
+	operator, direction := ">", ""
+	if req.Desc {
+		operator, direction = "<", " DESC"
+	}
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (")
+	b.WriteString(trimQuery(query))
+	b.WriteString(") AS page")
+	if after != nil {
+		args = append([]interface{}(nil), args...)
+		b.WriteString(" WHERE ")
+		for i := range cursorColumns {
+			if i > 0 {
+				b.WriteString(" OR ")
+			}
+			b.WriteByte('(')
+			for k := 0; k <= i; k++ {
+				if k > 0 {
+					b.WriteString(" AND ")
+				}
+				op := "="
+				if k == i {
+					op = operator
+				}
+				args = append(args, after[k])
+				b.WriteString(api.dialect.quoteIfNeeded(cursorColumns[k]) + " " + op + " ")
+				b.WriteString(api.dialect.Placeholder.placeholder(len(args)))
+			}
+			b.WriteByte(')')
+		}
+	}
+	b.WriteString(" ORDER BY ")
+	for i, column := range cursorColumns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(api.dialect.quoteIfNeeded(column) + direction)
+	}
+	b.WriteString(api.dialect.limitClause(req.Limit + 1))
+	return b.String(), args
+}
+
+func encodeCursor(values []interface{}) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("scany: encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor decodes values of the cursor into the types of cursor fields,
+// so they are passed to the database the same way as values of the fields.
+func (api *API) decodeCursor(cursor string, structType reflect.Type, cursorColumns []string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("scany: decode cursor: %w", err)
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("scany: decode cursor: %w", err)
+	}
+	if len(raw) != len(cursorColumns) {
+		return nil, fmt.Errorf("scany: decode cursor: got %d values, but %d cursor columns", len(raw), len(cursorColumns))
+	}
+	values := make([]interface{}, len(raw))
+	for i, column := range cursorColumns {
+		fieldType, _ := api.dbscanAPI.FieldType(structType, column)
+		value := reflect.New(fieldType)
+		if err := json.Unmarshal(raw[i], value.Interface()); err != nil {
+			return nil, fmt.Errorf("scany: decode cursor column '%s': %w", column, err)
+		}
+		values[i] = value.Elem().Interface()
+	}
+	return values, nil
+}
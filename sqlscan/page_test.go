@@ -0,0 +1,118 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type pageModel struct {
+	Foo string `db:"foo,cursor"`
+	Bar int64  `db:"bar,cursor"`
+}
+
+const pageQuery = `
+	SELECT * FROM (
+		VALUES ('a', 1), ('a', 2), ('b', 1), ('c', 1), ('c', 2)
+	) AS t (foo, bar)
+`
+
+func TestSelectPage(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		desc     bool
+		expected [][]*pageModel
+	}{
+		{
+			name: "ascending",
+			expected: [][]*pageModel{
+				{{Foo: "a", Bar: 1}, {Foo: "a", Bar: 2}},
+				{{Foo: "b", Bar: 1}, {Foo: "c", Bar: 1}},
+				{{Foo: "c", Bar: 2}},
+			},
+		},
+		{
+			name: "descending",
+			desc: true,
+			expected: [][]*pageModel{
+				{{Foo: "c", Bar: 2}, {Foo: "c", Bar: 1}},
+				{{Foo: "b", Bar: 1}, {Foo: "a", Bar: 2}},
+				{{Foo: "a", Bar: 1}},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got [][]*pageModel
+			cursor := ""
+			for {
+				var page []*pageModel
+				next, err := testAPI.SelectPage(ctx, testDB, &page, pageQuery,
+					sqlscan.PageRequest{After: cursor, Limit: 2, Desc: tc.desc},
+				)
+				require.NoError(t, err)
+				got = append(got, page)
+				if next == "" {
+					break
+				}
+				cursor = next
+			}
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestSelectPage_withAppendToSlice_accumulatesPages(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI(dbscan.WithAppendToSlice(true))
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	expected := []*pageModel{
+		{Foo: "a", Bar: 1}, {Foo: "a", Bar: 2}, {Foo: "b", Bar: 1}, {Foo: "c", Bar: 1}, {Foo: "c", Bar: 2},
+	}
+
+	var got []*pageModel
+	var pages int
+	cursor := ""
+	for {
+		next, err := api.SelectPage(ctx, testDB, &got, pageQuery, sqlscan.PageRequest{After: cursor, Limit: 2})
+		require.NoError(t, err)
+		pages++
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, 3, pages)
+}
+
+func TestSelectPage_noCursorFields_returnsErr(t *testing.T) {
+	t.Parallel()
+	var page []*testModel
+
+	_, err := sqlscan.SelectPage(ctx, testDB, &page, multipleRowsQuery, sqlscan.PageRequest{Limit: 2})
+
+	assert.EqualError(t, err,
+		`scany: sqlscan_test.testModel has no cursor fields, mark them with the "cursor" tag option`,
+	)
+}
+
+func TestSelectPage_invalidCursor_returnsErr(t *testing.T) {
+	t.Parallel()
+	var page []*pageModel
+
+	_, err := sqlscan.SelectPage(ctx, testDB, &page, pageQuery, sqlscan.PageRequest{After: "WyJhIl0", Limit: 2})
+
+	assert.EqualError(t, err, "scany: decode cursor: got 1 values, but 2 cursor columns")
+}
@@ -0,0 +1,154 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// newRowsAdapter returns a new RowsAdapter instance configured according to the dialect.
+func (api *API) newRowsAdapter(rows *sql.Rows) *RowsAdapter {
+	ra := NewRowsAdapter(rows)
+	ra.bytesAsString = api.dialect.BytesAsString
+	ra.valueConverters = api.dialect.ValueConverters
+	if api.dialect.ParseTime {
+		ra.timeLocation = api.dialect.TimeLocation
+		if ra.timeLocation == nil {
+			ra.timeLocation = time.UTC
+		}
+		ra.timeLayouts = api.dialect.TimeLayouts
+		if len(ra.timeLayouts) == 0 {
+			ra.timeLayouts = defaultTimeLayouts
+		}
+	}
+	return ra
+}
+
+// wrapInterfaceDestinations returns dest with *interface{} destinations wrapped into bytesAsStringScanner,
+// or dest itself if there are no such destinations.
+func wrapInterfaceDestinations(dest []interface{}) []interface{} {
+	var wrapped []interface{}
+	for i, d := range dest {
+		ptr, ok := d.(*interface{})
+		if !ok {
+			continue
+		}
+		if wrapped == nil {
+			wrapped = make([]interface{}, len(dest))
+			copy(wrapped, dest)
+		}
+		wrapped[i] = &bytesAsStringScanner{dst: ptr}
+	}
+	if wrapped == nil {
+		return dest
+	}
+	return wrapped
+}
+
+// bytesAsStringScanner scans a value into *interface{} as is, except for []byte values that become strings.
+type bytesAsStringScanner struct {
+	dst *interface{}
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (bs *bytesAsStringScanner) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		*bs.dst = string(b)
+		return nil
+	}
+	*bs.dst = src
+	return nil
+}
+
+// defaultTimeLayouts are layouts of MySQL DATETIME and DATE values.
+var defaultTimeLayouts = []string{"2006-01-02 15:04:05.999999999", "2006-01-02"}
+
+// wrapTimeDestinations returns dest with time destinations wrapped into timeScanner,
+// or dest itself if there are no time destinations.
+func wrapTimeDestinations(dest []interface{}, layouts []string, loc *time.Location) []interface{} {
+	var wrapped []interface{}
+	for i, d := range dest {
+		switch d.(type) {
+		case *time.Time, **time.Time, *sql.NullTime:
+		default:
+			continue
+		}
+		if wrapped == nil {
+			// dest belongs to the caller, so it's copied instead of modified.
+			wrapped = make([]interface{}, len(dest))
+			copy(wrapped, dest)
+		}
+		wrapped[i] = &timeScanner{dst: d, layouts: layouts, loc: loc}
+	}
+	if wrapped == nil {
+		return dest
+	}
+	return wrapped
+}
+
+// timeScanner scans time values that MySQL drivers without parseTime=true return as strings or bytes,
+// e.g. "2006-01-02 15:04:05.999999" or "2006-01-02", into *time.Time, **time.Time or *sql.NullTime.
+// Zero dates like "0000-00-00 00:00:00" are scanned as the zero time.Time, the same way the driver does it.
+type timeScanner struct {
+	dst     interface{}
+	layouts []string
+	loc     *time.Location
+}
+
+// Scan implements the sql.Scanner.Scan method.
+func (ts *timeScanner) Scan(src interface{}) error {
+	var t time.Time
+	valid := true
+	switch v := src.(type) {
+	case nil:
+		valid = false
+	case time.Time:
+		t = v
+	case []byte:
+		var err error
+		if t, err = parseDateTime(string(v), ts.layouts, ts.loc); err != nil {
+			return err
+		}
+	case string:
+		var err error
+		if t, err = parseDateTime(v, ts.layouts, ts.loc); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("scany: can't scan %T into a time value", src)
+	}
+	switch d := ts.dst.(type) {
+	case *time.Time:
+		if !valid {
+			return fmt.Errorf("scany: can't scan NULL into %T", d)
+		}
+		*d = t
+	case **time.Time:
+		if !valid {
+			*d = nil
+			return nil
+		}
+		*d = &t
+	case *sql.NullTime:
+		*d = sql.NullTime{Time: t, Valid: valid}
+	}
+	return nil
+}
+
+func parseDateTime(s string, layouts []string, loc *time.Location) (time.Time, error) {
+	if strings.HasPrefix(s, "0000-00-00") {
+		return time.Time{}, nil
+	}
+	var firstErr error
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, fmt.Errorf("scany: parse time value: %w", firstErr)
+}
@@ -0,0 +1,120 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestSelect_withParseTime_parsesTimeStrings(t *testing.T) {
+	t.Parallel()
+	type event struct {
+		CreatedAt time.Time
+		UpdatedAt *time.Time
+		DeletedAt sql.NullTime
+	}
+	dialect := sqlscan.DialectPostgres
+	dialect.ParseTime = true
+	dialect.TimeLocation = time.FixedZone("UTC+3", 3*60*60)
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	query := `
+		SELECT * FROM (
+			VALUES ('2021-03-04 05:06:07.5', NULL, '2021-03-05'), ('0000-00-00 00:00:00', '2021-03-04 05:06:07', NULL)
+		) AS t (created_at, updated_at, deleted_at)
+	`
+	updatedAt := time.Date(2021, 3, 4, 5, 6, 7, 0, dialect.TimeLocation)
+	expected := []event{
+		{
+			CreatedAt: time.Date(2021, 3, 4, 5, 6, 7, 500000000, dialect.TimeLocation),
+			DeletedAt: sql.NullTime{Time: time.Date(2021, 3, 5, 0, 0, 0, 0, dialect.TimeLocation), Valid: true},
+		},
+		{UpdatedAt: &updatedAt},
+	}
+
+	var got []event
+	err = api.Select(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_withParseTime_invalidValue_returnsErr(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(sqlscan.DialectMySQL))
+	require.NoError(t, err)
+
+	var got []struct{ CreatedAt time.Time }
+	err = api.Select(ctx, testDB, &got, `SELECT 'yesterday' AS created_at`)
+
+	assert.ErrorContains(t, err, `scany: parse time value: parsing time "yesterday"`)
+}
+
+func TestSelect_withBytesAsString_convertsBytes(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectPostgres
+	dialect.BytesAsString = true
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	expected := []map[string]interface{}{{"name": "foo val", "data": "bar val", "id": int64(1)}}
+
+	var got []map[string]interface{}
+	err = api.Select(ctx, testDB, &got, `SELECT 'foo val' AS name, 'bar val'::BYTES AS data, 1 AS id`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanPivot_withBytesAsString_convertsBytes(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectPostgres
+	dialect.BytesAsString = true
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	rows, err := testDB.Query(`SELECT 'foo val' AS name, 'bar val'::BYTES AS data`)
+	require.NoError(t, err)
+	expected := map[string]map[string]interface{}{"foo val": {"data": "bar val"}}
+
+	got, err := api.ScanPivot(rows, "name")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelect_withTimeLayouts_parsesTimeStrings(t *testing.T) {
+	t.Parallel()
+	dialect := sqlscan.DialectPostgres
+	dialect.ParseTime = true
+	dialect.TimeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithDialect(dialect))
+	require.NoError(t, err)
+	query := `SELECT * FROM (VALUES ('2021-03-04T05:06:07.5+03:00'), ('2021-03-05')) AS t (created_at)`
+	expected := []time.Time{
+		time.Date(2021, 3, 4, 2, 6, 7, 500000000, time.UTC),
+		time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC),
+	}
+
+	var got []struct{ CreatedAt time.Time }
+	err = api.Select(ctx, testDB, &got, query)
+	require.NoError(t, err)
+
+	require.Len(t, got, 2)
+	for i, row := range got {
+		assert.True(t, expected[i].Equal(row.CreatedAt), "row %d: %v", i, row.CreatedAt)
+	}
+}
@@ -0,0 +1,53 @@
+package sqlscan
+
+import (
+	"strconv"
+)
+
+// PlaceholderStyle is the style of positional placeholders that the database driver expects.
+type PlaceholderStyle int
+
+const (
+	// PlaceholderDollar is the "$1, $2" style of PostgreSQL drivers. It's the default one.
+	PlaceholderDollar PlaceholderStyle = iota
+	// PlaceholderQuestion is the "?, ?" style of MySQL and SQLite drivers.
+	PlaceholderQuestion
+	// PlaceholderAtP is the "@p1, @p2" style of SQL Server drivers.
+	PlaceholderAtP
+	// PlaceholderColon is the ":1, :2" style of Oracle drivers.
+	PlaceholderColon
+)
+
+// WithPlaceholderStyle sets the placeholder style that sqlscan uses when it rewrites queries,
+// e.g. for named parameters. The default style is PlaceholderDollar.
+// It's a shortcut for WithDialect with the dialect of the same style:
+// DialectPostgres, DialectSQLite, DialectMSSQL or DialectOracle, use WithDialect(DialectMySQL) for MySQL.
+func WithPlaceholderStyle(style PlaceholderStyle) APIOption {
+	return func(api *API) {
+		switch style {
+		case PlaceholderQuestion:
+			api.dialect = DialectSQLite
+		case PlaceholderAtP:
+			api.dialect = DialectMSSQL
+		case PlaceholderColon:
+			api.dialect = DialectOracle
+		default:
+			api.dialect = DialectPostgres
+		}
+		api.dialect.Placeholder = style
+	}
+}
+
+// placeholder returns the placeholder of the n-th argument, starting from 1.
+func (s PlaceholderStyle) placeholder(n int) string {
+	switch s {
+	case PlaceholderQuestion:
+		return "?"
+	case PlaceholderAtP:
+		return "@p" + strconv.Itoa(n)
+	case PlaceholderColon:
+		return ":" + strconv.Itoa(n)
+	default:
+		return "$" + strconv.Itoa(n)
+	}
+}
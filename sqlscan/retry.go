@@ -0,0 +1,171 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how sqlscan retries queries that fail with transient errors, see WithRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a query is run, including the first attempt.
+	MaxAttempts int
+	// Backoff returns how long to wait before the given retry attempt, starting from 1.
+	// If it's nil, queries are retried immediately. See ExponentialBackoff.
+	Backoff func(attempt int) time.Duration
+	// Retryable reports whether the error is transient and the query can be retried.
+	// If it's nil, IsTransientError is used.
+	Retryable func(err error) bool
+}
+
+// WithRetryPolicy makes Select, Get, SelectSets and other high-level functions built on top of them
+// rerun the query and scanning if it fails with a transient error, according to the policy:
+//
+//	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithRetryPolicy(sqlscan.RetryPolicy{
+//		MaxAttempts: 3,
+//		Backoff:     sqlscan.ExponentialBackoff(50*time.Millisecond, time.Second),
+//	}))
+//
+// Statements that change data, like ExecReturning and InsertAll, aren't retried, since they may be already applied.
+// Queries within WithTx aren't retried either, the whole transaction is retried instead.
+// With dbscan.WithAppendToSlice the rows that a failed attempt has appended are removed from the destination slice
+// before the next attempt, so they aren't duplicated.
+// Retries are disabled by default.
+func WithRetryPolicy(policy RetryPolicy) APIOption {
+	return func(api *API) {
+		api.retryPolicy = &policy
+	}
+}
+
+// RetryPolicy returns the policy set with WithRetryPolicy, ok is false if it isn't set.
+func (api *API) RetryPolicy() (policy RetryPolicy, ok bool) {
+	if api.retryPolicy == nil {
+		return RetryPolicy{}, false
+	}
+	return *api.retryPolicy, true
+}
+
+// ExponentialBackoff returns a backoff function for RetryPolicy that doubles the delay with every attempt,
+// starting from initial and capped at maxDelay.
+func ExponentialBackoff(initial, maxDelay time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay
+	}
+}
+
+// IsTransientError reports whether the error is likely caused by a temporary condition,
+// so the same query can succeed if retried. These are broken connections, network timeouts,
+// busy databases, see ErrBusy, and for errors that implement the SQLState() string method, e.g. *pgconn.PgError,
+// serialization failures, deadlocks, connection exceptions and the server shutting down.
+func IsTransientError(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	if errors.Is(err, ErrBusy) || errorSentinel(err) == ErrBusy {
+		return true
+	}
+	var sqlStateErr interface{ SQLState() string }
+	if !errors.As(err, &sqlStateErr) {
+		return false
+	}
+	sqlState := sqlStateErr.SQLState()
+	switch {
+	case sqlState == "40001", sqlState == "40P01":
+		// Serialization failure and deadlock.
+		return true
+	case strings.HasPrefix(sqlState, "08"):
+		// Connection exception.
+		return true
+	case sqlState == "57P01", sqlState == "57P02", sqlState == "57P03":
+		// Admin shutdown, crash shutdown and cannot connect now.
+		return true
+	default:
+		return false
+	}
+}
+
+// withRetry calls fn until it succeeds, returns an error that isn't retryable, or attempts are exhausted.
+// Slice destinations in dsts are truncated to their length before the first attempt before every retry.
+func (api *API) withRetry(ctx context.Context, fn func() error, dsts ...interface{}) error {
+	policy := api.retryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 {
+		return fn()
+	}
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+	sliceLens := make([]int, len(dsts))
+	for i, dst := range dsts {
+		sliceLens[i] = sliceLen(dst)
+	}
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 && policy.Backoff != nil {
+			timer := time.NewTimer(policy.Backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("scany: retry aborted: %w (last error: %v)", ctx.Err(), err)
+			case <-timer.C:
+			}
+		}
+		if attempt > 1 {
+			for i, dst := range dsts {
+				truncateSlice(dst, sliceLens[i])
+			}
+		}
+		if err = fn(); err == nil || !retryable(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("scany: query failed after %d attempts: %w", policy.MaxAttempts, err)
+}
+
+// sliceLen returns the length of the slice that dst points to, or -1 if dst isn't a pointer to a slice.
+func sliceLen(dst interface{}) int {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Ptr || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Slice {
+		return -1
+	}
+	return dstValue.Elem().Len()
+}
+
+// truncateSlice removes elements that were appended to the slice that dst points to after its length was n,
+// see sliceLen.
+func truncateSlice(dst interface{}, n int) {
+	if n < 0 {
+		return
+	}
+	sliceValue := reflect.ValueOf(dst).Elem()
+	if sliceValue.Len() > n {
+		sliceValue.Set(sliceValue.Slice(0, n))
+	}
+}
+
+// withoutRetry returns a copy of the API that doesn't retry queries.
+func (api *API) withoutRetry() *API {
+	if api.retryPolicy == nil {
+		return api
+	}
+	copied := *api
+	copied.retryPolicy = nil
+	return &copied
+}
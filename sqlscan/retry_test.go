@@ -0,0 +1,169 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// flakyQuerier fails the first failures queries with err and passes the rest to testDB.
+type flakyQuerier struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (fq *flakyQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	fq.calls++
+	if fq.calls <= fq.failures {
+		return nil, fq.err
+	}
+	return testDB.QueryContext(ctx, query, args...)
+}
+
+// brokenRowsQuerier runs brokenQuery instead of the first query, so the first attempt fails while scanning rows.
+type brokenRowsQuerier struct {
+	brokenQuery string
+	calls       int
+}
+
+func (bq *brokenRowsQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	bq.calls++
+	if bq.calls == 1 {
+		query = bq.brokenQuery
+	}
+	return testDB.QueryContext(ctx, query, args...)
+}
+
+func getRetryAPI(t *testing.T, policy sqlscan.RetryPolicy) *sqlscan.API {
+	t.Helper()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	return api
+}
+
+func TestGet_withRetryPolicy_retriesTransientErr(t *testing.T) {
+	t.Parallel()
+	api := getRetryAPI(t, sqlscan.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     sqlscan.ExponentialBackoff(time.Millisecond, time.Millisecond),
+	})
+	db := &flakyQuerier{failures: 2, err: driver.ErrBadConn}
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := api.Get(ctx, db, &got, singleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, 3, db.calls)
+}
+
+func TestSelect_withRetryPolicy_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name          string
+		err           error
+		expectedErr   string
+		expectedCalls int
+	}{
+		{
+			name:          "attempts exhausted",
+			err:           driver.ErrBadConn,
+			expectedErr:   "scany: query failed after 2 attempts: scany: query multiple result rows: driver: bad connection",
+			expectedCalls: 2,
+		},
+		{
+			name:          "not retryable",
+			err:           errors.New("syntax error"),
+			expectedErr:   "scany: query multiple result rows: syntax error",
+			expectedCalls: 1,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api := getRetryAPI(t, sqlscan.RetryPolicy{MaxAttempts: 2})
+			db := &flakyQuerier{failures: 2, err: tc.err}
+
+			var got []*testModel
+			err := api.Select(ctx, db, &got, multipleRowsQuery)
+
+			assert.EqualError(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expectedCalls, db.calls)
+		})
+	}
+}
+
+func TestSelect_withRetryPolicyAndAppendToSlice_doesNotDuplicateRows(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI(dbscan.WithAppendToSlice(true))
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithRetryPolicy(sqlscan.RetryPolicy{
+		MaxAttempts: 2,
+		Retryable:   func(error) bool { return true },
+	}))
+	require.NoError(t, err)
+	// The second row fails to scan, since NULL can't be scanned into a string.
+	db := &brokenRowsQuerier{brokenQuery: `
+		SELECT * FROM (VALUES ('foo val', 'bar val'), ('foo val 2', NULL)) AS t (foo, bar)
+	`}
+	got := []*testModel{{Foo: "existing foo", Bar: "existing bar"}}
+	expected := []*testModel{
+		{Foo: "existing foo", Bar: "existing bar"},
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	err = api.Select(ctx, db, &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, 2, db.calls)
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{err: fmt.Errorf("query: %w", driver.ErrBadConn), expected: true},
+		{err: &pgconn.PgError{Code: "40001"}, expected: true},
+		{err: &pgconn.PgError{Code: "08006"}, expected: true},
+		{err: &pgconn.PgError{Code: "23505"}, expected: false},
+		{err: errors.New("syntax error"), expected: false},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, sqlscan.IsTransientError(tc.err))
+		})
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+	backoff := sqlscan.ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+
+	got := []time.Duration{backoff(1), backoff(2), backoff(3), backoff(4)}
+
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond}
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,67 @@
+package sqlscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// ExecReturning executes a statement that returns rows, e.g. `INSERT ... RETURNING *` in PostgreSQL and SQLite,
+// and scans the returned rows into dst:
+//
+//	var id int64
+//	err := sqlscan.ExecReturning(ctx, db, &id, `INSERT INTO users (email) VALUES ($1) RETURNING id`, email)
+//
+// If dst is a pointer to a slice or a dbscan.Collector, it scans all rows like Select does,
+// otherwise it expects exactly one row like Get does.
+// Unlike Get, if the statement returns no rows, e.g. an UPDATE that matched nothing, it isn't an error
+// for a slice destination, since the statement itself succeeded.
+// The statement goes through query hooks and the statement cache like Select, but it isn't retried.
+func (api *API) ExecReturning(
+	ctx context.Context, db Querier, dst interface{}, query string, args ...interface{},
+) error {
+	query, args, err := api.prepareQuery(query, args)
+	if err != nil {
+		return err
+	}
+	// Unlike Select, the statement isn't wrapped in withRetry, since it isn't idempotent in general.
+	return api.runQuery(ctx, query, args, func(ctx context.Context, counted *queryRows) error {
+		rows, err := api.queryContext(ctx, db, query, args)
+		if err != nil {
+			return fmt.Errorf("scany: exec returning: %w", err)
+		}
+		counted.setRows(api.newRowsAdapter(rows))
+		if returnsMultipleRows(dst) {
+			if err := api.dbscanAPI.ScanAllContext(ctx, dst, counted); err != nil {
+				return fmt.Errorf("scanning all: %w", err)
+			}
+			return nil
+		}
+		if err := api.scanOne(ctx, dst, counted); err != nil {
+			return fmt.Errorf("scanning one: %w", err)
+		}
+		return nil
+	})
+}
+
+// returnsMultipleRows reports whether dst is meant for all rows rather than a single row.
+// Byte slices are scanned from a single column, so they aren't considered as multiple rows.
+func returnsMultipleRows(dst interface{}) bool {
+	if _, ok := dst.(dbscan.Collector); ok {
+		return true
+	}
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr {
+		return false
+	}
+	elemType := dstType.Elem()
+	return elemType.Kind() == reflect.Slice && elemType.Elem().Kind() != reflect.Uint8
+}
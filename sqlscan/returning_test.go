@@ -0,0 +1,73 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestExecReturning_oneRow(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE returning_one (id SERIAL PRIMARY KEY, foo TEXT)`)
+	require.NoError(t, err)
+
+	var got struct {
+		ID  int64
+		Foo string
+	}
+	err = testAPI.ExecReturning(ctx, tx, &got, `INSERT INTO returning_one (foo) VALUES ($1) RETURNING *`, "foo val")
+	require.NoError(t, err)
+
+	assert.NotZero(t, got.ID)
+	assert.Equal(t, "foo val", got.Foo)
+}
+
+func TestExecReturning_multipleRows(t *testing.T) {
+	t.Parallel()
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE returning_many (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	query := `INSERT INTO returning_many (foo, bar) VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2')
+		RETURNING foo, bar`
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	var got []*testModel
+	err = sqlscan.ExecReturning(ctx, tx, &got, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestExecReturning_withQueryHook_reportsQuery(t *testing.T) {
+	t.Parallel()
+	var events []sqlscan.QueryEvent
+	api := getHookAPI(t, &events)
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+	_, err = tx.ExecContext(ctx, `CREATE TEMP TABLE returning_hook (foo TEXT)`)
+	require.NoError(t, err)
+	query := `INSERT INTO returning_hook (foo) VALUES ($1) RETURNING foo`
+
+	var got string
+	err = api.ExecReturning(ctx, tx, &got, query, "foo val")
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo val", got)
+	require.Len(t, events, 1)
+	assert.Equal(t, query, events[0].Query)
+	assert.Equal(t, []interface{}{"foo val"}, events[0].Args)
+	assert.Equal(t, 1, events[0].Rows)
+	assert.NoError(t, events[0].Err)
+}
@@ -0,0 +1,180 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+)
+
+// ReplicaPolicy chooses the replica that the next read query of Router goes to.
+type ReplicaPolicy interface {
+	// Pick returns one of the replicas, replicas is never empty.
+	Pick(ctx context.Context, replicas []*sql.DB) *sql.DB
+}
+
+// ReplicaPolicyFunc is an adapter to allow the use of ordinary functions as ReplicaPolicy.
+type ReplicaPolicyFunc func(ctx context.Context, replicas []*sql.DB) *sql.DB
+
+// Pick implements the ReplicaPolicy.Pick method.
+func (f ReplicaPolicyFunc) Pick(ctx context.Context, replicas []*sql.DB) *sql.DB {
+	return f(ctx, replicas)
+}
+
+// RoundRobin returns a ReplicaPolicy that picks replicas one after another. It's the default policy.
+func RoundRobin() ReplicaPolicy {
+	var next uint32
+	return ReplicaPolicyFunc(func(ctx context.Context, replicas []*sql.DB) *sql.DB {
+		n := atomic.AddUint32(&next, 1) - 1
+		return replicas[n%uint32(len(replicas))]
+	})
+}
+
+// RandomReplica returns a ReplicaPolicy that picks a random replica.
+func RandomReplica() ReplicaPolicy {
+	return ReplicaPolicyFunc(func(ctx context.Context, replicas []*sql.DB) *sql.DB {
+		return replicas[rand.Intn(len(replicas))] //nolint: gosec
+	})
+}
+
+// RouterOption is a function type that changes Router configuration.
+type RouterOption func(r *Router)
+
+// WithReplicaPolicy sets the policy that chooses replicas for read queries, the default one is RoundRobin.
+func WithReplicaPolicy(policy ReplicaPolicy) RouterOption {
+	return func(r *Router) {
+		r.policy = policy
+	}
+}
+
+// WithRouterAPI sets the API object that Router runs queries with, the default one is DefaultAPI.
+func WithRouterAPI(api *API) RouterOption {
+	return func(r *Router) {
+		r.api = api
+	}
+}
+
+// Router holds the primary database and its read replicas, and routes high-level functions between them:
+// read queries, like Select and Get, go to a replica chosen by the ReplicaPolicy,
+// while statements that change data, like ExecReturning, InsertAll and WithTx, go to the primary.
+//
+//	router, err := sqlscan.NewRouter(primary, []*sql.DB{replica1, replica2})
+//	err = router.Get(ctx, &user, `SELECT * FROM users WHERE id = $1`, id)
+//
+// Replicas may lag behind the primary, use UsePrimary for reads that must see the latest writes.
+// If there are no replicas, all queries go to the primary.
+type Router struct {
+	api      *API
+	primary  *sql.DB
+	replicas []*sql.DB
+	policy   ReplicaPolicy
+}
+
+// NewRouter creates a new Router instance with the primary database, replicas and provided list of options.
+func NewRouter(primary *sql.DB, replicas []*sql.DB, opts ...RouterOption) (*Router, error) {
+	if primary == nil {
+		return nil, fmt.Errorf("scany: router primary database must not be nil")
+	}
+	r := &Router{
+		api:      DefaultAPI,
+		primary:  primary,
+		replicas: replicas,
+		policy:   RoundRobin(),
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r, nil
+}
+
+type usePrimaryKey struct{}
+
+// UsePrimary returns a context that makes Router send read queries to the primary,
+// e.g. to read data that was just written and may not have reached replicas yet.
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, usePrimaryKey{}, true)
+}
+
+// Primary returns the primary database.
+func (r *Router) Primary() *sql.DB {
+	return r.primary
+}
+
+// Reader returns the database that a read query with the given context goes to.
+func (r *Router) Reader(ctx context.Context) *sql.DB {
+	if len(r.replicas) == 0 {
+		return r.primary
+	}
+	if usePrimary, _ := ctx.Value(usePrimaryKey{}).(bool); usePrimary {
+		return r.primary
+	}
+	return r.policy.Pick(ctx, r.replicas)
+}
+
+// Select is the same as API.Select, the query goes to a replica.
+func (r *Router) Select(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return r.api.Select(ctx, r.Reader(ctx), dst, query, args...)
+}
+
+// Get is the same as API.Get, the query goes to a replica.
+func (r *Router) Get(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return r.api.Get(ctx, r.Reader(ctx), dst, query, args...)
+}
+
+// SelectSets is the same as API.SelectSets, the query goes to a replica.
+func (r *Router) SelectSets(ctx context.Context, dsts []interface{}, query string, args ...interface{}) error {
+	return r.api.SelectSets(ctx, r.Reader(ctx), dsts, query, args...)
+}
+
+// SelectNamed is the same as API.SelectNamed, the query goes to a replica.
+func (r *Router) SelectNamed(ctx context.Context, dst interface{}, query string, arg interface{}) error {
+	return r.api.SelectNamed(ctx, r.Reader(ctx), dst, query, arg)
+}
+
+// GetNamed is the same as API.GetNamed, the query goes to a replica.
+func (r *Router) GetNamed(ctx context.Context, dst interface{}, query string, arg interface{}) error {
+	return r.api.GetNamed(ctx, r.Reader(ctx), dst, query, arg)
+}
+
+// SelectPage is the same as API.SelectPage, the query goes to a replica.
+func (r *Router) SelectPage(
+	ctx context.Context, dst interface{}, query string, req PageRequest, args ...interface{},
+) (string, error) {
+	return r.api.SelectPage(ctx, r.Reader(ctx), dst, query, req, args...)
+}
+
+// Each is the same as API.Each, the query goes to a replica.
+func (r *Router) Each(ctx context.Context, dst interface{}, fn func() error, query string, args ...interface{}) error {
+	return r.api.Each(ctx, r.Reader(ctx), dst, fn, query, args...)
+}
+
+// Exists is the same as API.Exists, the query goes to a replica.
+func (r *Router) Exists(ctx context.Context, query string, args ...interface{}) (bool, error) {
+	return r.api.Exists(ctx, r.Reader(ctx), query, args...)
+}
+
+// Count is the same as API.Count, the query goes to a replica.
+func (r *Router) Count(ctx context.Context, query string, args ...interface{}) (int64, error) {
+	return r.api.Count(ctx, r.Reader(ctx), query, args...)
+}
+
+// ExecReturning is the same as API.ExecReturning, the statement goes to the primary.
+func (r *Router) ExecReturning(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return r.api.ExecReturning(ctx, r.primary, dst, query, args...)
+}
+
+// InsertAll is the same as API.InsertAll, the statements go to the primary.
+func (r *Router) InsertAll(ctx context.Context, table string, rows interface{}) error {
+	return r.api.InsertAll(ctx, r.primary, table, rows)
+}
+
+// WithTx is the same as API.WithTx, the transaction runs on the primary.
+func (r *Router) WithTx(ctx context.Context, fn func(tx *Tx) error) error {
+	return r.api.WithTx(ctx, r.primary, fn)
+}
+
+// WithTxOptions is the same as API.WithTxOptions, the transaction runs on the primary.
+func (r *Router) WithTxOptions(ctx context.Context, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	return r.api.WithTxOptions(ctx, r.primary, opts, fn)
+}
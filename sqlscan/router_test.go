@@ -0,0 +1,91 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func getRouter(t *testing.T, picks *int) *sqlscan.Router {
+	t.Helper()
+	policy := sqlscan.ReplicaPolicyFunc(func(ctx context.Context, replicas []*sql.DB) *sql.DB {
+		*picks++
+		return replicas[0]
+	})
+	router, err := sqlscan.NewRouter(testDB, []*sql.DB{testDB}, sqlscan.WithReplicaPolicy(policy))
+	require.NoError(t, err)
+	return router
+}
+
+func TestRouter_readsGoToReplica(t *testing.T) {
+	t.Parallel()
+	var picks int
+	router := getRouter(t, &picks)
+
+	var got testModel
+	err := router.Get(ctx, &got, singleRowsQuery)
+	require.NoError(t, err)
+	count, err := router.Count(ctx, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, got)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, 2, picks)
+}
+
+func TestRouter_usePrimary_readGoesToPrimary(t *testing.T) {
+	t.Parallel()
+	var picks int
+	router := getRouter(t, &picks)
+
+	var got []*testModel
+	err := router.Select(sqlscan.UsePrimary(ctx), &got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Len(t, got, 3)
+	assert.Equal(t, 0, picks)
+}
+
+func TestRouter_writesGoToPrimary(t *testing.T) {
+	t.Parallel()
+	var picks int
+	router := getRouter(t, &picks)
+
+	err := router.WithTx(ctx, func(tx *sqlscan.Tx) error {
+		var got int
+		return tx.Get(ctx, &got, `SELECT 1`)
+	})
+	require.NoError(t, err)
+	var got string
+	err = router.ExecReturning(ctx, &got, `SELECT 'foo val'`)
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo val", got)
+	assert.Equal(t, 0, picks)
+}
+
+func TestRoundRobin(t *testing.T) {
+	t.Parallel()
+	replicas := []*sql.DB{{}, {}, {}}
+	policy := sqlscan.RoundRobin()
+
+	var got []*sql.DB
+	for i := 0; i < 4; i++ {
+		got = append(got, policy.Pick(ctx, replicas))
+	}
+
+	assert.Equal(t, []*sql.DB{replicas[0], replicas[1], replicas[2], replicas[0]}, got)
+}
+
+func TestNewRouter_nilPrimary_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := sqlscan.NewRouter(nil, nil)
+
+	assert.EqualError(t, err, "scany: router primary database must not be nil")
+}
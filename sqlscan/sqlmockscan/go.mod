@@ -0,0 +1,17 @@
+module github.com/georgysavva/scany/v2/sqlscan/sqlmockscan
+
+go 1.20
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/georgysavva/scany/v2 v2.1.3
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/georgysavva/scany/v2 => ../..
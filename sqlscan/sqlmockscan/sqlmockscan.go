@@ -0,0 +1,56 @@
+// Package sqlmockscan builds go-sqlmock rows from Go structs with the same mapping rules as scanning,
+// so mocked rows stay consistent with structs that the code under test scans them into.
+/*
+Instead of listing columns and values of every row by hand, pass structs to ExpectSelect:
+
+	db, mock, _ := sqlmock.New()
+	sqlmockscan.ExpectSelect(mock, `SELECT .+ FROM users`, []User{{ID: 1, Name: "foo"}}).WithArgs("foo")
+
+	var users []User
+	err := sqlscan.Select(ctx, db, &users, `SELECT * FROM users WHERE name = $1`, "foo")
+
+Columns and values come from dbscan.Columns and dbscan.Values of the default dbscan API,
+values of fields that implement driver.Valuer are converted with it.
+sqlmockscan is a separate module, so go-sqlmock isn't a dependency of scany itself.
+*/
+package sqlmockscan
+
+import (
+	"database/sql/driver"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+// NewRows returns sqlmock rows with data of the structs, or pointers to structs, one row per struct.
+func NewRows[T any](rows []T) (*sqlmock.Rows, error) {
+	columns, values, err := dbscantest.StructRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	mockRows := sqlmock.NewRows(columns)
+	for _, row := range values {
+		mockRow := make([]driver.Value, len(row))
+		for i, v := range row {
+			mockRow[i] = v
+		}
+		mockRows.AddRow(mockRow...)
+	}
+	return mockRows, nil
+}
+
+// ExpectSelect expects the query, like sqlmock.Sqlmock.ExpectQuery does, and makes it return rows
+// with data of the structs, see NewRows. It panics if T isn't a struct or a pointer to a struct.
+func ExpectSelect[T any](mock sqlmock.Sqlmock, query string, rows []T) *sqlmock.ExpectedQuery {
+	mockRows, err := NewRows(rows)
+	if err != nil {
+		panic(err)
+	}
+	return mock.ExpectQuery(query).WillReturnRows(mockRows)
+}
+
+// ExpectGet is the same as ExpectSelect, but the query returns a single row with data of the struct.
+func ExpectGet[T any](mock sqlmock.Sqlmock, query string, row T) *sqlmock.ExpectedQuery {
+	return ExpectSelect(mock, query, []T{row})
+}
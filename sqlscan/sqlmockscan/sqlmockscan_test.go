@@ -0,0 +1,95 @@
+package sqlmockscan_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlmockscan"
+)
+
+var ctx = context.Background()
+
+type post struct {
+	ID    int64
+	Title string
+}
+
+type user struct {
+	ID    int64
+	Name  string `db:"user_name"`
+	Email *string
+	Post  post
+}
+
+func newMock(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, mock.ExpectationsWereMet())
+		db.Close()
+	})
+	return db, mock
+}
+
+func TestExpectSelect(t *testing.T) {
+	t.Parallel()
+	db, mock := newMock(t)
+	email := "foo@example.com"
+	expected := []*user{
+		{ID: 1, Name: "foo", Email: &email, Post: post{ID: 10, Title: "hello"}},
+		{ID: 2, Name: "bar"},
+	}
+	sqlmockscan.ExpectSelect(mock, `SELECT .+ FROM users WHERE id > \$1`, expected).WithArgs(0)
+
+	var got []*user
+	err := sqlscan.Select(ctx, db, &got, `SELECT * FROM users WHERE id > $1`, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestExpectGet(t *testing.T) {
+	t.Parallel()
+	db, mock := newMock(t)
+	expected := user{ID: 1, Name: "foo"}
+	sqlmockscan.ExpectGet(mock, `SELECT .+ FROM users`, expected)
+
+	var got user
+	err := sqlscan.Get(ctx, db, &got, `SELECT * FROM users WHERE id = 1`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestNewRows_columnsFollowMapping(t *testing.T) {
+	t.Parallel()
+	db, mock := newMock(t)
+	rows, err := sqlmockscan.NewRows([]user{})
+	require.NoError(t, err)
+	mock.ExpectQuery(`SELECT`).WillReturnRows(rows)
+
+	sqlRows, err := db.QueryContext(ctx, `SELECT * FROM users`)
+	require.NoError(t, err)
+	defer sqlRows.Close()
+	columns, err := sqlRows.Columns()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"id", "user_name", "email", "post.id", "post.title"}, columns)
+	assert.False(t, sqlRows.Next())
+}
+
+func TestExpectSelect_notStruct_panics(t *testing.T) {
+	t.Parallel()
+	_, mock := newMock(t)
+
+	assert.Panics(t, func() {
+		sqlmockscan.ExpectSelect(mock, `SELECT`, []int{1})
+	})
+}
@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/georgysavva/scany/v2/dbscan"
 )
@@ -15,6 +18,12 @@ type Querier interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
+var (
+	_ dbscan.Rows                = &RowsAdapter{}
+	_ dbscan.ColumnTypesRows     = &RowsAdapter{}
+	_ dbscan.ScanErrorColumnRows = &RowsAdapter{}
+)
+
 var (
 	_ Querier = &sql.DB{}
 	_ Querier = &sql.Conn{}
@@ -33,6 +42,24 @@ func Get(ctx context.Context, db Querier, dst interface{}, query string, args ..
 	return DefaultAPI.Get(ctx, db, dst, query, args...)
 }
 
+// SelectSets is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectSets for details.
+func SelectSets(ctx context.Context, db Querier, dsts []interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.SelectSets(ctx, db, dsts, query, args...)
+}
+
+// Exists is a package-level helper function that uses the DefaultAPI object.
+// See API.Exists for details.
+func Exists(ctx context.Context, db Querier, query string, args ...interface{}) (bool, error) {
+	return DefaultAPI.Exists(ctx, db, query, args...)
+}
+
+// Count is a package-level helper function that uses the DefaultAPI object.
+// See API.Count for details.
+func Count(ctx context.Context, db Querier, query string, args ...interface{}) (int64, error) {
+	return DefaultAPI.Count(ctx, db, query, args...)
+}
+
 // ScanAll is a package-level helper function that uses the DefaultAPI object.
 // See API.ScanAll for details.
 func ScanAll(dst interface{}, rows *sql.Rows) error {
@@ -45,12 +72,48 @@ func ScanOne(dst interface{}, rows *sql.Rows) error {
 	return DefaultAPI.ScanOne(dst, rows)
 }
 
+// ScanAllContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllContext for details.
+func ScanAllContext(ctx context.Context, dst interface{}, rows *sql.Rows) error {
+	return DefaultAPI.ScanAllContext(ctx, dst, rows)
+}
+
+// ScanOneContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanOneContext for details.
+func ScanOneContext(ctx context.Context, dst interface{}, rows *sql.Rows) error {
+	return DefaultAPI.ScanOneContext(ctx, dst, rows)
+}
+
 // ScanAllSets is a package-level helper function that uses the DefaultAPI object.
 // See API.ScanAllSets for details.
 func ScanAllSets(dsts []interface{}, rows *sql.Rows) error {
 	return DefaultAPI.ScanAllSets(dsts, rows)
 }
 
+// ScanAllSetsContext is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSetsContext for details.
+func ScanAllSetsContext(ctx context.Context, dsts []interface{}, rows *sql.Rows) error {
+	return DefaultAPI.ScanAllSetsContext(ctx, dsts, rows)
+}
+
+// ScanPivot is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanPivot for details.
+func ScanPivot(rows *sql.Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return DefaultAPI.ScanPivot(rows, keyColumn)
+}
+
+// ScanToJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToJSON for details.
+func ScanToJSON(w io.Writer, rows *sql.Rows) error {
+	return DefaultAPI.ScanToJSON(w, rows)
+}
+
+// ScanToCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanToCSV for details.
+func ScanToCSV(w io.Writer, rows *sql.Rows) error {
+	return DefaultAPI.ScanToCSV(w, rows)
+}
+
 // RowScanner is a wrapper around the dbscan.RowScanner type.
 // See dbscan.RowScanner for details.
 type RowScanner struct {
@@ -84,56 +147,166 @@ func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
 // API is a wrapper around the dbscan.API type.
 // See dbscan.API for details.
 type API struct {
-	dbscanAPI *dbscan.API
+	dbscanAPI       *dbscan.API
+	dialect         Dialect
+	inExpansion     bool
+	txMaxAttempts   int
+	retryPolicy     *RetryPolicy
+	queryHooks      []QueryHook
+	stmtCache       *stmtCache
+	abortDrain      bool
+	abortDrainLimit int
+	cache           CacheStore
+	cacheTTL        time.Duration
 }
 
-// NewAPI creates new API instance from dbscan.API instance.
-func NewAPI(dbscanAPI *dbscan.API) (*API, error) {
-	api := &API{dbscanAPI: dbscanAPI}
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{
+		dbscanAPI:     dbscanAPI,
+		dialect:       DialectPostgres,
+		txMaxAttempts: defaultTxMaxAttempts,
+	}
+	for _, o := range opts {
+		o(api)
+	}
 	return api, nil
 }
 
 // Select is a high-level function that queries rows from Querier and calls the ScanAll function.
 // See ScanAll for details.
 func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.QueryContext(ctx, query, args...)
+	query, args, err := api.prepareQuery(query, args)
 	if err != nil {
-		return fmt.Errorf("scany: query multiple result rows: %w", err)
-	}
-	if err := api.ScanAll(dst, rows); err != nil {
-		return fmt.Errorf("scanning all: %w", err)
+		return err
 	}
-	return nil
+	return api.withCache(ctx, db, dst, query, args, func(dst interface{}) error {
+		return api.withRetry(ctx, func() error {
+			return api.runQuery(ctx, query, args, func(ctx context.Context, counted *queryRows) error {
+				rows, err := api.queryContext(ctx, db, query, args)
+				if err != nil {
+					return fmt.Errorf("scany: query multiple result rows: %w", err)
+				}
+				counted.setRows(api.newRowsAdapter(rows))
+				if err := api.dbscanAPI.ScanAllContext(ctx, dst, counted); err != nil {
+					return fmt.Errorf("scanning all: %w", err)
+				}
+				return nil
+			})
+		}, dst)
+	})
 }
 
 // Get is a high-level function that queries rows from Querier and calls the ScanOne function.
 // See ScanOne for details.
 func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.QueryContext(ctx, query, args...)
+	query, args, err := api.prepareQuery(query, args)
 	if err != nil {
-		return fmt.Errorf("scany: query one result row: %w", err)
+		return err
 	}
-	if err := api.ScanOne(dst, rows); err != nil {
-		return fmt.Errorf("scanning one: %w", err)
+	return api.withCache(ctx, db, dst, query, args, func(dst interface{}) error {
+		return api.withRetry(ctx, func() error {
+			return api.runQuery(ctx, query, args, func(ctx context.Context, counted *queryRows) error {
+				rows, err := api.queryContext(ctx, db, query, args)
+				if err != nil {
+					return fmt.Errorf("scany: query one result row: %w", err)
+				}
+				counted.setRows(api.newRowsAdapter(rows))
+				if err := api.scanOne(ctx, dst, counted); err != nil {
+					return fmt.Errorf("scanning one: %w", err)
+				}
+				return nil
+			})
+		})
+	})
+}
+
+// SelectSets is a high-level function that queries rows from Querier and calls the ScanAllSets function.
+// It's meant for queries that return multiple result sets, e.g. stored procedures or multi-statement queries
+// in MySQL and SQL Server, every result set is scanned into the corresponding destination, see ScanAllSets.
+func (api *API) SelectSets(
+	ctx context.Context, db Querier, dsts []interface{}, query string, args ...interface{},
+) error {
+	query, args, err := api.prepareQuery(query, args)
+	if err != nil {
+		return err
 	}
-	return nil
+	return api.withRetry(ctx, func() error {
+		return api.runQuery(ctx, query, args, func(ctx context.Context, counted *queryRows) error {
+			rows, err := api.queryContext(ctx, db, query, args)
+			if err != nil {
+				return fmt.Errorf("scany: query multiple result sets: %w", err)
+			}
+			counted.setRows(api.newRowsAdapter(rows))
+			if err := api.dbscanAPI.ScanAllSetsContext(ctx, dsts, counted); err != nil {
+				return fmt.Errorf("scanning all sets: %w", err)
+			}
+			return nil
+		})
+	}, dsts...)
+}
+
+// Exists reports whether the query returns at least one row.
+// The query is wrapped as `SELECT EXISTS (query)`, so the database doesn't need to produce all rows,
+// it works with databases that support EXISTS in the select list, e.g. PostgreSQL, MySQL and SQLite.
+func (api *API) Exists(ctx context.Context, db Querier, query string, args ...interface{}) (bool, error) {
+	var exists bool
+	if err := api.Get(ctx, db, &exists, "SELECT EXISTS ("+trimQuery(query)+")", args...); err != nil {
+		return false, fmt.Errorf("scany: check rows exist: %w", err)
+	}
+	return exists, nil
+}
+
+// Count returns the number of rows that the query returns.
+// The query is wrapped as `SELECT COUNT(*) FROM (query) AS t`, so it can be the same query that is used for Select.
+func (api *API) Count(ctx context.Context, db Querier, query string, args ...interface{}) (int64, error) {
+	var count int64
+	if err := api.Get(ctx, db, &count, "SELECT COUNT(*) FROM ("+trimQuery(query)+") AS t", args...); err != nil {
+		return 0, fmt.Errorf("scany: count rows: %w", err)
+	}
+	return count, nil
+}
+
+// trimQuery removes the trailing semicolon, so the query can be used as a subquery.
+func trimQuery(query string) string {
+	return strings.TrimRight(strings.TrimSpace(query), "; \t\n")
 }
 
 // ScanAll is a wrapper around the dbscan.ScanAll function.
 // See dbscan.ScanAll for details.
 func (api *API) ScanAll(dst interface{}, rows *sql.Rows) error {
-	return api.dbscanAPI.ScanAll(dst, rows)
+	return api.dbscanAPI.ScanAll(dst, api.newRowsAdapter(rows))
+}
+
+// ScanAllContext is a wrapper around the dbscan.ScanAllContext function.
+// See dbscan.ScanAllContext for details.
+func (api *API) ScanAllContext(ctx context.Context, dst interface{}, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanAllContext(ctx, dst, api.newRowsAdapter(rows))
 }
 
 // ScanOne is a wrapper around the dbscan.ScanOne function.
 // See dbscan.ScanOne for details. If no rows are found it
-// returns an sql.ErrNoRows error.
+// returns an sql.ErrNoRows error, that matches dbscan.ErrNotFound via errors.Is as well.
 func (api *API) ScanOne(dst interface{}, rows *sql.Rows) error {
-	switch err := api.dbscanAPI.ScanOne(dst, rows); {
-	case dbscan.NotFound(err):
-		return fmt.Errorf("%w", sql.ErrNoRows)
+	return api.ScanOneContext(context.Background(), dst, rows)
+}
+
+// ScanOneContext is a wrapper around the dbscan.ScanOneContext function.
+// See dbscan.ScanOneContext for details. If no rows are found it
+// returns an sql.ErrNoRows error, that matches dbscan.ErrNotFound via errors.Is as well.
+func (api *API) ScanOneContext(ctx context.Context, dst interface{}, rows *sql.Rows) error {
+	return api.scanOne(ctx, dst, api.newRowsAdapter(rows))
+}
+
+func (api *API) scanOne(ctx context.Context, dst interface{}, rows dbscan.Rows) error {
+	switch err := api.dbscanAPI.ScanOneContext(ctx, dst, rows); {
+	case dbscan.NotFound(err), errors.Is(err, sql.ErrNoRows):
+		return dbscan.WrapNotFound(sql.ErrNoRows)
 	case err != nil:
-		return fmt.Errorf("%w", err)
+		return err
 	default:
 		return nil
 	}
@@ -142,24 +315,162 @@ func (api *API) ScanOne(dst interface{}, rows *sql.Rows) error {
 // ScanAllSets is a wrapper around the dbscan.ScanAllSets function.
 // See dbscan.ScanAllSets for details.
 func (api *API) ScanAllSets(dsts []interface{}, rows *sql.Rows) error {
-	return api.dbscanAPI.ScanAllSets(dsts, rows)
+	return api.dbscanAPI.ScanAllSets(dsts, api.newRowsAdapter(rows))
+}
+
+// ScanAllSetsContext is a wrapper around the dbscan.ScanAllSetsContext function.
+// See dbscan.ScanAllSetsContext for details.
+func (api *API) ScanAllSetsContext(ctx context.Context, dsts []interface{}, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanAllSetsContext(ctx, dsts, api.newRowsAdapter(rows))
+}
+
+// ScanPivot is a wrapper around the dbscan.ScanPivot function.
+// See dbscan.ScanPivot for details.
+func (api *API) ScanPivot(rows *sql.Rows, keyColumn string) (map[string]map[string]interface{}, error) {
+	return api.dbscanAPI.ScanPivot(api.newRowsAdapter(rows), keyColumn)
+}
+
+// ScanToJSON is a wrapper around the dbscan.ScanToJSON function.
+// See dbscan.ScanToJSON for details.
+func (api *API) ScanToJSON(w io.Writer, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanToJSON(w, api.newRowsAdapter(rows))
+}
+
+// ScanToJSONContext is a wrapper around the dbscan.ScanToJSONContext function.
+// See dbscan.ScanToJSONContext for details.
+func (api *API) ScanToJSONContext(ctx context.Context, w io.Writer, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanToJSONContext(ctx, w, api.newRowsAdapter(rows))
+}
+
+// ScanToCSV is a wrapper around the dbscan.ScanToCSV function.
+// See dbscan.ScanToCSV for details.
+func (api *API) ScanToCSV(w io.Writer, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanToCSV(w, api.newRowsAdapter(rows))
+}
+
+// ScanToCSVContext is a wrapper around the dbscan.ScanToCSVContext function.
+// See dbscan.ScanToCSVContext for details.
+func (api *API) ScanToCSVContext(ctx context.Context, w io.Writer, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanToCSVContext(ctx, w, api.newRowsAdapter(rows))
 }
 
 // NotFound is a helper function to check if an error
-// is `sql.ErrNoRows`.
+// is `sql.ErrNoRows` or `dbscan.ErrNotFound`.
 func NotFound(err error) bool {
-	return errors.Is(err, sql.ErrNoRows)
+	return errors.Is(err, sql.ErrNoRows) || dbscan.NotFound(err)
 }
 
 // NewRowScanner returns a new RowScanner instance.
 func (api *API) NewRowScanner(rows *sql.Rows) *RowScanner {
-	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(rows)}
+	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(api.newRowsAdapter(rows))}
 }
 
 // ScanRow is a wrapper around the dbscan.ScanRow function.
 // See dbscan.ScanRow for details.
 func (api *API) ScanRow(dst interface{}, rows *sql.Rows) error {
-	return api.dbscanAPI.ScanRow(dst, rows)
+	return api.dbscanAPI.ScanRow(dst, api.newRowsAdapter(rows))
+}
+
+// RowsAdapter makes *sql.Rows expose additional information to dbscan,
+// such as database types of columns, so dbscan can return errors with a richer context.
+// See dbscan.ColumnTypesRows and dbscan.ScanErrorColumnRows for details.
+type RowsAdapter struct {
+	*sql.Rows
+	// timeLocation and timeLayouts are set if time values are parsed, see Dialect.ParseTime.
+	timeLocation *time.Location
+	timeLayouts  []string
+	// bytesAsString is set if []byte values are converted into strings, see Dialect.BytesAsString.
+	bytesAsString bool
+	// valueConverters convert values depending on column types, see Dialect.ValueConverters.
+	valueConverters []ValueConverter
+	// columnTypes are column types of the current result set, they are loaded once for converted values.
+	columnTypes []*sql.ColumnType
+}
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(rows *sql.Rows) *RowsAdapter {
+	return &RowsAdapter{Rows: rows}
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *RowsAdapter) Scan(dest ...interface{}) error {
+	if ra.timeLocation != nil {
+		dest = wrapTimeDestinations(dest, ra.timeLayouts, ra.timeLocation)
+	}
+	if ra.bytesAsString {
+		dest = wrapInterfaceDestinations(dest)
+	}
+	if len(ra.valueConverters) > 0 {
+		dest = ra.wrapConvertedDestinations(dest)
+	}
+	return ra.Rows.Scan(dest...)
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+func (ra *RowsAdapter) NextResultSet() bool {
+	ra.columnTypes = nil
+	return ra.Rows.NextResultSet()
+}
+
+// wrapConvertedDestinations returns dest with destinations wrapped into scanners of value converters,
+// or dest itself if there is nothing to convert.
+func (ra *RowsAdapter) wrapConvertedDestinations(dest []interface{}) []interface{} {
+	if ra.columnTypes == nil {
+		columnTypes, err := ra.Rows.ColumnTypes()
+		if err != nil {
+			// Rows.Scan returns the same error.
+			return dest
+		}
+		ra.columnTypes = columnTypes
+	}
+	var wrapped []interface{}
+	for i, d := range dest {
+		if i >= len(ra.columnTypes) || d == nil {
+			continue
+		}
+		var scanner sql.Scanner
+		for _, convert := range ra.valueConverters {
+			if scanner = convert(ra.columnTypes[i], d); scanner != nil {
+				break
+			}
+		}
+		if scanner == nil {
+			continue
+		}
+		if wrapped == nil {
+			// dest belongs to the caller, so it's copied instead of modified.
+			wrapped = make([]interface{}, len(dest))
+			copy(wrapped, dest)
+		}
+		wrapped[i] = scanner
+	}
+	if wrapped == nil {
+		return dest
+	}
+	return wrapped
+}
+
+// ColumnDatabaseTypes implements the dbscan.ColumnTypesRows.ColumnDatabaseTypes method.
+func (ra RowsAdapter) ColumnDatabaseTypes() ([]string, error) {
+	columnTypes, err := ra.Rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("scany: get rows column types: %w", err)
+	}
+	dbTypes := make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		dbTypes[i] = ct.DatabaseTypeName()
+	}
+	return dbTypes, nil
+}
+
+// ScanErrorColumn implements the dbscan.ScanErrorColumnRows.ScanErrorColumn method.
+// It extracts the column index from the error message that database/sql returns from Rows.Scan.
+func (ra RowsAdapter) ScanErrorColumn(err error) (int, bool) {
+	var columnIndex int
+	if _, scanErr := fmt.Sscanf(err.Error(), "sql: Scan error on column index %d,", &columnIndex); scanErr != nil {
+		return 0, false
+	}
+	return columnIndex, true
 }
 
 func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
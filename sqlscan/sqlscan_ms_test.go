@@ -76,3 +76,30 @@ func TestMSScanAllSets(t *testing.T) {
 	assert.Equal(t, expected1, got1)
 	assert.Equal(t, expected2, got2)
 }
+
+func TestMSSelectSets(t *testing.T) {
+	t.Parallel()
+	testMSSQLDB, err := sql.Open("sqlserver", getEnv("MSSQL_URL", "sqlserver://sa:p@sSword@localhost:1433?database=master"))
+	require.NoError(t, err)
+	defer testMSSQLDB.Close()
+	type testModel2 struct {
+		Egg   string
+		Bacon string
+	}
+	expected1 := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	expected2 := []*testModel2{
+		{Egg: "egg val", Bacon: "bacon val"},
+	}
+
+	var got1 []*testModel
+	var got2 []*testModel2
+	err = sqlscan.SelectSets(ctx, testMSSQLDB, []any{&got1, &got2}, multipleSetsQueryMssql)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected1, got1)
+	assert.Equal(t, expected2, got2)
+}
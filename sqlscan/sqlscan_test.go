@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/georgysavva/scany/v2"
+	"github.com/georgysavva/scany/v2/dbscan"
 	"github.com/georgysavva/scany/v2/sqlscan"
 )
 
@@ -98,6 +100,61 @@ func TestGet_queryError_propagatesAndWrapsErr(t *testing.T) {
 	assert.EqualError(t, err, expectedErr)
 }
 
+func TestExists(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{name: "rows exist", query: multipleRowsQuery, expected: true},
+		{name: "no rows", query: noRowsQuery, expected: false},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := testAPI.Exists(ctx, testDB, tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		query    string
+		expected int64
+	}{
+		{name: "multiple rows", query: multipleRowsQuery, expected: 3},
+		{name: "no rows", query: noRowsQuery, expected: 0},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := testAPI.Count(ctx, testDB, tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestCount_queryError_propagatesAndWrapsErr(t *testing.T) {
+	t.Parallel()
+	query := `
+		SELECT 'foo val' AS foo, 'bar val' AS bar, baz
+	`
+	expectedErr := "scany: count rows: scany: query one result row: " +
+		"ERROR: column \"baz\" does not exist (SQLSTATE 42703)"
+
+	_, err := testAPI.Count(ctx, testDB, query)
+
+	assert.EqualError(t, err, expectedErr)
+}
+
 func TestScanAll(t *testing.T) {
 	t.Parallel()
 	expected := []*testModel{
@@ -138,6 +195,88 @@ func TestScanOne_noRows_returnsNotFoundErr(t *testing.T) {
 
 	assert.True(t, sqlscan.NotFound(err))
 	assert.True(t, errors.Is(err, sql.ErrNoRows))
+	assert.True(t, errors.Is(err, scany.ErrNotFound))
+}
+
+func TestScanAllContext(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	rows, err := testDB.Query(multipleRowsQuery)
+	require.NoError(t, err)
+
+	var got []*testModel
+	err = testAPI.ScanAllContext(ctx, &got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestScanOneContext_noRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	rows, err := testDB.Query(noRowsQuery)
+	require.NoError(t, err)
+
+	var got testModel
+	err = testAPI.ScanOneContext(ctx, &got, rows)
+
+	assert.True(t, sqlscan.NotFound(err))
+}
+
+func TestScanAll_withRowErrorHandler_skipsInvalidRows(t *testing.T) {
+	t.Parallel()
+	var handledRows []int
+	dbscanAPI, err := sqlscan.NewDBScanAPI(dbscan.WithRowErrorHandler(func(rowIndex int, err error) error {
+		handledRows = append(handledRows, rowIndex)
+		return nil
+	}))
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	rows, err := testDB.Query(`
+		SELECT *
+		FROM (
+			VALUES ('1', 'foo val'), ('invalid', 'foo val 2'), ('3', 'foo val 3')
+		) AS t (num, foo)
+	`)
+	require.NoError(t, err)
+	type Destination struct {
+		Num int
+		Foo string
+	}
+	expected := []Destination{
+		{Num: 1, Foo: "foo val"},
+		{Num: 3, Foo: "foo val 3"},
+	}
+
+	var got []Destination
+	err = api.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []int{1}, handledRows)
+}
+
+func TestScanAll_invalidValue_returnsScanErr(t *testing.T) {
+	t.Parallel()
+	rows, err := testDB.Query(singleRowsQuery)
+	require.NoError(t, err)
+
+	var got []struct {
+		Foo string
+		Bar int
+	}
+	err = testAPI.ScanAll(&got, rows)
+
+	var scanErr *dbscan.ScanError
+	require.ErrorAs(t, err, &scanErr)
+	assert.Equal(t, "bar", scanErr.Column)
+	assert.Equal(t, "TEXT", scanErr.DatabaseType)
+	assert.Equal(t, "Bar", scanErr.FieldPath)
+	assert.Equal(t, 0, scanErr.Row)
 }
 
 func TestScanAllSets(t *testing.T) {
@@ -168,6 +307,21 @@ func TestScanAllSets(t *testing.T) {
 	assert.Nil(t, got2)
 }
 
+func TestSelectSets(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err := testAPI.SelectSets(ctx, testDB, []any{&got}, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
 func TestRowScanner_Scan(t *testing.T) {
 	t.Parallel()
 	rows, err := testDB.Query(singleRowsQuery)
@@ -0,0 +1,232 @@
+// Package sqlscantest provides a mock of sqlscan.Querier for unit tests of code that queries databases
+// with sqlscan, e.g. repositories, without a database or sqlmock expectations.
+/*
+Querier records queries and returns canned rows, that can be built from structs:
+
+	db := sqlscantest.NewQuerier()
+	defer db.Close()
+	db.On("FROM users").Return([]User{{ID: 1, Name: "foo"}})
+
+	users, err := repo.ListUsers(ctx, db)
+	// db.Queries() has the query and its arguments.
+
+Querier passes *sql.Rows of an in-memory database/sql driver to sqlscan,
+so scanning works the same way as with a real driver.
+*/
+package sqlscantest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+var (
+	_ sqlscan.Querier = &Querier{}
+	_ sqlscan.Execer  = &Querier{}
+)
+
+// Query is a query that Querier received.
+type Query struct {
+	SQL  string
+	Args []interface{}
+}
+
+// Response is the canned response to queries that contain the text, see Querier.On.
+type Response struct {
+	text         string
+	columns      []string
+	values       [][]interface{}
+	err          error
+	lastInsertID int64
+	rowsAffected int64
+}
+
+// Return makes queries return rows with data of the struct or the slice of structs, see dbscantest.StructRows.
+// It panics if src isn't a struct or a slice of structs.
+func (r *Response) Return(src interface{}) *Response {
+	columns, values, err := dbscantest.StructRows(src)
+	if err != nil {
+		panic(err)
+	}
+	return r.ReturnRows(columns, values)
+}
+
+// ReturnRows makes queries return rows with the columns and values of every row in the order of columns.
+func (r *Response) ReturnRows(columns []string, values [][]interface{}) *Response {
+	r.columns = columns
+	r.values = values
+	return r
+}
+
+// ReturnError makes queries and statements fail with the error.
+func (r *Response) ReturnError(err error) *Response {
+	r.err = err
+	return r
+}
+
+// ReturnResult makes statements return the result, see sql.Result.
+func (r *Response) ReturnResult(lastInsertID, rowsAffected int64) *Response {
+	r.lastInsertID = lastInsertID
+	r.rowsAffected = rowsAffected
+	return r
+}
+
+// Querier is a mock of sqlscan.Querier and sqlscan.Execer, that works with *sql.DB of an in-memory driver.
+// Querier is safe for concurrent use.
+type Querier struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	responses []*Response
+	queries   []Query
+}
+
+// NewQuerier returns a new mock querier without responses. Close it to release the underlying *sql.DB.
+func NewQuerier() *Querier {
+	q := &Querier{}
+	q.db = sql.OpenDB(connector{q: q})
+	return q
+}
+
+// On adds a response to queries and statements that contain the text, e.g. "FROM users",
+// an empty text matches any query. Responses are matched in the order they were added.
+// Queries that match no response fail.
+func (q *Querier) On(text string) *Response {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	r := &Response{text: text}
+	q.responses = append(q.responses, r)
+	return r
+}
+
+// Queries returns queries and statements that the querier received, in order.
+func (q *Querier) Queries() []Query {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]Query(nil), q.queries...)
+}
+
+// DB returns the underlying *sql.DB, for code that needs it rather than sqlscan.Querier.
+func (q *Querier) DB() *sql.DB {
+	return q.db
+}
+
+// Close closes the underlying *sql.DB.
+func (q *Querier) Close() error {
+	return q.db.Close()
+}
+
+// QueryContext implements the sqlscan.Querier.QueryContext method.
+func (q *Querier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return q.db.QueryContext(ctx, query, args...)
+}
+
+// ExecContext implements the sqlscan.Execer.ExecContext method.
+func (q *Querier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return q.db.ExecContext(ctx, query, args...)
+}
+
+// respond records the query and returns its response.
+func (q *Querier) respond(query string, args []driver.NamedValue) (*Response, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	q.queries = append(q.queries, Query{SQL: query, Args: values})
+	for _, r := range q.responses {
+		if strings.Contains(query, r.text) {
+			if r.err != nil {
+				return nil, r.err
+			}
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("scany: unexpected query: %s", query)
+}
+
+type connector struct {
+	q *Querier
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn(c), nil }
+func (c connector) Driver() driver.Driver                        { return mockDriver{} }
+
+type mockDriver struct{}
+
+func (mockDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("scany: open isn't supported, use NewQuerier")
+}
+
+type conn struct {
+	q *Querier
+}
+
+func (c conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("scany: prepared statements aren't supported")
+}
+func (c conn) Close() error { return nil }
+func (c conn) Begin() (driver.Tx, error) {
+	return nil, errors.New("scany: transactions aren't supported")
+}
+
+// CheckNamedValue accepts arguments as is, so Queries returns them the way the code under test passed them.
+func (c conn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	r, err := c.q.respond(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &rows{columns: r.columns, values: r.values}, nil
+}
+
+func (c conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	r, err := c.q.respond(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return result{lastInsertID: r.lastInsertID, rowsAffected: r.rowsAffected}, nil
+}
+
+type rows struct {
+	columns []string
+	values  [][]interface{}
+	next    int
+}
+
+func (r *rows) Columns() []string { return r.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	row := r.values[r.next]
+	if len(row) != len(r.columns) {
+		return fmt.Errorf("scany: row %d has %d values, not %d", r.next, len(row), len(r.columns))
+	}
+	for i, v := range row {
+		dest[i] = v
+	}
+	r.next++
+	return nil
+}
+
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r result) RowsAffected() (int64, error) { return r.rowsAffected, nil }
@@ -0,0 +1,136 @@
+package sqlscantest_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+var ctx = context.Background()
+
+type user struct {
+	ID        int64
+	Name      string
+	Email     sql.NullString
+	CreatedAt time.Time
+}
+
+func newQuerier(t *testing.T) *sqlscantest.Querier {
+	t.Helper()
+	q := sqlscantest.NewQuerier()
+	t.Cleanup(func() { q.Close() })
+	return q
+}
+
+func TestQuerier_Return_selectsStructs(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	expected := []user{
+		{ID: 1, Name: "foo", Email: sql.NullString{String: "foo@example.com", Valid: true}, CreatedAt: time.Unix(1, 0).UTC()},
+		{ID: 2, Name: "bar", CreatedAt: time.Unix(2, 0).UTC()},
+	}
+	q.On("FROM users").Return(expected)
+
+	var got []user
+	err := sqlscan.Select(ctx, q, &got, `SELECT * FROM users WHERE name <> $1`, "baz")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.Equal(t, []sqlscantest.Query{
+		{SQL: `SELECT * FROM users WHERE name <> $1`, Args: []interface{}{"baz"}},
+	}, q.Queries())
+}
+
+func TestQuerier_Return_getsStruct(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	expected := &user{ID: 1, Name: "foo"}
+	q.On("").Return(expected)
+
+	var got user
+	err := sqlscan.Get(ctx, q, &got, `SELECT * FROM users WHERE id = $1`, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, *expected, got)
+}
+
+func TestQuerier_Return_emptySlice_notFound(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	q.On("FROM users").Return([]user{})
+
+	var got user
+	err := sqlscan.Get(ctx, q, &got, `SELECT * FROM users WHERE id = $1`, 1)
+
+	assert.True(t, sqlscan.NotFound(err))
+}
+
+func TestQuerier_ReturnRows(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	q.On("count").ReturnRows([]string{"count"}, [][]interface{}{{int64(3)}})
+
+	var got int
+	err := sqlscan.Get(ctx, q, &got, `SELECT count(*) FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, got)
+}
+
+func TestQuerier_respondsInOrder(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	q.On("FROM users WHERE").ReturnRows([]string{"name"}, [][]interface{}{{"foo"}})
+	q.On("FROM users").ReturnRows([]string{"name"}, [][]interface{}{{"foo"}, {"bar"}})
+
+	var one, all []string
+	require.NoError(t, sqlscan.Select(ctx, q, &one, `SELECT name FROM users WHERE id = 1`))
+	require.NoError(t, sqlscan.Select(ctx, q, &all, `SELECT name FROM users`))
+
+	assert.Equal(t, []string{"foo"}, one)
+	assert.Equal(t, []string{"foo", "bar"}, all)
+}
+
+func TestQuerier_ReturnError(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	errQuery := errors.New("query failed")
+	q.On("FROM users").ReturnError(errQuery)
+
+	var got []user
+	err := sqlscan.Select(ctx, q, &got, `SELECT * FROM users`)
+
+	assert.ErrorIs(t, err, errQuery)
+}
+
+func TestQuerier_unexpectedQuery(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+
+	var got []user
+	err := sqlscan.Select(ctx, q, &got, `SELECT * FROM users`)
+
+	assert.ErrorContains(t, err, "scany: unexpected query: SELECT * FROM users")
+}
+
+func TestQuerier_ExecContext(t *testing.T) {
+	t.Parallel()
+	q := newQuerier(t)
+	q.On("UPDATE users").ReturnResult(0, 2)
+
+	res, err := q.ExecContext(ctx, `UPDATE users SET name = $1`, "foo")
+	require.NoError(t, err)
+	n, err := res.RowsAffected()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, []sqlscantest.Query{{SQL: `UPDATE users SET name = $1`, Args: []interface{}{"foo"}}}, q.Queries())
+}
@@ -0,0 +1,65 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// StmtQuerier is a prepared statement that sqlscan can query and get the *sql.Rows from.
+// For example, it can be: *sql.Stmt, or a statement bound to a transaction via sql.Tx.StmtContext.
+// Use Querier for *sql.DB, *sql.Conn and *sql.Tx.
+type StmtQuerier interface {
+	QueryContext(ctx context.Context, args ...interface{}) (*sql.Rows, error)
+}
+
+var _ StmtQuerier = &sql.Stmt{}
+
+// SelectStmt is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectStmt for details.
+func SelectStmt(ctx context.Context, stmt StmtQuerier, dst interface{}, args ...interface{}) error {
+	return DefaultAPI.SelectStmt(ctx, stmt, dst, args...)
+}
+
+// GetStmt is a package-level helper function that uses the DefaultAPI object.
+// See API.GetStmt for details.
+func GetStmt(ctx context.Context, stmt StmtQuerier, dst interface{}, args ...interface{}) error {
+	return DefaultAPI.GetStmt(ctx, stmt, dst, args...)
+}
+
+// SelectStmt is the same as Select, but it executes the prepared statement with the given args.
+// The statement runs with query hooks and the retry policy the same way as queries of Select,
+// hooks get an empty QueryEvent.Query, since the statement doesn't expose its text.
+func (api *API) SelectStmt(ctx context.Context, stmt StmtQuerier, dst interface{}, args ...interface{}) error {
+	return api.withRetry(ctx, func() error {
+		return api.runQuery(ctx, "", args, func(ctx context.Context, counted *queryRows) error {
+			rows, err := stmt.QueryContext(ctx, args...)
+			if err != nil {
+				return fmt.Errorf("scany: query multiple result rows: %w", err)
+			}
+			counted.setRows(api.newRowsAdapter(rows))
+			if err := api.dbscanAPI.ScanAllContext(ctx, dst, counted); err != nil {
+				return fmt.Errorf("scanning all: %w", err)
+			}
+			return nil
+		})
+	}, dst)
+}
+
+// GetStmt is the same as Get, but it executes the prepared statement with the given args.
+// The statement runs with query hooks and the retry policy, see SelectStmt.
+func (api *API) GetStmt(ctx context.Context, stmt StmtQuerier, dst interface{}, args ...interface{}) error {
+	return api.withRetry(ctx, func() error {
+		return api.runQuery(ctx, "", args, func(ctx context.Context, counted *queryRows) error {
+			rows, err := stmt.QueryContext(ctx, args...)
+			if err != nil {
+				return fmt.Errorf("scany: query one result row: %w", err)
+			}
+			counted.setRows(api.newRowsAdapter(rows))
+			if err := api.scanOne(ctx, dst, counted); err != nil {
+				return fmt.Errorf("scanning one: %w", err)
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,68 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestSelectStmt(t *testing.T) {
+	t.Parallel()
+	stmt, err := testDB.PrepareContext(ctx, multipleRowsQuery)
+	require.NoError(t, err)
+	defer stmt.Close()
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	var got []*testModel
+	err = testAPI.SelectStmt(ctx, stmt, &got)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetStmt(t *testing.T) {
+	t.Parallel()
+	stmt, err := testDB.PrepareContext(ctx, `SELECT $1::TEXT AS foo, $2::TEXT AS bar`)
+	require.NoError(t, err)
+	defer stmt.Close()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err = testAPI.GetStmt(ctx, stmt, &got, "foo val", "bar val")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetStmt_noRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	stmt, err := testDB.PrepareContext(ctx, noRowsQuery)
+	require.NoError(t, err)
+	defer stmt.Close()
+
+	var got testModel
+	err = sqlscan.GetStmt(ctx, stmt, &got)
+
+	assert.True(t, sqlscan.NotFound(err))
+}
+
+func TestGet_conn(t *testing.T) {
+	t.Parallel()
+	conn, err := testDB.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err = testAPI.Get(ctx, conn, &got, singleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
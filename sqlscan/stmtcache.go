@@ -0,0 +1,165 @@
+package sqlscan
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Preparer is something that sqlscan can prepare statements with, see WithStmtCache.
+// For example, it can be: *sql.DB or *sql.Conn.
+type Preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+var (
+	_ Preparer = &sql.DB{}
+	_ Preparer = &sql.Conn{}
+)
+
+// WithStmtCache makes Select, Get, SelectSets and other high-level functions built on top of them
+// prepare queries and reuse the prepared statements for the same query text,
+// it can save a round trip per query on drivers that prepare statements for every query with arguments,
+// e.g. MySQL and SQL Server. At most size statements are kept, the least recently used ones are closed.
+//
+//	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithStmtCache(100))
+//	defer api.CloseStmts()
+//
+// Statements are cached per Querier that implements Preparer, like *sql.DB and *sql.Conn.
+// Queries on *sql.Tx aren't cached, since its statements are closed along with the transaction.
+// Statements prepared on *sql.Conn must be released with CloseStmts before the connection is closed.
+func WithStmtCache(size int) APIOption {
+	return func(api *API) {
+		if size > 0 {
+			api.stmtCache = newStmtCache(size)
+		}
+	}
+}
+
+// CloseStmts closes all statements cached by the API object and empties the cache, see WithStmtCache.
+// The API object remains usable, statements are prepared again on demand.
+func (api *API) CloseStmts() error {
+	if api.stmtCache == nil {
+		return nil
+	}
+	return api.stmtCache.closeAll()
+}
+
+// queryContext runs the query on db, via a cached prepared statement if the statement cache is enabled.
+func (api *API) queryContext(ctx context.Context, db Querier, query string, args []interface{}) (*sql.Rows, error) {
+	preparer, ok := db.(Preparer)
+	if api.stmtCache == nil || !ok || !isStmtCacheable(db) {
+		return db.QueryContext(ctx, query, args...)
+	}
+	return api.stmtCache.query(ctx, preparer, query, args)
+}
+
+func isStmtCacheable(db Querier) bool {
+	if _, ok := db.(*sql.Tx); ok {
+		return false
+	}
+	// The Querier is a part of the cache key, so it must be usable as a map key.
+	return reflect.TypeOf(db).Comparable()
+}
+
+type stmtCacheKey struct {
+	preparer Preparer
+	query    string
+}
+
+type stmtCacheEntry struct {
+	key  stmtCacheKey
+	stmt *sql.Stmt
+}
+
+// stmtCache is an LRU cache of prepared statements.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[stmtCacheKey]*list.Element
+	lru     *list.List
+	// closeMu is held for reading while a statement from the cache is used to start a query,
+	// and for writing while evicted statements are closed, so a statement isn't closed before it's used.
+	// Once the query has started, database/sql defers closing the statement until its rows are closed.
+	closeMu sync.RWMutex
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:    size,
+		entries: make(map[stmtCacheKey]*list.Element, size),
+		lru:     list.New(),
+	}
+}
+
+func (c *stmtCache) query(ctx context.Context, preparer Preparer, query string, args []interface{}) (*sql.Rows, error) {
+	c.closeMu.RLock()
+	stmt, evicted, err := c.get(ctx, preparer, query)
+	var rows *sql.Rows
+	if err == nil {
+		rows, err = stmt.QueryContext(ctx, args...)
+	}
+	c.closeMu.RUnlock()
+	if len(evicted) > 0 {
+		c.closeMu.Lock()
+		for _, evictedStmt := range evicted {
+			_ = evictedStmt.Close()
+		}
+		c.closeMu.Unlock()
+	}
+	return rows, err
+}
+
+// get returns the cached statement for the query, or prepares and caches a new one.
+// It also returns statements evicted from the cache that the caller must close.
+func (c *stmtCache) get(ctx context.Context, preparer Preparer, query string) (*sql.Stmt, []*sql.Stmt, error) {
+	key := stmtCacheKey{preparer: preparer, query: query}
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.mu.Unlock()
+		return elem.Value.(*stmtCacheEntry).stmt, nil, nil
+	}
+	c.mu.Unlock()
+
+	// Preparing involves a round trip, so it's done without the lock.
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("scany: prepare statement: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		// Another goroutine has prepared the same query in the meantime.
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*stmtCacheEntry).stmt, []*sql.Stmt{stmt}, nil
+	}
+	c.entries[key] = c.lru.PushFront(&stmtCacheEntry{key: key, stmt: stmt})
+	var evicted []*sql.Stmt
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Remove(c.lru.Back()).(*stmtCacheEntry)
+		delete(c.entries, oldest.key)
+		evicted = append(evicted, oldest.stmt)
+	}
+	return stmt, evicted, nil
+}
+
+func (c *stmtCache) closeAll() error {
+	c.closeMu.Lock()
+	defer c.closeMu.Unlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var firstErr error
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if err := elem.Value.(*stmtCacheEntry).stmt.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("scany: close statement: %w", err)
+		}
+	}
+	c.entries = make(map[stmtCacheKey]*list.Element, c.size)
+	c.lru.Init()
+	return firstErr
+}
@@ -0,0 +1,86 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type countingPreparer struct {
+	*sql.DB
+	prepares int32
+}
+
+func (cp *countingPreparer) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	atomic.AddInt32(&cp.prepares, 1)
+	return cp.DB.PrepareContext(ctx, query)
+}
+
+func getStmtCacheAPI(t *testing.T, size int) *sqlscan.API {
+	t.Helper()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithStmtCache(size))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, api.CloseStmts())
+	})
+	return api
+}
+
+func TestSelect_withStmtCache_reusesStmt(t *testing.T) {
+	t.Parallel()
+	api := getStmtCacheAPI(t, 10)
+	db := &countingPreparer{DB: testDB}
+	expected := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+
+	for i := 0; i < 3; i++ {
+		var got []*testModel
+		err := api.Select(ctx, db, &got, multipleRowsQuery)
+		require.NoError(t, err)
+		assert.Equal(t, expected, got)
+	}
+
+	assert.Equal(t, int32(1), db.prepares)
+}
+
+func TestGet_withStmtCache_evictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	api := getStmtCacheAPI(t, 1)
+	db := &countingPreparer{DB: testDB}
+
+	for _, query := range []string{`SELECT 1`, `SELECT 2`, `SELECT 1`} {
+		var got int
+		err := api.Get(ctx, db, &got, query)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), db.prepares)
+}
+
+func TestGet_withStmtCache_txIsNotCached(t *testing.T) {
+	t.Parallel()
+	api := getStmtCacheAPI(t, 10)
+	tx, err := testDB.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback() //nolint: errcheck
+
+	var got testModel
+	err = api.Get(ctx, tx, &got, singleRowsQuery)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	err = api.Get(ctx, testDB, &got, singleRowsQuery)
+	require.NoError(t, err)
+	assert.Equal(t, testModel{Foo: "foo val", Bar: "bar val"}, got)
+}
@@ -0,0 +1,149 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+const defaultTxMaxAttempts = 3
+
+// TxBeginner is something that sqlscan can begin a transaction with.
+// For example, it can be: *sql.DB or *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+var (
+	_ TxBeginner = &sql.DB{}
+	_ TxBeginner = &sql.Conn{}
+)
+
+// ErrTxManaged is returned by Tx.Commit and Tx.Rollback, since WithTx ends the transaction itself.
+var ErrTxManaged = errors.New("scany: the transaction is committed or rolled back by WithTx")
+
+// Tx is a wrapper around *sql.Tx that exposes sqlscan high-level functions bound to the transaction.
+// All *sql.Tx methods are available as well, but Commit and Rollback return ErrTxManaged,
+// fn returns nil to commit the transaction and an error to roll it back, see WithTx.
+type Tx struct {
+	*sql.Tx
+	api *API
+}
+
+// Commit returns ErrTxManaged without committing the transaction.
+func (tx *Tx) Commit() error {
+	return ErrTxManaged
+}
+
+// Rollback returns ErrTxManaged without rolling the transaction back.
+func (tx *Tx) Rollback() error {
+	return ErrTxManaged
+}
+
+// Select is the same as API.Select executed within the transaction.
+func (tx *Tx) Select(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.Select(ctx, tx.Tx, dst, query, args...)
+}
+
+// Get is the same as API.Get executed within the transaction.
+func (tx *Tx) Get(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.Get(ctx, tx.Tx, dst, query, args...)
+}
+
+// ExecReturning is the same as API.ExecReturning executed within the transaction.
+func (tx *Tx) ExecReturning(ctx context.Context, dst interface{}, query string, args ...interface{}) error {
+	return tx.api.ExecReturning(ctx, tx.Tx, dst, query, args...)
+}
+
+// WithTxMaxAttempts sets how many times WithTx runs the transaction if it fails with a serialization failure.
+// The default is 3 attempts, 1 disables retries.
+func WithTxMaxAttempts(maxAttempts int) APIOption {
+	return func(api *API) {
+		api.txMaxAttempts = maxAttempts
+	}
+}
+
+// WithTx is a package-level helper function that uses the DefaultAPI object.
+// See API.WithTx for details.
+func WithTx(ctx context.Context, db TxBeginner, fn func(tx *Tx) error) error {
+	return DefaultAPI.WithTx(ctx, db, fn)
+}
+
+// WithTxOptions is a package-level helper function that uses the DefaultAPI object.
+// See API.WithTxOptions for details.
+func WithTxOptions(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	return DefaultAPI.WithTxOptions(ctx, db, opts, fn)
+}
+
+// WithTx begins a transaction, calls fn with it and commits the transaction if fn returns nil,
+// otherwise it rolls the transaction back and returns the error of fn. It rolls back on panic as well.
+//
+//	err := sqlscan.WithTx(ctx, db, func(tx *sqlscan.Tx) error {
+//		var user User
+//		if err := tx.Get(ctx, &user, `SELECT * FROM users WHERE id = $1 FOR UPDATE`, id); err != nil {
+//			return err
+//		}
+//		_, err := tx.ExecContext(ctx, `UPDATE users SET balance = $1 WHERE id = $2`, user.Balance+amount, id)
+//		return err
+//	})
+//
+// If fn or the commit fails with a serialization failure or a deadlock, SQLSTATE 40001 or 40P01,
+// the whole transaction is retried, so fn must be safe to call multiple times, see WithTxMaxAttempts.
+// The SQLSTATE is taken from errors that implement the SQLState() string method, e.g. *pgconn.PgError.
+func (api *API) WithTx(ctx context.Context, db TxBeginner, fn func(tx *Tx) error) error {
+	return api.WithTxOptions(ctx, db, nil, fn)
+}
+
+// WithTxOptions is the same as WithTx, but begins the transaction with the given options,
+// e.g. the sql.LevelSerializable isolation level.
+func (api *API) WithTxOptions(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(tx *Tx) error) error {
+	maxAttempts := api.txMaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = classifyError(api.runTx(ctx, db, opts, fn))
+		if err == nil || !isSerializationFailure(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("scany: transaction failed after %d attempts: %w", maxAttempts, err)
+}
+
+func (api *API) runTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(tx *Tx) error) (err error) {
+	sqlTx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(&Tx{Tx: sqlTx, api: api.withoutRetry()}); err != nil {
+		if rollbackErr := sqlTx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			return fmt.Errorf("%w (rollback error: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("scany: commit transaction: %w", err)
+	}
+	return nil
+}
+
+func isSerializationFailure(err error) bool {
+	var sqlStateErr interface{ SQLState() string }
+	if !errors.As(err, &sqlStateErr) {
+		return false
+	}
+	switch sqlStateErr.SQLState() {
+	case "40001", "40P01":
+		return true
+	default:
+		return false
+	}
+}
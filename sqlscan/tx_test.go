@@ -0,0 +1,82 @@
+package sqlscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestWithTx_commits(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.WithTx(ctx, testDB, func(tx *sqlscan.Tx) error {
+		return tx.Get(ctx, &got, singleRowsQuery)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestWithTx_fnError_rollsBackAndReturnsErr(t *testing.T) {
+	t.Parallel()
+	fnErr := errors.New("fn error")
+	var attempts int
+
+	err := sqlscan.WithTx(ctx, testDB, func(tx *sqlscan.Tx) error {
+		attempts++
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWithTx_commitInFn_returnsErr(t *testing.T) {
+	t.Parallel()
+
+	err := sqlscan.WithTx(ctx, testDB, func(tx *sqlscan.Tx) error {
+		return tx.Commit()
+	})
+
+	assert.ErrorIs(t, err, sqlscan.ErrTxManaged)
+}
+
+func TestWithTx_serializationFailure_retries(t *testing.T) {
+	t.Parallel()
+	var attempts int
+
+	err := sqlscan.WithTx(ctx, testDB, func(tx *sqlscan.Tx) error {
+		attempts++
+		if attempts < 2 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, attempts)
+}
+
+func TestWithTx_withTxMaxAttempts_returnsErr(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithTxMaxAttempts(2))
+	require.NoError(t, err)
+	var attempts int
+
+	err = api.WithTx(ctx, testDB, func(tx *sqlscan.Tx) error {
+		attempts++
+		return &pgconn.PgError{Severity: "ERROR", Code: "40001", Message: "restart transaction"}
+	})
+
+	assert.EqualError(t, err, "scany: transaction failed after 2 attempts: ERROR: restart transaction (SQLSTATE 40001)")
+	assert.Equal(t, 2, attempts)
+}